@@ -19,6 +19,9 @@ type Config struct {
 	LogLevel          string
 	GuildID           string // Optional: specific guild for slash command registration
 	CoordinatorRoleID string // Optional: specific role ID for Coordinator permissions
+	WOMAPIKey         string // Optional: Wise Old Man API key for a higher rate limit
+	WOMUserAgent      string // Optional: User-Agent sent to Wise Old Man, ideally with a contact
+	WOMBaseURL        string // Optional: Wise Old Man API URL, e.g. a self-hosted instance or a fake
 }
 
 // Load loads configuration from .env file and environment variables.
@@ -48,6 +51,9 @@ func Load() (*Config, error) {
 
 	guildID := os.Getenv("DISCORD_GUILD_ID")
 	coordinatorRoleID := os.Getenv("COORDINATOR_ROLE_ID")
+	womAPIKey := os.Getenv("WOM_API_KEY")
+	womUserAgent := os.Getenv("WOM_USER_AGENT")
+	womBaseURL := os.Getenv("WOM_BASE_URL")
 
 	return &Config{
 		DiscordToken:      token,
@@ -55,5 +61,8 @@ func Load() (*Config, error) {
 		LogLevel:          logLevel,
 		GuildID:           guildID,
 		CoordinatorRoleID: coordinatorRoleID,
+		WOMAPIKey:         womAPIKey,
+		WOMUserAgent:      womUserAgent,
+		WOMBaseURL:        womBaseURL,
 	}, nil
 }
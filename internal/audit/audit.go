@@ -0,0 +1,155 @@
+// Package audit records bot-driven configuration and event changes so coordinators
+// can see who changed what, and optionally mirrors them to a log channel.
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/embeds"
+)
+
+// Audit log actions. Related actions share a prefix so /audit can filter by it.
+const (
+	ActionCoordinatorRole          = "config.coordinator_role"
+	ActionCompetitionCodeChannel   = "config.competition_code_channel"
+	ActionDefaultTimezone          = "config.default_timezone"
+	ActionEventNotificationChannel = "config.event_notification_channel"
+	ActionEventNotificationRole    = "config.event_notification_role"
+	ActionNicknameTemplate         = "config.nickname_template"
+	ActionAuditLogChannel          = "config.audit_log_channel"
+	ActionCommandPermissions       = "config.command_permissions"
+	ActionConfigImport             = "config.import"
+	ActionConfigRollback           = "config.rollback"
+	ActionSetup                    = "config.setup"
+	ActionFeatures                 = "config.features"
+	ActionWelcome                  = "config.welcome"
+	ActionNotificationRoles        = "config.notification_roles"
+	ActionApplicationSettings      = "config.applications"
+	ActionInactivitySettings       = "config.inactivity"
+	ActionWOMGroup                 = "config.wom_group"
+	ActionRankRoles                = "config.rank_roles"
+	ActionEventStart               = "event.start"
+	ActionEventFinish              = "event.finish"
+	ActionMassCreate               = "event.mass_create"
+	ActionLinkCreate               = "link.create"
+	ActionLinkRemove               = "link.remove"
+	ActionLinkImport               = "link.import"
+	ActionWarningAdd               = "warning.add"
+	ActionWarningRemove            = "warning.remove"
+	ActionWarningRevert            = "warning.revert"
+	ActionWarningChannel           = "warning.channel"
+	ActionWarningPolicy            = "warning.policy"
+	ActionWarningExpiry            = "warning.expiry"
+	ActionApplicationSubmit        = "application.submit"
+	ActionApplicationApprove       = "application.approve"
+	ActionApplicationDeny          = "application.deny"
+	ActionRosterSync               = "roster.sync"
+	ActionRankRoleAdd              = "roster.rank_role_add"
+	ActionRankRoleRemove           = "roster.rank_role_remove"
+)
+
+// Entry describes a single mutating action. IDs are Discord snowflakes as strings.
+type Entry struct {
+	GuildID string
+	ActorID string
+	Action  string
+	Target  string
+	Before  string
+	After   string
+}
+
+// Poster posts embeds to a channel. *discordgo.Session implements it.
+type Poster interface {
+	ChannelMessageSendEmbed(channelID string, embed *discordgo.MessageEmbed, options ...discordgo.RequestOption) (*discordgo.Message, error)
+}
+
+// Logger writes audit entries to the database and mirrors them to the guild's audit log channel.
+type Logger struct {
+	db *database.Queries
+}
+
+// NewLogger creates a new Logger.
+func NewLogger(db *database.Queries) *Logger {
+	return &Logger{db: db}
+}
+
+// Record stores entry and mirrors it to the configured audit log channel, if any.
+// Failures are logged rather than returned so auditing never blocks the command itself.
+func (l *Logger) Record(ctx context.Context, s Poster, entry Entry) {
+	if l == nil {
+		return
+	}
+
+	row, err := l.insert(ctx, entry)
+	if err != nil {
+		log.Printf("Error recording audit entry %s for guild %s: %v", entry.Action, entry.GuildID, err)
+		return
+	}
+
+	l.mirror(ctx, s, row)
+}
+
+// insert parses the entry's IDs and writes it to the audit_log table.
+func (l *Logger) insert(ctx context.Context, entry Entry) (database.AuditLog, error) {
+	guildID, err := strconv.ParseInt(entry.GuildID, 10, 64)
+	if err != nil {
+		return database.AuditLog{}, fmt.Errorf("parse guild ID: %w", err)
+	}
+	actorID, err := strconv.ParseInt(entry.ActorID, 10, 64)
+	if err != nil {
+		return database.AuditLog{}, fmt.Errorf("parse actor ID: %w", err)
+	}
+
+	row, err := l.db.CreateAuditLogEntry(ctx, database.CreateAuditLogEntryParams{
+		GuildID:     guildID,
+		ActorID:     actorID,
+		Action:      entry.Action,
+		Target:      nullString(entry.Target),
+		BeforeValue: nullString(entry.Before),
+		AfterValue:  nullString(entry.After),
+	})
+	if err != nil {
+		return database.AuditLog{}, fmt.Errorf("insert audit entry: %w", err)
+	}
+	return row, nil
+}
+
+// mirror posts row to the guild's audit log channel when one is configured.
+func (l *Logger) mirror(ctx context.Context, s Poster, row database.AuditLog) {
+	if s == nil {
+		return
+	}
+
+	config, err := l.db.GetGuildConfig(ctx, row.GuildID)
+	if err != nil || !config.AuditLogChannelID.Valid {
+		return
+	}
+
+	channelID := strconv.FormatInt(config.AuditLogChannelID.Int64, 10)
+	if _, err := s.ChannelMessageSendEmbed(channelID, embeds.AuditLogEntry(ToEmbedEntry(row))); err != nil {
+		log.Printf("Error mirroring audit entry %d to channel %s: %v", row.ID, channelID, err)
+	}
+}
+
+// ToEmbedEntry converts a stored audit row into its display form.
+func ToEmbedEntry(row database.AuditLog) embeds.AuditEntry {
+	return embeds.AuditEntry{
+		ID:        row.ID,
+		ActorID:   row.ActorID,
+		Action:    row.Action,
+		Target:    row.Target.String,
+		Before:    row.BeforeValue.String,
+		After:     row.AfterValue.String,
+		CreatedAt: row.CreatedAt,
+	}
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
@@ -0,0 +1,100 @@
+package audit
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/testutil"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoggerRecordAndList(t *testing.T) {
+	db, q := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	ctx := t.Context()
+	logger := NewLogger(q)
+
+	logger.Record(ctx, nil, Entry{GuildID: "1", ActorID: "10", Action: ActionCoordinatorRole, Before: "<@&5>", After: "<@&6>"})
+	logger.Record(ctx, nil, Entry{GuildID: "1", ActorID: "11", Action: ActionEventStart, Target: "BOTW - Zulrah"})
+	logger.Record(ctx, nil, Entry{GuildID: "1", ActorID: "10", Action: ActionWarningAdd, After: "spam"})
+	logger.Record(ctx, nil, Entry{GuildID: "2", ActorID: "10", Action: ActionCoordinatorRole})
+	logger.Record(ctx, nil, Entry{GuildID: "not-a-snowflake", ActorID: "10", Action: ActionCoordinatorRole})
+
+	t.Run("all entries for guild", func(t *testing.T) {
+		rows, err := q.ListAuditLog(ctx, database.ListAuditLogParams{GuildID: 1, RowLimit: 10})
+		require.NoError(t, err)
+		require.Len(t, rows, 3)
+		assert.Equal(t, ActionWarningAdd, rows[0].Action, "newest first")
+		assert.Equal(t, "<@&5>", rows[2].BeforeValue.String)
+		assert.False(t, rows[1].BeforeValue.Valid, "empty values are stored as NULL")
+	})
+
+	t.Run("filter by actor", func(t *testing.T) {
+		rows, err := q.ListAuditLog(ctx, database.ListAuditLogParams{
+			GuildID:  1,
+			ActorID:  sql.NullInt64{Int64: 10, Valid: true},
+			RowLimit: 10,
+		})
+		require.NoError(t, err)
+		assert.Len(t, rows, 2)
+	})
+
+	t.Run("filter by action prefix", func(t *testing.T) {
+		rows, err := q.ListAuditLog(ctx, database.ListAuditLogParams{
+			GuildID:  1,
+			Action:   sql.NullString{String: "event.", Valid: true},
+			RowLimit: 10,
+		})
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Equal(t, "BOTW - Zulrah", rows[0].Target.String)
+	})
+
+	t.Run("action prefix has no wildcards", func(t *testing.T) {
+		for _, prefix := range []string{"%", "event_start", "_vent."} {
+			rows, err := q.ListAuditLog(ctx, database.ListAuditLogParams{
+				GuildID:  1,
+				Action:   sql.NullString{String: prefix, Valid: true},
+				RowLimit: 10,
+			})
+			require.NoError(t, err)
+			assert.Empty(t, rows, prefix)
+		}
+	})
+
+	t.Run("filter by since", func(t *testing.T) {
+		rows, err := q.ListAuditLog(ctx, database.ListAuditLogParams{
+			GuildID:  1,
+			Since:    sql.NullTime{Time: time.Now().UTC().Add(time.Hour), Valid: true},
+			RowLimit: 10,
+		})
+		require.NoError(t, err)
+		assert.Empty(t, rows)
+
+		rows, err = q.ListAuditLog(ctx, database.ListAuditLogParams{
+			GuildID:  1,
+			Since:    sql.NullTime{Time: time.Now().UTC().Add(-time.Hour), Valid: true},
+			RowLimit: 10,
+		})
+		require.NoError(t, err)
+		assert.Len(t, rows, 3)
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		rows, err := q.ListAuditLog(ctx, database.ListAuditLogParams{GuildID: 1, RowLimit: 1})
+		require.NoError(t, err)
+		assert.Len(t, rows, 1)
+	})
+}
+
+func TestNilLoggerRecord(t *testing.T) {
+	var logger *Logger
+	assert.NotPanics(t, func() {
+		logger.Record(t.Context(), nil, Entry{GuildID: "1", ActorID: "1", Action: ActionCoordinatorRole})
+	})
+}
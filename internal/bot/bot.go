@@ -9,12 +9,13 @@ import (
 	"log/slog"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/kaffeed/voidling/config"
+	"github.com/kaffeed/voidling/internal/audit"
 	"github.com/kaffeed/voidling/internal/commands"
 	"github.com/kaffeed/voidling/internal/database"
-	"github.com/kaffeed/voidling/internal/embeds"
 	"github.com/kaffeed/voidling/internal/models"
 	"github.com/kaffeed/voidling/internal/timezone"
 	"github.com/kaffeed/voidling/internal/wiseoldman"
@@ -22,6 +23,27 @@ import (
 
 type handlerFunc func(s *discordgo.Session, i *discordgo.InteractionCreate)
 
+// minEscalationThreshold is the smallest warning count an escalation rule can trigger on.
+var minEscalationThreshold = 1.0
+
+// minAuditDays and minAuditLimit are the lower bounds for /audit search filters.
+var (
+	minAuditDays  = 1.0
+	minAuditLimit = 1.0
+)
+
+// minConfigVersion is the smallest ID /config rollback accepts.
+var minConfigVersion = 1.0
+
+// minApplicationRequirement is the smallest value an application requirement accepts; 0 removes it.
+var minApplicationRequirement = 0.0
+
+// minInactivityDays is the smallest inactivity threshold /inactive and /config inactivity accept.
+var minInactivityDays = 1.0
+
+// minWOMGroupID is the smallest Wise Old Man group ID /config wom-group set accepts.
+var minWOMGroupID = 1.0
+
 // Bot represents the.
 type Bot struct {
 	Session         *discordgo.Session
@@ -36,6 +58,19 @@ type Bot struct {
 	trackableCmds   *commands.TrackableCommands
 	schedulableCmds *commands.SchedulableCommands
 	configCmds      *commands.ConfigCommands
+	adminCmds       *commands.AdminCommands
+	warnCmds        *commands.WarnCommands
+	auditCmds       *commands.AuditCommands
+	setupCmds       *commands.SetupCommands
+	welcomeCmds     *commands.WelcomeCommands
+	notifyCmds      *commands.NotificationCommands
+	applicationCmds *commands.ApplicationCommands
+	inactivityCmds  *commands.InactivityCommands
+	rosterCmds      *commands.RosterCommands
+	profileCmds     *commands.ProfileCommands
+	gainsCmds       *commands.GainsCommands
+	stopJobs        chan struct{}
+	stopOnce        sync.Once
 }
 
 // New creates a new Bot instance.
@@ -45,7 +80,12 @@ func New(cfg *config.Config, db *database.Queries, dbSQL *sql.DB) (*Bot, error)
 		return nil, fmt.Errorf("failed to create Discord session: %w", err)
 	}
 
-	womClient := wiseoldman.NewClient()
+	womClient := wiseoldman.NewClient(
+		wiseoldman.WithAPIKey(cfg.WOMAPIKey),
+		wiseoldman.WithUserAgent(cfg.WOMUserAgent),
+		wiseoldman.WithBaseURL(cfg.WOMBaseURL),
+	)
+	auditLog := audit.NewLogger(db)
 
 	bot := &Bot{
 		Session:         session,
@@ -56,16 +96,28 @@ func New(cfg *config.Config, db *database.Queries, dbSQL *sql.DB) (*Bot, error)
 		GuildID:         cfg.GuildID,
 		handlers:        make(map[string]handlerFunc),
 		registerCmds:    commands.NewRegisterCommands(db, dbSQL, womClient),
-		trackableCmds:   commands.NewTrackableCommands(db, dbSQL, womClient),
-		schedulableCmds: commands.NewSchedulableCommands(db, dbSQL),
-		configCmds:      commands.NewConfigCommands(db, dbSQL),
+		trackableCmds:   commands.NewTrackableCommands(db, dbSQL, womClient, auditLog),
+		schedulableCmds: commands.NewSchedulableCommands(db, dbSQL, auditLog),
+		configCmds:      commands.NewConfigCommands(db, dbSQL, womClient, auditLog),
+		adminCmds:       commands.NewAdminCommands(db, dbSQL, womClient, auditLog),
+		warnCmds:        commands.NewWarnCommands(db, dbSQL, auditLog),
+		auditCmds:       commands.NewAuditCommands(db, dbSQL),
+		setupCmds:       commands.NewSetupCommands(db, dbSQL, auditLog),
+		welcomeCmds:     commands.NewWelcomeCommands(db, dbSQL),
+		notifyCmds:      commands.NewNotificationCommands(db, dbSQL),
+		applicationCmds: commands.NewApplicationCommands(db, dbSQL, womClient, auditLog),
+		inactivityCmds:  commands.NewInactivityCommands(db, dbSQL),
+		rosterCmds:      commands.NewRosterCommands(db, dbSQL, womClient, auditLog),
+		profileCmds:     commands.NewProfileCommands(db, dbSQL, womClient),
+		gainsCmds:       commands.NewGainsCommands(db, dbSQL, womClient),
+		stopJobs:        make(chan struct{}),
 	}
 
 	// Register interaction handler
 	session.AddHandler(bot.interactionHandler)
 
 	// Register guild member add handler for auto-greeting
-	session.AddHandler(bot.handleGuildMemberAdd)
+	session.AddHandler(bot.welcomeCmds.HandleGuildMemberAdd)
 
 	return bot, nil
 }
@@ -86,11 +138,16 @@ func (b *Bot) Start() error {
 		return fmt.Errorf("failed to register commands: %w", err)
 	}
 
+	// Start background jobs
+	b.startJobs()
+
 	return nil
 }
 
-// Stop stops the bot.
+// Stop stops the bot. It's safe to call more than once.
 func (b *Bot) Stop() error {
+	b.stopOnce.Do(func() { close(b.stopJobs) })
+
 	// Unregister commands
 	if err := b.unregisterCommands(); err != nil {
 		log.Printf("Error unregistering commands: %v", err)
@@ -111,6 +168,50 @@ func (b *Bot) registerCommands() error {
 			Name:        "unlink-rsn",
 			Description: "Unlink your RuneScape account from Discord",
 		},
+		{
+			Name:        "profile",
+			Description: "Show a member's account stats and clan event history",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "member",
+					Description: "Member to show (defaults to you)",
+				},
+			},
+		},
+		{
+			Name:        "gains",
+			Description: "Show what a member gained on Wise Old Man, in your timezone",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "period",
+					Description: "Period to show gains for",
+					Required:    true,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: "Today", Value: "day"},
+						{Name: "This week", Value: "week"},
+						{Name: "This month", Value: "month"},
+						{Name: "Custom (set from and to)", Value: "custom"},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "member",
+					Description: "Member to show (defaults to you)",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "from",
+					Description: "First day of a custom period (YYYY-MM-DD)",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "to",
+					Description: "Last day of a custom period (YYYY-MM-DD, defaults to today)",
+				},
+			},
+		},
 		{
 			Name:        "botw",
 			Description: "Boss of the Week commands",
@@ -127,6 +228,8 @@ func (b *Bot) registerCommands() error {
 							Required:    true,
 							Choices:     commands.WildyBossChoices(),
 						},
+						teamsOption(),
+						balanceTeamsOption(),
 					},
 				},
 				{
@@ -141,6 +244,8 @@ func (b *Bot) registerCommands() error {
 							Required:    true,
 							Choices:     commands.GroupBossChoices(),
 						},
+						teamsOption(),
+						balanceTeamsOption(),
 					},
 				},
 				{
@@ -155,6 +260,8 @@ func (b *Bot) registerCommands() error {
 							Required:    true,
 							Choices:     commands.QuestBossChoices(),
 						},
+						teamsOption(),
+						balanceTeamsOption(),
 					},
 				},
 				{
@@ -169,6 +276,8 @@ func (b *Bot) registerCommands() error {
 							Required:    true,
 							Choices:     commands.SlayerBossChoices(),
 						},
+						teamsOption(),
+						balanceTeamsOption(),
 					},
 				},
 				{
@@ -183,6 +292,8 @@ func (b *Bot) registerCommands() error {
 							Required:    true,
 							Choices:     commands.WorldBossChoices(),
 						},
+						teamsOption(),
+						balanceTeamsOption(),
 					},
 				},
 				{
@@ -208,6 +319,8 @@ func (b *Bot) registerCommands() error {
 							Required:    true,
 							Choices:     commands.SkillChoices(),
 						},
+						teamsOption(),
+						balanceTeamsOption(),
 					},
 				},
 				{
@@ -347,94 +460,946 @@ func (b *Bot) registerCommands() error {
 						},
 					},
 				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "set-nickname-template",
+					Description: "Set the nickname format for linked members (e.g. {rsn} | {rank})",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "template",
+							Description: "Placeholders: {rsn} {rank} {combat} {total} {ehp} {ehb} {type} {build}",
+							Required:    true,
+							MaxLength:   100,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "resync-nicknames",
+					Description: "Re-apply the nickname template to all linked members",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "set-audit-log-channel",
+					Description: "Set the channel audit log entries are mirrored to (omit to disable)",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:         discordgo.ApplicationCommandOptionChannel,
+							Name:         "channel",
+							Description:  "The channel to post audit log entries in",
+							Required:     false,
+							ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "doctor",
+					Description: "Check the bot's permissions, configured roles and channels, and service health",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "export",
+					Description: "Export all server settings as a JSON file",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "import",
+					Description: "Import server settings from a JSON export (shows a preview first)",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionAttachment,
+							Name:        "file",
+							Description: "JSON file from /config export",
+							Required:    true,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "history",
+					Description: "List saved versions of the server settings",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "rollback",
+					Description: "Restore a saved version of the server settings (shows a preview first)",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Name:        "version",
+							Description: "Version ID from /config history",
+							Required:    true,
+							MinValue:    &minConfigVersion,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+					Name:        "permissions",
+					Description: "Restrict commands to specific roles or members",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "allow",
+							Description: "Allow a role or member to use a command (restricts it to the listed roles/members)",
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Type:         discordgo.ApplicationCommandOptionString,
+									Name:         "command",
+									Description:  "The command or subcommand, e.g. botw finish",
+									Required:     true,
+									Autocomplete: true,
+								},
+								{
+									Type:        discordgo.ApplicationCommandOptionRole,
+									Name:        "role",
+									Description: "Role to allow",
+									Required:    false,
+								},
+								{
+									Type:        discordgo.ApplicationCommandOptionUser,
+									Name:        "user",
+									Description: "Member to allow",
+									Required:    false,
+								},
+							},
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "revoke",
+							Description: "Remove a role or member from a command's allow list",
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Type:         discordgo.ApplicationCommandOptionString,
+									Name:         "command",
+									Description:  "The command or subcommand, e.g. botw finish",
+									Required:     true,
+									Autocomplete: true,
+								},
+								{
+									Type:        discordgo.ApplicationCommandOptionRole,
+									Name:        "role",
+									Description: "Role to remove",
+									Required:    false,
+								},
+								{
+									Type:        discordgo.ApplicationCommandOptionUser,
+									Name:        "user",
+									Description: "Member to remove",
+									Required:    false,
+								},
+							},
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "reset",
+							Description: "Restore a command's default permission",
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Type:         discordgo.ApplicationCommandOptionString,
+									Name:         "command",
+									Description:  "The command or subcommand, e.g. botw finish",
+									Required:     true,
+									Autocomplete: true,
+								},
+							},
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "list",
+							Description: "Show all command permission overrides",
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+					Name:        "features",
+					Description: "Turn bot features on or off for this server",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "enable",
+							Description: "Turn a feature on",
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "feature",
+									Description: "The feature to enable",
+									Required:    true,
+									Choices:     commands.FeatureChoices(),
+								},
+							},
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "disable",
+							Description: "Turn a feature off",
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "feature",
+									Description: "The feature to disable",
+									Required:    true,
+									Choices:     commands.FeatureChoices(),
+								},
+							},
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "list",
+							Description: "Show which features are enabled",
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+					Name:        "welcome",
+					Description: "Customise how new members are welcomed",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "edit",
+							Description: "Edit the welcome DM and welcome channel message",
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "channel",
+							Description: "Set the channel new members are welcomed in (omit to turn off)",
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Type:         discordgo.ApplicationCommandOptionChannel,
+									Name:         "channel",
+									Description:  "The welcome channel",
+									Required:     false,
+									ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
+								},
+							},
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "checklist",
+							Description: "Choose the onboarding steps offered in the welcome DM",
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Type:        discordgo.ApplicationCommandOptionBoolean,
+									Name:        commands.OnboardingLinkRSN,
+									Description: "Ask new members to link their RuneScape account",
+									Required:    false,
+								},
+								{
+									Type:        discordgo.ApplicationCommandOptionBoolean,
+									Name:        commands.OnboardingSetTimezone,
+									Description: "Ask new members to set their timezone",
+									Required:    false,
+								},
+								{
+									Type:        discordgo.ApplicationCommandOptionBoolean,
+									Name:        commands.OnboardingNotificationRoles,
+									Description: "Let new members opt in to event pings",
+									Required:    false,
+								},
+							},
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "linked-role",
+							Description: "Give members a role once they link their RSN (omit to turn off)",
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Type:        discordgo.ApplicationCommandOptionRole,
+									Name:        "role",
+									Description: "The role to assign",
+									Required:    false,
+								},
+							},
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "preview",
+							Description: "Preview the welcome messages",
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+					Name:        "notifications",
+					Description: "Set up ping roles for each kind of event",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "set-role",
+							Description: "Set the role pinged for one kind of event (omit the role to use the default)",
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "event",
+									Description: "The kind of event",
+									Required:    true,
+									Choices:     commands.NotificationTypeChoices(),
+								},
+								{
+									Type:        discordgo.ApplicationCommandOptionRole,
+									Name:        "role",
+									Description: "The role to ping",
+									Required:    false,
+								},
+							},
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "list",
+							Description: "Show which role is pinged for each kind of event",
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "post-picker",
+							Description: "Post a message members use to pick their ping roles",
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Type:         discordgo.ApplicationCommandOptionChannel,
+									Name:         "channel",
+									Description:  "Where to post it (defaults to this channel)",
+									Required:     false,
+									ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
+								},
+							},
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+					Name:        "applications",
+					Description: "Set up clan applications with staff review",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "review-channel",
+							Description: "Set the staff channel applications are posted in (omit to close applications)",
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Type:         discordgo.ApplicationCommandOptionChannel,
+									Name:         "channel",
+									Description:  "The review channel",
+									Required:     false,
+									ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
+								},
+							},
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "approved-role",
+							Description: "Set the role approved applicants get (omit to give none)",
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Type:        discordgo.ApplicationCommandOptionRole,
+									Name:        "role",
+									Description: "The member role",
+									Required:    false,
+								},
+							},
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "requirements",
+							Description: "Set the minimum stats applicants should have (0 removes a requirement)",
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Type:        discordgo.ApplicationCommandOptionInteger,
+									Name:        "min-total-level",
+									Description: "Minimum total level",
+									Required:    false,
+									MinValue:    &minApplicationRequirement,
+									MaxValue:    2277,
+								},
+								{
+									Type:        discordgo.ApplicationCommandOptionInteger,
+									Name:        "min-combat-level",
+									Description: "Minimum combat level",
+									Required:    false,
+									MinValue:    &minApplicationRequirement,
+									MaxValue:    126,
+								},
+								{
+									Type:        discordgo.ApplicationCommandOptionNumber,
+									Name:        "min-ehp",
+									Description: "Minimum efficient hours played",
+									Required:    false,
+									MinValue:    &minApplicationRequirement,
+								},
+							},
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "post-button",
+							Description: "Post the message members use to apply",
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Type:         discordgo.ApplicationCommandOptionChannel,
+									Name:         "channel",
+									Description:  "Where to post it (defaults to this channel)",
+									Required:     false,
+									ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
+								},
+							},
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+					Name:        "inactivity",
+					Description: "Set up reports of members who stopped gaining XP",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "report-channel",
+							Description: "Set the staff channel the weekly report is posted in (omit to turn it off)",
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Type:         discordgo.ApplicationCommandOptionChannel,
+									Name:         "channel",
+									Description:  "The report channel",
+									Required:     false,
+									ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
+								},
+							},
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "threshold",
+							Description: "Set how many days without XP make a member inactive",
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Type:        discordgo.ApplicationCommandOptionInteger,
+									Name:        "days",
+									Description: "Days without XP gained",
+									Required:    true,
+									MinValue:    &minInactivityDays,
+									MaxValue:    365,
+								},
+							},
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "nudges",
+							Description: "DM members when they become inactive",
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Type:        discordgo.ApplicationCommandOptionBoolean,
+									Name:        "enabled",
+									Description: "Whether inactive members get a DM",
+									Required:    true,
+								},
+							},
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+					Name:        "wom-group",
+					Description: "Link the server to a Wise Old Man group",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "set",
+							Description: "Use a Wise Old Man group for the roster and group competitions",
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Type:        discordgo.ApplicationCommandOptionInteger,
+									Name:        "group-id",
+									Description: "The group's ID, the number in its wiseoldman.net URL",
+									Required:    true,
+									MinValue:    &minWOMGroupID,
+								},
+								{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "verification-code",
+									Description: "The group's verification code, shown when it was created",
+									Required:    true,
+								},
+							},
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "clear",
+							Description: "Stop using a Wise Old Man group",
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+					Name:        "rank-roles",
+					Description: "Give linked members Discord roles matching their Wise Old Man group rank",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "set",
+							Description: "Map a group rank to a role (omit the role to remove the mapping)",
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "rank",
+									Description: "The Wise Old Man group role, e.g. captain or dragon_slayer",
+									Required:    true,
+								},
+								{
+									Type:        discordgo.ApplicationCommandOptionRole,
+									Name:        "role",
+									Description: "The Discord role members with this rank get",
+									Required:    false,
+								},
+							},
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "list",
+							Description: "Show which ranks map to which roles",
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "preview",
+							Description: "Show the role changes a sync would make without making them",
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "sync",
+							Description: "Give and remove rank roles now",
+						},
+					},
+				},
 			},
 		},
-	}
-
-	// Register command handlers
-	b.registerHandler("link-rsn", b.registerCmds.HandleLinkRSN)
-	b.registerHandler("unlink-rsn", b.registerCmds.HandleUnlinkRSN)
-	b.registerHandler("botw", b.handleBOTWCommand)
-	b.registerHandler("sotw", b.handleSOTWCommand)
-	b.registerHandler("mass", b.schedulableCmds.HandleMassEvent)
-	b.registerHandler("config", b.handleConfigCommand)
-
-	// Register commands with Discord
-	for _, cmd := range b.commands {
-		_, err := b.Session.ApplicationCommandCreate(b.Session.State.User.ID, b.GuildID, cmd)
-		if err != nil {
-			return fmt.Errorf("failed to create command %s: %w", cmd.Name, err)
-		}
-		log.Printf("Registered command: %s", cmd.Name)
-	}
-
-	return nil
-}
-
-// unregisterCommands removes all registered commands.
-func (b *Bot) unregisterCommands() error {
-	commands, err := b.Session.ApplicationCommands(b.Session.State.User.ID, b.GuildID)
-	if err != nil {
-		return fmt.Errorf("failed to fetch commands: %w", err)
-	}
-
-	for _, cmd := range commands {
-		err := b.Session.ApplicationCommandDelete(b.Session.State.User.ID, b.GuildID, cmd.ID)
-		if err != nil {
-			log.Printf("Failed to delete command %s: %v", cmd.Name, err)
-		}
-		log.Printf("Removed cmd %s", cmd.Name)
-	}
-
-	return nil
-}
-
-// registerHandler registers a command handler.
-func (b *Bot) registerHandler(name string, handler func(s *discordgo.Session, i *discordgo.InteractionCreate)) {
-	b.handlers[name] = handler
-}
-
-// handleBOTWCommand routes BOTW subcommands.
-func (b *Bot) handleBOTWCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	data := i.ApplicationCommandData()
-	if len(data.Options) == 0 {
-		return
-	}
-
-	subcommand := data.Options[0].Name
-
-	// All BOTW commands require Coordinator permission
-	if !b.HasPermission(s, i, PermissionCoordinator) {
-		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Content: "❌ You don't have permission to use this command. Coordinator role required.",
-				Flags:   discordgo.MessageFlagsEphemeral,
-			},
-		})
-		return
-	}
-
-	switch subcommand {
-	case "wildy":
-		b.trackableCmds.HandleBOTWWildy(s, i)
-	case "group":
-		b.trackableCmds.HandleBOTWGroup(s, i)
-	case "quest":
-		b.trackableCmds.HandleBOTWQuest(s, i)
-	case "slayer":
-		b.trackableCmds.HandleBOTWSlayer(s, i)
-	case "world":
-		b.trackableCmds.HandleBOTWWorld(s, i)
-	case "finish":
-		b.trackableCmds.HandleBOTWFinish(s, i)
-	default:
-		log.Printf("Unknown BOTW subcommand: %s", subcommand)
-	}
-}
-
-// handleSOTWCommand routes SOTW subcommands.
-func (b *Bot) handleSOTWCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		{
+			Name:        "admin",
+			Description: "Manage other members' account links (Coordinator only)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "link",
+					Description: "Link a RuneScape account to a member",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionUser,
+							Name:        "user",
+							Description: "The member to link",
+							Required:    true,
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "rsn",
+							Description: "The RuneScape username to link",
+							Required:    true,
+							MaxLength:   12,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "unlink",
+					Description: "Unlink a member's RuneScape account",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionUser,
+							Name:        "user",
+							Description: "The member to unlink",
+							Required:    true,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "whois",
+					Description: "Look up account links by member or RSN, including history",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionUser,
+							Name:        "user",
+							Description: "The member to look up",
+							Required:    false,
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "rsn",
+							Description: "The RuneScape username to look up",
+							Required:    false,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "import-links",
+					Description: "Bulk link accounts from a CSV of discord_id,rsn (shows a dry run first)",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionAttachment,
+							Name:        "file",
+							Description: "CSV file with one discord_id,rsn pair per line",
+							Required:    true,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "export-links",
+					Description: "Export the active account links of this server's members",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "format",
+							Description: "File format (default: CSV)",
+							Required:    false,
+							Choices: []*discordgo.ApplicationCommandOptionChoice{
+								{Name: "CSV", Value: "csv"},
+								{Name: "JSON", Value: "json"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "warn",
+			Description: "Member warnings (Coordinator only)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "add",
+					Description: "Warn a member",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionUser,
+							Name:        "user",
+							Description: "The member to warn",
+							Required:    true,
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "reason",
+							Description: "Why the member is being warned",
+							Required:    true,
+							MaxLength:   1000,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "list",
+					Description: "Show a member's warning history",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionUser,
+							Name:        "user",
+							Description: "The member to look up",
+							Required:    true,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "remove",
+					Description: "Remove a warning by ID",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Name:        "id",
+							Description: "The warning ID (shown in /warn list)",
+							Required:    true,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "channel",
+					Description: "Set the channel warnings are posted to (omit to clear)",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionChannel,
+							Name:        "channel",
+							Description: "Channel for warning notices",
+							Required:    false,
+							ChannelTypes: []discordgo.ChannelType{
+								discordgo.ChannelTypeGuildText,
+							},
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "actions",
+					Description: "Show automatic actions taken against a member",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionUser,
+							Name:        "user",
+							Description: "The member to look up",
+							Required:    true,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "revert",
+					Description: "Undo an automatic action (timeout, role removal, kick suggestion)",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Name:        "id",
+							Description: "The action ID (shown in /warn actions)",
+							Required:    true,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+					Name:        "policy",
+					Description: "Warning expiry and escalation rules",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "add",
+							Description: "Take an action when a member reaches a number of active warnings",
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Type:        discordgo.ApplicationCommandOptionInteger,
+									Name:        "warnings",
+									Description: "Number of active warnings that triggers the action",
+									Required:    true,
+									MinValue:    &minEscalationThreshold,
+								},
+								{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "action",
+									Description: "What to do",
+									Required:    true,
+									Choices: []*discordgo.ApplicationCommandOptionChoice{
+										{Name: "Timeout", Value: commands.EscalationTimeout},
+										{Name: "Remove role", Value: commands.EscalationRemoveRole},
+										{Name: "Suggest kick to moderators", Value: commands.EscalationKickSuggestion},
+									},
+								},
+								{
+									Type:        discordgo.ApplicationCommandOptionInteger,
+									Name:        "duration_hours",
+									Description: "Timeout length in hours (timeout only, max 672)",
+									Required:    false,
+								},
+								{
+									Type:        discordgo.ApplicationCommandOptionRole,
+									Name:        "role",
+									Description: "Role to remove (remove role only)",
+									Required:    false,
+								},
+							},
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "remove",
+							Description: "Remove an escalation rule",
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Type:        discordgo.ApplicationCommandOptionInteger,
+									Name:        "id",
+									Description: "The rule ID (shown in /warn policy list)",
+									Required:    true,
+								},
+							},
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "list",
+							Description: "Show warning expiry and escalation rules",
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "expiry",
+							Description: "Set how many days warnings stay active (0 = never expire)",
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Type:        discordgo.ApplicationCommandOptionInteger,
+									Name:        "days",
+									Description: "Days until a warning expires",
+									Required:    true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Type: discordgo.UserApplicationCommand,
+			Name: "View Warnings",
+		},
+		{
+			Name:        "setup",
+			Description: "Walk through the server configuration step by step (Owner/Admin only)",
+		},
+		{
+			Name:        "audit",
+			Description: "Search the audit log of configuration and event changes (Coordinator only)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "search",
+					Description: "List recent audit log entries",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionUser,
+							Name:        "user",
+							Description: "Only show changes made by this member",
+							Required:    false,
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "action",
+							Description: "Only show this kind of change",
+							Required:    false,
+							Choices: []*discordgo.ApplicationCommandOptionChoice{
+								{Name: "Configuration", Value: "config."},
+								{Name: "Events", Value: "event."},
+								{Name: "Account links", Value: "link."},
+								{Name: "Applications", Value: "application."},
+								{Name: "Warnings", Value: "warning."},
+								{Name: "Roster", Value: "roster."},
+							},
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Name:        "days",
+							Description: "Only show changes from the last N days",
+							Required:    false,
+							MinValue:    &minAuditDays,
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Name:        "limit",
+							Description: "Number of entries to show (default 10, max 25)",
+							Required:    false,
+							MinValue:    &minAuditLimit,
+							MaxValue:    25,
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "inactive",
+			Description: "List linked members who haven't gained XP recently (Coordinator only)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "days",
+					Description: "Days without XP gained (defaults to the server's threshold)",
+					Required:    false,
+					MinValue:    &minInactivityDays,
+					MaxValue:    365,
+				},
+			},
+		},
+		{
+			Name:        "roster",
+			Description: "Keep the Wise Old Man group in sync with linked members (Coordinator only)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "sync",
+					Description: "Add linked RSNs to the group and list group members without a link",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "update-all",
+					Description: "Update every outdated group member on Wise Old Man",
+				},
+			},
+		},
+	}
+
+	// Register command handlers
+	b.registerHandler("link-rsn", sessionHandler(b.registerCmds.HandleLinkRSN))
+	b.registerHandler("unlink-rsn", sessionHandler(b.registerCmds.HandleUnlinkRSN))
+	b.registerHandler("botw", b.RequireFeature(commands.FeatureBOTW, b.RequirePermission(PermissionCoordinator, b.handleBOTWCommand)))
+	b.registerHandler("sotw", b.RequireFeature(commands.FeatureSOTW, b.RequirePermission(PermissionCoordinator, b.handleSOTWCommand)))
+	b.registerHandler("mass", b.RequireFeature(commands.FeatureMass, b.RequirePermission(PermissionEveryone, sessionHandler(b.schedulableCmds.HandleMassEvent))))
+	b.registerHandler("config", b.handleConfigCommand)
+	b.registerHandler("setup", b.setupCmds.HandleSetup)
+	b.registerHandler("admin", b.RequirePermission(PermissionCoordinator, b.handleAdminCommand))
+	b.registerHandler("warn", b.RequireFeature(commands.FeatureWarnings, b.RequirePermission(PermissionCoordinator, b.handleWarnCommand)))
+	b.registerHandler("View Warnings", b.RequireFeature(commands.FeatureWarnings, b.RequirePermission(PermissionCoordinator, b.warnCmds.HandleViewWarnings)))
+	b.registerHandler("audit", b.RequirePermission(PermissionCoordinator, b.handleAuditCommand))
+	b.registerHandler("inactive", b.RequirePermission(PermissionCoordinator, b.inactivityCmds.HandleInactive))
+	b.registerHandler("roster", b.RequirePermission(PermissionCoordinator, b.handleRosterCommand))
+	b.registerHandler("profile", b.RequirePermission(PermissionEveryone, b.handleProfileCommand))
+	b.registerHandler("gains", b.RequirePermission(PermissionEveryone, sessionHandler(b.gainsCmds.HandleGains)))
+
+	// /config, /setup, account linking and the administrator-only /warn policy changes keep their own
+	// checks and can't be restricted further
+	b.configCmds.PermissionKeys = commands.CommandPermissionKeys(b.commands, "config", "setup", "link-rsn", "unlink-rsn",
+		"warn policy", "warn policy add", "warn policy remove", "warn policy expiry")
+
+	// Register commands with Discord
+	for _, cmd := range b.commands {
+		_, err := b.Session.ApplicationCommandCreate(b.Session.State.User.ID, b.GuildID, cmd)
+		if err != nil {
+			return fmt.Errorf("failed to create command %s: %w", cmd.Name, err)
+		}
+		log.Printf("Registered command: %s", cmd.Name)
+	}
+
+	return nil
+}
+
+// teamsOption lets coordinators start a BOTW or SOTW as a team event.
+func teamsOption() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        "teams",
+		Description: "Comma-separated team names to run a team event, e.g. Red, Blue",
+		MaxLength:   320,
+	}
+}
+
+// balanceTeamsOption places team event members by their current KC or XP.
+func balanceTeamsOption() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionBoolean,
+		Name:        "balance",
+		Description: "Balance teams by current KC/XP (defaults to two teams without team names)",
+	}
+}
+
+// unregisterCommands removes all registered commands.
+func (b *Bot) unregisterCommands() error {
+	commands, err := b.Session.ApplicationCommands(b.Session.State.User.ID, b.GuildID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch commands: %w", err)
+	}
+
+	for _, cmd := range commands {
+		err := b.Session.ApplicationCommandDelete(b.Session.State.User.ID, b.GuildID, cmd.ID)
+		if err != nil {
+			log.Printf("Failed to delete command %s: %v", cmd.Name, err)
+		}
+		log.Printf("Removed cmd %s", cmd.Name)
+	}
+
+	return nil
+}
+
+// registerHandler registers a command handler.
+func (b *Bot) registerHandler(name string, handler func(s *discordgo.Session, i *discordgo.InteractionCreate)) {
+	b.handlers[name] = handler
+}
+
+// sessionHandler adapts a handler written against commands.DiscordSession to the router.
+func sessionHandler(handler func(s commands.DiscordSession, i *discordgo.InteractionCreate)) handlerFunc {
+	return func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		handler(s, i)
+	}
+}
+
+// handleBOTWCommand routes BOTW subcommands.
+func (b *Bot) handleBOTWCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	data := i.ApplicationCommandData()
 	if len(data.Options) == 0 {
 		return
@@ -442,18 +1407,33 @@ func (b *Bot) handleSOTWCommand(s *discordgo.Session, i *discordgo.InteractionCr
 
 	subcommand := data.Options[0].Name
 
-	// All SOTW commands require Coordinator permission
-	if !b.HasPermission(s, i, PermissionCoordinator) {
-		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Content: "❌ You don't have permission to use this command. Coordinator role required.",
-				Flags:   discordgo.MessageFlagsEphemeral,
-			},
-		})
+	switch subcommand {
+	case "wildy":
+		b.trackableCmds.HandleBOTWWildy(s, i)
+	case "group":
+		b.trackableCmds.HandleBOTWGroup(s, i)
+	case "quest":
+		b.trackableCmds.HandleBOTWQuest(s, i)
+	case "slayer":
+		b.trackableCmds.HandleBOTWSlayer(s, i)
+	case "world":
+		b.trackableCmds.HandleBOTWWorld(s, i)
+	case "finish":
+		b.trackableCmds.HandleBOTWFinish(s, i)
+	default:
+		log.Printf("Unknown BOTW subcommand: %s", subcommand)
+	}
+}
+
+// handleSOTWCommand routes SOTW subcommands.
+func (b *Bot) handleSOTWCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	data := i.ApplicationCommandData()
+	if len(data.Options) == 0 {
 		return
 	}
 
+	subcommand := data.Options[0].Name
+
 	switch subcommand {
 	case "start":
 		b.trackableCmds.HandleSOTWStart(s, i)
@@ -464,6 +1444,11 @@ func (b *Bot) handleSOTWCommand(s *discordgo.Session, i *discordgo.InteractionCr
 	}
 }
 
+// handleProfileCommand routes /profile, showing warning counts to coordinators.
+func (b *Bot) handleProfileCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	b.profileCmds.HandleProfile(s, i, b.HasPermission(s, i, PermissionCoordinator))
+}
+
 // handleConfigCommand routes config subcommands.
 func (b *Bot) handleConfigCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	data := i.ApplicationCommandData()
@@ -488,11 +1473,330 @@ func (b *Bot) handleConfigCommand(s *discordgo.Session, i *discordgo.Interaction
 		b.configCmds.HandleSetEventNotificationChannel(s, i)
 	case "set-event-notification-role":
 		b.configCmds.HandleSetEventNotificationRole(s, i)
+	case "set-nickname-template":
+		b.configCmds.HandleSetNicknameTemplate(s, i)
+	case "resync-nicknames":
+		b.configCmds.HandleResyncNicknames(s, i)
+	case "set-audit-log-channel":
+		b.configCmds.HandleSetAuditLogChannel(s, i)
+	case "doctor":
+		b.configCmds.HandleDoctor(s, i)
+	case "export":
+		b.configCmds.HandleExportConfig(s, i)
+	case "import":
+		b.configCmds.HandleImportConfig(s, i)
+	case "history":
+		b.configCmds.HandleConfigHistory(s, i)
+	case "rollback":
+		b.configCmds.HandleConfigRollback(s, i)
+	case "permissions":
+		b.handleConfigPermissionsCommand(s, i)
+	case "features":
+		b.handleConfigFeaturesCommand(s, i)
+	case "welcome":
+		b.handleConfigWelcomeCommand(s, i)
+	case "notifications":
+		b.handleConfigNotificationsCommand(s, i)
+	case "applications":
+		b.handleConfigApplicationsCommand(s, i)
+	case "inactivity":
+		b.handleConfigInactivityCommand(s, i)
+	case "wom-group":
+		b.handleConfigWOMGroupCommand(s, i)
+	case "rank-roles":
+		b.handleConfigRankRolesCommand(s, i)
 	default:
 		log.Printf("Unknown config subcommand: %s", subcommand)
 	}
 }
 
+// handleConfigPermissionsCommand routes /config permissions subcommands.
+func (b *Bot) handleConfigPermissionsCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	group := i.ApplicationCommandData().Options[0]
+	if len(group.Options) == 0 {
+		return
+	}
+
+	subcommand := group.Options[0].Name
+
+	switch subcommand {
+	case "allow":
+		b.configCmds.HandlePermissionsAllow(s, i)
+	case "revoke":
+		b.configCmds.HandlePermissionsRevoke(s, i)
+	case "reset":
+		b.configCmds.HandlePermissionsReset(s, i)
+	case "list":
+		b.configCmds.HandlePermissionsList(s, i)
+	default:
+		log.Printf("Unknown config permissions subcommand: %s", subcommand)
+	}
+}
+
+// handleAuditCommand routes audit subcommands.
+func (b *Bot) handleAuditCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	data := i.ApplicationCommandData()
+	if len(data.Options) == 0 {
+		return
+	}
+
+	subcommand := data.Options[0].Name
+	switch subcommand {
+	case "search":
+		b.auditCmds.HandleAuditSearch(s, i)
+	default:
+		log.Printf("Unknown audit subcommand: %s", subcommand)
+	}
+}
+
+// handleRosterCommand routes roster subcommands.
+func (b *Bot) handleRosterCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	data := i.ApplicationCommandData()
+	if len(data.Options) == 0 {
+		return
+	}
+
+	subcommand := data.Options[0].Name
+	switch subcommand {
+	case "sync":
+		b.rosterCmds.HandleSync(s, i)
+	case "update-all":
+		b.rosterCmds.HandleUpdateAll(s, i)
+	default:
+		log.Printf("Unknown roster subcommand: %s", subcommand)
+	}
+}
+
+// handleAdminCommand routes admin subcommands.
+func (b *Bot) handleAdminCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	data := i.ApplicationCommandData()
+	if len(data.Options) == 0 {
+		return
+	}
+
+	subcommand := data.Options[0].Name
+
+	switch subcommand {
+	case "link":
+		b.adminCmds.HandleAdminLink(s, i)
+	case "unlink":
+		b.adminCmds.HandleAdminUnlink(s, i)
+	case "whois":
+		b.adminCmds.HandleAdminWhois(s, i)
+	case "import-links":
+		b.adminCmds.HandleImportLinks(s, i)
+	case "export-links":
+		b.adminCmds.HandleExportLinks(s, i)
+	default:
+		log.Printf("Unknown admin subcommand: %s", subcommand)
+	}
+}
+
+// handleWarnCommand routes warn subcommands.
+func (b *Bot) handleWarnCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	data := i.ApplicationCommandData()
+	if len(data.Options) == 0 {
+		return
+	}
+
+	subcommand := data.Options[0].Name
+
+	switch subcommand {
+	case "add":
+		b.warnCmds.HandleWarnAdd(s, i)
+	case "list":
+		b.warnCmds.HandleWarnList(s, i)
+	case "remove":
+		b.warnCmds.HandleWarnRemove(s, i)
+	case "channel":
+		b.warnCmds.HandleWarnChannel(s, i)
+	case "actions":
+		b.warnCmds.HandleWarnActions(s, i)
+	case "revert":
+		b.warnCmds.HandleWarnRevert(s, i)
+	case "policy":
+		b.handleWarnPolicyCommand(s, i)
+	default:
+		log.Printf("Unknown warn subcommand: %s", subcommand)
+	}
+}
+
+// handleWarnPolicyCommand routes /warn policy subcommands.
+func (b *Bot) handleWarnPolicyCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	group := i.ApplicationCommandData().Options[0]
+	if len(group.Options) == 0 {
+		return
+	}
+
+	subcommand := group.Options[0].Name
+
+	switch subcommand {
+	case "add":
+		b.warnCmds.HandleWarnPolicyAdd(s, i)
+	case "remove":
+		b.warnCmds.HandleWarnPolicyRemove(s, i)
+	case "list":
+		b.warnCmds.HandleWarnPolicyList(s, i)
+	case "expiry":
+		b.warnCmds.HandleWarnPolicyExpiry(s, i)
+	default:
+		log.Printf("Unknown warn policy subcommand: %s", subcommand)
+	}
+}
+
+// handleConfigFeaturesCommand routes /config features subcommands.
+func (b *Bot) handleConfigFeaturesCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	group := i.ApplicationCommandData().Options[0]
+	if len(group.Options) == 0 {
+		return
+	}
+
+	subcommand := group.Options[0].Name
+
+	switch subcommand {
+	case "enable":
+		b.configCmds.HandleFeaturesEnable(s, i)
+	case "disable":
+		b.configCmds.HandleFeaturesDisable(s, i)
+	case "list":
+		b.configCmds.HandleFeaturesList(s, i)
+	default:
+		log.Printf("Unknown config features subcommand: %s", subcommand)
+	}
+}
+
+// handleConfigWelcomeCommand routes /config welcome subcommands.
+func (b *Bot) handleConfigWelcomeCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	group := i.ApplicationCommandData().Options[0]
+	if len(group.Options) == 0 {
+		return
+	}
+
+	subcommand := group.Options[0].Name
+
+	switch subcommand {
+	case "edit":
+		b.configCmds.HandleWelcomeEdit(s, i)
+	case "channel":
+		b.configCmds.HandleWelcomeChannel(s, i)
+	case "checklist":
+		b.configCmds.HandleWelcomeChecklist(s, i)
+	case "linked-role":
+		b.configCmds.HandleWelcomeLinkedRole(s, i)
+	case "preview":
+		b.configCmds.HandleWelcomePreview(s, i)
+	default:
+		log.Printf("Unknown config welcome subcommand: %s", subcommand)
+	}
+}
+
+// handleConfigNotificationsCommand routes /config notifications subcommands.
+func (b *Bot) handleConfigNotificationsCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	group := i.ApplicationCommandData().Options[0]
+	if len(group.Options) == 0 {
+		return
+	}
+
+	subcommand := group.Options[0].Name
+
+	switch subcommand {
+	case "set-role":
+		b.configCmds.HandleNotificationsSetRole(s, i)
+	case "list":
+		b.configCmds.HandleNotificationsList(s, i)
+	case "post-picker":
+		b.configCmds.HandleNotificationsPostPicker(s, i)
+	default:
+		log.Printf("Unknown config notifications subcommand: %s", subcommand)
+	}
+}
+
+// handleConfigApplicationsCommand routes /config applications subcommands.
+func (b *Bot) handleConfigApplicationsCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	group := i.ApplicationCommandData().Options[0]
+	if len(group.Options) == 0 {
+		return
+	}
+
+	subcommand := group.Options[0].Name
+
+	switch subcommand {
+	case "review-channel":
+		b.configCmds.HandleApplicationsReviewChannel(s, i)
+	case "approved-role":
+		b.configCmds.HandleApplicationsApprovedRole(s, i)
+	case "requirements":
+		b.configCmds.HandleApplicationsRequirements(s, i)
+	case "post-button":
+		b.configCmds.HandleApplicationsPostButton(s, i)
+	default:
+		log.Printf("Unknown config applications subcommand: %s", subcommand)
+	}
+}
+
+// handleConfigInactivityCommand routes /config inactivity subcommands.
+func (b *Bot) handleConfigInactivityCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	group := i.ApplicationCommandData().Options[0]
+	if len(group.Options) == 0 {
+		return
+	}
+
+	subcommand := group.Options[0].Name
+
+	switch subcommand {
+	case "report-channel":
+		b.configCmds.HandleInactivityReportChannel(s, i)
+	case "threshold":
+		b.configCmds.HandleInactivityThreshold(s, i)
+	case "nudges":
+		b.configCmds.HandleInactivityNudges(s, i)
+	default:
+		log.Printf("Unknown config inactivity subcommand: %s", subcommand)
+	}
+}
+
+// handleConfigWOMGroupCommand routes /config wom-group subcommands.
+func (b *Bot) handleConfigWOMGroupCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	group := i.ApplicationCommandData().Options[0]
+	if len(group.Options) == 0 {
+		return
+	}
+
+	subcommand := group.Options[0].Name
+
+	switch subcommand {
+	case "set":
+		b.configCmds.HandleWOMGroupSet(s, i)
+	case "clear":
+		b.configCmds.HandleWOMGroupClear(s, i)
+	default:
+		log.Printf("Unknown config wom-group subcommand: %s", subcommand)
+	}
+}
+
+// handleConfigRankRolesCommand routes /config rank-roles subcommands.
+func (b *Bot) handleConfigRankRolesCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	group := i.ApplicationCommandData().Options[0]
+	if len(group.Options) == 0 {
+		return
+	}
+
+	subcommand := group.Options[0].Name
+
+	switch subcommand {
+	case "set":
+		b.configCmds.HandleRankRolesSet(s, i)
+	case "list":
+		b.configCmds.HandleRankRolesList(s, i)
+	case "preview":
+		b.configCmds.HandleRankRolesPreview(s, i)
+	case "sync":
+		b.configCmds.HandleRankRolesSync(s, i)
+	default:
+		log.Printf("Unknown config rank-roles subcommand: %s", subcommand)
+	}
+}
+
 // interactionHandler handles all interactions.
 func (b *Bot) interactionHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	switch i.Type {
@@ -501,7 +1805,7 @@ func (b *Bot) interactionHandler(s *discordgo.Session, i *discordgo.InteractionC
 			handler(s, i)
 		}
 	case discordgo.InteractionApplicationCommandAutocomplete:
-		b.handleTimezoneAutocomplete(s, i)
+		b.handleAutocomplete(s, i)
 	case discordgo.InteractionMessageComponent:
 		b.handleComponentInteraction(s, i)
 	case discordgo.InteractionModalSubmit:
@@ -526,11 +1830,30 @@ func (b *Bot) handleComponentInteraction(s *discordgo.Session, i *discordgo.Inte
 		data = parts[1]
 	}
 
+	if feature, ok := componentFeatures[action]; ok && !b.featureAllowed(s, i, feature) {
+		return
+	}
+
 	// Route based on action
 	switch action {
 	case "dm-link-rsn":
-		// Handle DM link button - show modal (reuse existing handler)
-		b.registerCmds.HandleLinkRSN(s, i)
+		b.registerCmds.HandleDMLinkRSN(s, i, data)
+	case "onboarding-timezone":
+		b.welcomeCmds.HandleTimezoneButton(s, i)
+	case "onboarding-notifications":
+		b.welcomeCmds.HandleNotificationsButton(s, i, data)
+	case "notify-toggle":
+		b.notifyCmds.HandleNotificationToggle(s, i, data)
+	case "apply-open":
+		b.applicationCmds.HandleApplyButton(s, i)
+	case "application-approve":
+		b.RequirePermission(PermissionCoordinator, func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			b.applicationCmds.HandleApproveApplication(s, i, data)
+		})(s, i)
+	case "application-deny":
+		b.RequirePermission(PermissionCoordinator, func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			b.applicationCmds.HandleDenyApplication(s, i, data)
+		})(s, i)
 	case "confirm-rsn":
 		b.registerCmds.HandleConfirmRSN(s, i, data)
 	case "cancel-rsn":
@@ -543,10 +1866,32 @@ func (b *Bot) handleComponentInteraction(s *discordgo.Session, i *discordgo.Inte
 		b.handleListParticipants(s, i, data)
 	case "list-participants-sotw":
 		b.handleListParticipants(s, i, data)
+	case "join-team-event":
+		b.handleTeamEvent(s, i, data, b.trackableCmds.RegisterForTeamEvent)
+	case "list-team-event":
+		b.handleTeamEvent(s, i, data, b.trackableCmds.ListTeamEvent)
 	case "participate-mass":
 		b.schedulableCmds.HandleParticipateInMass(s, i, data)
 	case "list-participants-mass":
 		b.schedulableCmds.HandleListParticipantsMass(s, i, data)
+	case "confirm-link-import":
+		b.adminCmds.HandleConfirmLinkImport(s, i, data)
+	case "cancel-link-import":
+		b.adminCmds.HandleCancelLinkImport(s, i, data)
+	case "confirm-config-import":
+		b.configCmds.HandleConfirmConfigImport(s, i, data)
+	case "cancel-config-import":
+		b.configCmds.HandleCancelConfigImport(s, i, data)
+	case "setup-select":
+		b.setupCmds.HandleSetupSelect(s, i, data)
+	case "setup-skip":
+		b.setupCmds.HandleSetupSkip(s, i, data)
+	case "setup-back":
+		b.setupCmds.HandleSetupBack(s, i, data)
+	case "setup-save":
+		b.setupCmds.HandleSetupSave(s, i, data)
+	case "setup-cancel":
+		b.setupCmds.HandleSetupCancel(s, i, data)
 	default:
 		log.Printf("Unknown component action: %s", action)
 	}
@@ -601,19 +1946,68 @@ func (b *Bot) handleListParticipants(s *discordgo.Session, i *discordgo.Interact
 	}
 }
 
+// handleTeamEvent handles team event button clicks, whose data is the team event ID.
+func (b *Bot) handleTeamEvent(s *discordgo.Session, i *discordgo.InteractionCreate, data string, handle func(commands.DiscordSession, *discordgo.InteractionCreate, int64) error) {
+	teamEventID, err := strconv.ParseInt(data, 10, 64)
+	if err != nil {
+		log.Printf("Invalid team event ID: %s", data)
+		return
+	}
+
+	if !b.teamEventAllowed(s, i, teamEventID) {
+		return
+	}
+
+	if err := handle(s, i, teamEventID); err != nil {
+		slog.Error("failed to handle team event button",
+			"error", err,
+			"custom_id", i.MessageComponentData().CustomID,
+			"team_event_id", teamEventID,
+		)
+	}
+}
+
 // handleModalSubmit handles modal submissions.
 func (b *Bot) handleModalSubmit(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	customID := i.ModalSubmitData().CustomID
 
+	// Parse custom ID: "action:data"
+	action, data, _ := strings.Cut(customID, ":")
+
+	if feature, ok := componentFeatures[action]; ok && !b.featureAllowed(s, i, feature) {
+		return
+	}
+
 	// Route modal submissions
-	switch customID {
+	switch action {
 	case "link-rsn-modal":
-		b.registerCmds.HandleLinkRSNModal(s, i)
+		b.registerCmds.HandleLinkRSNModal(s, i, data)
+	case "onboarding-timezone-modal":
+		b.welcomeCmds.HandleTimezoneModal(s, i)
+	case "welcome-edit-modal":
+		b.configCmds.HandleWelcomeEditModal(s, i)
+	case "application-modal":
+		b.applicationCmds.HandleApplicationModal(s, i)
+	case "application-deny-modal":
+		b.RequirePermission(PermissionCoordinator, func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			b.applicationCmds.HandleDenyApplicationModal(s, i, data)
+		})(s, i)
 	default:
 		log.Printf("Unknown modal submit: %s", customID)
 	}
 }
 
+// handleAutocomplete routes autocomplete requests by the command being typed.
+func (b *Bot) handleAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	path := commandPath(i)
+	if len(path) >= 2 && path[0] == "config" && path[1] == "permissions" {
+		b.configCmds.HandlePermissionsAutocomplete(s, i)
+		return
+	}
+
+	b.handleTimezoneAutocomplete(s, i)
+}
+
 // handleTimezoneAutocomplete handles timezone autocomplete.
 func (b *Bot) handleTimezoneAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	data := i.ApplicationCommandData()
@@ -662,49 +2056,3 @@ func (b *Bot) handleTimezoneAutocomplete(s *discordgo.Session, i *discordgo.Inte
 		log.Printf("Error responding to autocomplete: %v", err)
 	}
 }
-
-// handleGuildMemberAdd sends a greeting DM to new members.
-func (b *Bot) handleGuildMemberAdd(s *discordgo.Session, m *discordgo.GuildMemberAdd) {
-	// Get guild information for greeting
-	guild, err := s.Guild(m.GuildID)
-	if err != nil {
-		log.Printf("Error fetching guild info for greeting: %v", err)
-		return
-	}
-
-	// Create DM channel with the new member
-	dmChannel, err := s.UserChannelCreate(m.User.ID)
-	if err != nil {
-		log.Printf("Error creating DM channel for user %s: %v", m.User.Username, err)
-		return
-	}
-
-	// Create greeting embed
-	embed := embeds.WelcomeGreeting(guild.Name)
-
-	// Create "Link My Account" button
-	components := []discordgo.MessageComponent{
-		discordgo.ActionsRow{
-			Components: []discordgo.MessageComponent{
-				discordgo.Button{
-					Label:    "🔗 Link My RuneScape Account",
-					Style:    discordgo.PrimaryButton,
-					CustomID: fmt.Sprintf("dm-link-rsn:%s", m.GuildID), // Include guild ID for context
-				},
-			},
-		},
-	}
-
-	// Send greeting message in DM
-	_, err = s.ChannelMessageSendComplex(dmChannel.ID, &discordgo.MessageSend{
-		Embeds:     []*discordgo.MessageEmbed{embed},
-		Components: components,
-	})
-	if err != nil {
-		log.Printf("Error sending greeting DM to user %s: %v", m.User.Username, err)
-		// User likely has DMs disabled, fail silently
-		return
-	}
-
-	log.Printf("Sent greeting DM to new member: %s (ID: %s) in guild: %s", m.User.Username, m.User.ID, guild.Name)
-}
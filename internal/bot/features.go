@@ -0,0 +1,78 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/commands"
+	"github.com/kaffeed/voidling/internal/models"
+)
+
+// componentFeatures maps the action of a button or modal custom ID to the feature it belongs to,
+// so messages posted before a feature was disabled stop working along with its commands.
+var componentFeatures = map[string]string{
+	"register-for-botw":      commands.FeatureBOTW,
+	"list-participants-botw": commands.FeatureBOTW,
+	"register-for-sotw":      commands.FeatureSOTW,
+	"list-participants-sotw": commands.FeatureSOTW,
+	"participate-mass":       commands.FeatureMass,
+	"list-participants-mass": commands.FeatureMass,
+}
+
+// eventTypeFeatures maps a trackable event type to the feature that runs it.
+var eventTypeFeatures = map[models.EventType]string{
+	models.EventTypeBossOfTheWeek:  commands.FeatureBOTW,
+	models.EventTypeSkillOfTheWeek: commands.FeatureSOTW,
+}
+
+// RequireFeature wraps a handler so it only runs in guilds that haven't disabled feature
+// (see /config features). Otherwise the user is told the command is turned off.
+func (b *Bot) RequireFeature(feature string, handler func(s *discordgo.Session, i *discordgo.InteractionCreate)) func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	return func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		if b.featureAllowed(s, i, feature) {
+			handler(s, i)
+		}
+	}
+}
+
+// featureAllowed reports whether feature is enabled in the interaction's guild. If it isn't, the
+// user is told so and false is returned.
+func (b *Bot) featureAllowed(s *discordgo.Session, i *discordgo.InteractionCreate, feature string) bool {
+	if commands.FeatureEnabled(context.Background(), b.DB, i.GuildID, feature) {
+		return true
+	}
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: featureDisabledMessage(i, feature),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+	return false
+}
+
+// teamEventAllowed is featureAllowed for a team event button. Team events run as BOTW or SOTW, so
+// they follow that feature.
+func (b *Bot) teamEventAllowed(s *discordgo.Session, i *discordgo.InteractionCreate, teamEventID int64) bool {
+	event, err := b.DB.GetTeamEvent(context.Background(), teamEventID)
+	if err != nil {
+		return true // The handler reports unknown events
+	}
+	feature, ok := eventTypeFeatures[models.EventType(event.Type)]
+	return !ok || b.featureAllowed(s, i, feature)
+}
+
+// featureDisabledMessage explains that the invoked command or button is turned off on this server.
+func featureDisabledMessage(i *discordgo.InteractionCreate, feature string) string {
+	if i.Type != discordgo.InteractionApplicationCommand {
+		return fmt.Sprintf("❌ The `%s` feature is disabled on this server. An administrator can turn it back on with `/config features enable`.", feature)
+	}
+
+	name := i.ApplicationCommandData().Name
+	if i.ApplicationCommandData().CommandType == discordgo.ChatApplicationCommand {
+		name = "/" + name
+	}
+	return fmt.Sprintf("❌ `%s` is disabled on this server. An administrator can turn it back on with `/config features enable`.", name)
+}
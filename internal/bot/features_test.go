@@ -0,0 +1,24 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/commands"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeatureDisabledMessage(t *testing.T) {
+	command := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		Type: discordgo.InteractionApplicationCommand,
+		Data: discordgo.ApplicationCommandInteractionData{Name: "mass", CommandType: discordgo.ChatApplicationCommand},
+	}}
+	button := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		Type: discordgo.InteractionMessageComponent,
+		Data: discordgo.MessageComponentInteractionData{CustomID: "participate-mass:1"},
+	}}
+
+	assert.Contains(t, featureDisabledMessage(command, commands.FeatureMass), "`/mass` is disabled")
+	assert.Contains(t, featureDisabledMessage(button, commands.FeatureMass), "The `mass` feature is disabled")
+	assert.Equal(t, commands.FeatureMass, componentFeatures["participate-mass"])
+}
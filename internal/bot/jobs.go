@@ -0,0 +1,143 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/kaffeed/voidling/internal/commands"
+)
+
+// nicknameSyncInterval is how often linked members' nicknames are refreshed from WOM and role data.
+const nicknameSyncInterval = 6 * time.Hour
+
+// massReminderInterval is how often upcoming masses are checked for participants to remind.
+const massReminderInterval = time.Minute
+
+// inactivityCheckInterval is how often linked members' WOM activity is refreshed and due inactivity reports are posted.
+const inactivityCheckInterval = 6 * time.Hour
+
+// rosterSyncInterval is how often newly linked RSNs are added to each guild's Wise Old Man group.
+const rosterSyncInterval = 12 * time.Hour
+
+// rankRoleSyncInterval is how often linked members' Discord roles are reconciled with their WOM group rank.
+const rankRoleSyncInterval = 6 * time.Hour
+
+// startJobs starts the bot's periodic background jobs. They stop when Stop is called.
+func (b *Bot) startJobs() {
+	go b.runPeriodic("nickname sync", nicknameSyncInterval, b.syncAllNicknames)
+	go b.runPeriodic("mass reminders", massReminderInterval, b.sendMassReminders)
+	go b.runPeriodic("inactivity check", inactivityCheckInterval, b.checkInactivity)
+	go b.runPeriodic("roster sync", rosterSyncInterval, b.syncRosters)
+	go b.runPeriodic("rank role sync", rankRoleSyncInterval, b.syncRankRoles)
+}
+
+// runPeriodic calls job every interval until the bot is stopped.
+func (b *Bot) runPeriodic(name string, interval time.Duration, job func(ctx context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.stopJobs:
+			log.Printf("Stopping %s job", name)
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			job(ctx)
+			cancel()
+		}
+	}
+}
+
+// syncAllNicknames re-applies the nickname template in every guild the bot is in.
+func (b *Bot) syncAllNicknames(ctx context.Context) {
+	for _, guildID := range b.guildIDs() {
+		result, err := commands.ResyncNicknames(ctx, b.Session, b.DB, b.WOMClient, guildID)
+		if errors.Is(err, commands.ErrAutoNicknameDisabled) {
+			continue
+		}
+		if err != nil {
+			log.Printf("Nickname sync failed for guild %s: %v", guildID, err)
+			continue
+		}
+		log.Printf("Nickname sync for guild %s: %d updated, %d unchanged, %d blocked by role hierarchy, %d failed",
+			guildID, result.Updated, result.Unchanged, result.Forbidden, result.Failed)
+	}
+}
+
+// sendMassReminders DMs participants of masses that are about to start.
+func (b *Bot) sendMassReminders(ctx context.Context) {
+	sent, err := commands.SendMassReminders(ctx, b.Session, b.DB, time.Now())
+	if err != nil {
+		log.Printf("Mass reminders failed: %v", err)
+	}
+	if sent > 0 {
+		log.Printf("Sent %d mass reminders", sent)
+	}
+}
+
+// checkInactivity refreshes linked members' WOM activity and posts inactivity reports that are due.
+func (b *Bot) checkInactivity(ctx context.Context) {
+	refreshed, err := commands.RefreshPlayerActivity(ctx, b.DB, b.WOMClient, time.Now())
+	if err != nil {
+		// Reports still go out with the activity that was stored
+		log.Printf("Activity refresh failed: %v", err)
+	}
+	if refreshed > 0 {
+		log.Printf("Refreshed WOM activity for %d players", refreshed)
+	}
+
+	for _, guildID := range b.guildIDs() {
+		if err := commands.SendInactivityReport(ctx, b.Session, b.DB, guildID, time.Now()); err != nil {
+			log.Printf("Inactivity report failed for guild %s: %v", guildID, err)
+		}
+	}
+}
+
+// syncRosters adds newly linked RSNs to the Wise Old Man group of every guild that has one.
+func (b *Bot) syncRosters(ctx context.Context) {
+	for _, guildID := range b.guildIDs() {
+		result, err := commands.SyncRoster(ctx, b.Session, b.DB, b.WOMClient, guildID)
+		if errors.Is(err, commands.ErrNoWOMGroup) {
+			continue
+		}
+		if err != nil {
+			log.Printf("Roster sync failed for guild %s: %v", guildID, err)
+			continue
+		}
+		log.Printf("Roster sync for guild %s: %d added to WOM group %d, %d group members unlinked",
+			guildID, len(result.Added), result.GroupID, len(result.Unlinked))
+	}
+}
+
+// syncRankRoles reconciles rank roles in every guild that maps WOM group ranks to roles. Changes are
+// audited as the bot.
+func (b *Bot) syncRankRoles(ctx context.Context) {
+	for _, guildID := range b.guildIDs() {
+		result, err := commands.SyncRankRoles(ctx, b.Session, b.DB, b.WOMClient, b.configCmds.Audit, guildID, b.Session.State.User.ID, true)
+		if errors.Is(err, commands.ErrNoWOMGroup) || errors.Is(err, commands.ErrNoRankRoles) {
+			continue
+		}
+		if err != nil {
+			log.Printf("Rank role sync failed for guild %s: %v", guildID, err)
+			continue
+		}
+		if len(result.Changes) > 0 || result.Failed > 0 {
+			log.Printf("Rank role sync for guild %s: %d changed, %d failed", guildID, len(result.Changes), result.Failed)
+		}
+	}
+}
+
+// guildIDs returns the IDs of all guilds the bot is currently in.
+func (b *Bot) guildIDs() []string {
+	b.Session.State.RLock()
+	defer b.Session.State.RUnlock()
+
+	ids := make([]string, 0, len(b.Session.State.Guilds))
+	for _, guild := range b.Session.State.Guilds {
+		ids = append(ids, guild.ID)
+	}
+	return ids
+}
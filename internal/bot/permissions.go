@@ -2,10 +2,14 @@ package bot
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"strconv"
+	"strings"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/commands"
+	"github.com/kaffeed/voidling/internal/database"
 )
 
 // PermissionLevel represents the required permission level for a command.
@@ -100,14 +104,22 @@ func hasRole(member *discordgo.Member, roleID string) bool {
 }
 
 // RequirePermission wraps a handler with permission checking.
+// Per-guild command overrides (see /config permissions) take precedence over level.
 func (b *Bot) RequirePermission(level PermissionLevel, handler func(s *discordgo.Session, i *discordgo.InteractionCreate)) func(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	return func(s *discordgo.Session, i *discordgo.InteractionCreate) {
-		if !b.HasPermission(s, i, level) {
+		path := commandPath(i)
+
+		allowed, overridden := b.commandOverride(i, path)
+		if !overridden {
+			allowed = b.HasPermission(s, i, level)
+		}
+
+		if !allowed {
 			// Send permission denied message
 			s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 				Type: discordgo.InteractionResponseChannelMessageWithSource,
 				Data: &discordgo.InteractionResponseData{
-					Content: "❌ You don't have permission to use this command. Coordinator role required.",
+					Content: permissionDeniedMessage(level, path, overridden),
 					Flags:   discordgo.MessageFlagsEphemeral,
 				},
 			})
@@ -118,3 +130,99 @@ func (b *Bot) RequirePermission(level PermissionLevel, handler func(s *discordgo
 		handler(s, i)
 	}
 }
+
+// commandOverride looks up the guild's permission overrides for the invoked command.
+// overridden is false when the guild hasn't restricted this command, in which case
+// the default permission level applies.
+func (b *Bot) commandOverride(i *discordgo.InteractionCreate, path []string) (allowed, overridden bool) {
+	member := i.Member
+	if member == nil || member.User == nil || len(path) == 0 {
+		return false, false
+	}
+
+	guildID, err := strconv.ParseInt(i.GuildID, 10, 64)
+	if err != nil {
+		return false, false
+	}
+
+	rules, err := b.DB.GetCommandPermissions(context.Background(), guildID)
+	if err != nil {
+		log.Printf("Error fetching command permissions for guild %s: %v", i.GuildID, err)
+		return false, false
+	}
+
+	allowed, overridden = matchCommandOverride(rules, path, member)
+	if overridden && !allowed {
+		// Server administrators can't be locked out of a command
+		allowed = member.Permissions&discordgo.PermissionAdministrator != 0
+	}
+	return allowed, overridden
+}
+
+// matchCommandOverride applies the most specific override for path: "warn policy add" wins
+// over "warn policy", which wins over "warn". A member is allowed if they are listed
+// directly or have one of the listed roles.
+func matchCommandOverride(rules []database.CommandPermission, path []string, member *discordgo.Member) (allowed, overridden bool) {
+	for n := len(path); n > 0; n-- {
+		key := strings.Join(path[:n], " ")
+
+		for _, rule := range rules {
+			if rule.Command != key {
+				continue
+			}
+			overridden = true
+
+			subjectID := strconv.FormatInt(rule.SubjectID, 10)
+			switch rule.SubjectType {
+			case commands.PermissionSubjectUser:
+				if member.User != nil && member.User.ID == subjectID {
+					return true, true
+				}
+			case commands.PermissionSubjectRole:
+				if hasRole(member, subjectID) {
+					return true, true
+				}
+			}
+		}
+
+		if overridden {
+			return false, true
+		}
+	}
+
+	return false, false
+}
+
+// commandPath returns the invoked command with its subcommand group and subcommand,
+// e.g. ["warn", "policy", "add"].
+func commandPath(i *discordgo.InteractionCreate) []string {
+	if i.Type != discordgo.InteractionApplicationCommand && i.Type != discordgo.InteractionApplicationCommandAutocomplete {
+		return nil
+	}
+
+	data := i.ApplicationCommandData()
+	path := []string{data.Name}
+
+	options := data.Options
+	for len(options) > 0 {
+		opt := options[0]
+		if opt.Type != discordgo.ApplicationCommandOptionSubCommandGroup && opt.Type != discordgo.ApplicationCommandOptionSubCommand {
+			break
+		}
+		path = append(path, opt.Name)
+		options = opt.Options
+	}
+
+	return path
+}
+
+// permissionDeniedMessage explains why a command was refused.
+func permissionDeniedMessage(level PermissionLevel, path []string, overridden bool) string {
+	if overridden {
+		return fmt.Sprintf("❌ You don't have permission to use `/%s` on this server.", strings.Join(path, " "))
+	}
+	if level == PermissionAdmin {
+		return "❌ You don't have permission to use this command. Administrator permission required."
+	}
+	return "❌ You don't have permission to use this command. Coordinator role required."
+}
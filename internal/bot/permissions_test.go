@@ -0,0 +1,68 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/commands"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchCommandOverride(t *testing.T) {
+	rules := []database.CommandPermission{
+		{Command: "botw", SubjectType: commands.PermissionSubjectRole, SubjectID: 100},
+		{Command: "botw finish", SubjectType: commands.PermissionSubjectUser, SubjectID: 7},
+		{Command: "warn policy", SubjectType: commands.PermissionSubjectRole, SubjectID: 200},
+	}
+
+	member := func(userID string, roles ...string) *discordgo.Member {
+		return &discordgo.Member{User: &discordgo.User{ID: userID}, Roles: roles}
+	}
+
+	tests := []struct {
+		name           string
+		path           []string
+		member         *discordgo.Member
+		wantAllowed    bool
+		wantOverridden bool
+	}{
+		{"no override falls back to default", []string{"sotw", "start"}, member("1"), false, false},
+		{"parent command override applies to subcommand", []string{"botw", "wildy"}, member("1", "100"), true, true},
+		{"parent override denies members without the role", []string{"botw", "wildy"}, member("1", "300"), false, true},
+		{"most specific override wins", []string{"botw", "finish"}, member("1", "100"), false, true},
+		{"user override on subcommand", []string{"botw", "finish"}, member("7"), true, true},
+		{"group override applies to nested subcommand", []string{"warn", "policy", "add"}, member("1", "200"), true, true},
+		{"group override does not affect siblings", []string{"warn", "add"}, member("1"), false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, overridden := matchCommandOverride(rules, tt.path, tt.member)
+			assert.Equal(t, tt.wantAllowed, allowed)
+			assert.Equal(t, tt.wantOverridden, overridden)
+		})
+	}
+}
+
+func TestCommandPath(t *testing.T) {
+	i := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		Type: discordgo.InteractionApplicationCommand,
+		Data: discordgo.ApplicationCommandInteractionData{
+			Name: "warn",
+			Options: []*discordgo.ApplicationCommandInteractionDataOption{{
+				Name: "policy",
+				Type: discordgo.ApplicationCommandOptionSubCommandGroup,
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{{
+					Name: "add",
+					Type: discordgo.ApplicationCommandOptionSubCommand,
+					Options: []*discordgo.ApplicationCommandInteractionDataOption{
+						{Name: "warnings", Type: discordgo.ApplicationCommandOptionInteger, Value: float64(3)},
+					},
+				}},
+			}},
+		},
+	}}
+
+	assert.Equal(t, []string{"warn", "policy", "add"}, commandPath(i))
+}
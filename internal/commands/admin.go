@@ -0,0 +1,282 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/audit"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/embeds"
+	"github.com/kaffeed/voidling/internal/wiseoldman"
+)
+
+// AdminCommands handles coordinator commands that manage other members' account links.
+type AdminCommands struct {
+	DB        *database.Queries
+	DBSQL     *sql.DB
+	WOMClient *wiseoldman.Client
+	Audit     *audit.Logger
+
+	importsMu      sync.Mutex
+	pendingImports map[string]*pendingLinkImport
+}
+
+// NewAdminCommands creates a new AdminCommands instance.
+func NewAdminCommands(db *database.Queries, dbSQL *sql.DB, womClient *wiseoldman.Client, auditLog *audit.Logger) *AdminCommands {
+	return &AdminCommands{
+		DB:             db,
+		DBSQL:          dbSQL,
+		Audit:          auditLog,
+		WOMClient:      womClient,
+		pendingImports: make(map[string]*pendingLinkImport),
+	}
+}
+
+// HandleAdminLink handles /admin link, linking a RuneScape account to another member.
+func (a *AdminCommands) HandleAdminLink(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	userOpt := subcommandOption(i, "user")
+	rsnOpt := subcommandOption(i, "rsn")
+	if userOpt == nil || rsnOpt == nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Missing user or RSN parameter. Please try again."))
+		return
+	}
+
+	target := userOpt.UserValue(nil)
+	username := strings.TrimSpace(rsnOpt.StringValue())
+	if username == "" {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("RSN cannot be empty."))
+		return
+	}
+
+	discordID, err := strconv.ParseInt(target.ID, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing Discord ID: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Invalid Discord ID."))
+		return
+	}
+
+	// Verify the player exists on Wise Old Man
+	player, err := a.WOMClient.GetPlayer(ctx, username)
+	if err != nil {
+		log.Printf("Error fetching player %s: %v", username, err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(womErrorMessage(err, fmt.Sprintf("Failed to fetch player data for '%s'. Make sure the username is correct and try again.", username))))
+		return
+	}
+
+	log.Printf("Coordinator %s linking RSN %s to Discord user %d", i.Member.User.Username, player.DisplayName, discordID)
+
+	previous, _ := a.DB.GetAccountLinkByDiscordID(ctx, discordID)
+
+	// Refuse to steal an RSN that is actively linked to someone else
+	var linked *RSNLinkedError
+	err = linkAvailableAccount(ctx, a.DB, a.DBSQL, discordID, player.DisplayName)
+	if errors.As(err, &linked) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(fmt.Sprintf("**%s** is currently linked to <@%d>. Use `/admin unlink` on that member first.", linked.RSN, linked.DiscordMemberID)))
+		return
+	}
+	if errors.Is(err, ErrAccountAlreadyLinked) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(fmt.Sprintf("<@%s> is already linked to **%s**.", target.ID, player.DisplayName)))
+		return
+	}
+	if err != nil {
+		log.Printf("Error linking account: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to link account. Please try again."))
+		return
+	}
+
+	a.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionLinkCreate,
+		Target:  "<@" + target.ID + ">",
+		Before:  previous.RunescapeName,
+		After:   player.DisplayName,
+	})
+
+	msg := fmt.Sprintf("Linked <@%s> to **%s**.", target.ID, player.DisplayName)
+	if err := syncMemberNickname(ctx, s, a.DB, a.WOMClient, i.GuildID, target.ID, player.DisplayName, player); err != nil {
+		log.Printf("Failed to update nickname for user %s in guild %s: %v", target.ID, i.GuildID, err)
+		msg += "\n\n*Note: I couldn't update their server nickname automatically.*"
+	}
+	if err := syncLinkedRole(ctx, s, a.DB, i.GuildID, target.ID, true); err != nil {
+		log.Printf("Failed to assign linked role to user %s in guild %s: %v", target.ID, i.GuildID, err)
+		msg += "\n\n*Note: I couldn't give them the linked member role.*"
+	}
+
+	sendEphemeralEmbed(s, i, embeds.SuccessEmbed(msg))
+}
+
+// HandleAdminUnlink handles /admin unlink, removing another member's active link.
+func (a *AdminCommands) HandleAdminUnlink(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	userOpt := subcommandOption(i, "user")
+	if userOpt == nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Missing user parameter. Please try again."))
+		return
+	}
+	target := userOpt.UserValue(nil)
+
+	discordID, err := strconv.ParseInt(target.ID, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing Discord ID: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Invalid Discord ID."))
+		return
+	}
+
+	activeLink, err := a.DB.GetAccountLinkByDiscordID(ctx, discordID)
+	if errors.Is(err, sql.ErrNoRows) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(fmt.Sprintf("<@%s> doesn't have a linked account.", target.ID)))
+		return
+	}
+	if err != nil {
+		log.Printf("Error fetching account link: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Database error. Please try again later."))
+		return
+	}
+
+	if err := a.DB.DeactivateAccountLink(ctx, activeLink.ID); err != nil {
+		log.Printf("Error deactivating account link: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to unlink account. Please try again."))
+		return
+	}
+
+	log.Printf("Coordinator %s unlinked RSN %s from Discord user %d", i.Member.User.Username, activeLink.RunescapeName, discordID)
+
+	a.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionLinkRemove,
+		Target:  "<@" + target.ID + ">",
+		Before:  activeLink.RunescapeName,
+	})
+
+	if err := syncLinkedRole(ctx, s, a.DB, i.GuildID, target.ID, false); err != nil {
+		log.Printf("Failed to remove linked role from user %s in guild %s: %v", target.ID, i.GuildID, err)
+	}
+
+	sendEphemeralEmbed(s, i, embeds.SuccessEmbed(fmt.Sprintf("Unlinked **%s** from <@%s>.", activeLink.RunescapeName, target.ID)))
+}
+
+// HandleAdminWhois handles /admin whois, looking up links by Discord member or RSN.
+func (a *AdminCommands) HandleAdminWhois(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	userOpt := subcommandOption(i, "user")
+	rsnOpt := subcommandOption(i, "rsn")
+
+	switch {
+	case userOpt != nil:
+		a.whoisByUser(ctx, s, i, userOpt.UserValue(nil).ID)
+	case rsnOpt != nil:
+		a.whoisByRSN(ctx, s, i, strings.TrimSpace(rsnOpt.StringValue()))
+	default:
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Provide either a `user` or an `rsn` to look up."))
+	}
+}
+
+// whoisByUser lists every account link (active and historical) of a member of this server.
+func (a *AdminCommands) whoisByUser(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, userID string) {
+	discordID, err := strconv.ParseInt(userID, 10, 64)
+	if err != nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Invalid Discord ID."))
+		return
+	}
+
+	// Links aren't scoped to a guild, so only reveal them for members of this server
+	if _, err := s.GuildMember(i.GuildID, userID); err != nil {
+		if isDiscordErrorCode(err, discordgo.ErrCodeUnknownMember) {
+			sendEphemeralEmbed(s, i, embeds.ErrorEmbed(fmt.Sprintf("<@%s> is not a member of this server.", userID)))
+			return
+		}
+		log.Printf("Error fetching member %s: %v", userID, err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to fetch the member from Discord. Please try again later."))
+		return
+	}
+
+	links, err := a.DB.GetAllAccountLinksForUser(ctx, discordID)
+	if err != nil {
+		log.Printf("Error fetching account links for %d: %v", discordID, err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Database error. Please try again later."))
+		return
+	}
+
+	if len(links) == 0 {
+		sendEphemeralEmbed(s, i, embeds.InfoEmbed("🔎 Whois", fmt.Sprintf("<@%s> has never linked a RuneScape account.", userID)))
+		return
+	}
+
+	lines := make([]string, 0, len(links))
+	for _, link := range links {
+		lines = append(lines, formatLinkHistoryLine(link, fmt.Sprintf("**%s**", link.RunescapeName)))
+	}
+
+	sendEphemeralEmbed(s, i, embeds.Whois(fmt.Sprintf("Account links for <@%s>:", userID), lines))
+}
+
+// whoisByRSN lists every member of this server that has ever been linked to an RSN.
+func (a *AdminCommands) whoisByRSN(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, username string) {
+	if username == "" {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("RSN cannot be empty."))
+		return
+	}
+
+	links, err := a.DB.GetAccountLinksByUsername(ctx, username)
+	if err != nil {
+		log.Printf("Error fetching account links for %s: %v", username, err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Database error. Please try again later."))
+		return
+	}
+
+	memberIDs, err := guildMemberIDs(s, i.GuildID)
+	if err != nil {
+		log.Printf("Error fetching members of guild %s: %v", i.GuildID, err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to fetch server members. Please try again later."))
+		return
+	}
+	links = guildLinks(links, memberIDs)
+
+	if len(links) == 0 {
+		sendEphemeralEmbed(s, i, embeds.InfoEmbed("🔎 Whois", fmt.Sprintf("No member of this server has ever linked **%s**.", username)))
+		return
+	}
+
+	lines := make([]string, 0, len(links))
+	for _, link := range links {
+		lines = append(lines, formatLinkHistoryLine(link, fmt.Sprintf("<@%d>", link.DiscordMemberID)))
+	}
+
+	sendEphemeralEmbed(s, i, embeds.Whois(fmt.Sprintf("Members of this server linked to **%s**:", username), lines))
+}
+
+// formatLinkHistoryLine renders a single account link for whois output.
+func formatLinkHistoryLine(link database.AccountLink, subject string) string {
+	if link.IsActive {
+		return fmt.Sprintf("✅ %s — linked <t:%d:D>\n", subject, link.CreatedAt.Unix())
+	}
+	return fmt.Sprintf("▫️ %s — linked <t:%d:D>, inactive since <t:%d:D>\n", subject, link.CreatedAt.Unix(), link.UpdatedAt.Unix())
+}
@@ -0,0 +1,629 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/audit"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/embeds"
+	"github.com/kaffeed/voidling/internal/wiseoldman"
+)
+
+// Application statuses.
+const (
+	ApplicationPending  = "pending"
+	ApplicationApproved = "approved"
+	ApplicationDenied   = "denied"
+)
+
+// Highest levels in Old School RuneScape.
+const (
+	maxTotalLevel  = 2277
+	maxCombatLevel = 126
+)
+
+// applicationStats are the Wise Old Man stats an application is checked against.
+type applicationStats struct {
+	TotalLevel  int64
+	CombatLevel int64
+	EHP         float64
+}
+
+// ApplicationCommands handles the clan application flow: the apply button, the application
+// form and the staff review buttons.
+type ApplicationCommands struct {
+	DB        *database.Queries
+	DBSQL     *sql.DB
+	WOMClient *wiseoldman.Client
+	Audit     *audit.Logger
+}
+
+// NewApplicationCommands creates a new ApplicationCommands instance.
+func NewApplicationCommands(db *database.Queries, dbSQL *sql.DB, womClient *wiseoldman.Client, auditLog *audit.Logger) *ApplicationCommands {
+	return &ApplicationCommands{
+		DB:        db,
+		DBSQL:     dbSQL,
+		WOMClient: womClient,
+		Audit:     auditLog,
+	}
+}
+
+// HandleApplyButton opens the application form, unless applications are closed or the member
+// already has one waiting for review.
+func (ac *ApplicationCommands) HandleApplyButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	reject := func(message string) {
+		respondToInteraction(s, i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Embeds: []*discordgo.MessageEmbed{embeds.ErrorEmbed(message)},
+				Flags:  discordgo.MessageFlagsEphemeral,
+			},
+		})
+	}
+
+	settings, err := loadApplicationSettings(ctx, ac.DB, i.GuildID)
+	if err != nil {
+		log.Printf("Error loading application settings: %v", err)
+		reject("Failed to fetch configuration. Please try again.")
+		return
+	}
+	if !settings.ReviewChannelID.Valid {
+		reject("Applications aren't open right now.")
+		return
+	}
+
+	userID, err := strconv.ParseInt(interactionUser(i).ID, 10, 64)
+	if err != nil {
+		reject("Failed to parse user ID.")
+		return
+	}
+	_, err = ac.DB.GetPendingApplicationByUser(ctx, database.GetPendingApplicationByUserParams{GuildID: settings.GuildID, DiscordUserID: userID})
+	if err == nil {
+		reject("You already have an application waiting for review. You'll get a DM once staff have looked at it.")
+		return
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		log.Printf("Error checking pending applications: %v", err)
+		reject("Database error. Please try again later.")
+		return
+	}
+
+	input := func(id, label, placeholder string, style discordgo.TextInputStyle, maxLength int) discordgo.MessageComponent {
+		return discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.TextInput{
+					CustomID:    id,
+					Label:       label,
+					Style:       style,
+					Placeholder: placeholder,
+					Required:    true,
+					MinLength:   1,
+					MaxLength:   maxLength,
+				},
+			},
+		}
+	}
+
+	err = respondToInteraction(s, i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID: "application-modal",
+			Title:    "Clan Application",
+			Components: []discordgo.MessageComponent{
+				input("rsn", "RuneScape Username", "Enter your RSN", discordgo.TextInputShort, 12),
+				input("total-level", "Total level", "e.g. 1850", discordgo.TextInputShort, 4),
+				input("playtime", "When and how often do you play?", "e.g. evenings EU time, most days", discordgo.TextInputShort, 200),
+				input("referral", "How did you find us?", "A friend, Reddit, in game...", discordgo.TextInputParagraph, 500),
+			},
+		},
+	})
+	if err != nil {
+		log.Printf("Error showing application modal: %v", err)
+	}
+}
+
+// HandleApplicationModal checks a submitted application against Wise Old Man and posts it to the staff channel.
+func (ac *ApplicationCommands) HandleApplicationModal(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	rsn := strings.TrimSpace(modalValue(i, "rsn"))
+	playtime := strings.TrimSpace(modalValue(i, "playtime"))
+	referral := strings.TrimSpace(modalValue(i, "referral"))
+	claimedTotal, err := strconv.ParseInt(strings.TrimSpace(modalValue(i, "total-level")), 10, 64)
+	if err != nil || claimedTotal < 1 || claimedTotal > maxTotalLevel {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(fmt.Sprintf("Total level must be a number between 1 and %d.", maxTotalLevel)))
+		return
+	}
+
+	settings, err := loadApplicationSettings(ctx, ac.DB, i.GuildID)
+	if err != nil {
+		log.Printf("Error loading application settings: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to fetch configuration. Please try again."))
+		return
+	}
+	if !settings.ReviewChannelID.Valid {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Applications aren't open right now."))
+		return
+	}
+
+	user := interactionUser(i)
+	userID, err := strconv.ParseInt(user.ID, 10, 64)
+	if err != nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse user ID."))
+		return
+	}
+
+	player, err := ac.WOMClient.GetPlayer(ctx, rsn)
+	if errors.Is(err, wiseoldman.ErrPlayerNotFound) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(fmt.Sprintf("I couldn't find **%s** on Wise Old Man. Search for your name on https://wiseoldman.net to start tracking it, then apply again.", rsn)))
+		return
+	}
+	if err != nil {
+		log.Printf("Error fetching player %s: %v", rsn, err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(womErrorMessage(err, "I couldn't reach Wise Old Man to check your stats. Please try again in a few minutes.")))
+		return
+	}
+
+	// The apply button checked this too, but the form may have been open in two windows
+	pending := database.GetPendingApplicationByUserParams{GuildID: settings.GuildID, DiscordUserID: userID}
+	if _, err := ac.DB.GetPendingApplicationByUser(ctx, pending); err == nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("You already have an application waiting for review. You'll get a DM once staff have looked at it."))
+		return
+	}
+
+	stats := playerApplicationStats(player)
+	unmet := unmetRequirements(settings, stats)
+
+	app, err := ac.DB.CreateApplication(ctx, database.CreateApplicationParams{
+		GuildID:           settings.GuildID,
+		DiscordUserID:     userID,
+		Rsn:               player.DisplayName,
+		ClaimedTotalLevel: claimedTotal,
+		Playtime:          playtime,
+		Referral:          referral,
+		TotalLevel:        stats.TotalLevel,
+		CombatLevel:       stats.CombatLevel,
+		Ehp:               stats.EHP,
+		MeetsRequirements: len(unmet) == 0,
+	})
+	if err != nil {
+		// Only one pending application per member is allowed, so a concurrent submission loses here
+		if _, pendingErr := ac.DB.GetPendingApplicationByUser(ctx, pending); pendingErr == nil {
+			sendEphemeralEmbed(s, i, embeds.ErrorEmbed("You already have an application waiting for review. You'll get a DM once staff have looked at it."))
+			return
+		}
+		log.Printf("Error saving application: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to save your application. Please try again."))
+		return
+	}
+
+	channelID := strconv.FormatInt(settings.ReviewChannelID.Int64, 10)
+	msg, err := s.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+		Embeds:          []*discordgo.MessageEmbed{embeds.ApplicationReview(applicationEntry(app, unmet))},
+		Components:      applicationReviewButtons(app.ID),
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	})
+	if err != nil {
+		log.Printf("Error posting application %d to channel %s: %v", app.ID, channelID, err)
+		if err := ac.DB.DeleteApplication(ctx, app.ID); err != nil {
+			log.Printf("Error deleting unposted application %d: %v", app.ID, err)
+		}
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("I couldn't deliver your application to the staff. Please let a server admin know."))
+		return
+	}
+
+	err = ac.DB.SetApplicationReviewMessage(ctx, database.SetApplicationReviewMessageParams{
+		ReviewChannelID: settings.ReviewChannelID,
+		ReviewMessageID: settingID(msg.ID),
+		ID:              app.ID,
+	})
+	if err != nil {
+		log.Printf("Error saving review message for application %d: %v", app.ID, err)
+	}
+
+	ac.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: user.ID,
+		Action:  audit.ActionApplicationSubmit,
+		Target:  fmt.Sprintf("%s (application #%d)", player.DisplayName, app.ID),
+	})
+
+	sendEphemeralEmbed(s, i, embeds.SuccessEmbed(fmt.Sprintf("Thanks for applying as **%s**! Staff will review your application and you'll get a DM with the result.", player.DisplayName)))
+}
+
+// HandleApproveApplication handles the approve button: it links the applicant's RSN, gives them
+// the approved role and lets them know by DM.
+func (ac *ApplicationCommands) HandleApproveApplication(s *discordgo.Session, i *discordgo.InteractionCreate, data string) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	app, ok := ac.pendingApplication(ctx, s, i, data)
+	if !ok {
+		return
+	}
+	applicantID := strconv.FormatInt(app.DiscordUserID, 10)
+
+	previous, _ := ac.DB.GetAccountLinkByDiscordID(ctx, app.DiscordUserID)
+
+	linked, ok := ac.approveAndLink(ctx, s, i, app)
+	if !ok {
+		return
+	}
+	ac.updateReviewMessage(s, i, app, ApplicationApproved, "")
+
+	if linked {
+		ac.Audit.Record(ctx, s, audit.Entry{
+			GuildID: i.GuildID,
+			ActorID: i.Member.User.ID,
+			Action:  audit.ActionLinkCreate,
+			Target:  "<@" + applicantID + ">",
+			Before:  previous.RunescapeName,
+			After:   app.Rsn,
+		})
+	}
+
+	var notes []string
+
+	settings, err := loadApplicationSettings(ctx, ac.DB, i.GuildID)
+	if err != nil {
+		log.Printf("Error loading application settings: %v", err)
+	}
+	if settings.ApprovedRoleID.Valid {
+		roleID := strconv.FormatInt(settings.ApprovedRoleID.Int64, 10)
+		if err := s.GuildMemberRoleAdd(i.GuildID, applicantID, roleID); err != nil {
+			log.Printf("Error giving approved role to %s: %v", applicantID, err)
+			notes = append(notes, fmt.Sprintf("I couldn't give them <@&%s>.", roleID))
+		}
+	}
+	if err := syncMemberNickname(ctx, s, ac.DB, ac.WOMClient, i.GuildID, applicantID, app.Rsn, nil); err != nil {
+		log.Printf("Failed to update nickname for user %s in guild %s: %v", applicantID, i.GuildID, err)
+		notes = append(notes, "I couldn't update their server nickname.")
+	}
+	if err := syncLinkedRole(ctx, s, ac.DB, i.GuildID, applicantID, true); err != nil {
+		log.Printf("Failed to assign linked role to user %s in guild %s: %v", applicantID, i.GuildID, err)
+		notes = append(notes, "I couldn't give them the linked member role.")
+	}
+	if !ac.notifyApplicant(s, i.GuildID, applicantID, true, "") {
+		notes = append(notes, "I couldn't DM them; they may have DMs turned off.")
+	}
+
+	ac.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionApplicationApprove,
+		Target:  fmt.Sprintf("<@%s> as %s (application #%d)", applicantID, app.Rsn, app.ID),
+	})
+
+	msg := fmt.Sprintf("Approved <@%s> as **%s**.", applicantID, app.Rsn)
+	if len(notes) > 0 {
+		msg += "\n\n*Note: " + strings.Join(notes, " ") + "*"
+	}
+	sendEphemeralEmbed(s, i, embeds.SuccessEmbed(msg))
+}
+
+// HandleDenyApplication handles the deny button by asking for an optional reason.
+func (ac *ApplicationCommands) HandleDenyApplication(s *discordgo.Session, i *discordgo.InteractionCreate, data string) {
+	err := respondToInteraction(s, i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID: "application-deny-modal:" + data,
+			Title:    "Deny Application",
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.TextInput{
+							CustomID:    "reason",
+							Label:       "Reason (sent to the applicant)",
+							Style:       discordgo.TextInputParagraph,
+							Placeholder: "Leave empty to not give a reason",
+							Required:    false,
+							MaxLength:   500,
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		log.Printf("Error showing deny modal: %v", err)
+	}
+}
+
+// HandleDenyApplicationModal denies an application with the reason from the deny modal.
+func (ac *ApplicationCommands) HandleDenyApplicationModal(s *discordgo.Session, i *discordgo.InteractionCreate, data string) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	app, ok := ac.pendingApplication(ctx, s, i, data)
+	if !ok {
+		return
+	}
+	applicantID := strconv.FormatInt(app.DiscordUserID, 10)
+	reason := strings.TrimSpace(modalValue(i, "reason"))
+
+	if !ac.markReviewed(ctx, s, i, app, ApplicationDenied, reason) {
+		return
+	}
+
+	ac.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionApplicationDeny,
+		Target:  fmt.Sprintf("<@%s> as %s (application #%d)", applicantID, app.Rsn, app.ID),
+		After:   reason,
+	})
+
+	msg := fmt.Sprintf("Denied the application from <@%s>.", applicantID)
+	if !ac.notifyApplicant(s, i.GuildID, applicantID, false, reason) {
+		msg += "\n\n*Note: I couldn't DM them; they may have DMs turned off.*"
+	}
+	sendEphemeralEmbed(s, i, embeds.SuccessEmbed(msg))
+}
+
+// pendingApplication loads the application named by a review button and checks it belongs to
+// this guild and still awaits review. It reports the problem to the reviewer otherwise.
+func (ac *ApplicationCommands) pendingApplication(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, data string) (database.ClanApplication, bool) {
+	id, err := strconv.ParseInt(data, 10, 64)
+	if err != nil {
+		log.Printf("Invalid application ID: %s", data)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Invalid application."))
+		return database.ClanApplication{}, false
+	}
+
+	app, err := ac.DB.GetApplication(ctx, id)
+	if err != nil || strconv.FormatInt(app.GuildID, 10) != i.GuildID {
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("Error fetching application %d: %v", id, err)
+		}
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("That application no longer exists."))
+		return database.ClanApplication{}, false
+	}
+	if app.Status != ApplicationPending {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(fmt.Sprintf("This application was already %s by <@%d>.", app.Status, app.ReviewedBy.Int64)))
+		return database.ClanApplication{}, false
+	}
+	return app, true
+}
+
+// approveAndLink approves app and links the applicant's RSN in one transaction, so the RSN can't
+// be claimed by someone else in between. It reports whether a link was created or reactivated, and
+// false for ok, after telling the reviewer, if nothing was saved.
+func (ac *ApplicationCommands) approveAndLink(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, app database.ClanApplication) (linked, ok bool) {
+	tx, err := ac.DBSQL.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("Error starting transaction for application %d: %v", app.ID, err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to save the decision. Please try again."))
+		return false, false
+	}
+	defer func() { _ = tx.Rollback() }() // Rollback is safe to call even after commit
+
+	qtx := ac.DB.WithTx(tx)
+
+	// Refuse to steal an RSN that is actively linked to someone else
+	var linkedElsewhere *RSNLinkedError
+	err = linkAvailableAccountTx(ctx, qtx, app.DiscordUserID, app.Rsn)
+	switch {
+	case errors.As(err, &linkedElsewhere):
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(fmt.Sprintf("**%s** is currently linked to <@%d>. Use `/admin unlink` on that member first.", linkedElsewhere.RSN, linkedElsewhere.DiscordMemberID)))
+		return false, false
+	case errors.Is(err, ErrAccountAlreadyLinked):
+	case err != nil:
+		log.Printf("Error linking account for application %d: %v", app.ID, err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to link their RSN. Please try again."))
+		return false, false
+	default:
+		linked = true
+	}
+
+	if !ac.recordReview(ctx, s, i, qtx, app, ApplicationApproved, "") {
+		return false, false
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("Error committing approval of application %d: %v", app.ID, err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to save the decision. Please try again."))
+		return false, false
+	}
+	return linked, true
+}
+
+// markReviewed records the decision and updates the staff message. It reports false, after telling
+// the reviewer, if someone else reviewed the application first.
+func (ac *ApplicationCommands) markReviewed(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, app database.ClanApplication, status, reason string) bool {
+	if !ac.recordReview(ctx, s, i, ac.DB, app, status, reason) {
+		return false
+	}
+	ac.updateReviewMessage(s, i, app, status, reason)
+	return true
+}
+
+// recordReview saves the decision using q. It reports false, after telling the reviewer, if the
+// decision wasn't saved.
+func (ac *ApplicationCommands) recordReview(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, q *database.Queries, app database.ClanApplication, status, reason string) bool {
+	rows, err := q.ReviewApplication(ctx, database.ReviewApplicationParams{
+		Status:       status,
+		ReviewedBy:   settingID(i.Member.User.ID),
+		ReviewReason: sql.NullString{String: reason, Valid: reason != ""},
+		ID:           app.ID,
+	})
+	if err != nil {
+		log.Printf("Error reviewing application %d: %v", app.ID, err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to save the decision. Please try again."))
+		return false
+	}
+	if rows == 0 {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Someone else reviewed this application just now."))
+		return false
+	}
+	return true
+}
+
+// updateReviewMessage adds the decision to the staff message and removes its buttons.
+func (ac *ApplicationCommands) updateReviewMessage(s *discordgo.Session, i *discordgo.InteractionCreate, app database.ClanApplication, status, reason string) {
+	if app.ReviewChannelID.Valid && app.ReviewMessageID.Valid {
+		decision := fmt.Sprintf("✅ Approved by <@%s>", i.Member.User.ID)
+		if status == ApplicationDenied {
+			decision = fmt.Sprintf("❌ Denied by <@%s>", i.Member.User.ID)
+			if reason != "" {
+				decision += "\n" + reason
+			}
+		}
+
+		embed := embeds.ApplicationReview(applicationEntry(app, nil))
+		if i.Message != nil && len(i.Message.Embeds) > 0 && i.Message.ID == strconv.FormatInt(app.ReviewMessageID.Int64, 10) {
+			embed = i.Message.Embeds[0] // Keep the requirement check shown when the application came in
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: "Decision", Value: decision})
+
+		channelID := strconv.FormatInt(app.ReviewChannelID.Int64, 10)
+		messageID := strconv.FormatInt(app.ReviewMessageID.Int64, 10)
+		components := []discordgo.MessageComponent{}
+		_, err := s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+			Channel:    channelID,
+			ID:         messageID,
+			Embeds:     &[]*discordgo.MessageEmbed{embed},
+			Components: &components,
+		})
+		if err != nil {
+			log.Printf("Error updating review message for application %d: %v", app.ID, err)
+		}
+	}
+}
+
+// notifyApplicant DMs the applicant the decision, reporting whether the DM was delivered.
+func (ac *ApplicationCommands) notifyApplicant(s *discordgo.Session, guildID, userID string, approved bool, reason string) bool {
+	guildName := "the clan"
+	if guild, err := s.State.Guild(guildID); err == nil {
+		guildName = guild.Name
+	}
+
+	channel, err := s.UserChannelCreate(userID)
+	if err != nil {
+		log.Printf("Error creating DM channel for applicant %s: %v", userID, err)
+		return false
+	}
+	_, err = s.ChannelMessageSendEmbed(channel.ID, embeds.ApplicationDecision(guildName, approved, reason))
+	if err != nil {
+		log.Printf("Error sending application decision to %s: %v", userID, err)
+		return false
+	}
+	return true
+}
+
+// loadApplicationSettings returns the guild's application settings, or the defaults if it has none.
+func loadApplicationSettings(ctx context.Context, db *database.Queries, guildID string) (database.GuildApplicationSetting, error) {
+	id, err := strconv.ParseInt(guildID, 10, 64)
+	if err != nil {
+		return database.GuildApplicationSetting{}, fmt.Errorf("parse guild ID: %w", err)
+	}
+
+	settings, err := db.GetApplicationSettings(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return database.GuildApplicationSetting{GuildID: id}, nil
+	}
+	return settings, err
+}
+
+// playerApplicationStats extracts the stats applications are checked against.
+func playerApplicationStats(player *wiseoldman.Player) applicationStats {
+	stats := applicationStats{
+		CombatLevel: int64(player.CombatLevel),
+		EHP:         player.EHP,
+	}
+	if overall := player.GetSkill("overall"); overall != nil {
+		stats.TotalLevel = int64(overall.Level)
+	}
+	return stats
+}
+
+// unmetRequirements lists the guild's minimum requirements that stats fall short of.
+func unmetRequirements(settings database.GuildApplicationSetting, stats applicationStats) []string {
+	var unmet []string
+	if settings.MinTotalLevel > 0 && stats.TotalLevel < settings.MinTotalLevel {
+		unmet = append(unmet, fmt.Sprintf("Total level %d (needs %d)", stats.TotalLevel, settings.MinTotalLevel))
+	}
+	if settings.MinCombatLevel > 0 && stats.CombatLevel < settings.MinCombatLevel {
+		unmet = append(unmet, fmt.Sprintf("Combat level %d (needs %d)", stats.CombatLevel, settings.MinCombatLevel))
+	}
+	if settings.MinEhp > 0 && stats.EHP < settings.MinEhp {
+		unmet = append(unmet, fmt.Sprintf("EHP %.1f (needs %.1f)", stats.EHP, settings.MinEhp))
+	}
+	return unmet
+}
+
+// formatRequirements renders the guild's minimum requirements, e.g. "Total level 1500, Combat level 100".
+func formatRequirements(settings database.GuildApplicationSetting) string {
+	var parts []string
+	if settings.MinTotalLevel > 0 {
+		parts = append(parts, fmt.Sprintf("Total level %d", settings.MinTotalLevel))
+	}
+	if settings.MinCombatLevel > 0 {
+		parts = append(parts, fmt.Sprintf("Combat level %d", settings.MinCombatLevel))
+	}
+	if settings.MinEhp > 0 {
+		parts = append(parts, fmt.Sprintf("EHP %g", settings.MinEhp))
+	}
+	if len(parts) == 0 {
+		return "None"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// applicationEntry converts a stored application for display.
+func applicationEntry(app database.ClanApplication, unmet []string) embeds.ApplicationEntry {
+	return embeds.ApplicationEntry{
+		ID:                app.ID,
+		UserID:            strconv.FormatInt(app.DiscordUserID, 10),
+		RSN:               app.Rsn,
+		ClaimedTotalLevel: app.ClaimedTotalLevel,
+		Playtime:          app.Playtime,
+		Referral:          app.Referral,
+		TotalLevel:        app.TotalLevel,
+		CombatLevel:       app.CombatLevel,
+		EHP:               app.Ehp,
+		Unmet:             unmet,
+		CreatedAt:         app.CreatedAt,
+	}
+}
+
+// applicationReviewButtons returns the approve and deny buttons for an application.
+func applicationReviewButtons(id int64) []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "Approve",
+					Style:    discordgo.SuccessButton,
+					CustomID: fmt.Sprintf("application-approve:%d", id),
+				},
+				discordgo.Button{
+					Label:    "Deny",
+					Style:    discordgo.DangerButton,
+					CustomID: fmt.Sprintf("application-deny:%d", id),
+				},
+			},
+		},
+	}
+}
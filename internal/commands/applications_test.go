@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/testutil"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmetRequirements(t *testing.T) {
+	stats := playerApplicationStats(testutil.CreateTestPlayer("Zezima"))
+	assert.Equal(t, applicationStats{TotalLevel: 2277, CombatLevel: 126, EHP: 500.5}, stats)
+
+	tests := []struct {
+		name     string
+		settings database.GuildApplicationSetting
+		stats    applicationStats
+		expected []string
+	}{
+		{"no requirements", database.GuildApplicationSetting{}, applicationStats{}, nil},
+		{"all met", database.GuildApplicationSetting{MinTotalLevel: 1500, MinCombatLevel: 100, MinEhp: 200}, stats, nil},
+		{
+			"some missed",
+			database.GuildApplicationSetting{MinTotalLevel: 1500, MinCombatLevel: 100, MinEhp: 200},
+			applicationStats{TotalLevel: 1200, CombatLevel: 100, EHP: 150.25},
+			[]string{"Total level 1200 (needs 1500)", "EHP 150.2 (needs 200.0)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, unmetRequirements(tt.settings, tt.stats))
+		})
+	}
+}
+
+func TestFormatRequirements(t *testing.T) {
+	assert.Equal(t, "None", formatRequirements(database.GuildApplicationSetting{}))
+	assert.Equal(t, "Total level 1500, EHP 50.5", formatRequirements(database.GuildApplicationSetting{MinTotalLevel: 1500, MinEhp: 50.5}))
+}
+
+func TestReviewApplication(t *testing.T) {
+	db, q := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	ctx := t.Context()
+
+	settings, err := loadApplicationSettings(ctx, q, "42")
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), settings.GuildID)
+	assert.False(t, settings.ReviewChannelID.Valid, "applications are closed by default")
+
+	app, err := q.CreateApplication(ctx, database.CreateApplicationParams{
+		GuildID:           42,
+		DiscordUserID:     7,
+		Rsn:               "Zezima",
+		ClaimedTotalLevel: 2277,
+		TotalLevel:        2277,
+		CombatLevel:       126,
+		MeetsRequirements: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ApplicationPending, app.Status)
+
+	pending, err := q.GetPendingApplicationByUser(ctx, database.GetPendingApplicationByUserParams{GuildID: 42, DiscordUserID: 7})
+	require.NoError(t, err)
+	assert.Equal(t, app.ID, pending.ID)
+
+	// Only one application per member can wait for review
+	params := database.CreateApplicationParams{GuildID: 42, DiscordUserID: 7, Rsn: "Zezima", ClaimedTotalLevel: 2277}
+	_, err = q.CreateApplication(ctx, params)
+	assert.Error(t, err, "duplicate pending application")
+
+	review := database.ReviewApplicationParams{
+		Status:     ApplicationApproved,
+		ReviewedBy: sql.NullInt64{Int64: 9, Valid: true},
+		ID:         app.ID,
+	}
+	rows, err := q.ReviewApplication(ctx, review)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	// A second reviewer clicking at the same time changes nothing
+	review.Status = ApplicationDenied
+	rows, err = q.ReviewApplication(ctx, review)
+	require.NoError(t, err)
+	assert.Zero(t, rows)
+
+	app, err = q.GetApplication(ctx, app.ID)
+	require.NoError(t, err)
+	assert.Equal(t, ApplicationApproved, app.Status)
+
+	_, err = q.GetPendingApplicationByUser(ctx, database.GetPendingApplicationByUserParams{GuildID: 42, DiscordUserID: 7})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = q.CreateApplication(ctx, params)
+	assert.NoError(t, err, "a reviewed member can apply again")
+}
+
+func TestMigrationDeniesDuplicatePendingApplications(t *testing.T) {
+	db, err := sql.Open("sqlite3", "file:pending_applications?mode=memory")
+	require.NoError(t, err)
+	defer testutil.CleanupTestDB(t, db)
+	db.SetMaxOpenConns(1)
+	q := database.New(db)
+	ctx := t.Context()
+
+	require.NoError(t, goose.SetDialect("sqlite3"))
+	require.NoError(t, goose.UpTo(db, "../../migrations", 24))
+
+	// Double submissions from before the unique index
+	params := database.CreateApplicationParams{GuildID: 42, DiscordUserID: 7, Rsn: "Zezima", ClaimedTotalLevel: 2277}
+	first, err := q.CreateApplication(ctx, params)
+	require.NoError(t, err)
+	second, err := q.CreateApplication(ctx, params)
+	require.NoError(t, err)
+
+	require.NoError(t, goose.Up(db, "../../migrations"))
+
+	pending, err := q.GetPendingApplicationByUser(ctx, database.GetPendingApplicationByUserParams{GuildID: 42, DiscordUserID: 7})
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, pending.ID)
+
+	duplicate, err := q.GetApplication(ctx, second.ID)
+	require.NoError(t, err)
+	assert.Equal(t, ApplicationDenied, duplicate.Status)
+}
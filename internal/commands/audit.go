@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/audit"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/embeds"
+)
+
+const (
+	// defaultAuditLimit is how many entries /audit search shows when no limit is given.
+	defaultAuditLimit = 10
+	// maxAuditLimit matches the number of fields Discord allows in one embed.
+	maxAuditLimit = 25
+)
+
+// AuditCommands handles querying the audit log.
+type AuditCommands struct {
+	DB    *database.Queries
+	DBSQL *sql.DB
+}
+
+// NewAuditCommands creates a new AuditCommands instance.
+func NewAuditCommands(db *database.Queries, dbSQL *sql.DB) *AuditCommands {
+	return &AuditCommands{
+		DB:    db,
+		DBSQL: dbSQL,
+	}
+}
+
+// HandleAuditSearch handles /audit search, listing recent audit entries matching the given filters.
+func (a *AuditCommands) HandleAuditSearch(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	guildID, err := strconv.ParseInt(i.GuildID, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing guild ID: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse guild ID."))
+		return
+	}
+
+	params := database.ListAuditLogParams{
+		GuildID:  guildID,
+		RowLimit: defaultAuditLimit,
+	}
+	var filters []string
+
+	if opt := subcommandOption(i, "user"); opt != nil {
+		userID := opt.UserValue(nil).ID
+		id, err := strconv.ParseInt(userID, 10, 64)
+		if err != nil {
+			sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Invalid user ID."))
+			return
+		}
+		params.ActorID = sql.NullInt64{Int64: id, Valid: true}
+		filters = append(filters, "by <@"+userID+">")
+	}
+
+	if opt := subcommandOption(i, "action"); opt != nil {
+		action := opt.StringValue()
+		params.Action = sql.NullString{String: action, Valid: true}
+		filters = append(filters, "action `"+action+"`")
+	}
+
+	if opt := subcommandOption(i, "days"); opt != nil {
+		days := opt.IntValue()
+		params.Since = sql.NullTime{Time: time.Now().UTC().AddDate(0, 0, -int(days)), Valid: true}
+		filters = append(filters, fmt.Sprintf("last %d day(s)", days))
+	}
+
+	if opt := subcommandOption(i, "limit"); opt != nil {
+		params.RowLimit = min(max(opt.IntValue(), 1), maxAuditLimit)
+	}
+
+	rows, err := a.DB.ListAuditLog(ctx, params)
+	if err != nil {
+		log.Printf("Error querying audit log: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Database error. Please try again later."))
+		return
+	}
+
+	entries := make([]embeds.AuditEntry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, audit.ToEmbedEntry(row))
+	}
+
+	sendEphemeralEmbed(s, i, embeds.AuditLogList(entries, strings.Join(filters, ", ")))
+}
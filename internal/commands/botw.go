@@ -7,11 +7,9 @@ import (
 
 // HandleBOTWWildy handles /botw wildy command.
 func (t *TrackableCommands) HandleBOTWWildy(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	data := i.ApplicationCommandData()
-	// Options[0] is the subcommand, Options[0].Options[0] is the boss parameter
-	boss := data.Options[0].Options[0].StringValue()
+	boss := subcommandOption(i, "boss").StringValue()
 
-	err := t.StartEvent(s, i, models.EventTypeBossOfTheWeek, boss)
+	err := t.StartEvent(s, i, models.EventTypeBossOfTheWeek, models.EventTypeWildyWednesday, boss, teamSetupOption(i))
 	if err != nil {
 		return
 	}
@@ -19,11 +17,9 @@ func (t *TrackableCommands) HandleBOTWWildy(s *discordgo.Session, i *discordgo.I
 
 // HandleBOTWGroup handles /botw group command.
 func (t *TrackableCommands) HandleBOTWGroup(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	data := i.ApplicationCommandData()
-	// Options[0] is the subcommand, Options[0].Options[0] is the boss parameter
-	boss := data.Options[0].Options[0].StringValue()
+	boss := subcommandOption(i, "boss").StringValue()
 
-	err := t.StartEvent(s, i, models.EventTypeBossOfTheWeek, boss)
+	err := t.StartEvent(s, i, models.EventTypeBossOfTheWeek, models.EventTypeBossOfTheWeek, boss, teamSetupOption(i))
 	if err != nil {
 		return
 	}
@@ -31,11 +27,9 @@ func (t *TrackableCommands) HandleBOTWGroup(s *discordgo.Session, i *discordgo.I
 
 // HandleBOTWQuest handles /botw quest command.
 func (t *TrackableCommands) HandleBOTWQuest(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	data := i.ApplicationCommandData()
-	// Options[0] is the subcommand, Options[0].Options[0] is the boss parameter
-	boss := data.Options[0].Options[0].StringValue()
+	boss := subcommandOption(i, "boss").StringValue()
 
-	err := t.StartEvent(s, i, models.EventTypeBossOfTheWeek, boss)
+	err := t.StartEvent(s, i, models.EventTypeBossOfTheWeek, models.EventTypeBossOfTheWeek, boss, teamSetupOption(i))
 	if err != nil {
 		return
 	}
@@ -43,11 +37,9 @@ func (t *TrackableCommands) HandleBOTWQuest(s *discordgo.Session, i *discordgo.I
 
 // HandleBOTWSlayer handles /botw slayer command.
 func (t *TrackableCommands) HandleBOTWSlayer(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	data := i.ApplicationCommandData()
-	// Options[0] is the subcommand, Options[0].Options[0] is the boss parameter
-	boss := data.Options[0].Options[0].StringValue()
+	boss := subcommandOption(i, "boss").StringValue()
 
-	err := t.StartEvent(s, i, models.EventTypeBossOfTheWeek, boss)
+	err := t.StartEvent(s, i, models.EventTypeBossOfTheWeek, models.EventTypeBossOfTheWeek, boss, teamSetupOption(i))
 	if err != nil {
 		return
 	}
@@ -55,11 +47,9 @@ func (t *TrackableCommands) HandleBOTWSlayer(s *discordgo.Session, i *discordgo.
 
 // HandleBOTWWorld handles /botw world command.
 func (t *TrackableCommands) HandleBOTWWorld(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	data := i.ApplicationCommandData()
-	// Options[0] is the subcommand, Options[0].Options[0] is the boss parameter
-	boss := data.Options[0].Options[0].StringValue()
+	boss := subcommandOption(i, "boss").StringValue()
 
-	err := t.StartEvent(s, i, models.EventTypeBossOfTheWeek, boss)
+	err := t.StartEvent(s, i, models.EventTypeBossOfTheWeek, models.EventTypeBossOfTheWeek, boss, teamSetupOption(i))
 	if err != nil {
 		return
 	}
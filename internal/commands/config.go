@@ -7,26 +7,40 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"sync"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/audit"
 	"github.com/kaffeed/voidling/internal/database"
 	"github.com/kaffeed/voidling/internal/embeds"
 	"github.com/kaffeed/voidling/internal/timezone"
+	"github.com/kaffeed/voidling/internal/wiseoldman"
 )
 
 const notConfiguredText = "Not configured"
 
 // ConfigCommands handles server configuration commands.
 type ConfigCommands struct {
-	DB    *database.Queries
-	DBSQL *sql.DB
+	DB        *database.Queries
+	DBSQL     *sql.DB
+	Audit     *audit.Logger
+	WOMClient *wiseoldman.Client
+
+	// PermissionKeys lists the command paths that /config permissions can restrict.
+	PermissionKeys []string
+
+	importsMu      sync.Mutex
+	pendingImports map[string]*pendingConfigImport
 }
 
 // NewConfigCommands creates a new ConfigCommands instance.
-func NewConfigCommands(db *database.Queries, dbSQL *sql.DB) *ConfigCommands {
+func NewConfigCommands(db *database.Queries, dbSQL *sql.DB, womClient *wiseoldman.Client, auditLog *audit.Logger) *ConfigCommands {
 	return &ConfigCommands{
-		DB:    db,
-		DBSQL: dbSQL,
+		DB:             db,
+		DBSQL:          dbSQL,
+		Audit:          auditLog,
+		WOMClient:      womClient,
+		pendingImports: make(map[string]*pendingConfigImport),
 	}
 }
 
@@ -119,6 +133,8 @@ func (cc *ConfigCommands) HandleSetCoordinatorRole(s *discordgo.Session, i *disc
 		}
 	}
 
+	recordConfigVersion(ctx, cc.DB, guildID, i.Member.User.ID, "/config set-coordinator-role")
+
 	// Upsert guild config
 	err = cc.DB.UpsertGuildConfig(ctx, database.UpsertGuildConfigParams{
 		GuildID:                  guildID,
@@ -137,6 +153,14 @@ func (cc *ConfigCommands) HandleSetCoordinatorRole(s *discordgo.Session, i *disc
 		return
 	}
 
+	cc.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionCoordinatorRole,
+		Before:  formatRoleID(existingConfig.CoordinatorRoleID),
+		After:   "<@&" + roleOption.ID + ">",
+	})
+
 	// Send success message
 	sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
 		Embeds: []*discordgo.MessageEmbed{
@@ -232,14 +256,39 @@ func (cc *ConfigCommands) HandleShowConfig(s *discordgo.Session, i *discordgo.In
 		defaultTimezone = config.DefaultTimezone.String
 	}
 
+	nicknameTemplate := nicknameTemplateOrDefault(config.NicknameTemplate)
+
+	auditLogChannel := notConfiguredText
+	if config.AuditLogChannelID.Valid {
+		auditLogChannel = fmt.Sprintf("<#%d>", config.AuditLogChannelID.Int64)
+	}
+
+	commandPermissions := "All commands use their default permissions"
+	if rules, err := cc.DB.GetCommandPermissions(ctx, guildID); err != nil {
+		log.Printf("Error fetching command permissions: %v", err)
+	} else if len(rules) > 0 {
+		commandPermissions = "\n" + formatCommandPermissions(rules)
+	}
+
+	disabledFeatures := "None"
+	if disabled, err := cc.DB.GetDisabledGuildFeatures(ctx, guildID); err != nil {
+		log.Printf("Error fetching disabled features: %v", err)
+	} else if len(disabled) > 0 {
+		disabledFeatures = formatFeatureNames(disabled)
+	}
+
 	sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
 		Content: fmt.Sprintf("**Server Configuration**\n\n"+
 			"**Coordinator Role:** %s\n"+
 			"**Competition Code Channel:** %s\n"+
 			"**Event Notification Role:** %s\n"+
 			"**Event Notification Channel:** %s\n"+
-			"**Default Timezone:** %s",
-			coordinatorRole, competitionCodeChannel, eventNotificationRole, eventNotificationChannel, defaultTimezone),
+			"**Default Timezone:** %s\n"+
+			"**Nickname Template:** `%s`\n"+
+			"**Audit Log Channel:** %s\n"+
+			"**Disabled Features:** %s\n"+
+			"**Command Permissions:** %s",
+			coordinatorRole, competitionCodeChannel, eventNotificationRole, eventNotificationChannel, defaultTimezone, nicknameTemplate, auditLogChannel, disabledFeatures, commandPermissions),
 		Flags: discordgo.MessageFlagsEphemeral,
 	})
 }
@@ -320,6 +369,10 @@ func (cc *ConfigCommands) HandleSetCompetitionCodeChannel(s *discordgo.Session,
 		return
 	}
 
+	before, _ := cc.DB.GetGuildConfig(ctx, guildID)
+
+	recordConfigVersion(ctx, cc.DB, guildID, i.Member.User.ID, "/config set-competition-code-channel")
+
 	// Update guild config
 	err = cc.DB.UpdateCompetitionCodeChannel(ctx, database.UpdateCompetitionCodeChannelParams{
 		CompetitionCodeChannelID: sql.NullInt64{Int64: channelID, Valid: true},
@@ -336,6 +389,14 @@ func (cc *ConfigCommands) HandleSetCompetitionCodeChannel(s *discordgo.Session,
 		return
 	}
 
+	cc.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionCompetitionCodeChannel,
+		Before:  formatChannelID(before.CompetitionCodeChannelID),
+		After:   "<#" + channelOption.ID + ">",
+	})
+
 	// Send success message
 	sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
 		Embeds: []*discordgo.MessageEmbed{
@@ -430,6 +491,10 @@ func (cc *ConfigCommands) HandleSetDefaultTimezone(s *discordgo.Session, i *disc
 		return
 	}
 
+	before, _ := cc.DB.GetGuildConfig(ctx, guildID)
+
+	recordConfigVersion(ctx, cc.DB, guildID, i.Member.User.ID, "/config set-default-timezone")
+
 	err = cc.DB.UpdateDefaultTimezone(ctx, database.UpdateDefaultTimezoneParams{
 		DefaultTimezone: sql.NullString{String: timezoneStr, Valid: true},
 		GuildID:         guildID,
@@ -445,6 +510,14 @@ func (cc *ConfigCommands) HandleSetDefaultTimezone(s *discordgo.Session, i *disc
 		return
 	}
 
+	cc.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionDefaultTimezone,
+		Before:  before.DefaultTimezone.String,
+		After:   timezoneStr,
+	})
+
 	sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
 		Embeds: []*discordgo.MessageEmbed{
 			embeds.SuccessEmbed(fmt.Sprintf("Default server timezone set to **%s**", timezoneStr)),
@@ -606,7 +679,7 @@ func (cc *ConfigCommands) HandleSetEventNotificationChannel(s *discordgo.Session
 	}
 
 	// Check if guild config exists
-	_, err = cc.DB.GetGuildConfig(ctx, guildID)
+	before, err := cc.DB.GetGuildConfig(ctx, guildID)
 	if err != nil {
 		// Create guild config if it doesn't exist
 		if errors.Is(err, sql.ErrNoRows) {
@@ -636,6 +709,8 @@ func (cc *ConfigCommands) HandleSetEventNotificationChannel(s *discordgo.Session
 		}
 	}
 
+	recordConfigVersion(ctx, cc.DB, guildID, i.Member.User.ID, "/config set-event-notification-channel")
+
 	// Update event notification channel
 	err = cc.DB.UpdateEventNotificationChannel(ctx, database.UpdateEventNotificationChannelParams{
 		EventNotificationChannelID: sql.NullInt64{Int64: channelID, Valid: true},
@@ -652,6 +727,14 @@ func (cc *ConfigCommands) HandleSetEventNotificationChannel(s *discordgo.Session
 		return
 	}
 
+	cc.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionEventNotificationChannel,
+		Before:  formatChannelID(before.EventNotificationChannelID),
+		After:   "<#" + channelOption.ID + ">",
+	})
+
 	// Send success message
 	sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
 		Embeds: []*discordgo.MessageEmbed{
@@ -738,7 +821,7 @@ func (cc *ConfigCommands) HandleSetEventNotificationRole(s *discordgo.Session, i
 	}
 
 	// Check if guild config exists
-	_, err = cc.DB.GetGuildConfig(ctx, guildID)
+	before, err := cc.DB.GetGuildConfig(ctx, guildID)
 	if err != nil {
 		// Create guild config if it doesn't exist
 		if errors.Is(err, sql.ErrNoRows) {
@@ -768,6 +851,8 @@ func (cc *ConfigCommands) HandleSetEventNotificationRole(s *discordgo.Session, i
 		}
 	}
 
+	recordConfigVersion(ctx, cc.DB, guildID, i.Member.User.ID, "/config set-event-notification-role")
+
 	// Update event notification role
 	err = cc.DB.UpdateEventNotificationRole(ctx, database.UpdateEventNotificationRoleParams{
 		EventNotificationRoleID: sql.NullInt64{Int64: roleID, Valid: true},
@@ -784,10 +869,18 @@ func (cc *ConfigCommands) HandleSetEventNotificationRole(s *discordgo.Session, i
 		return
 	}
 
+	cc.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionEventNotificationRole,
+		Before:  formatRoleID(before.EventNotificationRoleID),
+		After:   "<@&" + roleOption.ID + ">",
+	})
+
 	// Send success message
 	sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
 		Embeds: []*discordgo.MessageEmbed{
-			embeds.SuccessEmbed(fmt.Sprintf("Event notification role set to <@&%s>\n\nThis role will be pinged when BOTW, SOTW, and Mass events are created.", roleOption.ID)),
+			embeds.SuccessEmbed(fmt.Sprintf("Event notification role set to <@&%s>\n\nThis role will be pinged when BOTW, SOTW, and Mass events are created, unless the event type has its own role (`/config notifications set-role`).", roleOption.ID)),
 		},
 		Flags: discordgo.MessageFlagsEphemeral,
 	})
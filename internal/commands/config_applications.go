@@ -0,0 +1,240 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/audit"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/embeds"
+)
+
+// HandleApplicationsReviewChannel handles /config applications review-channel. Omitting the channel
+// closes applications.
+func (cc *ConfigCommands) HandleApplicationsReviewChannel(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	cc.updateApplicationSettings(s, i, "/config applications review-channel", func(settings *database.GuildApplicationSetting) (string, bool) {
+		opt := subcommandOption(i, "channel")
+		if opt == nil {
+			settings.ReviewChannelID = sql.NullInt64{}
+			return "Applications closed. The apply button will tell members applications aren't open.", true
+		}
+
+		channelID := opt.ChannelValue(nil).ID
+		if missing := missingChannelPermissions(s, channelID, channelPostPermissions); len(missing) > 0 {
+			return fmt.Sprintf("I can't post in <#%s>. Missing: %s", channelID, strings.Join(missing, ", ")), false
+		}
+		settings.ReviewChannelID = settingID(channelID)
+		return fmt.Sprintf("Applications will be posted in <#%s> for staff to review.\n\nPost the apply button with `/config applications post-button`.", channelID), true
+	})
+}
+
+// HandleApplicationsApprovedRole handles /config applications approved-role. Omitting the role means
+// approving an application only links the applicant's RSN.
+func (cc *ConfigCommands) HandleApplicationsApprovedRole(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	cc.updateApplicationSettings(s, i, "/config applications approved-role", func(settings *database.GuildApplicationSetting) (string, bool) {
+		opt := subcommandOption(i, "role")
+		if opt == nil {
+			settings.ApprovedRoleID = sql.NullInt64{}
+			return "Approved applicants will no longer get a role.", true
+		}
+
+		role := opt.RoleValue(nil, i.GuildID)
+		if role.ID == i.GuildID {
+			return "The @everyone role can't be assigned.", false
+		}
+		if full, err := s.State.Role(i.GuildID, role.ID); err == nil && full.Managed {
+			return fmt.Sprintf("<@&%s> is managed by an integration and can't be assigned.", role.ID), false
+		}
+
+		settings.ApprovedRoleID = settingID(role.ID)
+		return fmt.Sprintf("Approved applicants will get <@&%s>.\n\nMake sure my role is above it so I can assign it; `/config doctor` checks this.", role.ID), true
+	})
+}
+
+// HandleApplicationsRequirements handles /config applications requirements. Only the given
+// requirements change, and 0 removes a requirement.
+func (cc *ConfigCommands) HandleApplicationsRequirements(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	cc.updateApplicationSettings(s, i, "/config applications requirements", func(settings *database.GuildApplicationSetting) (string, bool) {
+		total := subcommandOption(i, "min-total-level")
+		combat := subcommandOption(i, "min-combat-level")
+		ehp := subcommandOption(i, "min-ehp")
+		if total == nil && combat == nil && ehp == nil {
+			return fmt.Sprintf("Current requirements: %s\n\nGive at least one option to change them.", formatRequirements(*settings)), false
+		}
+
+		if total != nil {
+			settings.MinTotalLevel = total.IntValue()
+		}
+		if combat != nil {
+			settings.MinCombatLevel = combat.IntValue()
+		}
+		if ehp != nil {
+			settings.MinEhp = ehp.FloatValue()
+		}
+		return fmt.Sprintf("Minimum requirements: %s\n\nApplicants who fall short can still apply; staff see which requirements they miss.", formatRequirements(*settings)), true
+	})
+}
+
+// HandleApplicationsPostButton handles /config applications post-button, posting the message
+// members use to apply.
+func (cc *ConfigCommands) HandleApplicationsPostButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	if !isServerOwnerOrAdmin(s, i) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Only the server owner or administrators can post the apply button."))
+		return
+	}
+
+	settings, err := loadApplicationSettings(ctx, cc.DB, i.GuildID)
+	if err != nil {
+		log.Printf("Error loading application settings: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to fetch configuration. Please try again."))
+		return
+	}
+	if !settings.ReviewChannelID.Valid {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Set a review channel with `/config applications review-channel` first."))
+		return
+	}
+
+	channelID := i.ChannelID
+	if opt := subcommandOption(i, "channel"); opt != nil {
+		channelID = opt.ChannelValue(nil).ID
+	}
+	if missing := missingChannelPermissions(s, channelID, channelPostPermissions); len(missing) > 0 {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(fmt.Sprintf("I can't post in <#%s>. Missing: %s", channelID, strings.Join(missing, ", "))))
+		return
+	}
+
+	description := "Want to join the clan? Click **Apply** and fill in the form. Staff will review your application and you'll get a DM with the result."
+	if requirements := formatRequirements(settings); requirements != "None" {
+		description += "\n\n**Requirements:** " + requirements
+	}
+	embed := embeds.InfoEmbed("📝 Clan Applications", description)
+	embed.Timestamp = ""
+
+	_, err = s.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{embed},
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						Label:    "Apply",
+						Emoji:    &discordgo.ComponentEmoji{Name: "📝"},
+						Style:    discordgo.PrimaryButton,
+						CustomID: "apply-open",
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		log.Printf("Error posting apply button: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to post the apply button. Please try again."))
+		return
+	}
+
+	sendEphemeralEmbed(s, i, embeds.SuccessEmbed(fmt.Sprintf("Apply button posted in <#%s>. Post a new one if you change the requirements.", channelID)))
+}
+
+// updateApplicationSettings loads the guild's application settings, lets apply change them and saves
+// the result. apply returns the message to show and whether the change is valid.
+func (cc *ConfigCommands) updateApplicationSettings(s *discordgo.Session, i *discordgo.InteractionCreate, reason string, apply func(*database.GuildApplicationSetting) (string, bool)) {
+	ctx := context.Background()
+
+	if !isServerOwnerOrAdmin(s, i) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Only the server owner or administrators can configure clan applications."))
+		return
+	}
+
+	guildID, err := strconv.ParseInt(i.GuildID, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing guild ID: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse guild ID."))
+		return
+	}
+
+	actorID, err := strconv.ParseInt(i.Member.User.ID, 10, 64)
+	if err != nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse user ID."))
+		return
+	}
+
+	settings, err := loadApplicationSettings(ctx, cc.DB, i.GuildID)
+	if err != nil {
+		log.Printf("Error loading application settings: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to fetch configuration. Please try again."))
+		return
+	}
+	before := describeApplicationSettings(settings)
+
+	msg, ok := apply(&settings)
+	if !ok {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(msg))
+		return
+	}
+
+	recordConfigVersion(ctx, cc.DB, guildID, i.Member.User.ID, reason)
+
+	err = cc.DB.UpsertApplicationSettings(ctx, database.UpsertApplicationSettingsParams{
+		GuildID:         guildID,
+		ReviewChannelID: settings.ReviewChannelID,
+		ApprovedRoleID:  settings.ApprovedRoleID,
+		MinTotalLevel:   settings.MinTotalLevel,
+		MinCombatLevel:  settings.MinCombatLevel,
+		MinEhp:          settings.MinEhp,
+		UpdatedBy:       actorID,
+	})
+	if err != nil {
+		log.Printf("Error saving application settings: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to save configuration. Please try again."))
+		return
+	}
+
+	cc.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionApplicationSettings,
+		Before:  before,
+		After:   describeApplicationSettings(settings),
+	})
+
+	sendEphemeralEmbed(s, i, embeds.SuccessEmbed(msg))
+}
+
+// describeApplicationSettings summarises application settings for the audit log.
+func describeApplicationSettings(settings database.GuildApplicationSetting) string {
+	channel := "none"
+	if settings.ReviewChannelID.Valid {
+		channel = fmt.Sprintf("<#%d>", settings.ReviewChannelID.Int64)
+	}
+	role := "none"
+	if settings.ApprovedRoleID.Valid {
+		role = fmt.Sprintf("<@&%d>", settings.ApprovedRoleID.Int64)
+	}
+	return fmt.Sprintf("review channel: %s, approved role: %s, requirements: %s", channel, role, formatRequirements(settings))
+}
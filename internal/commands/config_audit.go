@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/audit"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/embeds"
+)
+
+// HandleSetAuditLogChannel handles /config set-audit-log-channel command.
+// Omitting the channel turns off mirroring; entries are still recorded for /audit.
+func (cc *ConfigCommands) HandleSetAuditLogChannel(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	if !isServerOwnerOrAdmin(s, i) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Only the server owner or administrators can configure the audit log channel."))
+		return
+	}
+
+	guildID, err := strconv.ParseInt(i.GuildID, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing guild ID: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse guild ID."))
+		return
+	}
+
+	channel := sql.NullInt64{Valid: false}
+	after := ""
+	if opt := subcommandOption(i, "channel"); opt != nil {
+		channelID := opt.ChannelValue(nil).ID
+		id, err := strconv.ParseInt(channelID, 10, 64)
+		if err != nil {
+			log.Printf("Error parsing channel ID: %v", err)
+			sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse channel ID."))
+			return
+		}
+		channel = sql.NullInt64{Int64: id, Valid: true}
+		after = "<#" + channelID + ">"
+	}
+
+	if err := ensureGuildConfig(ctx, cc.DB, guildID); err != nil {
+		log.Printf("Error ensuring guild config: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to fetch configuration. Please try again."))
+		return
+	}
+
+	before, _ := cc.DB.GetGuildConfig(ctx, guildID)
+
+	recordConfigVersion(ctx, cc.DB, guildID, i.Member.User.ID, "/config set-audit-log-channel")
+
+	err = cc.DB.UpdateAuditLogChannel(ctx, database.UpdateAuditLogChannelParams{
+		AuditLogChannelID: channel,
+		GuildID:           guildID,
+	})
+	if err != nil {
+		log.Printf("Error updating audit log channel: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to save configuration. Please try again."))
+		return
+	}
+
+	cc.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionAuditLogChannel,
+		Before:  formatChannelID(before.AuditLogChannelID),
+		After:   after,
+	})
+
+	if !channel.Valid {
+		sendEphemeralEmbed(s, i, embeds.SuccessEmbed("Audit log mirroring disabled.\n\nChanges are still recorded and can be searched with `/audit`."))
+		return
+	}
+
+	sendEphemeralEmbed(s, i, embeds.SuccessEmbed(fmt.Sprintf("Audit log channel set to %s\n\nConfiguration changes and event actions will be posted there.", after)))
+}
+
+// formatRoleID renders a stored role ID as a mention, or "" when unset.
+func formatRoleID(id sql.NullInt64) string {
+	if !id.Valid {
+		return ""
+	}
+	return fmt.Sprintf("<@&%d>", id.Int64)
+}
+
+// formatChannelID renders a stored channel ID as a mention, or "" when unset.
+func formatChannelID(id sql.NullInt64) string {
+	if !id.Valid {
+		return ""
+	}
+	return fmt.Sprintf("<#%d>", id.Int64)
+}
@@ -0,0 +1,564 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/embeds"
+	"github.com/kaffeed/voidling/internal/wiseoldman"
+	"github.com/kaffeed/voidling/migrations"
+	"github.com/pressly/goose/v3"
+)
+
+// doctorProbeRSN is looked up to check that Wise Old Man is reachable; "not found" still counts as reachable.
+const doctorProbeRSN = "Zezima"
+
+// doctorTimeout bounds the external calls made by /config doctor.
+const doctorTimeout = 10 * time.Second
+
+// Result levels for /config doctor checks.
+const (
+	doctorOK = iota
+	doctorWarn
+	doctorFail
+)
+
+// doctorCheck is one line of the /config doctor report.
+type doctorCheck struct {
+	level   int
+	message string
+	fix     string
+}
+
+func (c doctorCheck) String() string {
+	icon := "✅"
+	switch c.level {
+	case doctorWarn:
+		icon = "⚠️"
+	case doctorFail:
+		icon = "❌"
+	}
+	if c.fix == "" {
+		return icon + " " + c.message
+	}
+	return icon + " " + c.message + "\n  → " + c.fix
+}
+
+// doctorSection groups related checks under a heading.
+type doctorSection struct {
+	title  string
+	checks []doctorCheck
+}
+
+// HandleDoctor handles /config doctor, checking permissions, configured roles and channels,
+// Wise Old Man reachability and the database version.
+func (cc *ConfigCommands) HandleDoctor(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx, cancel := context.WithTimeout(context.Background(), doctorTimeout)
+	defer cancel()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	if !isServerOwnerOrAdmin(s, i) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Only the server owner or administrators can run the health check."))
+		return
+	}
+
+	guildID, err := strconv.ParseInt(i.GuildID, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing guild ID: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse guild ID."))
+		return
+	}
+
+	settings, err := loadGuildSettings(ctx, cc.DB, guildID)
+	if err != nil {
+		log.Printf("Error loading guild settings for doctor: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Database error. Please try again later."))
+		return
+	}
+
+	womChecks := []doctorCheck{cc.checkWOM(ctx)}
+	if check, ok := cc.checkWOMGroup(ctx, i.GuildID); ok {
+		womChecks = append(womChecks, check)
+	}
+	if cc.WOMClient != nil {
+		womChecks = append(womChecks, womCacheCheck(cc.WOMClient.CacheStats()))
+	}
+	sections := []doctorSection{
+		{"Database", []doctorCheck{cc.checkDatabase()}},
+		{"Wise Old Man", womChecks},
+	}
+	sections = append(sections, checkDiscordSetup(s, i.GuildID, settings)...)
+
+	failures, warnings := 0, 0
+	var sb strings.Builder
+	for _, section := range sections {
+		if len(section.checks) == 0 {
+			continue
+		}
+		sb.WriteString("**" + section.title + "**\n")
+		for _, check := range section.checks {
+			switch check.level {
+			case doctorFail:
+				failures++
+			case doctorWarn:
+				warnings++
+			}
+			sb.WriteString(check.String() + "\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	sendEphemeralEmbed(s, i, embeds.HealthCheck(sb.String(), failures, warnings))
+}
+
+// checkDatabase compares the applied migration version with the newest embedded migration.
+func (cc *ConfigCommands) checkDatabase() doctorCheck {
+	latest, err := latestMigrationVersion(migrations.FS)
+	if err != nil {
+		log.Printf("Error reading embedded migrations: %v", err)
+		return doctorCheck{level: doctorWarn, message: "Couldn't read the bundled migrations"}
+	}
+
+	current, err := goose.GetDBVersion(cc.DBSQL)
+	if err != nil {
+		log.Printf("Error reading database version: %v", err)
+		return doctorCheck{level: doctorFail, message: "Couldn't read the database migration version", fix: "Check the bot's logs and that the database file is readable."}
+	}
+
+	if current < latest {
+		return doctorCheck{
+			level:   doctorFail,
+			message: fmt.Sprintf("Database is at migration %d, but this version of the bot expects %d", current, latest),
+			fix:     "Restart the bot so it can apply pending migrations.",
+		}
+	}
+	return doctorCheck{level: doctorOK, message: fmt.Sprintf("Database migrations up to date (version %d)", current)}
+}
+
+// checkWOM looks up a well-known player to confirm the Wise Old Man API answers. The lookup skips
+// the cache so it always reaches the API.
+func (cc *ConfigCommands) checkWOM(ctx context.Context) doctorCheck {
+	if cc.WOMClient == nil {
+		return doctorCheck{level: doctorWarn, message: "Wise Old Man client not configured"}
+	}
+
+	start := time.Now()
+	_, err := cc.WOMClient.GetPlayer(wiseoldman.NoCache(ctx), doctorProbeRSN)
+	elapsed := time.Since(start).Round(time.Millisecond)
+
+	if err != nil && !errors.Is(err, wiseoldman.ErrPlayerNotFound) {
+		log.Printf("Wise Old Man health check failed: %v", err)
+		return doctorCheck{
+			level:   doctorFail,
+			message: "Wise Old Man API is unreachable",
+			fix:     "BOTW/SOTW and account linking won't work until it's back. Check https://wiseoldman.net or the bot host's network.",
+		}
+	}
+	return doctorCheck{level: doctorOK, message: fmt.Sprintf("Wise Old Man API reachable (%s)", elapsed)}
+}
+
+// womCacheCheck reports how many Wise Old Man lookups were answered without an API request.
+func womCacheCheck(stats wiseoldman.CacheStats) doctorCheck {
+	lookups := stats.Hits + stats.Misses + stats.Shared
+	if lookups == 0 {
+		return doctorCheck{level: doctorOK, message: "Wise Old Man cache: no lookups yet"}
+	}
+	return doctorCheck{level: doctorOK, message: fmt.Sprintf(
+		"Wise Old Man cache: %.0f%% of %d lookups saved a request (%d hits, %d shared, %d misses; %d cached)",
+		stats.HitRate()*100, lookups, stats.Hits, stats.Shared, stats.Misses, stats.Entries)}
+}
+
+// checkWOMGroup confirms the guild's Wise Old Man group still exists. It reports false when no
+// group is configured.
+func (cc *ConfigCommands) checkWOMGroup(ctx context.Context, guildID string) (doctorCheck, bool) {
+	group, err := loadWOMGroup(ctx, cc.DB, guildID)
+	if errors.Is(err, ErrNoWOMGroup) || cc.WOMClient == nil {
+		return doctorCheck{}, false
+	}
+	if err != nil {
+		log.Printf("Error loading WOM group for doctor: %v", err)
+		return doctorCheck{level: doctorFail, message: "Couldn't read the configured Wise Old Man group"}, true
+	}
+
+	details, err := cc.WOMClient.GetGroup(ctx, group.GroupID)
+	if errors.Is(err, wiseoldman.ErrGroupNotFound) {
+		return doctorCheck{
+			level:   doctorFail,
+			message: fmt.Sprintf("Wise Old Man group #%d no longer exists", group.GroupID),
+			fix:     "Set the current group with `/config wom-group set` or remove it with `/config wom-group clear`.",
+		}, true
+	}
+	if err != nil {
+		log.Printf("Error fetching WOM group %d for doctor: %v", group.GroupID, err)
+		return doctorCheck{level: doctorWarn, message: fmt.Sprintf("Couldn't fetch Wise Old Man group #%d", group.GroupID)}, true
+	}
+	return doctorCheck{level: doctorOK, message: fmt.Sprintf("Wise Old Man group **%s** (%d members)", details.Name, details.MemberCount)}, true
+}
+
+// checkDiscordSetup verifies configured roles and channels and the bot's permissions and role position.
+func checkDiscordSetup(s *discordgo.Session, guildID string, settings guildSettings) []doctorSection {
+	roles, err := s.GuildRoles(guildID)
+	if err != nil {
+		log.Printf("Error fetching guild roles for doctor: %v", err)
+		return []doctorSection{{"Discord", []doctorCheck{{level: doctorFail, message: "Couldn't fetch the server's roles"}}}}
+	}
+	channels, err := s.GuildChannels(guildID)
+	if err != nil {
+		log.Printf("Error fetching guild channels for doctor: %v", err)
+		return []doctorSection{{"Discord", []doctorCheck{{level: doctorFail, message: "Couldn't fetch the server's channels"}}}}
+	}
+	botMember, err := s.GuildMember(guildID, s.State.User.ID)
+	if err != nil {
+		log.Printf("Error fetching bot member for doctor: %v", err)
+		return []doctorSection{{"Discord", []doctorCheck{{level: doctorFail, message: "Couldn't fetch the bot's server membership"}}}}
+	}
+
+	rolesByID := make(map[string]*discordgo.Role, len(roles))
+	for _, role := range roles {
+		rolesByID[role.ID] = role
+	}
+	channelsByID := make(map[string]*discordgo.Channel, len(channels))
+	for _, channel := range channels {
+		channelsByID[channel.ID] = channel
+	}
+
+	guildPerms := guildPermissions(guildID, roles, botMember.Roles)
+	botPosition := highestRolePosition(roles, botMember.Roles)
+
+	return []doctorSection{
+		{"Server Permissions", checkGuildPermissions(guildPerms, settings)},
+		{"Channels", checkChannels(s, channelsByID, rolesByID, settings)},
+		{"Roles", checkRoles(rolesByID, roles, append(slices.Clone(botMember.Roles), guildID), botPosition, guildPerms, settings)},
+	}
+}
+
+// checkGuildPermissions verifies the server-wide permissions each enabled feature needs.
+func checkGuildPermissions(perms int64, settings guildSettings) []doctorCheck {
+	type requirement struct {
+		bit     int64
+		feature string
+	}
+	var requirements []requirement
+	if settings.featureEnabled(FeatureMass) {
+		requirements = append(requirements, requirement{discordgo.PermissionManageEvents, "/mass creates scheduled events"})
+	}
+	if settings.featureEnabled(FeatureBOTW) || settings.featureEnabled(FeatureSOTW) {
+		requirements = append(requirements,
+			requirement{discordgo.PermissionCreatePublicThreads, "/botw and /sotw start a thread for each event"},
+			requirement{discordgo.PermissionSendMessagesInThreads, "/botw and /sotw post in their event threads"})
+	}
+	if settings.NicknameTemplate != "" && settings.featureEnabled(FeatureAutoNickname) {
+		requirements = append(requirements, requirement{discordgo.PermissionManageNicknames, "the nickname template renames linked members"})
+	}
+	if len(settings.NotificationRoles) > 0 {
+		requirements = append(requirements, requirement{discordgo.PermissionManageRoles, "members pick their event ping roles"})
+	}
+	if settings.LinkedRoleID != "" {
+		requirements = append(requirements, requirement{discordgo.PermissionManageRoles, "members get the linked role when they link their account"})
+	}
+	if settings.ApplicationRoleID != "" {
+		requirements = append(requirements, requirement{discordgo.PermissionManageRoles, "approved applicants get the member role"})
+	}
+	if len(settings.RankRoles) > 0 {
+		requirements = append(requirements, requirement{discordgo.PermissionManageRoles, "linked members get the role for their clan rank"})
+	}
+	for _, rule := range settings.EscalationRules {
+		if !settings.featureEnabled(FeatureWarnings) {
+			break
+		}
+		switch rule.Action {
+		case EscalationTimeout:
+			requirements = append(requirements, requirement{discordgo.PermissionModerateMembers, "warning escalation rules time members out"})
+		case EscalationRemoveRole:
+			requirements = append(requirements, requirement{discordgo.PermissionManageRoles, "warning escalation rules remove roles"})
+		}
+	}
+
+	var checks []doctorCheck
+	seen := make(map[int64]bool)
+	for _, req := range requirements {
+		if seen[req.bit] {
+			continue
+		}
+		seen[req.bit] = true
+
+		name := permissionName(req.bit)
+		if perms&discordgo.PermissionAdministrator == 0 && perms&req.bit == 0 {
+			checks = append(checks, doctorCheck{
+				level:   doctorFail,
+				message: fmt.Sprintf("Missing **%s** (%s)", name, req.feature),
+				fix:     fmt.Sprintf("Grant the bot's role **%s** in Server Settings → Roles.", name),
+			})
+			continue
+		}
+		checks = append(checks, doctorCheck{level: doctorOK, message: name})
+	}
+	return checks
+}
+
+// checkChannels verifies every configured channel exists and that the bot can post there.
+func checkChannels(s *discordgo.Session, channels map[string]*discordgo.Channel, roles map[string]*discordgo.Role, settings guildSettings) []doctorCheck {
+	notificationPerms := int64(channelPostPermissions)
+	pingRoles := []string{settings.EventNotificationRoleID}
+	for _, role := range settings.NotificationRoles {
+		pingRoles = append(pingRoles, role.RoleID)
+	}
+	for _, id := range pingRoles {
+		if role := roles[id]; role != nil && !role.Mentionable {
+			// Pinging a role that isn't mentionable needs Mention All Roles
+			notificationPerms |= discordgo.PermissionMentionEveryone
+		}
+	}
+
+	configured := []struct {
+		label     string
+		id        string
+		command   string
+		required  int64
+		important bool
+	}{
+		{"Event notification channel", settings.EventNotificationChannelID, "/config set-event-notification-channel", notificationPerms, true},
+		{"Competition code channel", settings.CompetitionCodeChannelID, "/config set-competition-code-channel", channelPostPermissions, settings.featureEnabled(FeatureBOTW) || settings.featureEnabled(FeatureSOTW)},
+		{"Warning channel", settings.WarningChannelID, "/warn channel", channelPostPermissions, false},
+		{"Audit log channel", settings.AuditLogChannelID, "/config set-audit-log-channel", channelPostPermissions, false},
+		{"Welcome channel", settings.WelcomeChannelID, "/config welcome channel", channelPostPermissions, false},
+		{"Application review channel", settings.ApplicationChannelID, "/config applications review-channel", channelPostPermissions, false},
+		{"Inactivity report channel", settings.InactivityChannelID, "/config inactivity report-channel", channelPostPermissions, false},
+	}
+
+	var checks []doctorCheck
+	for _, c := range configured {
+		if c.id == "" {
+			if c.important {
+				checks = append(checks, doctorCheck{level: doctorWarn, message: c.label + " not configured", fix: fmt.Sprintf("Set one with `%s` or `/setup`.", c.command)})
+			}
+			continue
+		}
+		if channels[c.id] == nil {
+			checks = append(checks, doctorCheck{
+				level:   doctorFail,
+				message: fmt.Sprintf("%s `%s` no longer exists", c.label, c.id),
+				fix:     fmt.Sprintf("Pick a new channel with `%s`.", c.command),
+			})
+			continue
+		}
+
+		missing := missingChannelPermissions(s, c.id, c.required)
+		if len(missing) > 0 {
+			checks = append(checks, doctorCheck{
+				level:   doctorFail,
+				message: fmt.Sprintf("%s <#%s>: missing %s", c.label, c.id, strings.Join(missing, ", ")),
+				fix:     "Edit the channel's permissions to allow the bot's role.",
+			})
+			continue
+		}
+		checks = append(checks, doctorCheck{level: doctorOK, message: fmt.Sprintf("%s <#%s>", c.label, c.id)})
+	}
+	return checks
+}
+
+// checkRoles verifies configured roles still exist and sit below the bot where the bot has to manage them.
+func checkRoles(rolesByID map[string]*discordgo.Role, roles []*discordgo.Role, botRoles []string, botPosition int, guildPerms int64, settings guildSettings) []doctorCheck {
+	var checks []doctorCheck
+
+	exists := func(label, id, command string) bool {
+		if id == "" {
+			return false
+		}
+		if rolesByID[id] == nil {
+			checks = append(checks, doctorCheck{
+				level:   doctorFail,
+				message: fmt.Sprintf("%s `%s` no longer exists", label, id),
+				fix:     fmt.Sprintf("Pick a new role with `%s`.", command),
+			})
+			return false
+		}
+		return true
+	}
+
+	if settings.CoordinatorRoleID == "" {
+		checks = append(checks, doctorCheck{level: doctorWarn, message: "Coordinator role not configured", fix: "Set one with `/config set-coordinator-role` or `/setup`."})
+	} else if exists("Coordinator role", settings.CoordinatorRoleID, "/config set-coordinator-role") {
+		checks = append(checks, doctorCheck{level: doctorOK, message: "Coordinator role <@&" + settings.CoordinatorRoleID + ">"})
+	}
+
+	pingRoles := []struct{ label, id, command string }{
+		{"Event notification role", settings.EventNotificationRoleID, "/config set-event-notification-role"},
+	}
+	for _, role := range settings.NotificationRoles {
+		if nt, ok := lookupNotificationType(role.EventType); ok {
+			pingRoles = append(pingRoles, struct{ label, id, command string }{nt.Label + " ping role", role.RoleID, "/config notifications set-role"})
+		}
+	}
+	for _, ping := range pingRoles {
+		if !exists(ping.label, ping.id, ping.command) {
+			continue
+		}
+		role := rolesByID[ping.id]
+		switch {
+		case !role.Mentionable && guildPerms&(discordgo.PermissionMentionEveryone|discordgo.PermissionAdministrator) == 0:
+			checks = append(checks, doctorCheck{
+				level:   doctorWarn,
+				message: fmt.Sprintf("%s <@&%s> isn't mentionable, so event pings won't notify anyone", ping.label, role.ID),
+				fix:     "Enable \"Allow anyone to @mention this role\" or grant the bot **Mention All Roles**.",
+			})
+		case role.Position >= botPosition:
+			checks = append(checks, doctorCheck{
+				level:   doctorWarn,
+				message: fmt.Sprintf("%s <@&%s> is above the bot's highest role, so members can't pick it from the role picker", ping.label, role.ID),
+				fix:     "Drag the bot's role above it in Server Settings → Roles.",
+			})
+		default:
+			checks = append(checks, doctorCheck{level: doctorOK, message: fmt.Sprintf("%s <@&%s>", ping.label, role.ID)})
+		}
+	}
+
+	for _, rule := range settings.EscalationRules {
+		if rule.Action != EscalationRemoveRole || !exists("Escalation rule role", rule.RoleID, "/warn policy add") {
+			continue
+		}
+		if role := rolesByID[rule.RoleID]; role.Position >= botPosition {
+			checks = append(checks, doctorCheck{
+				level:   doctorFail,
+				message: fmt.Sprintf("Escalation rule role <@&%s> is above the bot's highest role, so it can't be removed", role.ID),
+				fix:     "Drag the bot's role above it in Server Settings → Roles.",
+			})
+		}
+	}
+
+	if exists("Linked role", settings.LinkedRoleID, "/config welcome linked-role") {
+		if role := rolesByID[settings.LinkedRoleID]; role.Position >= botPosition {
+			checks = append(checks, doctorCheck{
+				level:   doctorFail,
+				message: fmt.Sprintf("Linked role <@&%s> is above the bot's highest role, so it can't be assigned", role.ID),
+				fix:     "Drag the bot's role above it in Server Settings → Roles.",
+			})
+		} else {
+			checks = append(checks, doctorCheck{level: doctorOK, message: "Linked role <@&" + role.ID + ">"})
+		}
+	}
+
+	if exists("Approved applicant role", settings.ApplicationRoleID, "/config applications approved-role") {
+		if role := rolesByID[settings.ApplicationRoleID]; role.Position >= botPosition {
+			checks = append(checks, doctorCheck{
+				level:   doctorFail,
+				message: fmt.Sprintf("Approved applicant role <@&%s> is above the bot's highest role, so it can't be assigned", role.ID),
+				fix:     "Drag the bot's role above it in Server Settings → Roles.",
+			})
+		} else {
+			checks = append(checks, doctorCheck{level: doctorOK, message: "Approved applicant role <@&" + role.ID + ">"})
+		}
+	}
+
+	for _, rank := range settings.RankRoles {
+		label := fmt.Sprintf("`%s` rank role", rank.WOMRole)
+		if !exists(label, rank.RoleID, "/config rank-roles set") {
+			continue
+		}
+		if role := rolesByID[rank.RoleID]; role.Position >= botPosition {
+			checks = append(checks, doctorCheck{
+				level:   doctorFail,
+				message: fmt.Sprintf("%s <@&%s> is above the bot's highest role, so it can't be assigned", label, role.ID),
+				fix:     "Drag the bot's role above it in Server Settings → Roles.",
+			})
+		} else {
+			checks = append(checks, doctorCheck{level: doctorOK, message: label + " <@&" + role.ID + ">"})
+		}
+	}
+
+	if settings.NicknameTemplate != "" && settings.featureEnabled(FeatureAutoNickname) {
+		var above []string
+		for _, role := range rolesAbove(roles, botPosition, botRoles) {
+			above = append(above, "<@&"+role.ID+">")
+		}
+		if len(above) > 0 {
+			checks = append(checks, doctorCheck{
+				level:   doctorWarn,
+				message: "Members with these roles can't be renamed by the nickname template: " + strings.Join(above, ", "),
+				fix:     "Drag the bot's role above them in Server Settings → Roles. The server owner can never be renamed.",
+			})
+		} else {
+			checks = append(checks, doctorCheck{level: doctorOK, message: "Bot's role is above every member role, so nicknames can be updated"})
+		}
+	}
+
+	return checks
+}
+
+// guildPermissions returns the server-wide permissions granted by @everyone and memberRoles.
+func guildPermissions(guildID string, roles []*discordgo.Role, memberRoles []string) int64 {
+	var perms int64
+	for _, role := range roles {
+		if role.ID == guildID || slices.Contains(memberRoles, role.ID) {
+			perms |= role.Permissions
+		}
+	}
+	return perms
+}
+
+// highestRolePosition returns the position of the highest role in memberRoles, or 0 for @everyone only.
+func highestRolePosition(roles []*discordgo.Role, memberRoles []string) int {
+	highest := 0
+	for _, role := range roles {
+		if slices.Contains(memberRoles, role.ID) && role.Position > highest {
+			highest = role.Position
+		}
+	}
+	return highest
+}
+
+// rolesAbove lists roles at or above position, except those in exclude and managed roles,
+// which belong to bots and integrations.
+func rolesAbove(roles []*discordgo.Role, position int, exclude []string) []*discordgo.Role {
+	var above []*discordgo.Role
+	for _, role := range roles {
+		if role.Position >= position && !role.Managed && !slices.Contains(exclude, role.ID) {
+			above = append(above, role)
+		}
+	}
+	return above
+}
+
+// latestMigrationVersion returns the highest version among the goose migrations in fsys.
+func latestMigrationVersion(fsys fs.FS) (int64, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return 0, fmt.Errorf("read migrations: %w", err)
+	}
+
+	var latest int64
+	for _, entry := range entries {
+		prefix, _, ok := strings.Cut(entry.Name(), "_")
+		if !ok || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil {
+			continue
+		}
+		latest = max(latest, version)
+	}
+	return latest, nil
+}
+
+// permissionName returns the display name of a single permission bit.
+func permissionName(bit int64) string {
+	for _, p := range permissionNames {
+		if p.bit == bit {
+			return p.name
+		}
+	}
+	return strconv.FormatInt(bit, 10)
+}
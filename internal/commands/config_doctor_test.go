@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuildPermissionsAndPosition(t *testing.T) {
+	roles := []*discordgo.Role{
+		{ID: "1", Position: 0, Permissions: discordgo.PermissionViewChannel},
+		{ID: "2", Position: 3, Permissions: discordgo.PermissionManageNicknames},
+		{ID: "3", Position: 5, Permissions: discordgo.PermissionManageEvents},
+		{ID: "4", Position: 7, Managed: true},
+		{ID: "5", Position: 8},
+	}
+
+	perms := guildPermissions("1", roles, []string{"2"})
+	assert.Equal(t, int64(discordgo.PermissionViewChannel|discordgo.PermissionManageNicknames), perms, "@everyone and member roles are combined")
+
+	assert.Equal(t, 3, highestRolePosition(roles, []string{"2"}))
+	assert.Equal(t, 0, highestRolePosition(roles, nil))
+
+	above := rolesAbove(roles, 3, []string{"2"})
+	require.Len(t, above, 2)
+	assert.Equal(t, "3", above[0].ID)
+	assert.Equal(t, "5", above[1].ID, "managed roles are skipped")
+}
+
+func TestCheckGuildPermissions(t *testing.T) {
+	settings := guildSettings{
+		NicknameTemplate: "{rsn}",
+		EscalationRules: []escalationRuleSetting{
+			{Threshold: 2, Action: EscalationTimeout, DurationMinutes: 60},
+			{Threshold: 3, Action: EscalationTimeout, DurationMinutes: 120},
+		},
+	}
+
+	checks := checkGuildPermissions(discordgo.PermissionManageEvents|discordgo.PermissionManageNicknames, settings)
+
+	require.Len(t, checks, 5, "duplicate requirements are checked once")
+	levels := map[string]int{}
+	for _, check := range checks {
+		levels[check.message] = check.level
+	}
+	assert.Equal(t, doctorOK, levels["Manage Events"])
+	assert.Equal(t, doctorOK, levels["Manage Nicknames"])
+	assert.Equal(t, doctorFail, levels["Missing **Timeout Members** (warning escalation rules time members out)"])
+
+	for _, check := range checkGuildPermissions(discordgo.PermissionAdministrator, settings) {
+		assert.Equal(t, doctorOK, check.level, "administrator grants everything")
+	}
+
+	settings.DisabledFeatures = []string{FeatureMass, FeatureAutoNickname, FeatureWarnings}
+	checks = checkGuildPermissions(0, settings)
+
+	require.Len(t, checks, 2, "disabled features need no permissions")
+	for _, check := range checks {
+		assert.NotContains(t, check.message, "Manage Events")
+	}
+}
+
+func TestLatestMigrationVersion(t *testing.T) {
+	fsys := fstest.MapFS{
+		"00001_initial.sql":  {},
+		"00012_feature.sql":  {},
+		"00003_other.sql":    {},
+		"migrations.go":      {},
+		"notes_00099.sql":    {},
+		"readme_without.txt": {},
+	}
+
+	version, err := latestMigrationVersion(fsys)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), version)
+}
@@ -0,0 +1,1330 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/audit"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/embeds"
+	"github.com/kaffeed/voidling/internal/models"
+	"github.com/kaffeed/voidling/internal/timezone"
+)
+
+const (
+	// guildSettingsVersion is the format version written by /config export.
+	guildSettingsVersion = 1
+	// defaultHistoryLimit is how many versions /config history shows.
+	defaultHistoryLimit = 10
+	// maxDiffLines limits how many changes are listed in an import preview.
+	maxDiffLines = 30
+)
+
+var (
+	// ErrUnsupportedSettingsVersion is returned when an import file was written by an unknown format version.
+	ErrUnsupportedSettingsVersion = errors.New("unsupported settings version")
+
+	// ErrSettingsTrailingData is returned when an import file contains more than one JSON document.
+	ErrSettingsTrailingData = errors.New("unexpected data after settings document")
+)
+
+// guildSettings is the portable form of a guild's configuration used by export, import and history.
+// Discord IDs are strings to avoid precision loss in JSON tooling; unset values are omitted.
+type guildSettings struct {
+	Version                    int                        `json:"version"`
+	CoordinatorRoleID          string                     `json:"coordinator_role_id,omitempty"`
+	CompetitionCodeChannelID   string                     `json:"competition_code_channel_id,omitempty"`
+	EventNotificationRoleID    string                     `json:"event_notification_role_id,omitempty"`
+	EventNotificationChannelID string                     `json:"event_notification_channel_id,omitempty"`
+	AuditLogChannelID          string                     `json:"audit_log_channel_id,omitempty"`
+	WarningChannelID           string                     `json:"warning_channel_id,omitempty"`
+	DefaultTimezone            string                     `json:"default_timezone,omitempty"`
+	NicknameTemplate           string                     `json:"nickname_template,omitempty"`
+	WarningExpiryDays          int64                      `json:"warning_expiry_days,omitempty"`
+	EscalationRules            []escalationRuleSetting    `json:"escalation_rules"`
+	CommandPermissions         []commandPermissionSetting `json:"command_permissions"`
+	NotificationRoles          []notificationRoleSetting  `json:"notification_roles"`
+	DisabledFeatures           []string                   `json:"disabled_features,omitempty"`
+	WelcomeDMTitle             string                     `json:"welcome_dm_title,omitempty"`
+	WelcomeDMMessage           string                     `json:"welcome_dm_message,omitempty"`
+	WelcomeChannelID           string                     `json:"welcome_channel_id,omitempty"`
+	WelcomeChannelMessage      string                     `json:"welcome_channel_message,omitempty"`
+	LinkedRoleID               string                     `json:"linked_role_id,omitempty"`
+	OnboardingChecklist        []string                   `json:"onboarding_checklist"`
+	ApplicationChannelID       string                     `json:"application_channel_id,omitempty"`
+	ApplicationRoleID          string                     `json:"application_role_id,omitempty"`
+	ApplicationMinTotalLevel   int64                      `json:"application_min_total_level,omitempty"`
+	ApplicationMinCombatLevel  int64                      `json:"application_min_combat_level,omitempty"`
+	ApplicationMinEHP          float64                    `json:"application_min_ehp,omitempty"`
+	InactivityChannelID        string                     `json:"inactivity_channel_id,omitempty"`
+	InactivityThresholdDays    int64                      `json:"inactivity_threshold_days,omitempty"`
+	InactivityNudges           bool                       `json:"inactivity_nudges,omitempty"`
+	RankRoles                  []rankRoleSetting          `json:"rank_roles,omitempty"`
+}
+
+// escalationRuleSetting is a warning escalation rule in exported settings.
+type escalationRuleSetting struct {
+	Threshold       int64  `json:"threshold"`
+	Action          string `json:"action"`
+	DurationMinutes int64  `json:"duration_minutes,omitempty"`
+	RoleID          string `json:"role_id,omitempty"`
+}
+
+// commandPermissionSetting is a command permission override in exported settings.
+type commandPermissionSetting struct {
+	Command     string `json:"command"`
+	SubjectType string `json:"subject_type"`
+	SubjectID   string `json:"subject_id"`
+}
+
+// notificationRoleSetting is a per-event-type ping role in exported settings.
+type notificationRoleSetting struct {
+	EventType string `json:"event_type"`
+	RoleID    string `json:"role_id"`
+}
+
+// rankRoleSetting maps a Wise Old Man group rank to a Discord role in exported settings.
+type rankRoleSetting struct {
+	WOMRole string `json:"wom_role"`
+	RoleID  string `json:"role_id"`
+}
+
+// pendingConfigImport holds validated settings waiting for confirmation.
+type pendingConfigImport struct {
+	requesterID string
+	settings    guildSettings
+	action      string
+	reason      string
+	expiresAt   time.Time
+}
+
+// HandleExportConfig handles /config export, returning the guild's settings as a JSON file.
+func (cc *ConfigCommands) HandleExportConfig(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	if !isServerOwnerOrAdmin(s, i) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Only the server owner or administrators can export the server configuration."))
+		return
+	}
+
+	guildID, err := strconv.ParseInt(i.GuildID, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing guild ID: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse guild ID."))
+		return
+	}
+
+	settings, err := loadGuildSettings(ctx, cc.DB, guildID)
+	if err != nil {
+		log.Printf("Error loading guild settings for export: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Database error. Please try again later."))
+		return
+	}
+
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		log.Printf("Error encoding guild settings: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to build the export file."))
+		return
+	}
+
+	sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
+		Content: "Exported the server configuration. Edit it and load it back with `/config import`.",
+		Files: []*discordgo.File{
+			{
+				Name:        fmt.Sprintf("voidling-config-%s.json", time.Now().UTC().Format("2006-01-02")),
+				ContentType: "application/json",
+				Reader:      bytes.NewReader(data),
+			},
+		},
+		Flags: discordgo.MessageFlagsEphemeral,
+	})
+}
+
+// HandleImportConfig handles /config import: validates a settings file and previews the changes.
+func (cc *ConfigCommands) HandleImportConfig(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	if !isServerOwnerOrAdmin(s, i) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Only the server owner or administrators can import a server configuration."))
+		return
+	}
+
+	fileOpt := subcommandOption(i, "file")
+	if fileOpt == nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Missing file parameter. Please attach a JSON file from `/config export`."))
+		return
+	}
+
+	attachmentID, _ := fileOpt.Value.(string)
+	resolved := i.ApplicationCommandData().Resolved
+	if resolved == nil || resolved.Attachments[attachmentID] == nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Couldn't read the attached file. Please try again."))
+		return
+	}
+	attachment := resolved.Attachments[attachmentID]
+
+	if attachment.Size > maxImportFileSize {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("The file is too large to be a configuration export."))
+		return
+	}
+
+	data, err := downloadAttachment(ctx, attachment.URL)
+	if err != nil {
+		log.Printf("Error downloading config import file: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to download the attached file. Please try again."))
+		return
+	}
+
+	settings, err := parseGuildSettings(data)
+	if err != nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(fmt.Sprintf("Couldn't parse the file: %v\n\nExpected a JSON file from `/config export`.", err)))
+		return
+	}
+
+	cc.previewGuildSettings(ctx, s, i, settings, audit.ActionConfigImport, "/config import", "📥 Config Import — Preview")
+}
+
+// HandleConfigHistory handles /config history, listing saved configuration versions.
+func (cc *ConfigCommands) HandleConfigHistory(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	if !isServerOwnerOrAdmin(s, i) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Only the server owner or administrators can view configuration history."))
+		return
+	}
+
+	guildID, err := strconv.ParseInt(i.GuildID, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing guild ID: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse guild ID."))
+		return
+	}
+
+	versions, err := cc.DB.GetGuildConfigHistory(ctx, database.GetGuildConfigHistoryParams{
+		GuildID: guildID,
+		Limit:   defaultHistoryLimit,
+	})
+	if err != nil {
+		log.Printf("Error fetching config history: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Database error. Please try again later."))
+		return
+	}
+
+	if len(versions) == 0 {
+		sendEphemeralEmbed(s, i, embeds.InfoEmbed("🕘 Configuration History", "No versions saved yet. A version is saved automatically before every configuration change."))
+		return
+	}
+
+	var sb strings.Builder
+	for _, version := range versions {
+		sb.WriteString(fmt.Sprintf("`#%d` <t:%d:R> by <@%d> — before `%s`\n", version.ID, version.CreatedAt.Unix(), version.ChangedBy, version.Reason))
+	}
+	sb.WriteString("\nUse `/config rollback version:<id>` to restore one of these versions.")
+
+	sendEphemeralEmbed(s, i, embeds.InfoEmbed("🕘 Configuration History", sb.String()))
+}
+
+// HandleConfigRollback handles /config rollback, previewing a restore of a saved version.
+func (cc *ConfigCommands) HandleConfigRollback(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	if !isServerOwnerOrAdmin(s, i) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Only the server owner or administrators can roll back the configuration."))
+		return
+	}
+
+	versionOpt := subcommandOption(i, "version")
+	if versionOpt == nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Missing version parameter."))
+		return
+	}
+
+	guildID, err := strconv.ParseInt(i.GuildID, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing guild ID: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse guild ID."))
+		return
+	}
+
+	version, err := cc.DB.GetGuildConfigVersion(ctx, database.GetGuildConfigVersionParams{
+		ID:      versionOpt.IntValue(),
+		GuildID: guildID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(fmt.Sprintf("Version #%d not found. Use `/config history` to see saved versions.", versionOpt.IntValue())))
+		return
+	}
+	if err != nil {
+		log.Printf("Error fetching config version: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Database error. Please try again later."))
+		return
+	}
+
+	settings, err := parseGuildSettings([]byte(version.Settings))
+	if err != nil {
+		log.Printf("Error parsing stored config version %d: %v", version.ID, err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(fmt.Sprintf("Version #%d couldn't be read.", version.ID)))
+		return
+	}
+
+	cc.previewGuildSettings(ctx, s, i, settings, audit.ActionConfigRollback,
+		fmt.Sprintf("/config rollback %d", version.ID), fmt.Sprintf("⏪ Rollback to #%d — Preview", version.ID))
+}
+
+// previewGuildSettings validates settings against the guild, shows a diff against the current
+// configuration and stores them for confirmation.
+func (cc *ConfigCommands) previewGuildSettings(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, settings guildSettings, action, reason, title string) {
+	guildID, err := strconv.ParseInt(i.GuildID, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing guild ID: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse guild ID."))
+		return
+	}
+
+	problems := validateGuildSettings(settings, cc.PermissionKeys)
+	if len(problems) == 0 {
+		problems = checkGuildResources(s, i.GuildID, settings)
+	}
+	if len(problems) > 0 {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Nothing was changed because the settings are invalid:\n"+formatProblems(problems)))
+		return
+	}
+
+	current, err := loadGuildSettings(ctx, cc.DB, guildID)
+	if err != nil {
+		log.Printf("Error loading guild settings: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Database error. Please try again later."))
+		return
+	}
+
+	changes := diffGuildSettings(current, settings)
+	if len(changes) == 0 {
+		sendEphemeralEmbed(s, i, embeds.InfoEmbed(title, "These settings match the current configuration. Nothing to change."))
+		return
+	}
+
+	token := i.ID
+	cc.storePendingImport(token, &pendingConfigImport{
+		requesterID: i.Member.User.ID,
+		settings:    settings,
+		action:      action,
+		reason:      reason,
+		expiresAt:   time.Now().Add(pendingImportTTL),
+	})
+
+	components := []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    fmt.Sprintf("Apply %d changes", len(changes)),
+					Style:    discordgo.SuccessButton,
+					CustomID: fmt.Sprintf("confirm-config-import:%s", token),
+				},
+				discordgo.Button{
+					Label:    "Cancel",
+					Style:    discordgo.SecondaryButton,
+					CustomID: fmt.Sprintf("cancel-config-import:%s", token),
+				},
+			},
+		},
+	}
+
+	report := formatProblems(changes) + "\nNothing has been written yet. The current configuration is saved to `/config history` before applying."
+
+	empty := ""
+	_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Content:    &empty,
+		Embeds:     &[]*discordgo.MessageEmbed{embeds.InfoEmbed(title, report)},
+		Components: &components,
+	})
+	if err != nil {
+		log.Printf("Error sending config preview: %v", err)
+	}
+}
+
+// HandleConfirmConfigImport applies previewed settings from an import or rollback.
+func (cc *ConfigCommands) HandleConfirmConfigImport(s *discordgo.Session, i *discordgo.InteractionCreate, token string) {
+	ctx := context.Background()
+
+	pending := cc.takePendingImport(token, i.Member.User.ID)
+	if pending == nil {
+		respondToInteraction(s, i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: &discordgo.InteractionResponseData{
+				Embeds:     []*discordgo.MessageEmbed{embeds.ErrorEmbed("This preview has expired or was already handled. Please run the command again.")},
+				Components: []discordgo.MessageComponent{},
+			},
+		})
+		return
+	}
+
+	guildID, err := strconv.ParseInt(i.GuildID, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing guild ID: %v", err)
+		return
+	}
+
+	recordConfigVersion(ctx, cc.DB, guildID, i.Member.User.ID, pending.reason)
+
+	if err := cc.applyGuildSettings(ctx, guildID, i.Member.User.ID, pending.settings); err != nil {
+		log.Printf("Error applying guild settings: %v", err)
+		respondToInteraction(s, i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: &discordgo.InteractionResponseData{
+				Embeds:     []*discordgo.MessageEmbed{embeds.ErrorEmbed("Applying the settings failed and nothing was changed. Please try again.")},
+				Components: []discordgo.MessageComponent{},
+			},
+		})
+		return
+	}
+
+	log.Printf("User %s applied guild settings via %s", i.Member.User.Username, pending.reason)
+
+	cc.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  pending.action,
+		After:   pending.reason,
+	})
+
+	respondToInteraction(s, i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{embeds.SuccessEmbed("Configuration applied.\n\nThe previous configuration was saved; use `/config history` to roll back.")},
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+}
+
+// HandleCancelConfigImport discards a pending import or rollback.
+func (cc *ConfigCommands) HandleCancelConfigImport(s *discordgo.Session, i *discordgo.InteractionCreate, token string) {
+	cc.takePendingImport(token, i.Member.User.ID)
+
+	respondToInteraction(s, i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    "Cancelled. The configuration was not changed.",
+			Embeds:     []*discordgo.MessageEmbed{},
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+}
+
+// applyGuildSettings replaces the guild's configuration with settings inside one transaction.
+// settings must already have passed validateGuildSettings.
+func (cc *ConfigCommands) applyGuildSettings(ctx context.Context, guildID int64, actorIDStr string, settings guildSettings) error {
+	actorID, err := strconv.ParseInt(actorIDStr, 10, 64)
+	if err != nil {
+		return fmt.Errorf("parse actor ID: %w", err)
+	}
+
+	tx, err := cc.DBSQL.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // Rollback is safe to call even after commit
+
+	qtx := cc.DB.WithTx(tx)
+
+	if err := ensureGuildConfig(ctx, qtx, guildID); err != nil {
+		return err
+	}
+
+	expiry := sql.NullInt64{}
+	if settings.WarningExpiryDays > 0 {
+		expiry = sql.NullInt64{Int64: settings.WarningExpiryDays, Valid: true}
+	}
+
+	err = qtx.ReplaceGuildConfigSettings(ctx, database.ReplaceGuildConfigSettingsParams{
+		CoordinatorRoleID:          settingID(settings.CoordinatorRoleID),
+		CompetitionCodeChannelID:   settingID(settings.CompetitionCodeChannelID),
+		DefaultTimezone:            sql.NullString{String: settings.DefaultTimezone, Valid: settings.DefaultTimezone != ""},
+		EventNotificationRoleID:    settingID(settings.EventNotificationRoleID),
+		EventNotificationChannelID: settingID(settings.EventNotificationChannelID),
+		NicknameTemplate:           sql.NullString{String: settings.NicknameTemplate, Valid: settings.NicknameTemplate != ""},
+		WarningExpiryDays:          expiry,
+		AuditLogChannelID:          settingID(settings.AuditLogChannelID),
+		GuildID:                    guildID,
+	})
+	if err != nil {
+		return fmt.Errorf("replace guild config: %w", err)
+	}
+
+	if channel := settingID(settings.WarningChannelID); channel.Valid {
+		if _, err := qtx.SetGuildWarningChannel(ctx, database.SetGuildWarningChannelParams{GuildID: guildID, ChannelID: channel.Int64}); err != nil {
+			return fmt.Errorf("set warning channel: %w", err)
+		}
+	} else if err := qtx.DeleteGuildWarningChannel(ctx, guildID); err != nil {
+		return fmt.Errorf("delete warning channel: %w", err)
+	}
+
+	if err := qtx.DeleteEscalationRulesByGuild(ctx, guildID); err != nil {
+		return fmt.Errorf("delete escalation rules: %w", err)
+	}
+	for _, rule := range settings.EscalationRules {
+		duration := sql.NullInt64{}
+		if rule.Action == EscalationTimeout {
+			duration = sql.NullInt64{Int64: rule.DurationMinutes, Valid: true}
+		}
+		_, err := qtx.CreateEscalationRule(ctx, database.CreateEscalationRuleParams{
+			GuildID:         guildID,
+			Threshold:       rule.Threshold,
+			Action:          rule.Action,
+			DurationMinutes: duration,
+			RoleID:          settingID(rule.RoleID),
+			CreatedBy:       actorID,
+		})
+		if err != nil {
+			return fmt.Errorf("create escalation rule: %w", err)
+		}
+	}
+
+	if err := qtx.DeleteCommandPermissionsByGuild(ctx, guildID); err != nil {
+		return fmt.Errorf("delete command permissions: %w", err)
+	}
+	for _, perm := range settings.CommandPermissions {
+		_, err := qtx.AddCommandPermission(ctx, database.AddCommandPermissionParams{
+			GuildID:     guildID,
+			Command:     perm.Command,
+			SubjectType: perm.SubjectType,
+			SubjectID:   settingID(perm.SubjectID).Int64,
+			CreatedBy:   actorID,
+		})
+		if err != nil {
+			return fmt.Errorf("add command permission: %w", err)
+		}
+	}
+
+	if err := qtx.DeleteNotificationRolesByGuild(ctx, guildID); err != nil {
+		return fmt.Errorf("delete notification roles: %w", err)
+	}
+	for _, role := range settings.NotificationRoles {
+		err := qtx.SetNotificationRole(ctx, database.SetNotificationRoleParams{
+			GuildID:   guildID,
+			EventType: role.EventType,
+			RoleID:    settingID(role.RoleID).Int64,
+			UpdatedBy: actorID,
+		})
+		if err != nil {
+			return fmt.Errorf("set notification role: %w", err)
+		}
+	}
+
+	if err := qtx.DeleteDisabledGuildFeaturesByGuild(ctx, guildID); err != nil {
+		return fmt.Errorf("delete disabled features: %w", err)
+	}
+	for _, feature := range settings.DisabledFeatures {
+		_, err := qtx.DisableGuildFeature(ctx, database.DisableGuildFeatureParams{
+			GuildID:    guildID,
+			Feature:    feature,
+			DisabledBy: actorID,
+		})
+		if err != nil {
+			return fmt.Errorf("disable feature: %w", err)
+		}
+	}
+
+	if settings.hasDefaultWelcome() {
+		if err := qtx.DeleteWelcomeSettings(ctx, guildID); err != nil {
+			return fmt.Errorf("delete welcome settings: %w", err)
+		}
+	} else {
+		err := qtx.UpsertWelcomeSettings(ctx, database.UpsertWelcomeSettingsParams{
+			GuildID:        guildID,
+			DmTitle:        sql.NullString{String: settings.WelcomeDMTitle, Valid: settings.WelcomeDMTitle != ""},
+			DmMessage:      sql.NullString{String: settings.WelcomeDMMessage, Valid: settings.WelcomeDMMessage != ""},
+			ChannelID:      settingID(settings.WelcomeChannelID),
+			ChannelMessage: sql.NullString{String: settings.WelcomeChannelMessage, Valid: settings.WelcomeChannelMessage != ""},
+			Checklist:      strings.Join(settings.OnboardingChecklist, ","),
+			LinkedRoleID:   settingID(settings.LinkedRoleID),
+			UpdatedBy:      actorID,
+		})
+		if err != nil {
+			return fmt.Errorf("save welcome settings: %w", err)
+		}
+	}
+
+	if settings.hasDefaultApplications() {
+		if err := qtx.DeleteApplicationSettings(ctx, guildID); err != nil {
+			return fmt.Errorf("delete application settings: %w", err)
+		}
+	} else {
+		err := qtx.UpsertApplicationSettings(ctx, database.UpsertApplicationSettingsParams{
+			GuildID:         guildID,
+			ReviewChannelID: settingID(settings.ApplicationChannelID),
+			ApprovedRoleID:  settingID(settings.ApplicationRoleID),
+			MinTotalLevel:   settings.ApplicationMinTotalLevel,
+			MinCombatLevel:  settings.ApplicationMinCombatLevel,
+			MinEhp:          settings.ApplicationMinEHP,
+			UpdatedBy:       actorID,
+		})
+		if err != nil {
+			return fmt.Errorf("save application settings: %w", err)
+		}
+	}
+
+	if settings.hasDefaultInactivity() {
+		if err := qtx.DeleteInactivitySettings(ctx, guildID); err != nil {
+			return fmt.Errorf("delete inactivity settings: %w", err)
+		}
+	} else {
+		err := qtx.UpsertInactivitySettings(ctx, database.UpsertInactivitySettingsParams{
+			GuildID:         guildID,
+			ReportChannelID: settingID(settings.InactivityChannelID),
+			ThresholdDays:   settings.inactivityThreshold(),
+			NudgeEnabled:    settings.InactivityNudges,
+			UpdatedBy:       actorID,
+		})
+		if err != nil {
+			return fmt.Errorf("save inactivity settings: %w", err)
+		}
+	}
+
+	if err := qtx.DeleteRankRolesByGuild(ctx, guildID); err != nil {
+		return fmt.Errorf("delete rank roles: %w", err)
+	}
+	for _, role := range settings.RankRoles {
+		err := qtx.SetRankRole(ctx, database.SetRankRoleParams{
+			GuildID:   guildID,
+			WomRole:   role.WOMRole,
+			RoleID:    settingID(role.RoleID).Int64,
+			UpdatedBy: actorID,
+		})
+		if err != nil {
+			return fmt.Errorf("set rank role: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// storePendingImport remembers previewed settings and drops any that have expired.
+func (cc *ConfigCommands) storePendingImport(token string, pending *pendingConfigImport) {
+	cc.importsMu.Lock()
+	defer cc.importsMu.Unlock()
+
+	now := time.Now()
+	for key, p := range cc.pendingImports {
+		if now.After(p.expiresAt) {
+			delete(cc.pendingImports, key)
+		}
+	}
+	cc.pendingImports[token] = pending
+}
+
+// takePendingImport removes and returns pending settings if they belong to requesterID and haven't expired.
+func (cc *ConfigCommands) takePendingImport(token, requesterID string) *pendingConfigImport {
+	cc.importsMu.Lock()
+	defer cc.importsMu.Unlock()
+
+	pending, ok := cc.pendingImports[token]
+	if !ok || pending.requesterID != requesterID {
+		return nil
+	}
+	delete(cc.pendingImports, token)
+
+	if time.Now().After(pending.expiresAt) {
+		return nil
+	}
+	return pending
+}
+
+// recordConfigVersion saves the guild's current settings to the history table before a change.
+// Failures are logged rather than returned so history never blocks the change itself.
+func recordConfigVersion(ctx context.Context, db *database.Queries, guildID int64, actorIDStr, reason string) {
+	actorID, err := strconv.ParseInt(actorIDStr, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing actor ID for config history: %v", err)
+		return
+	}
+
+	settings, err := loadGuildSettings(ctx, db, guildID)
+	if err != nil {
+		log.Printf("Error loading guild settings for history: %v", err)
+		return
+	}
+
+	data, err := json.Marshal(settings)
+	if err != nil {
+		log.Printf("Error encoding guild settings for history: %v", err)
+		return
+	}
+
+	// Skip consecutive duplicates, e.g. when the previous change was a no-op
+	latest, err := db.GetGuildConfigHistory(ctx, database.GetGuildConfigHistoryParams{GuildID: guildID, Limit: 1})
+	if err == nil && len(latest) > 0 && latest[0].Settings == string(data) {
+		return
+	}
+
+	_, err = db.CreateGuildConfigVersion(ctx, database.CreateGuildConfigVersionParams{
+		GuildID:   guildID,
+		Settings:  string(data),
+		Reason:    reason,
+		ChangedBy: actorID,
+	})
+	if err != nil {
+		log.Printf("Error saving config version for guild %d: %v", guildID, err)
+	}
+}
+
+// loadGuildSettings reads all of a guild's configuration into its portable form.
+func loadGuildSettings(ctx context.Context, db *database.Queries, guildID int64) (guildSettings, error) {
+	settings := guildSettings{
+		Version:             guildSettingsVersion,
+		EscalationRules:     []escalationRuleSetting{},
+		CommandPermissions:  []commandPermissionSetting{},
+		NotificationRoles:   []notificationRoleSetting{},
+		OnboardingChecklist: slices.Clone(defaultOnboardingChecklist),
+	}
+
+	config, err := db.GetGuildConfig(ctx, guildID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return guildSettings{}, fmt.Errorf("fetch guild config: %w", err)
+	}
+	if err == nil {
+		settings.CoordinatorRoleID = formatSettingID(config.CoordinatorRoleID)
+		settings.CompetitionCodeChannelID = formatSettingID(config.CompetitionCodeChannelID)
+		settings.EventNotificationRoleID = formatSettingID(config.EventNotificationRoleID)
+		settings.EventNotificationChannelID = formatSettingID(config.EventNotificationChannelID)
+		settings.AuditLogChannelID = formatSettingID(config.AuditLogChannelID)
+		settings.DefaultTimezone = config.DefaultTimezone.String
+		settings.NicknameTemplate = config.NicknameTemplate.String
+		if config.WarningExpiryDays.Valid {
+			settings.WarningExpiryDays = config.WarningExpiryDays.Int64
+		}
+	}
+
+	channel, err := db.GetGuildWarningChannel(ctx, guildID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return guildSettings{}, fmt.Errorf("fetch warning channel: %w", err)
+	}
+	if err == nil {
+		settings.WarningChannelID = strconv.FormatInt(channel.ChannelID, 10)
+	}
+
+	rules, err := db.GetEscalationRules(ctx, guildID)
+	if err != nil {
+		return guildSettings{}, fmt.Errorf("fetch escalation rules: %w", err)
+	}
+	for _, rule := range rules {
+		settings.EscalationRules = append(settings.EscalationRules, escalationRuleSetting{
+			Threshold:       rule.Threshold,
+			Action:          rule.Action,
+			DurationMinutes: rule.DurationMinutes.Int64,
+			RoleID:          formatSettingID(rule.RoleID),
+		})
+	}
+
+	perms, err := db.GetCommandPermissions(ctx, guildID)
+	if err != nil {
+		return guildSettings{}, fmt.Errorf("fetch command permissions: %w", err)
+	}
+	for _, perm := range perms {
+		settings.CommandPermissions = append(settings.CommandPermissions, commandPermissionSetting{
+			Command:     perm.Command,
+			SubjectType: perm.SubjectType,
+			SubjectID:   strconv.FormatInt(perm.SubjectID, 10),
+		})
+	}
+
+	notificationRoles, err := db.GetNotificationRoles(ctx, guildID)
+	if err != nil {
+		return guildSettings{}, fmt.Errorf("fetch notification roles: %w", err)
+	}
+	for _, role := range notificationRoles {
+		settings.NotificationRoles = append(settings.NotificationRoles, notificationRoleSetting{
+			EventType: role.EventType,
+			RoleID:    strconv.FormatInt(role.RoleID, 10),
+		})
+	}
+
+	disabled, err := db.GetDisabledGuildFeatures(ctx, guildID)
+	if err != nil {
+		return guildSettings{}, fmt.Errorf("fetch disabled features: %w", err)
+	}
+	if len(disabled) > 0 {
+		settings.DisabledFeatures = disabled
+	}
+
+	welcome, err := db.GetWelcomeSettings(ctx, guildID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return guildSettings{}, fmt.Errorf("fetch welcome settings: %w", err)
+	}
+	if err == nil {
+		settings.WelcomeDMTitle = welcome.DmTitle.String
+		settings.WelcomeDMMessage = welcome.DmMessage.String
+		settings.WelcomeChannelID = formatSettingID(welcome.ChannelID)
+		settings.WelcomeChannelMessage = welcome.ChannelMessage.String
+		settings.LinkedRoleID = formatSettingID(welcome.LinkedRoleID)
+		settings.OnboardingChecklist = parseChecklist(welcome.Checklist)
+	}
+
+	applications, err := db.GetApplicationSettings(ctx, guildID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return guildSettings{}, fmt.Errorf("fetch application settings: %w", err)
+	}
+	if err == nil {
+		settings.ApplicationChannelID = formatSettingID(applications.ReviewChannelID)
+		settings.ApplicationRoleID = formatSettingID(applications.ApprovedRoleID)
+		settings.ApplicationMinTotalLevel = applications.MinTotalLevel
+		settings.ApplicationMinCombatLevel = applications.MinCombatLevel
+		settings.ApplicationMinEHP = applications.MinEhp
+	}
+
+	inactivity, err := db.GetInactivitySettings(ctx, guildID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return guildSettings{}, fmt.Errorf("fetch inactivity settings: %w", err)
+	}
+	if err == nil {
+		settings.InactivityChannelID = formatSettingID(inactivity.ReportChannelID)
+		settings.InactivityNudges = inactivity.NudgeEnabled
+		if inactivity.ThresholdDays != DefaultInactivityDays {
+			settings.InactivityThresholdDays = inactivity.ThresholdDays
+		}
+	}
+
+	rankRoles, err := db.GetRankRoles(ctx, guildID)
+	if err != nil {
+		return guildSettings{}, fmt.Errorf("fetch rank roles: %w", err)
+	}
+	for _, role := range rankRoles {
+		settings.RankRoles = append(settings.RankRoles, rankRoleSetting{
+			WOMRole: role.WomRole,
+			RoleID:  strconv.FormatInt(role.RoleID, 10),
+		})
+	}
+
+	return settings, nil
+}
+
+// parseGuildSettings decodes a settings document, rejecting unknown fields and versions.
+func parseGuildSettings(data []byte) (guildSettings, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var settings guildSettings
+	if err := decoder.Decode(&settings); err != nil {
+		return guildSettings{}, fmt.Errorf("decode json: %w", err)
+	}
+	if decoder.More() {
+		return guildSettings{}, ErrSettingsTrailingData
+	}
+	if settings.Version != guildSettingsVersion {
+		return guildSettings{}, fmt.Errorf("%w: %d (expected %d)", ErrUnsupportedSettingsVersion, settings.Version, guildSettingsVersion)
+	}
+
+	if settings.EscalationRules == nil {
+		settings.EscalationRules = []escalationRuleSetting{}
+	}
+	if settings.CommandPermissions == nil {
+		settings.CommandPermissions = []commandPermissionSetting{}
+	}
+	if settings.NotificationRoles == nil {
+		settings.NotificationRoles = []notificationRoleSetting{}
+	}
+	if settings.OnboardingChecklist == nil {
+		// Documents exported before onboarding checklists existed get the default; [] means no steps.
+		settings.OnboardingChecklist = slices.Clone(defaultOnboardingChecklist)
+	}
+	return settings, nil
+}
+
+// validateGuildSettings checks values that don't need Discord, returning human-readable problems.
+// permissionKeys lists the commands that may have overrides.
+func validateGuildSettings(settings guildSettings, permissionKeys []string) []string {
+	var problems []string
+
+	ids := []struct{ field, value string }{
+		{"coordinator_role_id", settings.CoordinatorRoleID},
+		{"competition_code_channel_id", settings.CompetitionCodeChannelID},
+		{"event_notification_role_id", settings.EventNotificationRoleID},
+		{"event_notification_channel_id", settings.EventNotificationChannelID},
+		{"audit_log_channel_id", settings.AuditLogChannelID},
+		{"warning_channel_id", settings.WarningChannelID},
+		{"welcome_channel_id", settings.WelcomeChannelID},
+		{"linked_role_id", settings.LinkedRoleID},
+		{"application_channel_id", settings.ApplicationChannelID},
+		{"application_role_id", settings.ApplicationRoleID},
+		{"inactivity_channel_id", settings.InactivityChannelID},
+	}
+	for _, id := range ids {
+		if id.value != "" && !validSettingID(id.value) {
+			problems = append(problems, fmt.Sprintf("`%s`: `%s` is not a Discord ID", id.field, id.value))
+		}
+	}
+
+	if settings.DefaultTimezone != "" {
+		if err := timezone.ValidateTimezone(settings.DefaultTimezone); err != nil {
+			problems = append(problems, fmt.Sprintf("`default_timezone`: `%s` is not a valid timezone", settings.DefaultTimezone))
+		}
+	}
+	if settings.NicknameTemplate != "" {
+		if err := ValidateNicknameTemplate(settings.NicknameTemplate); err != nil {
+			problems = append(problems, fmt.Sprintf("`nickname_template`: %v", err))
+		}
+	}
+	if settings.WarningExpiryDays < 0 {
+		problems = append(problems, "`warning_expiry_days` can't be negative")
+	}
+	if settings.ApplicationMinTotalLevel < 0 || settings.ApplicationMinTotalLevel > maxTotalLevel {
+		problems = append(problems, fmt.Sprintf("`application_min_total_level` must be between 0 and %d", maxTotalLevel))
+	}
+	if settings.ApplicationMinCombatLevel < 0 || settings.ApplicationMinCombatLevel > maxCombatLevel {
+		problems = append(problems, fmt.Sprintf("`application_min_combat_level` must be between 0 and %d", maxCombatLevel))
+	}
+	if settings.ApplicationMinEHP < 0 {
+		problems = append(problems, "`application_min_ehp` can't be negative")
+	}
+	if settings.InactivityThresholdDays < 0 || settings.InactivityThresholdDays > maxInactivityDays {
+		problems = append(problems, fmt.Sprintf("`inactivity_threshold_days` must be between 1 and %d", maxInactivityDays))
+	}
+
+	for idx, rule := range settings.EscalationRules {
+		prefix := fmt.Sprintf("`escalation_rules[%d]`", idx)
+		if rule.Threshold < 1 {
+			problems = append(problems, prefix+": threshold must be at least 1")
+		}
+		switch rule.Action {
+		case EscalationTimeout:
+			if rule.DurationMinutes <= 0 || rule.DurationMinutes > maxTimeoutMinutes {
+				problems = append(problems, fmt.Sprintf("%s: timeout duration must be between 1 and %d minutes", prefix, maxTimeoutMinutes))
+			}
+		case EscalationRemoveRole:
+			if !validSettingID(rule.RoleID) {
+				problems = append(problems, prefix+": remove_role needs a valid `role_id`")
+			}
+		case EscalationKickSuggestion:
+		default:
+			problems = append(problems, fmt.Sprintf("%s: unknown action `%s`", prefix, rule.Action))
+		}
+	}
+
+	seen := make(map[commandPermissionSetting]bool)
+	for idx, perm := range settings.CommandPermissions {
+		prefix := fmt.Sprintf("`command_permissions[%d]`", idx)
+		if !slices.Contains(permissionKeys, perm.Command) {
+			problems = append(problems, fmt.Sprintf("%s: `/%s` isn't a command that can be restricted", prefix, perm.Command))
+		}
+		if perm.SubjectType != PermissionSubjectRole && perm.SubjectType != PermissionSubjectUser {
+			problems = append(problems, fmt.Sprintf("%s: subject_type must be `role` or `user`", prefix))
+		}
+		if !validSettingID(perm.SubjectID) {
+			problems = append(problems, fmt.Sprintf("%s: `%s` is not a Discord ID", prefix, perm.SubjectID))
+		}
+		if seen[perm] {
+			problems = append(problems, prefix+": duplicate entry")
+		}
+		seen[perm] = true
+	}
+
+	for idx, role := range settings.NotificationRoles {
+		prefix := fmt.Sprintf("`notification_roles[%d]`", idx)
+		if _, ok := lookupNotificationType(role.EventType); !ok {
+			problems = append(problems, fmt.Sprintf("%s: unknown event type `%s`", prefix, role.EventType))
+		}
+		if !validSettingID(role.RoleID) {
+			problems = append(problems, fmt.Sprintf("%s: `%s` is not a Discord ID", prefix, role.RoleID))
+		}
+		if slices.ContainsFunc(settings.NotificationRoles[:idx], func(other notificationRoleSetting) bool { return other.EventType == role.EventType }) {
+			problems = append(problems, prefix+": duplicate event type")
+		}
+	}
+
+	for idx, feature := range settings.DisabledFeatures {
+		prefix := fmt.Sprintf("`disabled_features[%d]`", idx)
+		if _, ok := lookupFeature(feature); !ok {
+			problems = append(problems, fmt.Sprintf("%s: unknown feature `%s`", prefix, feature))
+		}
+		if slices.Contains(settings.DisabledFeatures[:idx], feature) {
+			problems = append(problems, prefix+": duplicate entry")
+		}
+	}
+
+	templates := []struct {
+		field, value string
+		maxLength    int
+	}{
+		{"welcome_dm_title", settings.WelcomeDMTitle, maxWelcomeTitleLength},
+		{"welcome_dm_message", settings.WelcomeDMMessage, maxWelcomeMessageLength},
+		{"welcome_channel_message", settings.WelcomeChannelMessage, maxWelcomeChannelMessageLength},
+	}
+	for _, template := range templates {
+		if utf8.RuneCountInString(template.value) > template.maxLength {
+			problems = append(problems, fmt.Sprintf("`%s` can be at most %d characters", template.field, template.maxLength))
+		}
+		if err := ValidateWelcomeTemplate(template.value); err != nil {
+			problems = append(problems, fmt.Sprintf("`%s`: %v", template.field, err))
+		}
+	}
+
+	for idx, step := range settings.OnboardingChecklist {
+		prefix := fmt.Sprintf("`onboarding_checklist[%d]`", idx)
+		if len(checklistSteps([]string{step})) == 0 {
+			problems = append(problems, fmt.Sprintf("%s: unknown step `%s`", prefix, step))
+		}
+		if slices.Contains(settings.OnboardingChecklist[:idx], step) {
+			problems = append(problems, prefix+": duplicate entry")
+		}
+	}
+
+	for idx, role := range settings.RankRoles {
+		prefix := fmt.Sprintf("`rank_roles[%d]`", idx)
+		if !validWOMRole(role.WOMRole) {
+			problems = append(problems, fmt.Sprintf("%s: `%s` is not a Wise Old Man group role", prefix, role.WOMRole))
+		}
+		if !validSettingID(role.RoleID) {
+			problems = append(problems, fmt.Sprintf("%s: `%s` is not a Discord ID", prefix, role.RoleID))
+		}
+		if slices.ContainsFunc(settings.RankRoles[:idx], func(other rankRoleSetting) bool { return other.WOMRole == role.WOMRole }) {
+			problems = append(problems, prefix+": duplicate rank")
+		}
+	}
+
+	return problems
+}
+
+// checkGuildResources verifies that every role and channel in settings exists in the guild.
+func checkGuildResources(s *discordgo.Session, guildID string, settings guildSettings) []string {
+	roles, err := s.GuildRoles(guildID)
+	if err != nil {
+		log.Printf("Error fetching guild roles: %v", err)
+		return []string{"Couldn't fetch the server's roles to verify the settings."}
+	}
+	channels, err := s.GuildChannels(guildID)
+	if err != nil {
+		log.Printf("Error fetching guild channels: %v", err)
+		return []string{"Couldn't fetch the server's channels to verify the settings."}
+	}
+
+	roleIDs := make(map[string]bool, len(roles))
+	for _, role := range roles {
+		roleIDs[role.ID] = true
+	}
+	channelIDs := make(map[string]bool, len(channels))
+	for _, channel := range channels {
+		channelIDs[channel.ID] = true
+	}
+
+	var problems []string
+	checkRole := func(field, id string) {
+		if id != "" && !roleIDs[id] {
+			problems = append(problems, fmt.Sprintf("`%s`: role `%s` doesn't exist on this server", field, id))
+		}
+	}
+	checkChannel := func(field, id string) {
+		if id != "" && !channelIDs[id] {
+			problems = append(problems, fmt.Sprintf("`%s`: channel `%s` doesn't exist on this server", field, id))
+		}
+	}
+
+	checkRole("coordinator_role_id", settings.CoordinatorRoleID)
+	checkRole("event_notification_role_id", settings.EventNotificationRoleID)
+	checkChannel("competition_code_channel_id", settings.CompetitionCodeChannelID)
+	checkChannel("event_notification_channel_id", settings.EventNotificationChannelID)
+	checkChannel("audit_log_channel_id", settings.AuditLogChannelID)
+	checkChannel("warning_channel_id", settings.WarningChannelID)
+	checkChannel("welcome_channel_id", settings.WelcomeChannelID)
+	checkRole("linked_role_id", settings.LinkedRoleID)
+	checkChannel("application_channel_id", settings.ApplicationChannelID)
+	checkRole("application_role_id", settings.ApplicationRoleID)
+	checkChannel("inactivity_channel_id", settings.InactivityChannelID)
+	for idx, rule := range settings.EscalationRules {
+		checkRole(fmt.Sprintf("escalation_rules[%d].role_id", idx), rule.RoleID)
+	}
+	for idx, perm := range settings.CommandPermissions {
+		if perm.SubjectType == PermissionSubjectRole {
+			checkRole(fmt.Sprintf("command_permissions[%d].subject_id", idx), perm.SubjectID)
+		}
+	}
+	for idx, role := range settings.NotificationRoles {
+		checkRole(fmt.Sprintf("notification_roles[%d].role_id", idx), role.RoleID)
+	}
+	for idx, role := range settings.RankRoles {
+		checkRole(fmt.Sprintf("rank_roles[%d].role_id", idx), role.RoleID)
+	}
+
+	return problems
+}
+
+// diffGuildSettings lists the changes needed to go from current to next, one line per change.
+func diffGuildSettings(current, next guildSettings) []string {
+	var changes []string
+
+	fields := []struct {
+		label         string
+		before, after string
+		format        func(string) string
+	}{
+		{"Coordinator role", current.CoordinatorRoleID, next.CoordinatorRoleID, roleMention},
+		{"Competition code channel", current.CompetitionCodeChannelID, next.CompetitionCodeChannelID, channelMention},
+		{"Event notification role", current.EventNotificationRoleID, next.EventNotificationRoleID, roleMention},
+		{"Event notification channel", current.EventNotificationChannelID, next.EventNotificationChannelID, channelMention},
+		{"Audit log channel", current.AuditLogChannelID, next.AuditLogChannelID, channelMention},
+		{"Warning channel", current.WarningChannelID, next.WarningChannelID, channelMention},
+		{"Default timezone", current.DefaultTimezone, next.DefaultTimezone, codeValue},
+		{"Nickname template", current.NicknameTemplate, next.NicknameTemplate, codeValue},
+		{"Warning expiry", expiryValue(current.WarningExpiryDays), expiryValue(next.WarningExpiryDays), plainValue},
+		{"Welcome DM title", current.WelcomeDMTitle, next.WelcomeDMTitle, codeValue},
+		{"Welcome channel", current.WelcomeChannelID, next.WelcomeChannelID, channelMention},
+		{"Linked role", current.LinkedRoleID, next.LinkedRoleID, roleMention},
+		{"Onboarding checklist", formatChecklist(current.OnboardingChecklist), formatChecklist(next.OnboardingChecklist), plainValue},
+		{"Application review channel", current.ApplicationChannelID, next.ApplicationChannelID, channelMention},
+		{"Approved applicant role", current.ApplicationRoleID, next.ApplicationRoleID, roleMention},
+		{"Application requirements", formatRequirements(current.applicationSettings()), formatRequirements(next.applicationSettings()), plainValue},
+		{"Inactivity report channel", current.InactivityChannelID, next.InactivityChannelID, channelMention},
+		{"Inactivity threshold", fmt.Sprintf("%d days", current.inactivityThreshold()), fmt.Sprintf("%d days", next.inactivityThreshold()), plainValue},
+		{"Inactivity DMs", onOffValue(current.InactivityNudges), onOffValue(next.InactivityNudges), plainValue},
+	}
+	for _, field := range fields {
+		if field.before == field.after {
+			continue
+		}
+		changes = append(changes, fmt.Sprintf("**%s:** %s → %s", field.label, field.format(field.before), field.format(field.after)))
+	}
+
+	describeRule := func(rule escalationRuleSetting) string {
+		duration := sql.NullInt64{Int64: rule.DurationMinutes, Valid: rule.DurationMinutes > 0}
+		return fmt.Sprintf("at %d warnings → %s", rule.Threshold, describeEscalation(rule.Action, duration, settingID(rule.RoleID)))
+	}
+	for _, rule := range current.EscalationRules {
+		if !slices.Contains(next.EscalationRules, rule) {
+			changes = append(changes, "➖ Escalation rule: "+describeRule(rule))
+		}
+	}
+	for _, rule := range next.EscalationRules {
+		if !slices.Contains(current.EscalationRules, rule) {
+			changes = append(changes, "➕ Escalation rule: "+describeRule(rule))
+		}
+	}
+
+	describePerm := func(perm commandPermissionSetting) string {
+		return fmt.Sprintf("`/%s` → %s", perm.Command, permissionSubject{Type: perm.SubjectType, ID: settingID(perm.SubjectID).Int64}.mention())
+	}
+	for _, perm := range current.CommandPermissions {
+		if !slices.Contains(next.CommandPermissions, perm) {
+			changes = append(changes, "➖ Permission: "+describePerm(perm))
+		}
+	}
+	for _, perm := range next.CommandPermissions {
+		if !slices.Contains(current.CommandPermissions, perm) {
+			changes = append(changes, "➕ Permission: "+describePerm(perm))
+		}
+	}
+
+	for _, nt := range NotificationTypes {
+		before, after := current.notificationRole(nt.EventType), next.notificationRole(nt.EventType)
+		if before != after {
+			changes = append(changes, fmt.Sprintf("**%s ping role:** %s → %s", nt.Label, roleMention(before), roleMention(after)))
+		}
+	}
+
+	var ranks []string
+	for _, role := range append(slices.Clone(current.RankRoles), next.RankRoles...) {
+		if !slices.Contains(ranks, role.WOMRole) {
+			ranks = append(ranks, role.WOMRole)
+		}
+	}
+	slices.Sort(ranks)
+	for _, rank := range ranks {
+		before, after := current.rankRole(rank), next.rankRole(rank)
+		if before != after {
+			changes = append(changes, fmt.Sprintf("**`%s` rank role:** %s → %s", rank, roleMention(before), roleMention(after)))
+		}
+	}
+
+	for _, feature := range Features {
+		wasDisabled := slices.Contains(current.DisabledFeatures, feature.Name)
+		isDisabled := slices.Contains(next.DisabledFeatures, feature.Name)
+		switch {
+		case !wasDisabled && isDisabled:
+			changes = append(changes, fmt.Sprintf("**%s:** enabled → disabled", feature.Label))
+		case wasDisabled && !isDisabled:
+			changes = append(changes, fmt.Sprintf("**%s:** disabled → enabled", feature.Label))
+		}
+	}
+
+	// Messages are too long to show inline, so only say that they change.
+	messages := []struct{ label, before, after string }{
+		{"Welcome DM message", current.WelcomeDMMessage, next.WelcomeDMMessage},
+		{"Welcome channel message", current.WelcomeChannelMessage, next.WelcomeChannelMessage},
+	}
+	for _, message := range messages {
+		switch {
+		case message.before == message.after:
+		case message.after == "":
+			changes = append(changes, fmt.Sprintf("**%s:** reset to default", message.label))
+		default:
+			changes = append(changes, fmt.Sprintf("**%s:** updated", message.label))
+		}
+	}
+
+	return changes
+}
+
+// notificationRole returns the role settings ping for eventType, or "" if it uses the default.
+func (settings guildSettings) notificationRole(eventType models.EventType) string {
+	for _, role := range settings.NotificationRoles {
+		if role.EventType == string(eventType) {
+			return role.RoleID
+		}
+	}
+	return ""
+}
+
+// rankRole returns the role mapped to the Wise Old Man rank, or "" if it has none.
+func (settings guildSettings) rankRole(rank string) string {
+	for _, role := range settings.RankRoles {
+		if role.WOMRole == rank {
+			return role.RoleID
+		}
+	}
+	return ""
+}
+
+// hasDefaultWelcome reports whether settings leave every welcome option at its default.
+func (settings guildSettings) hasDefaultWelcome() bool {
+	return settings.WelcomeDMTitle == "" &&
+		settings.WelcomeDMMessage == "" &&
+		settings.WelcomeChannelID == "" &&
+		settings.WelcomeChannelMessage == "" &&
+		settings.LinkedRoleID == "" &&
+		slices.Equal(settings.OnboardingChecklist, defaultOnboardingChecklist)
+}
+
+// hasDefaultApplications reports whether settings leave every application option at its default.
+func (settings guildSettings) hasDefaultApplications() bool {
+	return settings.applicationSettings() == database.GuildApplicationSetting{}
+}
+
+// applicationSettings returns the application options in settings as stored.
+func (settings guildSettings) applicationSettings() database.GuildApplicationSetting {
+	return database.GuildApplicationSetting{
+		ReviewChannelID: settingID(settings.ApplicationChannelID),
+		ApprovedRoleID:  settingID(settings.ApplicationRoleID),
+		MinTotalLevel:   settings.ApplicationMinTotalLevel,
+		MinCombatLevel:  settings.ApplicationMinCombatLevel,
+		MinEhp:          settings.ApplicationMinEHP,
+	}
+}
+
+// hasDefaultInactivity reports whether settings leave every inactivity option at its default.
+func (settings guildSettings) hasDefaultInactivity() bool {
+	return settings.InactivityChannelID == "" &&
+		settings.inactivityThreshold() == DefaultInactivityDays &&
+		!settings.InactivityNudges
+}
+
+// inactivityThreshold returns the inactivity threshold in days; 0 in settings means the default.
+func (settings guildSettings) inactivityThreshold() int64 {
+	if settings.InactivityThresholdDays == 0 {
+		return DefaultInactivityDays
+	}
+	return settings.InactivityThresholdDays
+}
+
+// formatProblems renders lines as a bulleted list, truncated to maxDiffLines.
+func formatProblems(lines []string) string {
+	var sb strings.Builder
+	for idx, line := range lines {
+		if idx >= maxDiffLines {
+			sb.WriteString(fmt.Sprintf("...and %d more\n", len(lines)-maxDiffLines))
+			break
+		}
+		sb.WriteString("• " + line + "\n")
+	}
+	return sb.String()
+}
+
+// settingID converts a validated settings ID to its database form.
+func settingID(id string) sql.NullInt64 {
+	value, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: value, Valid: true}
+}
+
+// formatSettingID converts a database ID to its settings form.
+func formatSettingID(id sql.NullInt64) string {
+	if !id.Valid {
+		return ""
+	}
+	return strconv.FormatInt(id.Int64, 10)
+}
+
+// validSettingID reports whether id looks like a Discord snowflake.
+func validSettingID(id string) bool {
+	value, err := strconv.ParseInt(id, 10, 64)
+	return err == nil && value > 0
+}
+
+func roleMention(id string) string {
+	if id == "" {
+		return notConfiguredText
+	}
+	return "<@&" + id + ">"
+}
+
+func channelMention(id string) string {
+	if id == "" {
+		return notConfiguredText
+	}
+	return "<#" + id + ">"
+}
+
+func codeValue(value string) string {
+	if value == "" {
+		return notConfiguredText
+	}
+	return "`" + value + "`"
+}
+
+func plainValue(value string) string {
+	return value
+}
+
+func expiryValue(days int64) string {
+	return formatExpiryDays(sql.NullInt64{Int64: days, Valid: days > 0})
+}
+
+func onOffValue(on bool) string {
+	if on {
+		return "On"
+	}
+	return "Off"
+}
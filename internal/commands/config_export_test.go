@@ -0,0 +1,255 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/models"
+	"github.com/kaffeed/voidling/internal/testutil"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGuildSettings(t *testing.T) {
+	t.Run("valid document", func(t *testing.T) {
+		settings, err := parseGuildSettings([]byte(`{"version": 1, "coordinator_role_id": "123", "default_timezone": "Europe/Berlin"}`))
+
+		require.NoError(t, err)
+		assert.Equal(t, "123", settings.CoordinatorRoleID)
+		assert.Equal(t, "Europe/Berlin", settings.DefaultTimezone)
+		assert.NotNil(t, settings.EscalationRules)
+		assert.NotNil(t, settings.CommandPermissions)
+		assert.Equal(t, defaultOnboardingChecklist, settings.OnboardingChecklist)
+	})
+
+	t.Run("empty onboarding checklist is kept", func(t *testing.T) {
+		settings, err := parseGuildSettings([]byte(`{"version": 1, "onboarding_checklist": []}`))
+
+		require.NoError(t, err)
+		assert.Empty(t, settings.OnboardingChecklist)
+	})
+
+	t.Run("unknown fields are rejected", func(t *testing.T) {
+		_, err := parseGuildSettings([]byte(`{"version": 1, "coordinator_role": "123"}`))
+
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		_, err := parseGuildSettings([]byte(`{"version": 2}`))
+
+		assert.ErrorIs(t, err, ErrUnsupportedSettingsVersion)
+	})
+
+	t.Run("trailing data", func(t *testing.T) {
+		_, err := parseGuildSettings([]byte(`{"version": 1} {"version": 1}`))
+
+		assert.ErrorIs(t, err, ErrSettingsTrailingData)
+	})
+}
+
+func TestValidateGuildSettings(t *testing.T) {
+	keys := []string{"botw", "botw start", "warn"}
+
+	t.Run("valid settings", func(t *testing.T) {
+		settings := guildSettings{
+			Version:          guildSettingsVersion,
+			DefaultTimezone:  "UTC",
+			NicknameTemplate: "{rsn}",
+			EscalationRules: []escalationRuleSetting{
+				{Threshold: 2, Action: EscalationTimeout, DurationMinutes: 60},
+				{Threshold: 3, Action: EscalationRemoveRole, RoleID: "55"},
+				{Threshold: 4, Action: EscalationKickSuggestion},
+			},
+			CommandPermissions: []commandPermissionSetting{
+				{Command: "botw start", SubjectType: PermissionSubjectRole, SubjectID: "66"},
+			},
+			DisabledFeatures:      []string{FeatureSOTW, FeatureWelcomeDM},
+			WelcomeDMMessage:      "Hi {name}, welcome to {server}!",
+			WelcomeChannelMessage: "{user} is member #{members}",
+			OnboardingChecklist:   []string{OnboardingLinkRSN, OnboardingSetTimezone},
+		}
+
+		assert.Empty(t, validateGuildSettings(settings, keys))
+	})
+
+	t.Run("every problem is reported", func(t *testing.T) {
+		settings := guildSettings{
+			Version:           guildSettingsVersion,
+			CoordinatorRoleID: "abc",
+			DefaultTimezone:   "Mars/Olympus",
+			NicknameTemplate:  "{unknown}",
+			WarningExpiryDays: -1,
+			EscalationRules: []escalationRuleSetting{
+				{Threshold: 0, Action: EscalationKickSuggestion},
+				{Threshold: 1, Action: EscalationTimeout, DurationMinutes: maxTimeoutMinutes + 1},
+				{Threshold: 1, Action: EscalationRemoveRole},
+				{Threshold: 1, Action: "ban"},
+			},
+			CommandPermissions: []commandPermissionSetting{
+				{Command: "config", SubjectType: PermissionSubjectUser, SubjectID: "1"},
+				{Command: "warn", SubjectType: "group", SubjectID: "1"},
+				{Command: "warn", SubjectType: PermissionSubjectUser, SubjectID: "x"},
+				{Command: "botw", SubjectType: PermissionSubjectUser, SubjectID: "7"},
+				{Command: "botw", SubjectType: PermissionSubjectUser, SubjectID: "7"},
+			},
+			NotificationRoles: []notificationRoleSetting{
+				{EventType: "RAIDS", RoleID: "1"},
+				{EventType: string(models.EventTypeMass), RoleID: "x"},
+				{EventType: string(models.EventTypeMass), RoleID: "2"},
+			},
+			DisabledFeatures:    []string{FeatureBOTW, "raids", FeatureBOTW},
+			WelcomeDMTitle:      strings.Repeat("a", maxWelcomeTitleLength+1),
+			WelcomeDMMessage:    "Hi {rsn}",
+			LinkedRoleID:        "-5",
+			OnboardingChecklist: []string{OnboardingLinkRSN, "verify", OnboardingLinkRSN},
+
+			ApplicationChannelID:      "x",
+			ApplicationMinCombatLevel: maxCombatLevel + 1,
+			ApplicationMinEHP:         -1,
+			InactivityThresholdDays:   maxInactivityDays + 1,
+			RankRoles: []rankRoleSetting{
+				{WOMRole: "Captain", RoleID: "1"},
+				{WOMRole: "member", RoleID: "x"},
+				{WOMRole: "member", RoleID: "2"},
+			},
+		}
+
+		assert.Len(t, validateGuildSettings(settings, keys), 29)
+	})
+}
+
+func TestDiffGuildSettings(t *testing.T) {
+	current := guildSettings{
+		Version:           guildSettingsVersion,
+		CoordinatorRoleID: "1",
+		DefaultTimezone:   "UTC",
+		EscalationRules: []escalationRuleSetting{
+			{Threshold: 3, Action: EscalationKickSuggestion},
+		},
+		CommandPermissions: []commandPermissionSetting{
+			{Command: "botw", SubjectType: PermissionSubjectRole, SubjectID: "9"},
+		},
+	}
+
+	t.Run("identical settings", func(t *testing.T) {
+		assert.Empty(t, diffGuildSettings(current, current))
+	})
+
+	t.Run("changed fields and lists", func(t *testing.T) {
+		next := current
+		next.CoordinatorRoleID = "2"
+		next.DefaultTimezone = ""
+		next.WarningExpiryDays = 30
+		next.EscalationRules = []escalationRuleSetting{{Threshold: 2, Action: EscalationTimeout, DurationMinutes: 60}}
+		next.CommandPermissions = []commandPermissionSetting{
+			{Command: "botw", SubjectType: PermissionSubjectRole, SubjectID: "9"},
+			{Command: "warn", SubjectType: PermissionSubjectUser, SubjectID: "8"},
+		}
+		next.NotificationRoles = []notificationRoleSetting{{EventType: string(models.EventTypeWildyWednesday), RoleID: "5"}}
+		next.DisabledFeatures = []string{FeatureWelcomeDM}
+		next.WelcomeChannelMessage = "Welcome {user}!"
+		next.OnboardingChecklist = []string{OnboardingSetTimezone, OnboardingLinkRSN}
+		next.ApplicationMinTotalLevel = 1500
+		next.InactivityThresholdDays = 14
+		next.RankRoles = []rankRoleSetting{{WOMRole: "captain", RoleID: "6"}}
+
+		changes := diffGuildSettings(current, next)
+
+		assert.Equal(t, []string{
+			"**Coordinator role:** <@&1> → <@&2>",
+			"**Default timezone:** `UTC` → Not configured",
+			"**Warning expiry:** Never → 30 days",
+			"**Onboarding checklist:** None → Link RSN, Set timezone",
+			"**Application requirements:** None → Total level 1500",
+			"**Inactivity threshold:** 30 days → 14 days",
+			"➖ Escalation rule: at 3 warnings → suggest a kick to moderators",
+			"➕ Escalation rule: at 2 warnings → timeout for 1h",
+			"➕ Permission: `/warn` → <@8>",
+			"**Wildy Wednesday ping role:** Not configured → <@&5>",
+			"**`captain` rank role:** Not configured → <@&6>",
+			"**Welcome DM:** enabled → disabled",
+			"**Welcome channel message:** updated",
+		}, changes)
+	})
+}
+
+func TestApplyGuildSettings(t *testing.T) {
+	db, q := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	ctx := t.Context()
+	cc := NewConfigCommands(q, db, nil, nil)
+	const guildID = int64(42)
+
+	settings := guildSettings{
+		Version:           guildSettingsVersion,
+		CoordinatorRoleID: "100",
+		WarningChannelID:  "200",
+		DefaultTimezone:   "Europe/Berlin",
+		WarningExpiryDays: 30,
+		EscalationRules: []escalationRuleSetting{
+			{Threshold: 3, Action: EscalationRemoveRole, RoleID: "300"},
+		},
+		CommandPermissions: []commandPermissionSetting{
+			{Command: "botw", SubjectType: PermissionSubjectUser, SubjectID: "400"},
+		},
+		NotificationRoles: []notificationRoleSetting{
+			{EventType: string(models.EventTypeMass), RoleID: "450"},
+		},
+		DisabledFeatures:    []string{FeatureAutoNickname, FeatureMass},
+		WelcomeDMTitle:      "Hey {name}",
+		WelcomeChannelID:    "500",
+		LinkedRoleID:        "600",
+		OnboardingChecklist: []string{OnboardingLinkRSN, OnboardingNotificationRoles},
+
+		ApplicationChannelID:     "700",
+		ApplicationMinTotalLevel: 1500,
+		ApplicationMinEHP:        50.5,
+
+		InactivityChannelID:     "800",
+		InactivityThresholdDays: 14,
+		InactivityNudges:        true,
+
+		RankRoles: []rankRoleSetting{
+			{WOMRole: "captain", RoleID: "900"},
+			{WOMRole: "member", RoleID: "950"},
+		},
+	}
+
+	recordConfigVersion(ctx, q, guildID, "7", "/config import")
+	require.NoError(t, cc.applyGuildSettings(ctx, guildID, "7", settings))
+
+	loaded, err := loadGuildSettings(ctx, q, guildID)
+	require.NoError(t, err)
+	assert.Equal(t, settings, loaded)
+
+	// Recording twice without a change only keeps one version
+	recordConfigVersion(ctx, q, guildID, "7", "/config set-default-timezone")
+	recordConfigVersion(ctx, q, guildID, "7", "/config set-default-timezone")
+
+	history, err := q.GetGuildConfigHistory(ctx, database.GetGuildConfigHistoryParams{GuildID: guildID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, "/config set-default-timezone", history[0].Reason)
+
+	// Rolling back to the empty first version clears everything
+	first, err := parseGuildSettings([]byte(history[1].Settings))
+	require.NoError(t, err)
+	require.NoError(t, cc.applyGuildSettings(ctx, guildID, "7", first))
+
+	loaded, err = loadGuildSettings(ctx, q, guildID)
+	require.NoError(t, err)
+	assert.Equal(t, first, loaded)
+	assert.Empty(t, loaded.EscalationRules)
+	assert.Empty(t, loaded.WarningChannelID)
+	assert.Empty(t, loaded.DisabledFeatures)
+	assert.Empty(t, loaded.LinkedRoleID)
+	assert.Empty(t, loaded.NotificationRoles)
+	assert.Empty(t, loaded.ApplicationChannelID)
+	assert.Empty(t, loaded.InactivityChannelID)
+	assert.Empty(t, loaded.RankRoles)
+	assert.Equal(t, defaultOnboardingChecklist, loaded.OnboardingChecklist)
+}
@@ -0,0 +1,240 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/audit"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/embeds"
+)
+
+// Features that can be switched off per guild with /config features.
+const (
+	FeatureBOTW         = "botw"
+	FeatureSOTW         = "sotw"
+	FeatureMass         = "mass"
+	FeatureWarnings     = "warnings"
+	FeatureWelcomeDM    = "welcome-dm"
+	FeatureAutoNickname = "auto-nickname"
+	FeatureReminders    = "reminders"
+)
+
+// Feature describes a toggleable feature.
+type Feature struct {
+	Name        string
+	Label       string
+	Description string
+}
+
+// Features lists every toggleable feature in display order. All of them are enabled by default.
+var Features = []Feature{
+	{FeatureBOTW, "Boss of the Week", "`/botw` competitions"},
+	{FeatureSOTW, "Skill of the Week", "`/sotw` competitions"},
+	{FeatureMass, "Masses", "`/mass` events"},
+	{FeatureWarnings, "Warnings", "`/warn` and the View Warnings menu"},
+	{FeatureWelcomeDM, "Welcome DM", "Greeting new members with a link button"},
+	{FeatureAutoNickname, "Auto-nickname", "Renaming linked members with the nickname template"},
+	{FeatureReminders, "Mass reminders", "DMing participants shortly before a mass starts"},
+}
+
+// FeatureChoices returns the toggleable features as command choices.
+func FeatureChoices() []*discordgo.ApplicationCommandOptionChoice {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(Features))
+	for _, feature := range Features {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  feature.Label,
+			Value: feature.Name,
+		})
+	}
+	return choices
+}
+
+// FeatureEnabled reports whether feature is enabled in a guild.
+// Lookup failures count as enabled so a database error never silently switches features off.
+func FeatureEnabled(ctx context.Context, db *database.Queries, guildID, feature string) bool {
+	id, err := strconv.ParseInt(guildID, 10, 64)
+	if err != nil {
+		return true
+	}
+
+	disabled, err := db.GetDisabledGuildFeatures(ctx, id)
+	if err != nil {
+		log.Printf("Error fetching disabled features for guild %s: %v", guildID, err)
+		return true
+	}
+	return !slices.Contains(disabled, feature)
+}
+
+// featureEnabled reports whether settings leave feature enabled.
+func (settings guildSettings) featureEnabled(feature string) bool {
+	return !slices.Contains(settings.DisabledFeatures, feature)
+}
+
+// HandleFeaturesList handles /config features list.
+func (cc *ConfigCommands) HandleFeaturesList(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	if !isServerOwnerOrAdmin(s, i) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Only the server owner or administrators can view features."))
+		return
+	}
+
+	guildID, err := strconv.ParseInt(i.GuildID, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing guild ID: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse guild ID."))
+		return
+	}
+
+	disabled, err := cc.DB.GetDisabledGuildFeatures(ctx, guildID)
+	if err != nil {
+		log.Printf("Error fetching disabled features: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to fetch features. Please try again."))
+		return
+	}
+
+	var sb strings.Builder
+	for _, feature := range Features {
+		status := "✅"
+		if slices.Contains(disabled, feature.Name) {
+			status = "❌"
+		}
+		sb.WriteString(fmt.Sprintf("%s **%s** (`%s`): %s\n", status, feature.Label, feature.Name, feature.Description))
+	}
+	sb.WriteString("\nUse `/config features enable` or `/config features disable` to change them.")
+
+	sendEphemeralEmbed(s, i, embeds.InfoEmbed("Features", sb.String()))
+}
+
+// HandleFeaturesEnable handles /config features enable.
+func (cc *ConfigCommands) HandleFeaturesEnable(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	cc.setFeature(s, i, true)
+}
+
+// HandleFeaturesDisable handles /config features disable.
+func (cc *ConfigCommands) HandleFeaturesDisable(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	cc.setFeature(s, i, false)
+}
+
+// setFeature switches the feature named in the command's "feature" option on or off.
+func (cc *ConfigCommands) setFeature(s *discordgo.Session, i *discordgo.InteractionCreate, enabled bool) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	if !isServerOwnerOrAdmin(s, i) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Only the server owner or administrators can change features."))
+		return
+	}
+
+	featureOpt := subcommandOption(i, "feature")
+	if featureOpt == nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Missing feature parameter."))
+		return
+	}
+	feature, ok := lookupFeature(featureOpt.StringValue())
+	if !ok {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(fmt.Sprintf("Unknown feature `%s`.", featureOpt.StringValue())))
+		return
+	}
+
+	guildID, err := strconv.ParseInt(i.GuildID, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing guild ID: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse guild ID."))
+		return
+	}
+
+	actorID, err := strconv.ParseInt(i.Member.User.ID, 10, 64)
+	if err != nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse user ID."))
+		return
+	}
+
+	action := "disable"
+	if enabled {
+		action = "enable"
+	}
+	recordConfigVersion(ctx, cc.DB, guildID, i.Member.User.ID, "/config features "+action)
+
+	var rows int64
+	if enabled {
+		rows, err = cc.DB.EnableGuildFeature(ctx, database.EnableGuildFeatureParams{
+			GuildID: guildID,
+			Feature: feature.Name,
+		})
+	} else {
+		rows, err = cc.DB.DisableGuildFeature(ctx, database.DisableGuildFeatureParams{
+			GuildID:    guildID,
+			Feature:    feature.Name,
+			DisabledBy: actorID,
+		})
+	}
+	if err != nil {
+		log.Printf("Error updating feature %s: %v", feature.Name, err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to save configuration. Please try again."))
+		return
+	}
+
+	if rows == 0 {
+		sendEphemeralEmbed(s, i, embeds.InfoEmbed("Features", fmt.Sprintf("Nothing changed; **%s** was already %sd.", feature.Label, action)))
+		return
+	}
+
+	before, after := "enabled", "disabled"
+	if enabled {
+		before, after = after, before
+	}
+	cc.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionFeatures,
+		Target:  feature.Name,
+		Before:  before,
+		After:   after,
+	})
+
+	msg := fmt.Sprintf("**%s** is now %s.", feature.Label, after)
+	if feature.Name == FeatureAutoNickname && enabled {
+		msg += "\n\nNicknames are being resynced for all linked members in the background."
+		cc.startNicknameResync(s, i.GuildID)
+	}
+	sendEphemeralEmbed(s, i, embeds.SuccessEmbed(msg))
+}
+
+// lookupFeature finds a feature by name.
+func lookupFeature(name string) (Feature, bool) {
+	for _, feature := range Features {
+		if feature.Name == name {
+			return feature, true
+		}
+	}
+	return Feature{}, false
+}
+
+// formatFeatureNames renders feature names as their labels, e.g. "Boss of the Week, Masses".
+func formatFeatureNames(names []string) string {
+	labels := make([]string, 0, len(names))
+	for _, name := range names {
+		if feature, ok := lookupFeature(name); ok {
+			labels = append(labels, feature.Label)
+		} else {
+			labels = append(labels, "`"+name+"`")
+		}
+	}
+	return strings.Join(labels, ", ")
+}
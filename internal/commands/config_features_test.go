@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/testutil"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeatureEnabled(t *testing.T) {
+	db, q := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	ctx := t.Context()
+
+	assert.True(t, FeatureEnabled(ctx, q, "42", FeatureBOTW), "features are enabled by default")
+	assert.True(t, FeatureEnabled(ctx, q, "not-a-guild", FeatureBOTW))
+
+	rows, err := q.DisableGuildFeature(ctx, database.DisableGuildFeatureParams{GuildID: 42, Feature: FeatureBOTW, DisabledBy: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	rows, err = q.DisableGuildFeature(ctx, database.DisableGuildFeatureParams{GuildID: 42, Feature: FeatureBOTW, DisabledBy: 1})
+	require.NoError(t, err)
+	assert.Zero(t, rows, "disabling twice is a no-op")
+
+	assert.False(t, FeatureEnabled(ctx, q, "42", FeatureBOTW))
+	assert.True(t, FeatureEnabled(ctx, q, "42", FeatureSOTW))
+	assert.True(t, FeatureEnabled(ctx, q, "43", FeatureBOTW), "other guilds are unaffected")
+
+	rows, err = q.EnableGuildFeature(ctx, database.EnableGuildFeatureParams{GuildID: 42, Feature: FeatureBOTW})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+	assert.True(t, FeatureEnabled(ctx, q, "42", FeatureBOTW))
+}
+
+func TestFormatFeatureNames(t *testing.T) {
+	assert.Equal(t, "Boss of the Week, Welcome DM, `raids`", formatFeatureNames([]string{FeatureBOTW, FeatureWelcomeDM, "raids"}))
+}
+
+func TestSendMassRemindersSkipsDisabledGuilds(t *testing.T) {
+	db, q := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	ctx := t.Context()
+	now := time.Now().UTC()
+
+	link := testutil.CreateTestAccountLink(t, q, 1001, "Zezima", true)
+	event, err := q.CreateSchedulableEvent(ctx, database.CreateSchedulableEventParams{
+		Type:           "Mass",
+		Activity:       "corporeal_beast",
+		Location:       "World 444",
+		ScheduledAt:    now.Add(10 * time.Minute),
+		DiscordEventID: "555",
+		GuildID:        sql.NullInt64{Int64: 42, Valid: true},
+	})
+	require.NoError(t, err)
+	_, err = q.CreateSchedulableParticipation(ctx, database.CreateSchedulableParticipationParams{EventID: event.ID, AccountLinkID: link.ID})
+	require.NoError(t, err)
+
+	_, err = q.DisableGuildFeature(ctx, database.DisableGuildFeatureParams{GuildID: 42, Feature: FeatureReminders, DisabledBy: 1})
+	require.NoError(t, err)
+
+	// The session is never touched because the only reminder belongs to a guild with reminders off
+	sent, err := SendMassReminders(ctx, nil, q, now)
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+
+	pending, err := q.GetUnnotifiedParticipations(ctx, database.GetUnnotifiedParticipationsParams{
+		ScheduledAt:   now,
+		ScheduledAt_2: now.Add(MassReminderLead),
+	})
+	require.NoError(t, err)
+	require.Len(t, pending, 1, "skipped reminders stay pending in case the feature is re-enabled")
+	assert.Equal(t, int64(42), pending[0].GuildID.Int64)
+}
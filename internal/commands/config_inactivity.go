@@ -0,0 +1,163 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/audit"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/embeds"
+)
+
+// HandleInactivityReportChannel handles /config inactivity report-channel. Omitting the channel
+// turns the weekly report off.
+func (cc *ConfigCommands) HandleInactivityReportChannel(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	cc.updateInactivitySettings(s, i, "/config inactivity report-channel", func(settings *database.GuildInactivitySetting) (string, bool) {
+		opt := subcommandOption(i, "channel")
+		if opt == nil {
+			settings.ReportChannelID = sql.NullInt64{}
+			return "Weekly inactivity reports turned off. `/inactive` still works.", true
+		}
+
+		channelID := opt.ChannelValue(nil).ID
+		if missing := missingChannelPermissions(s, channelID, channelPostPermissions); len(missing) > 0 {
+			return fmt.Sprintf("I can't post in <#%s>. Missing: %s", channelID, strings.Join(missing, ", ")), false
+		}
+		settings.ReportChannelID = settingID(channelID)
+		return fmt.Sprintf("A weekly report of members with no XP gained in %d days will be posted in <#%s>.", settings.ThresholdDays, channelID), true
+	})
+}
+
+// HandleInactivityThreshold handles /config inactivity threshold.
+func (cc *ConfigCommands) HandleInactivityThreshold(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	cc.updateInactivitySettings(s, i, "/config inactivity threshold", func(settings *database.GuildInactivitySetting) (string, bool) {
+		opt := subcommandOption(i, "days")
+		if opt == nil {
+			return "Missing days parameter.", false
+		}
+
+		days := opt.IntValue()
+		if days < 1 || days > maxInactivityDays {
+			return fmt.Sprintf("Days must be between 1 and %d.", maxInactivityDays), false
+		}
+		settings.ThresholdDays = days
+		return fmt.Sprintf("Members with no XP gained in **%d days** now count as inactive.", days), true
+	})
+}
+
+// HandleInactivityNudges handles /config inactivity nudges, turning the inactivity DM on or off.
+func (cc *ConfigCommands) HandleInactivityNudges(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	cc.updateInactivitySettings(s, i, "/config inactivity nudges", func(settings *database.GuildInactivitySetting) (string, bool) {
+		opt := subcommandOption(i, "enabled")
+		if opt == nil {
+			return "Missing enabled parameter.", false
+		}
+
+		settings.NudgeEnabled = opt.BoolValue()
+		if !settings.NudgeEnabled {
+			return "Inactive members will no longer be sent a DM.", true
+		}
+
+		msg := "Members who become inactive will get a friendly DM with the next weekly report, once per inactive spell."
+		if !settings.ReportChannelID.Valid {
+			msg += "\n\n*Note: nudges are sent with the weekly report, so set a channel with `/config inactivity report-channel`.*"
+		}
+		return msg, true
+	})
+}
+
+// updateInactivitySettings loads the guild's inactivity settings, lets apply change them and saves
+// the result. apply returns the message to show and whether the change is valid.
+func (cc *ConfigCommands) updateInactivitySettings(s *discordgo.Session, i *discordgo.InteractionCreate, reason string, apply func(*database.GuildInactivitySetting) (string, bool)) {
+	ctx := context.Background()
+
+	if !isServerOwnerOrAdmin(s, i) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Only the server owner or administrators can configure inactivity reports."))
+		return
+	}
+
+	guildID, err := strconv.ParseInt(i.GuildID, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing guild ID: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse guild ID."))
+		return
+	}
+
+	actorID, err := strconv.ParseInt(i.Member.User.ID, 10, 64)
+	if err != nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse user ID."))
+		return
+	}
+
+	settings, err := loadInactivitySettings(ctx, cc.DB, i.GuildID)
+	if err != nil {
+		log.Printf("Error loading inactivity settings: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to fetch configuration. Please try again."))
+		return
+	}
+	before := describeInactivitySettings(settings)
+
+	msg, ok := apply(&settings)
+	if !ok {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(msg))
+		return
+	}
+
+	recordConfigVersion(ctx, cc.DB, guildID, i.Member.User.ID, reason)
+
+	err = cc.DB.UpsertInactivitySettings(ctx, database.UpsertInactivitySettingsParams{
+		GuildID:         guildID,
+		ReportChannelID: settings.ReportChannelID,
+		ThresholdDays:   settings.ThresholdDays,
+		NudgeEnabled:    settings.NudgeEnabled,
+		UpdatedBy:       actorID,
+	})
+	if err != nil {
+		log.Printf("Error saving inactivity settings: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to save configuration. Please try again."))
+		return
+	}
+
+	cc.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionInactivitySettings,
+		Before:  before,
+		After:   describeInactivitySettings(settings),
+	})
+
+	sendEphemeralEmbed(s, i, embeds.SuccessEmbed(msg))
+}
+
+// describeInactivitySettings summarises inactivity settings for the audit log.
+func describeInactivitySettings(settings database.GuildInactivitySetting) string {
+	channel := "none"
+	if settings.ReportChannelID.Valid {
+		channel = fmt.Sprintf("<#%d>", settings.ReportChannelID.Int64)
+	}
+	nudges := "off"
+	if settings.NudgeEnabled {
+		nudges = "on"
+	}
+	return fmt.Sprintf("report channel: %s, threshold: %d days, nudges: %s", channel, settings.ThresholdDays, nudges)
+}
@@ -0,0 +1,214 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/audit"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/embeds"
+	"github.com/kaffeed/voidling/internal/wiseoldman"
+)
+
+// nicknameResyncTimeout bounds a background resync started from a command.
+const nicknameResyncTimeout = 2 * time.Hour
+
+// HandleSetNicknameTemplate handles /config set-nickname-template command.
+func (cc *ConfigCommands) HandleSetNicknameTemplate(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	if !isServerOwnerOrAdmin(s, i) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Only the server owner or administrators can configure the nickname template."))
+		return
+	}
+
+	templateOpt := subcommandOption(i, "template")
+	if templateOpt == nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Missing template parameter."))
+		return
+	}
+	template := templateOpt.StringValue()
+
+	if err := ValidateNicknameTemplate(template); err != nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(fmt.Sprintf("%v\n\nAvailable placeholders: %s", err, nicknamePlaceholderList())))
+		return
+	}
+
+	guildID, err := strconv.ParseInt(i.GuildID, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing guild ID: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse guild ID."))
+		return
+	}
+
+	if err := ensureGuildConfig(ctx, cc.DB, guildID); err != nil {
+		log.Printf("Error ensuring guild config: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to fetch configuration. Please try again."))
+		return
+	}
+
+	before, _ := cc.DB.GetGuildConfig(ctx, guildID)
+
+	recordConfigVersion(ctx, cc.DB, guildID, i.Member.User.ID, "/config set-nickname-template")
+
+	err = cc.DB.UpdateNicknameTemplate(ctx, database.UpdateNicknameTemplateParams{
+		NicknameTemplate: sql.NullString{String: template, Valid: true},
+		GuildID:          guildID,
+	})
+	if err != nil {
+		log.Printf("Error updating nickname template: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to save configuration. Please try again."))
+		return
+	}
+
+	cc.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionNicknameTemplate,
+		Before:  nicknameTemplateOrDefault(before.NicknameTemplate),
+		After:   template,
+	})
+
+	preview := renderNickname(template, nicknameData{
+		RSN:    "Lynx Titan",
+		Rank:   "Member",
+		Player: samplePlayer(),
+	})
+
+	if !FeatureEnabled(ctx, cc.DB, i.GuildID, FeatureAutoNickname) {
+		sendEphemeralEmbed(s, i, embeds.SuccessEmbed(fmt.Sprintf(
+			"Nickname template set to `%s`\n\n**Preview:** %s\n\nAuto-nickname is disabled on this server, so nobody is renamed until it's enabled with `/config features enable`.",
+			template, preview)))
+		return
+	}
+
+	sendEphemeralEmbed(s, i, embeds.SuccessEmbed(fmt.Sprintf(
+		"Nickname template set to `%s`\n\n**Preview:** %s\n\nNicknames are being resynced for all linked members in the background.",
+		template, preview)))
+
+	cc.startNicknameResync(s, i.GuildID)
+}
+
+// HandleResyncNicknames handles /config resync-nicknames command.
+func (cc *ConfigCommands) HandleResyncNicknames(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	if !isServerOwnerOrAdmin(s, i) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Only the server owner or administrators can resync nicknames."))
+		return
+	}
+
+	sendEphemeralEmbed(s, i, embeds.InfoEmbed("Nickname Resync", "Resync started. I'll post a summary in this channel when it's done."))
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), nicknameResyncTimeout)
+		defer cancel()
+
+		result, err := ResyncNicknames(ctx, s, cc.DB, cc.WOMClient, i.GuildID)
+		if errors.Is(err, ErrResyncInProgress) {
+			sendEphemeralEmbed(s, i, embeds.ErrorEmbed("A nickname resync is already running. Please wait for it to finish."))
+			return
+		}
+		if errors.Is(err, ErrAutoNicknameDisabled) {
+			sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Auto-nickname is disabled on this server. Enable it with `/config features enable`."))
+			return
+		}
+		// A resync can outlive the 15 minute interaction token, so the outcome goes to the channel
+		summary := embeds.SuccessEmbed(formatNicknameResyncResult(result))
+		if err != nil {
+			log.Printf("Error resyncing nicknames for guild %s: %v", i.GuildID, err)
+			summary = embeds.ErrorEmbed("Nickname resync failed. Please try again later.")
+		}
+		if _, err := s.ChannelMessageSendEmbed(i.ChannelID, summary); err != nil {
+			log.Printf("Error posting nickname resync summary to channel %s: %v", i.ChannelID, err)
+		}
+	}()
+}
+
+// startNicknameResync runs a resync in the background and only logs the outcome.
+func (cc *ConfigCommands) startNicknameResync(s *discordgo.Session, guildID string) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), nicknameResyncTimeout)
+		defer cancel()
+
+		result, err := ResyncNicknames(ctx, s, cc.DB, cc.WOMClient, guildID)
+		if err != nil {
+			log.Printf("Error resyncing nicknames for guild %s: %v", guildID, err)
+			return
+		}
+		log.Printf("Nickname resync for guild %s: %+v", guildID, result)
+	}()
+}
+
+// formatNicknameResyncResult renders a resync summary for Discord.
+func formatNicknameResyncResult(result NicknameResyncResult) string {
+	msg := fmt.Sprintf("Nickname resync complete.\n\n"+
+		"**Updated:** %d\n"+
+		"**Already up to date:** %d",
+		result.Updated, result.Unchanged)
+
+	if result.Forbidden > 0 {
+		msg += fmt.Sprintf("\n**Skipped (role hierarchy):** %d\n\n*Move my role above these members' highest role to manage their nicknames.*", result.Forbidden)
+	}
+	if result.Failed > 0 {
+		msg += fmt.Sprintf("\n**Failed:** %d", result.Failed)
+	}
+	return msg
+}
+
+// nicknamePlaceholderList returns the supported placeholders for help text.
+func nicknamePlaceholderList() string {
+	return "`{rsn}` `{rank}` `{combat}` `{total}` `{ehp}` `{ehb}` `{type}` `{build}`"
+}
+
+// samplePlayer returns example WOM data used to preview nickname templates.
+func samplePlayer() *wiseoldman.Player {
+	return &wiseoldman.Player{
+		DisplayName: "Lynx Titan",
+		Type:        "regular",
+		Build:       "main",
+		CombatLevel: 126,
+		EHP:         15000,
+		EHB:         250,
+		LatestSnapshot: &wiseoldman.Snapshot{
+			Data: wiseoldman.SnapshotData{
+				Skills: map[string]wiseoldman.SkillData{
+					"overall": {Metric: "overall", Level: 2277},
+				},
+			},
+		},
+	}
+}
+
+// ensureGuildConfig creates an empty guild_config row if the guild doesn't have one yet.
+func ensureGuildConfig(ctx context.Context, db *database.Queries, guildID int64) error {
+	_, err := db.GetGuildConfig(ctx, guildID)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("fetch guild config: %w", err)
+	}
+
+	if _, err := db.CreateGuildConfig(ctx, database.CreateGuildConfigParams{
+		GuildID:           guildID,
+		CoordinatorRoleID: sql.NullInt64{Valid: false},
+	}); err != nil {
+		return fmt.Errorf("create guild config: %w", err)
+	}
+	return nil
+}
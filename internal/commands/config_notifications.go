@@ -0,0 +1,244 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/audit"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/embeds"
+)
+
+// HandleNotificationsSetRole handles /config notifications set-role. Omitting the role makes the
+// event type fall back to the default event notification role.
+func (cc *ConfigCommands) HandleNotificationsSetRole(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	if !isServerOwnerOrAdmin(s, i) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Only the server owner or administrators can configure event notification roles."))
+		return
+	}
+
+	eventOpt := subcommandOption(i, "event")
+	if eventOpt == nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Missing event parameter."))
+		return
+	}
+	nt, ok := lookupNotificationType(eventOpt.StringValue())
+	if !ok {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(fmt.Sprintf("Unknown event type `%s`.", eventOpt.StringValue())))
+		return
+	}
+
+	guildID, err := strconv.ParseInt(i.GuildID, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing guild ID: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse guild ID."))
+		return
+	}
+
+	actorID, err := strconv.ParseInt(i.Member.User.ID, 10, 64)
+	if err != nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse user ID."))
+		return
+	}
+
+	roles, err := cc.DB.GetNotificationRoles(ctx, guildID)
+	if err != nil {
+		log.Printf("Error fetching notification roles: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to fetch configuration. Please try again."))
+		return
+	}
+	before := ""
+	for _, role := range roles {
+		if role.EventType == string(nt.EventType) {
+			before = fmt.Sprintf("<@&%d>", role.RoleID)
+		}
+	}
+
+	roleOpt := subcommandOption(i, "role")
+	if roleOpt == nil {
+		recordConfigVersion(ctx, cc.DB, guildID, i.Member.User.ID, "/config notifications set-role")
+
+		rows, err := cc.DB.DeleteNotificationRole(ctx, database.DeleteNotificationRoleParams{GuildID: guildID, EventType: string(nt.EventType)})
+		if err != nil {
+			log.Printf("Error deleting notification role: %v", err)
+			sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to save configuration. Please try again."))
+			return
+		}
+		if rows == 0 {
+			sendEphemeralEmbed(s, i, embeds.InfoEmbed("Event Pings", fmt.Sprintf("Nothing changed; **%s** didn't have its own role.", nt.Label)))
+			return
+		}
+
+		cc.Audit.Record(ctx, s, audit.Entry{
+			GuildID: i.GuildID,
+			ActorID: i.Member.User.ID,
+			Action:  audit.ActionNotificationRoles,
+			Target:  nt.Label,
+			Before:  before,
+		})
+		sendEphemeralEmbed(s, i, embeds.SuccessEmbed(fmt.Sprintf("**%s** announcements will ping the default event notification role again.", nt.Label)))
+		return
+	}
+
+	role := roleOpt.RoleValue(nil, i.GuildID)
+	if role.ID == i.GuildID {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("The @everyone role can't be used as a ping role."))
+		return
+	}
+	if full, err := s.State.Role(i.GuildID, role.ID); err == nil && full.Managed {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(fmt.Sprintf("<@&%s> is managed by an integration and can't be self-assigned.", role.ID)))
+		return
+	}
+
+	if err := ensureGuildConfig(ctx, cc.DB, guildID); err != nil {
+		log.Printf("Error creating guild config: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to create configuration. Please try again."))
+		return
+	}
+
+	recordConfigVersion(ctx, cc.DB, guildID, i.Member.User.ID, "/config notifications set-role")
+
+	err = cc.DB.SetNotificationRole(ctx, database.SetNotificationRoleParams{
+		GuildID:   guildID,
+		EventType: string(nt.EventType),
+		RoleID:    settingID(role.ID).Int64,
+		UpdatedBy: actorID,
+	})
+	if err != nil {
+		log.Printf("Error saving notification role: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to save configuration. Please try again."))
+		return
+	}
+
+	cc.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionNotificationRoles,
+		Target:  nt.Label,
+		Before:  before,
+		After:   "<@&" + role.ID + ">",
+	})
+
+	sendEphemeralEmbed(s, i, embeds.SuccessEmbed(fmt.Sprintf("**%s** announcements will ping <@&%s>.\n\nPost a role picker with `/config notifications post-picker` so members can opt in.", nt.Label, role.ID)))
+}
+
+// HandleNotificationsList handles /config notifications list.
+func (cc *ConfigCommands) HandleNotificationsList(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	if !isServerOwnerOrAdmin(s, i) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Only the server owner or administrators can view event notification roles."))
+		return
+	}
+
+	guildID, err := strconv.ParseInt(i.GuildID, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing guild ID: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse guild ID."))
+		return
+	}
+
+	options, err := notificationRoleOptions(ctx, cc.DB, guildID)
+	if err != nil {
+		log.Printf("Error loading notification roles: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to fetch configuration. Please try again."))
+		return
+	}
+
+	roles := make(map[string]string, len(options))
+	for _, option := range options {
+		roles[option.Key] = "<@&" + option.RoleID + ">"
+	}
+
+	fallback := roles[NotifyAllEvents]
+	if fallback == "" {
+		fallback = notConfiguredText
+	}
+
+	var sb strings.Builder
+	sb.WriteString("🔔 **Default:** " + fallback + "\n\n")
+	for _, nt := range NotificationTypes {
+		role, ok := roles[string(nt.EventType)]
+		if !ok {
+			role = "Default (" + fallback + ")"
+		}
+		sb.WriteString(fmt.Sprintf("%s **%s:** %s\n", nt.Emoji, nt.Label, role))
+	}
+	sb.WriteString("\nSet the default with `/config set-event-notification-role` and per-event roles with `/config notifications set-role`.")
+
+	sendEphemeralEmbed(s, i, embeds.InfoEmbed("Event Pings", sb.String()))
+}
+
+// HandleNotificationsPostPicker handles /config notifications post-picker, posting a message with
+// buttons members use to toggle the ping roles themselves.
+func (cc *ConfigCommands) HandleNotificationsPostPicker(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	if !isServerOwnerOrAdmin(s, i) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Only the server owner or administrators can post the role picker."))
+		return
+	}
+
+	guildID, err := strconv.ParseInt(i.GuildID, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing guild ID: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse guild ID."))
+		return
+	}
+
+	channelID := i.ChannelID
+	if opt := subcommandOption(i, "channel"); opt != nil {
+		channelID = opt.ChannelValue(nil).ID
+	}
+
+	options, err := notificationRoleOptions(ctx, cc.DB, guildID)
+	if err != nil {
+		log.Printf("Error loading notification roles: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to fetch configuration. Please try again."))
+		return
+	}
+	if len(options) == 0 {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("No ping roles are set up yet. Add one with `/config notifications set-role` or `/config set-event-notification-role`."))
+		return
+	}
+
+	if missing := missingChannelPermissions(s, channelID, channelPostPermissions); len(missing) > 0 {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(fmt.Sprintf("I can't post in <#%s>. Missing: %s", channelID, strings.Join(missing, ", "))))
+		return
+	}
+
+	embed, components := notificationPicker(options, "")
+	_, err = s.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+		Embeds:          []*discordgo.MessageEmbed{embed},
+		Components:      components,
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	})
+	if err != nil {
+		log.Printf("Error posting role picker: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to post the role picker. Please try again."))
+		return
+	}
+
+	sendEphemeralEmbed(s, i, embeds.SuccessEmbed(fmt.Sprintf("Role picker posted in <#%s>. It keeps working when roles change, but post a new one to show newly added roles.", channelID)))
+}
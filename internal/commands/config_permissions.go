@@ -0,0 +1,373 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/audit"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/embeds"
+)
+
+// Subject types for command permission overrides.
+const (
+	PermissionSubjectRole = "role"
+	PermissionSubjectUser = "user"
+)
+
+// maxAutocompleteChoices is Discord's limit on autocomplete suggestions.
+const maxAutocompleteChoices = 25
+
+// CommandPermissionKeys lists every command, subcommand group and subcommand path that can be
+// restricted with /config permissions, e.g. "warn", "warn policy" and "warn policy add".
+// Paths named in exclude are left out; a command's subcommands go with it, a group's stay listed.
+func CommandPermissionKeys(cmds []*discordgo.ApplicationCommand, exclude ...string) []string {
+	var keys []string
+	for _, cmd := range cmds {
+		if slices.Contains(exclude, cmd.Name) {
+			continue
+		}
+		keys = append(keys, cmd.Name)
+		keys = appendSubcommandKeys(keys, cmd.Name, cmd.Options, exclude)
+	}
+	return keys
+}
+
+// appendSubcommandKeys adds the keys of nested subcommands and groups under prefix, skipping excluded paths.
+func appendSubcommandKeys(keys []string, prefix string, options []*discordgo.ApplicationCommandOption, exclude []string) []string {
+	for _, opt := range options {
+		if opt.Type != discordgo.ApplicationCommandOptionSubCommand && opt.Type != discordgo.ApplicationCommandOptionSubCommandGroup {
+			continue
+		}
+		key := prefix + " " + opt.Name
+		if !slices.Contains(exclude, key) {
+			keys = append(keys, key)
+		}
+		if opt.Type == discordgo.ApplicationCommandOptionSubCommandGroup {
+			keys = appendSubcommandKeys(keys, key, opt.Options, exclude)
+		}
+	}
+	return keys
+}
+
+// HandlePermissionsAllow handles /config permissions allow, letting a role or user run a command.
+func (cc *ConfigCommands) HandlePermissionsAllow(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	if !isServerOwnerOrAdmin(s, i) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Only the server owner or administrators can change command permissions."))
+		return
+	}
+
+	command, subjects, guildID, ok := cc.permissionTarget(s, i)
+	if !ok {
+		return
+	}
+
+	actorID, err := strconv.ParseInt(i.Member.User.ID, 10, 64)
+	if err != nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse user ID."))
+		return
+	}
+
+	recordConfigVersion(ctx, cc.DB, guildID, i.Member.User.ID, "/config permissions allow")
+
+	var added []string
+	for _, subject := range subjects {
+		rows, err := cc.DB.AddCommandPermission(ctx, database.AddCommandPermissionParams{
+			GuildID:     guildID,
+			Command:     command,
+			SubjectType: subject.Type,
+			SubjectID:   subject.ID,
+			CreatedBy:   actorID,
+		})
+		if err != nil {
+			log.Printf("Error adding command permission: %v", err)
+			sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to save command permissions. Please try again."))
+			return
+		}
+		if rows > 0 {
+			added = append(added, subject.mention())
+		}
+	}
+
+	if len(added) == 0 {
+		sendEphemeralEmbed(s, i, embeds.InfoEmbed("Command Permissions", fmt.Sprintf("Nothing changed; they were already allowed to use `/%s`.", command)))
+		return
+	}
+
+	cc.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionCommandPermissions,
+		Target:  "/" + command,
+		After:   "allow " + strings.Join(added, ", "),
+	})
+
+	sendEphemeralEmbed(s, i, embeds.SuccessEmbed(fmt.Sprintf(
+		"%s can now use `/%s`.\n\nOnly the roles and users listed for this command (and server administrators) can run it. Use `/config permissions reset` to restore the default.",
+		strings.Join(added, ", "), command)))
+}
+
+// HandlePermissionsRevoke handles /config permissions revoke, removing a role or user from a command's overrides.
+func (cc *ConfigCommands) HandlePermissionsRevoke(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	if !isServerOwnerOrAdmin(s, i) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Only the server owner or administrators can change command permissions."))
+		return
+	}
+
+	command, subjects, guildID, ok := cc.permissionTarget(s, i)
+	if !ok {
+		return
+	}
+
+	recordConfigVersion(ctx, cc.DB, guildID, i.Member.User.ID, "/config permissions revoke")
+
+	var removed []string
+	for _, subject := range subjects {
+		rows, err := cc.DB.RemoveCommandPermission(ctx, database.RemoveCommandPermissionParams{
+			GuildID:     guildID,
+			Command:     command,
+			SubjectType: subject.Type,
+			SubjectID:   subject.ID,
+		})
+		if err != nil {
+			log.Printf("Error removing command permission: %v", err)
+			sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to save command permissions. Please try again."))
+			return
+		}
+		if rows > 0 {
+			removed = append(removed, subject.mention())
+		}
+	}
+
+	if len(removed) == 0 {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(fmt.Sprintf("They weren't listed for `/%s`.", command)))
+		return
+	}
+
+	cc.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionCommandPermissions,
+		Target:  "/" + command,
+		Before:  "allow " + strings.Join(removed, ", "),
+	})
+
+	sendEphemeralEmbed(s, i, embeds.SuccessEmbed(fmt.Sprintf("Removed %s from `/%s`.\n\nIf no roles or users are left, the command falls back to its default permission.", strings.Join(removed, ", "), command)))
+}
+
+// HandlePermissionsReset handles /config permissions reset, removing all overrides for a command.
+func (cc *ConfigCommands) HandlePermissionsReset(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	if !isServerOwnerOrAdmin(s, i) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Only the server owner or administrators can change command permissions."))
+		return
+	}
+
+	command, ok := cc.permissionCommand(s, i)
+	if !ok {
+		return
+	}
+
+	guildID, err := strconv.ParseInt(i.GuildID, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing guild ID: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse guild ID."))
+		return
+	}
+
+	recordConfigVersion(ctx, cc.DB, guildID, i.Member.User.ID, "/config permissions reset")
+
+	removed, err := cc.DB.ResetCommandPermissions(ctx, database.ResetCommandPermissionsParams{
+		GuildID: guildID,
+		Command: command,
+	})
+	if err != nil {
+		log.Printf("Error resetting command permissions: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to save command permissions. Please try again."))
+		return
+	}
+	if removed == 0 {
+		sendEphemeralEmbed(s, i, embeds.InfoEmbed("Command Permissions", fmt.Sprintf("`/%s` already uses its default permission.", command)))
+		return
+	}
+
+	cc.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionCommandPermissions,
+		Target:  "/" + command,
+		Before:  fmt.Sprintf("%d override(s)", removed),
+		After:   "default",
+	})
+
+	sendEphemeralEmbed(s, i, embeds.SuccessEmbed(fmt.Sprintf("`/%s` is back to its default permission.", command)))
+}
+
+// HandlePermissionsList handles /config permissions list, showing all command overrides.
+func (cc *ConfigCommands) HandlePermissionsList(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	guildID, err := strconv.ParseInt(i.GuildID, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing guild ID: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse guild ID."))
+		return
+	}
+
+	rules, err := cc.DB.GetCommandPermissions(ctx, guildID)
+	if err != nil {
+		log.Printf("Error fetching command permissions: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Database error. Please try again later."))
+		return
+	}
+
+	if len(rules) == 0 {
+		sendEphemeralEmbed(s, i, embeds.InfoEmbed("🔐 Command Permissions", "All commands use their default permissions.\n\nUse `/config permissions allow` to restrict a command to specific roles or users."))
+		return
+	}
+
+	sendEphemeralEmbed(s, i, embeds.InfoEmbed("🔐 Command Permissions",
+		formatCommandPermissions(rules)+"\n*Server administrators can always run every command.*"))
+}
+
+// HandlePermissionsAutocomplete suggests command paths for /config permissions.
+func (cc *ConfigCommands) HandlePermissionsAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	query := ""
+	if opt := subcommandOption(i, "command"); opt != nil {
+		query = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(opt.StringValue(), "/")))
+	}
+
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, maxAutocompleteChoices)
+	for _, key := range cc.PermissionKeys {
+		if !strings.Contains(strings.ToLower(key), query) {
+			continue
+		}
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: "/" + key, Value: key})
+		if len(choices) == maxAutocompleteChoices {
+			break
+		}
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	})
+	if err != nil {
+		log.Printf("Error responding to autocomplete: %v", err)
+	}
+}
+
+// permissionSubject is a role or user named in a command override.
+type permissionSubject struct {
+	Type string
+	ID   int64
+}
+
+func (p permissionSubject) mention() string {
+	if p.Type == PermissionSubjectRole {
+		return fmt.Sprintf("<@&%d>", p.ID)
+	}
+	return fmt.Sprintf("<@%d>", p.ID)
+}
+
+// permissionCommand reads and validates the command option, replying with an error if it is unknown.
+func (cc *ConfigCommands) permissionCommand(s *discordgo.Session, i *discordgo.InteractionCreate) (string, bool) {
+	opt := subcommandOption(i, "command")
+	if opt == nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Missing command parameter."))
+		return "", false
+	}
+
+	command := strings.Join(strings.Fields(strings.TrimPrefix(strings.TrimSpace(opt.StringValue()), "/")), " ")
+	if !slices.Contains(cc.PermissionKeys, command) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(fmt.Sprintf("`/%s` isn't a command that can be restricted. Pick one from the suggestions.", command)))
+		return "", false
+	}
+	return command, true
+}
+
+// permissionTarget reads the command, role and user options for allow/revoke.
+func (cc *ConfigCommands) permissionTarget(s *discordgo.Session, i *discordgo.InteractionCreate) (string, []permissionSubject, int64, bool) {
+	command, ok := cc.permissionCommand(s, i)
+	if !ok {
+		return "", nil, 0, false
+	}
+
+	guildID, err := strconv.ParseInt(i.GuildID, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing guild ID: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse guild ID."))
+		return "", nil, 0, false
+	}
+
+	var subjects []permissionSubject
+	if opt := subcommandOption(i, "role"); opt != nil {
+		id, err := strconv.ParseInt(opt.RoleValue(nil, i.GuildID).ID, 10, 64)
+		if err != nil {
+			sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse role ID."))
+			return "", nil, 0, false
+		}
+		subjects = append(subjects, permissionSubject{Type: PermissionSubjectRole, ID: id})
+	}
+	if opt := subcommandOption(i, "user"); opt != nil {
+		id, err := strconv.ParseInt(opt.UserValue(nil).ID, 10, 64)
+		if err != nil {
+			sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse user ID."))
+			return "", nil, 0, false
+		}
+		subjects = append(subjects, permissionSubject{Type: PermissionSubjectUser, ID: id})
+	}
+
+	if len(subjects) == 0 {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Pick a role, a user, or both."))
+		return "", nil, 0, false
+	}
+
+	return command, subjects, guildID, true
+}
+
+// formatCommandPermissions renders overrides grouped by command, one line per command.
+func formatCommandPermissions(rules []database.CommandPermission) string {
+	var sb strings.Builder
+	for idx := 0; idx < len(rules); {
+		command := rules[idx].Command
+		var mentions []string
+		for ; idx < len(rules) && rules[idx].Command == command; idx++ {
+			mentions = append(mentions, permissionSubject{Type: rules[idx].SubjectType, ID: rules[idx].SubjectID}.mention())
+		}
+		sb.WriteString(fmt.Sprintf("`/%s` → %s\n", command, strings.Join(mentions, ", ")))
+	}
+	return sb.String()
+}
@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandPermissionKeys(t *testing.T) {
+	cmds := []*discordgo.ApplicationCommand{
+		{Name: "config", Options: []*discordgo.ApplicationCommandOption{
+			{Type: discordgo.ApplicationCommandOptionSubCommand, Name: "show"},
+		}},
+		{Name: "mass", Options: []*discordgo.ApplicationCommandOption{
+			{Type: discordgo.ApplicationCommandOptionString, Name: "activity"},
+		}},
+		{Name: "warn", Options: []*discordgo.ApplicationCommandOption{
+			{Type: discordgo.ApplicationCommandOptionSubCommand, Name: "add"},
+			{Type: discordgo.ApplicationCommandOptionSubCommandGroup, Name: "policy", Options: []*discordgo.ApplicationCommandOption{
+				{Type: discordgo.ApplicationCommandOptionSubCommand, Name: "add"},
+				{Type: discordgo.ApplicationCommandOptionSubCommand, Name: "list"},
+			}},
+		}},
+	}
+
+	assert.Equal(t, []string{"mass", "warn", "warn add", "warn policy", "warn policy add", "warn policy list"}, CommandPermissionKeys(cmds, "config"))
+	assert.Equal(t, []string{"mass", "warn", "warn add", "warn policy list"}, CommandPermissionKeys(cmds, "config", "warn policy", "warn policy add"))
+}
+
+func TestFormatCommandPermissions(t *testing.T) {
+	rules := []database.CommandPermission{
+		{Command: "botw finish", SubjectType: PermissionSubjectRole, SubjectID: 1},
+		{Command: "botw finish", SubjectType: PermissionSubjectUser, SubjectID: 2},
+		{Command: "warn add", SubjectType: PermissionSubjectRole, SubjectID: 3},
+	}
+
+	assert.Equal(t, "`/botw finish` → <@&1>, <@2>\n`/warn add` → <@&3>\n", formatCommandPermissions(rules))
+}
@@ -0,0 +1,214 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/audit"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/embeds"
+)
+
+// HandleRankRolesSet handles /config rank-roles set, mapping a Wise Old Man group rank to a Discord
+// role. Omitting the role removes the mapping; members keep the role until they lose it by hand.
+func (cc *ConfigCommands) HandleRankRolesSet(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	if !isServerOwnerOrAdmin(s, i) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Only the server owner or administrators can configure rank roles."))
+		return
+	}
+
+	rankOpt := subcommandOption(i, "rank")
+	if rankOpt == nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Missing rank parameter."))
+		return
+	}
+	rank := normalizeWOMRole(rankOpt.StringValue())
+	if !validWOMRole(rank) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(fmt.Sprintf("`%s` isn't a Wise Old Man group role. Use the role's name, e.g. `captain` or `dragon_slayer`.", rankOpt.StringValue())))
+		return
+	}
+
+	guildID, err := strconv.ParseInt(i.GuildID, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing guild ID: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse guild ID."))
+		return
+	}
+
+	actorID, err := strconv.ParseInt(i.Member.User.ID, 10, 64)
+	if err != nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse user ID."))
+		return
+	}
+
+	mappings, err := cc.DB.GetRankRoles(ctx, guildID)
+	if err != nil {
+		log.Printf("Error fetching rank roles: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to fetch configuration. Please try again."))
+		return
+	}
+	before := ""
+	for _, mapping := range mappings {
+		if mapping.WomRole == rank {
+			before = fmt.Sprintf("<@&%d>", mapping.RoleID)
+		}
+	}
+
+	roleOpt := subcommandOption(i, "role")
+	if roleOpt == nil {
+		recordConfigVersion(ctx, cc.DB, guildID, i.Member.User.ID, "/config rank-roles set")
+
+		rows, err := cc.DB.DeleteRankRole(ctx, database.DeleteRankRoleParams{GuildID: guildID, WomRole: rank})
+		if err != nil {
+			log.Printf("Error deleting rank role: %v", err)
+			sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to save configuration. Please try again."))
+			return
+		}
+		if rows == 0 {
+			sendEphemeralEmbed(s, i, embeds.InfoEmbed("Rank Roles", fmt.Sprintf("Nothing changed; `%s` didn't have a role.", rank)))
+			return
+		}
+
+		cc.Audit.Record(ctx, s, audit.Entry{
+			GuildID: i.GuildID,
+			ActorID: i.Member.User.ID,
+			Action:  audit.ActionRankRoles,
+			Target:  rank,
+			Before:  before,
+		})
+		sendEphemeralEmbed(s, i, embeds.SuccessEmbed(fmt.Sprintf("`%s` no longer has a Discord role. Members who have %s keep it; remove it by hand if needed.", rank, before)))
+		return
+	}
+
+	role := roleOpt.RoleValue(nil, i.GuildID)
+	if role.ID == i.GuildID {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("The @everyone role can't be used as a rank role."))
+		return
+	}
+	if full, err := s.State.Role(i.GuildID, role.ID); err == nil && full.Managed {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(fmt.Sprintf("<@&%s> is managed by an integration and can't be assigned.", role.ID)))
+		return
+	}
+
+	recordConfigVersion(ctx, cc.DB, guildID, i.Member.User.ID, "/config rank-roles set")
+
+	err = cc.DB.SetRankRole(ctx, database.SetRankRoleParams{
+		GuildID:   guildID,
+		WomRole:   rank,
+		RoleID:    settingID(role.ID).Int64,
+		UpdatedBy: actorID,
+	})
+	if err != nil {
+		log.Printf("Error saving rank role: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to save configuration. Please try again."))
+		return
+	}
+
+	cc.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionRankRoles,
+		Target:  rank,
+		Before:  before,
+		After:   "<@&" + role.ID + ">",
+	})
+
+	sendEphemeralEmbed(s, i, embeds.SuccessEmbed(fmt.Sprintf(
+		"Linked members ranked `%s` in the Wise Old Man group will get <@&%s>, and lose it when their rank changes.\n\nCheck what would change with `/config rank-roles preview`. Roles are reconciled every few hours.",
+		rank, role.ID)))
+}
+
+// HandleRankRolesList handles /config rank-roles list.
+func (cc *ConfigCommands) HandleRankRolesList(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	if !isServerOwnerOrAdmin(s, i) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Only the server owner or administrators can view rank roles."))
+		return
+	}
+
+	guildID, err := strconv.ParseInt(i.GuildID, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing guild ID: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse guild ID."))
+		return
+	}
+
+	mappings, err := cc.DB.GetRankRoles(ctx, guildID)
+	if err != nil {
+		log.Printf("Error fetching rank roles: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to fetch configuration. Please try again."))
+		return
+	}
+	if len(mappings) == 0 {
+		sendEphemeralEmbed(s, i, embeds.InfoEmbed("Rank Roles", "No ranks are mapped to roles. Add one with `/config rank-roles set`."))
+		return
+	}
+
+	var sb strings.Builder
+	for _, mapping := range mappings {
+		sb.WriteString(fmt.Sprintf("• `%s` → <@&%d>\n", mapping.WomRole, mapping.RoleID))
+	}
+	sb.WriteString("\nMembers need a linked RSN in the server's Wise Old Man group to get a rank role.")
+
+	sendEphemeralEmbed(s, i, embeds.InfoEmbed("Rank Roles", sb.String()))
+}
+
+// HandleRankRolesPreview handles /config rank-roles preview, listing the role changes a sync would
+// make without making them.
+func (cc *ConfigCommands) HandleRankRolesPreview(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	cc.syncRankRoles(s, i, false)
+}
+
+// HandleRankRolesSync handles /config rank-roles sync, reconciling rank roles now.
+func (cc *ConfigCommands) HandleRankRolesSync(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	cc.syncRankRoles(s, i, true)
+}
+
+// syncRankRoles previews or applies rank role changes for the guild.
+func (cc *ConfigCommands) syncRankRoles(s *discordgo.Session, i *discordgo.InteractionCreate, apply bool) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	if !isServerOwnerOrAdmin(s, i) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Only the server owner or administrators can sync rank roles."))
+		return
+	}
+
+	result, err := SyncRankRoles(ctx, s, cc.DB, cc.WOMClient, cc.Audit, i.GuildID, i.Member.User.ID, apply)
+	switch {
+	case errors.Is(err, ErrNoWOMGroup):
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("No Wise Old Man group is configured. Set one with `/config wom-group set` first."))
+		return
+	case errors.Is(err, ErrNoRankRoles):
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("No ranks are mapped to roles. Add one with `/config rank-roles set`."))
+		return
+	case err != nil:
+		log.Printf("Error syncing rank roles for guild %s: %v", i.GuildID, err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(womErrorMessage(err, "Failed to fetch the Wise Old Man group or server members. Please try again.")))
+		return
+	}
+
+	sendEphemeralEmbed(s, i, embeds.RankRoleSync(result.Changes, !apply, result.Failed))
+}
@@ -0,0 +1,301 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/audit"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/embeds"
+)
+
+// HandleWelcomeEdit handles /config welcome edit by opening a modal with the current templates.
+func (cc *ConfigCommands) HandleWelcomeEdit(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if !isServerOwnerOrAdmin(s, i) {
+		respondToInteraction(s, i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Embeds: []*discordgo.MessageEmbed{embeds.ErrorEmbed("Only the server owner or administrators can configure the welcome message.")},
+				Flags:  discordgo.MessageFlagsEphemeral,
+			},
+		})
+		return
+	}
+
+	settings, err := loadWelcomeSettings(context.Background(), cc.DB, i.GuildID)
+	if err != nil {
+		log.Printf("Error loading welcome settings: %v", err)
+	}
+
+	input := func(id, label string, style discordgo.TextInputStyle, value sql.NullString, maxLength int) discordgo.MessageComponent {
+		return discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.TextInput{
+					CustomID:    id,
+					Label:       label,
+					Style:       style,
+					Value:       value.String,
+					Placeholder: "Leave empty for the default",
+					Required:    false,
+					MaxLength:   maxLength,
+				},
+			},
+		}
+	}
+
+	err = respondToInteraction(s, i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID: "welcome-edit-modal",
+			Title:    "Welcome Messages",
+			Components: []discordgo.MessageComponent{
+				input("dm-title", "DM title", discordgo.TextInputShort, settings.DmTitle, maxWelcomeTitleLength),
+				input("dm-message", "DM message", discordgo.TextInputParagraph, settings.DmMessage, maxWelcomeMessageLength),
+				input("channel-message", "Welcome channel message", discordgo.TextInputParagraph, settings.ChannelMessage, maxWelcomeChannelMessageLength),
+			},
+		},
+	})
+	if err != nil {
+		log.Printf("Error showing welcome modal: %v", err)
+	}
+}
+
+// HandleWelcomeEditModal saves the templates submitted from the /config welcome edit modal.
+func (cc *ConfigCommands) HandleWelcomeEditModal(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	title := strings.TrimSpace(modalValue(i, "dm-title"))
+	message := strings.TrimSpace(modalValue(i, "dm-message"))
+	channelMessage := strings.TrimSpace(modalValue(i, "channel-message"))
+
+	cc.updateWelcomeSettings(s, i, "/config welcome edit", func(settings *database.GuildWelcomeSetting) (string, bool) {
+		for _, template := range []string{title, message, channelMessage} {
+			if err := ValidateWelcomeTemplate(template); err != nil {
+				return fmt.Sprintf("%v\n\nAvailable placeholders: %s", err, welcomePlaceholderList()), false
+			}
+		}
+
+		settings.DmTitle = sql.NullString{String: title, Valid: title != ""}
+		settings.DmMessage = sql.NullString{String: message, Valid: message != ""}
+		settings.ChannelMessage = sql.NullString{String: channelMessage, Valid: channelMessage != ""}
+		return "Welcome messages updated. Use `/config welcome preview` to see them.", true
+	})
+}
+
+// HandleWelcomeChannel handles /config welcome channel. Omitting the channel turns off the public welcome message.
+func (cc *ConfigCommands) HandleWelcomeChannel(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	cc.updateWelcomeSettings(s, i, "/config welcome channel", func(settings *database.GuildWelcomeSetting) (string, bool) {
+		opt := subcommandOption(i, "channel")
+		if opt == nil {
+			settings.ChannelID = sql.NullInt64{}
+			return "Public welcome messages disabled.", true
+		}
+
+		channelID := opt.ChannelValue(nil).ID
+		if missing := missingChannelPermissions(s, channelID, channelPostPermissions); len(missing) > 0 {
+			return fmt.Sprintf("I can't post in <#%s>. Missing: %s", channelID, strings.Join(missing, ", ")), false
+		}
+		settings.ChannelID = settingID(channelID)
+		return fmt.Sprintf("New members will be welcomed in <#%s>.", channelID), true
+	})
+}
+
+// HandleWelcomeChecklist handles /config welcome checklist. Steps that aren't given keep their current setting.
+func (cc *ConfigCommands) HandleWelcomeChecklist(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	cc.updateWelcomeSettings(s, i, "/config welcome checklist", func(settings *database.GuildWelcomeSetting) (string, bool) {
+		current := parseChecklist(settings.Checklist)
+
+		var next []string
+		for _, step := range onboardingSteps {
+			enabled := slices.Contains(current, step.Name)
+			if opt := subcommandOption(i, step.Name); opt != nil {
+				enabled = opt.BoolValue()
+			}
+			if enabled {
+				next = append(next, step.Name)
+			}
+		}
+
+		settings.Checklist = strings.Join(next, ",")
+		return "Onboarding checklist: " + formatChecklist(next), true
+	})
+}
+
+// HandleWelcomeLinkedRole handles /config welcome linked-role. Omitting the role turns off automatic assignment.
+func (cc *ConfigCommands) HandleWelcomeLinkedRole(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	cc.updateWelcomeSettings(s, i, "/config welcome linked-role", func(settings *database.GuildWelcomeSetting) (string, bool) {
+		opt := subcommandOption(i, "role")
+		if opt == nil {
+			settings.LinkedRoleID = sql.NullInt64{}
+			return "Members will no longer get a role when they link their account.", true
+		}
+
+		role := opt.RoleValue(nil, i.GuildID)
+		if role.ID == i.GuildID {
+			return "The @everyone role can't be assigned.", false
+		}
+		if full, err := s.State.Role(i.GuildID, role.ID); err == nil && full.Managed {
+			return fmt.Sprintf("<@&%s> is managed by an integration and can't be assigned.", role.ID), false
+		}
+
+		settings.LinkedRoleID = settingID(role.ID)
+		return fmt.Sprintf("Members will get <@&%s> once they link their RuneScape account.\n\nMake sure my role is above it so I can assign it; `/config doctor` checks this.", role.ID), true
+	})
+}
+
+// HandleWelcomePreview handles /config welcome preview, showing the welcome messages as the invoking user would see them.
+func (cc *ConfigCommands) HandleWelcomePreview(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	if !isServerOwnerOrAdmin(s, i) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Only the server owner or administrators can preview the welcome message."))
+		return
+	}
+
+	settings, err := loadWelcomeSettings(ctx, cc.DB, i.GuildID)
+	if err != nil {
+		log.Printf("Error loading welcome settings: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to fetch configuration. Please try again."))
+		return
+	}
+
+	data := welcomeData{UserID: i.Member.User.ID, Name: displayName(i.Member.User)}
+	if guild, err := s.State.Guild(i.GuildID); err == nil {
+		data.Server = guild.Name
+		data.MemberCount = guild.MemberCount
+	}
+
+	dm, _ := welcomeDM(i.GuildID, settings, data)
+
+	content := "**Welcome channel:** not configured"
+	if settings.ChannelID.Valid {
+		content = fmt.Sprintf("**Welcome channel <#%d>:**\n%s", settings.ChannelID.Int64, renderWelcome(welcomeChannelTemplate(settings), data))
+	}
+	if !FeatureEnabled(ctx, cc.DB, i.GuildID, FeatureWelcomeDM) {
+		content += "\n\n*The welcome DM is disabled; enable it with `/config features enable`.*"
+	}
+	if settings.LinkedRoleID.Valid {
+		content += fmt.Sprintf("\n**Linked role:** <@&%d>", settings.LinkedRoleID.Int64)
+	}
+
+	sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
+		Content:         content,
+		Embeds:          []*discordgo.MessageEmbed{dm},
+		Flags:           discordgo.MessageFlagsEphemeral,
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	})
+}
+
+// updateWelcomeSettings loads the guild's welcome settings, lets apply change them and saves the result.
+// apply returns the message to show and whether the change should be saved. The interaction must already be deferred.
+func (cc *ConfigCommands) updateWelcomeSettings(s *discordgo.Session, i *discordgo.InteractionCreate, reason string, apply func(*database.GuildWelcomeSetting) (string, bool)) {
+	ctx := context.Background()
+
+	if i.Type == discordgo.InteractionModalSubmit {
+		if err := deferEphemeral(s, i); err != nil {
+			log.Printf("Error deferring response: %v", err)
+			return
+		}
+	}
+
+	if !isServerOwnerOrAdmin(s, i) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Only the server owner or administrators can configure the welcome message."))
+		return
+	}
+
+	guildID, err := strconv.ParseInt(i.GuildID, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing guild ID: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse guild ID."))
+		return
+	}
+
+	actorID, err := strconv.ParseInt(i.Member.User.ID, 10, 64)
+	if err != nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse user ID."))
+		return
+	}
+
+	settings, err := loadWelcomeSettings(ctx, cc.DB, i.GuildID)
+	if err != nil {
+		log.Printf("Error loading welcome settings: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to fetch configuration. Please try again."))
+		return
+	}
+	before := describeWelcomeSettings(settings)
+
+	msg, ok := apply(&settings)
+	if !ok {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(msg))
+		return
+	}
+
+	recordConfigVersion(ctx, cc.DB, guildID, i.Member.User.ID, reason)
+
+	err = cc.DB.UpsertWelcomeSettings(ctx, database.UpsertWelcomeSettingsParams{
+		GuildID:        guildID,
+		DmTitle:        settings.DmTitle,
+		DmMessage:      settings.DmMessage,
+		ChannelID:      settings.ChannelID,
+		ChannelMessage: settings.ChannelMessage,
+		Checklist:      settings.Checklist,
+		LinkedRoleID:   settings.LinkedRoleID,
+		UpdatedBy:      actorID,
+	})
+	if err != nil {
+		log.Printf("Error saving welcome settings: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to save configuration. Please try again."))
+		return
+	}
+
+	cc.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionWelcome,
+		Before:  before,
+		After:   describeWelcomeSettings(settings),
+	})
+
+	sendEphemeralEmbed(s, i, embeds.SuccessEmbed(msg))
+}
+
+// describeWelcomeSettings summarises welcome settings for the audit log.
+func describeWelcomeSettings(settings database.GuildWelcomeSetting) string {
+	custom := "default"
+	if settings.DmTitle.Valid || settings.DmMessage.Valid {
+		custom = "custom"
+	}
+	channel := "none"
+	if settings.ChannelID.Valid {
+		channel = fmt.Sprintf("<#%d>", settings.ChannelID.Int64)
+	}
+	role := "none"
+	if settings.LinkedRoleID.Valid {
+		role = fmt.Sprintf("<@&%d>", settings.LinkedRoleID.Int64)
+	}
+	return fmt.Sprintf("DM: %s, channel: %s, checklist: %s, linked role: %s", custom, channel, formatChecklist(parseChecklist(settings.Checklist)), role)
+}
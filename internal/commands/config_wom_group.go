@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/audit"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/embeds"
+	"github.com/kaffeed/voidling/internal/wiseoldman"
+)
+
+// HandleWOMGroupSet handles /config wom-group set, linking the server to a Wise Old Man group. The
+// group must exist; its verification code is stored for adding members and group competitions.
+func (cc *ConfigCommands) HandleWOMGroupSet(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	if !isServerOwnerOrAdmin(s, i) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Only the server owner or administrators can configure the Wise Old Man group."))
+		return
+	}
+
+	guildID, err := strconv.ParseInt(i.GuildID, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing guild ID: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse guild ID."))
+		return
+	}
+
+	actorID, err := strconv.ParseInt(i.Member.User.ID, 10, 64)
+	if err != nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse user ID."))
+		return
+	}
+
+	var groupID int64
+	if opt := subcommandOption(i, "group-id"); opt != nil {
+		groupID = opt.IntValue()
+	}
+	var code string
+	if opt := subcommandOption(i, "verification-code"); opt != nil {
+		code = strings.TrimSpace(opt.StringValue())
+	}
+	if code == "" {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Give the group's verification code. It was shown when the group was created on Wise Old Man."))
+		return
+	}
+
+	group, err := cc.WOMClient.GetGroup(ctx, groupID)
+	if errors.Is(err, wiseoldman.ErrGroupNotFound) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(fmt.Sprintf("Wise Old Man has no group #%d. The ID is the number in the group's URL.", groupID)))
+		return
+	}
+	if err != nil {
+		log.Printf("Error fetching WOM group %d: %v", groupID, err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(womErrorMessage(err, "Failed to reach Wise Old Man. Please try again.")))
+		return
+	}
+
+	before := "none"
+	if previous, err := loadWOMGroup(ctx, cc.DB, i.GuildID); err == nil {
+		before = fmt.Sprintf("#%d", previous.GroupID)
+	}
+
+	err = cc.DB.UpsertWOMGroup(ctx, database.UpsertWOMGroupParams{
+		GuildID:          guildID,
+		GroupID:          group.ID,
+		VerificationCode: code,
+		UpdatedBy:        actorID,
+	})
+	if err != nil {
+		log.Printf("Error saving WOM group: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to save configuration. Please try again."))
+		return
+	}
+
+	// The code itself is never audited; anyone who can read the audit log could otherwise change the group
+	cc.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionWOMGroup,
+		Before:  before,
+		After:   fmt.Sprintf("#%d (%s)", group.ID, group.Name),
+	})
+
+	sendEphemeralEmbed(s, i, embeds.SuccessEmbed(fmt.Sprintf(
+		"Linked to Wise Old Man group **%s** (%d members). BOTW and SOTW will now be group competitions.\n\nRun `/roster sync` to add linked members to the group. If the verification code is wrong, that will fail.",
+		group.Name, group.MemberCount)))
+}
+
+// HandleWOMGroupClear handles /config wom-group clear. Competitions go back to open competitions
+// members register for individually.
+func (cc *ConfigCommands) HandleWOMGroupClear(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	if !isServerOwnerOrAdmin(s, i) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Only the server owner or administrators can configure the Wise Old Man group."))
+		return
+	}
+
+	previous, err := loadWOMGroup(ctx, cc.DB, i.GuildID)
+	if errors.Is(err, ErrNoWOMGroup) {
+		sendEphemeralEmbed(s, i, embeds.InfoEmbed("Wise Old Man Group", "No Wise Old Man group is configured."))
+		return
+	}
+	if err != nil {
+		log.Printf("Error loading WOM group: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to fetch configuration. Please try again."))
+		return
+	}
+
+	if err := cc.DB.DeleteWOMGroup(ctx, previous.GuildID); err != nil {
+		log.Printf("Error deleting WOM group: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to save configuration. Please try again."))
+		return
+	}
+
+	cc.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionWOMGroup,
+		Before:  fmt.Sprintf("#%d", previous.GroupID),
+		After:   "none",
+	})
+
+	sendEphemeralEmbed(s, i, embeds.SuccessEmbed("Wise Old Man group removed. New BOTW and SOTW events will be open competitions again."))
+}
@@ -0,0 +1,182 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/embeds"
+	"github.com/kaffeed/voidling/internal/wiseoldman"
+)
+
+// gainsDateLayout is the date format of the custom /gains period.
+const gainsDateLayout = "2006-01-02"
+
+// GainsCommands handles /gains.
+type GainsCommands struct {
+	DB        *database.Queries
+	DBSQL     *sql.DB
+	WOMClient GainsClient
+}
+
+// NewGainsCommands creates a new GainsCommands instance.
+func NewGainsCommands(db *database.Queries, dbSQL *sql.DB, womClient GainsClient) *GainsCommands {
+	return &GainsCommands{
+		DB:        db,
+		DBSQL:     dbSQL,
+		WOMClient: womClient,
+	}
+}
+
+// HandleGains handles /gains [member] period [from] [to], showing what a member gained on Wise Old
+// Man. Periods are calendar periods in the caller's timezone, so "day" starts at their midnight.
+func (g *GainsCommands) HandleGains(s DiscordSession, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	caller := interactionUser(i)
+	target := caller
+	var period, from, to string
+	for _, opt := range i.ApplicationCommandData().Options {
+		switch opt.Name {
+		case "member":
+			target = opt.UserValue(nil)
+		case "period":
+			period = opt.StringValue()
+		case "from":
+			from = opt.StringValue()
+		case "to":
+			to = opt.StringValue()
+		}
+	}
+
+	if err := respondToInteraction(s, i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	}); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	guildID, _ := strconv.ParseInt(i.GuildID, 10, 64)
+	callerID, _ := strconv.ParseInt(caller.ID, 10, 64)
+	tz := memberTimezone(ctx, g.DB, guildID, callerID)
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		log.Printf("Warning: invalid timezone %q for %s, using UTC: %v", tz, caller.ID, err)
+		loc = time.UTC
+	}
+
+	start, end, label, err := gainsPeriod(period, from, to, time.Now(), loc)
+	if err != nil {
+		sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
+			Embeds: []*discordgo.MessageEmbed{embeds.ErrorEmbed(fmt.Sprintf("Invalid period: %v.", err))},
+		})
+		return
+	}
+
+	targetID, err := strconv.ParseInt(target.ID, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing user ID %s: %v", target.ID, err)
+		sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
+			Embeds: []*discordgo.MessageEmbed{embeds.ErrorEmbed("Invalid user ID.")},
+		})
+		return
+	}
+	link, err := g.DB.GetAccountLinkByDiscordID(ctx, targetID)
+	if errors.Is(err, sql.ErrNoRows) {
+		message := fmt.Sprintf("<@%s> hasn't linked a RuneScape account.", target.ID)
+		if target.ID == caller.ID {
+			message = "You haven't linked a RuneScape account yet. Use `/link-rsn` to link one."
+		}
+		sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
+			Embeds:          []*discordgo.MessageEmbed{embeds.ErrorEmbed(message)},
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("Error getting account link for %s: %v", target.ID, err)
+		sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
+			Embeds: []*discordgo.MessageEmbed{embeds.ErrorEmbed("Failed to load the linked account. Please try again.")},
+		})
+		return
+	}
+
+	gains, err := g.WOMClient.GetPlayerGainsBetween(ctx, link.RunescapeName, start, end)
+	if err != nil {
+		log.Printf("Error fetching gains for %s: %v", link.RunescapeName, err)
+		message := womErrorMessage(err, "Failed to fetch gains from Wise Old Man. Please try again.")
+		if errors.Is(err, wiseoldman.ErrPlayerNotFound) {
+			message = fmt.Sprintf("Wise Old Man isn't tracking **%s** yet. Update the account on https://wiseoldman.net first.", link.RunescapeName)
+		}
+		sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
+			Embeds: []*discordgo.MessageEmbed{embeds.ErrorEmbed(message)},
+		})
+		return
+	}
+
+	sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
+		Embeds:          []*discordgo.MessageEmbed{embeds.Gains(target.ID, link.RunescapeName, label, gains)},
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	})
+}
+
+// gainsPeriod resolves a /gains period to the window [start, end) in loc, ending no later than now.
+// Custom periods cover whole days from from to to; to defaults to today.
+func gainsPeriod(period, from, to string, now time.Time, loc *time.Location) (start, end time.Time, label string, err error) {
+	now = now.In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	if period != "custom" && (from != "" || to != "") {
+		return time.Time{}, time.Time{}, "", errors.New("`from` and `to` only apply to the custom period")
+	}
+
+	switch period {
+	case "day":
+		return today, now, "Today", nil
+	case "week":
+		// Weeks start on Monday
+		offset := (int(today.Weekday()) + 6) % 7
+		return today.AddDate(0, 0, -offset), now, "This week", nil
+	case "month":
+		return today.AddDate(0, 0, 1-today.Day()), now, "This month", nil
+	case "custom":
+	default:
+		return time.Time{}, time.Time{}, "", fmt.Errorf("unknown period %q", period)
+	}
+
+	if from == "" {
+		return time.Time{}, time.Time{}, "", errors.New("the custom period needs a `from` date, e.g. `2024-01-31`")
+	}
+	start, err = time.ParseInLocation(gainsDateLayout, from, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, "", fmt.Errorf("`from` must be a date like `2024-01-31`, got %q", from)
+	}
+	last := today
+	if to != "" {
+		if last, err = time.ParseInLocation(gainsDateLayout, to, loc); err != nil {
+			return time.Time{}, time.Time{}, "", fmt.Errorf("`to` must be a date like `2024-01-31`, got %q", to)
+		}
+	}
+	if last.Before(start) {
+		return time.Time{}, time.Time{}, "", errors.New("`from` must not be after `to`")
+	}
+	if !start.Before(now) {
+		return time.Time{}, time.Time{}, "", errors.New("`from` can't be in the future")
+	}
+
+	end = last.AddDate(0, 0, 1)
+	if end.After(now) {
+		end = now
+	}
+	label = start.Format(gainsDateLayout)
+	if !last.Equal(start) {
+		label += " to " + last.Format(gainsDateLayout)
+	}
+	return start, end, label, nil
+}
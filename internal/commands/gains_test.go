@@ -0,0 +1,169 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/testutil"
+	"github.com/kaffeed/voidling/internal/wiseoldman"
+	"github.com/kaffeed/voidling/internal/wiseoldman/womtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// gainsInteraction builds a /gains interaction with the given string options, optionally for another member.
+func gainsInteraction(userID, member string, options map[string]string) *discordgo.InteractionCreate {
+	i := testutil.CreateTestInteraction("gains", userID, "42")
+	data := discordgo.ApplicationCommandInteractionData{Name: "gains"}
+	if member != "" {
+		data.Options = append(data.Options, &discordgo.ApplicationCommandInteractionDataOption{
+			Name: "member", Type: discordgo.ApplicationCommandOptionUser, Value: member,
+		})
+	}
+	for name, value := range options {
+		data.Options = append(data.Options, &discordgo.ApplicationCommandInteractionDataOption{
+			Name: name, Type: discordgo.ApplicationCommandOptionString, Value: value,
+		})
+	}
+	i.Data = data
+	return i
+}
+
+func TestGainsPeriod(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	// Wednesday 2024-05-15 00:30 in Berlin, still Tuesday in UTC
+	now := time.Date(2024, 5, 14, 22, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		period    string
+		from, to  string
+		loc       *time.Location
+		wantStart time.Time
+		wantEnd   time.Time
+		wantLabel string
+		wantErr   bool
+	}{
+		{name: "day in UTC", period: "day", loc: time.UTC, wantStart: time.Date(2024, 5, 14, 0, 0, 0, 0, time.UTC), wantEnd: now, wantLabel: "Today"},
+		{name: "day in Berlin", period: "day", loc: berlin, wantStart: time.Date(2024, 5, 15, 0, 0, 0, 0, berlin), wantEnd: now, wantLabel: "Today"},
+		{name: "week", period: "week", loc: berlin, wantStart: time.Date(2024, 5, 13, 0, 0, 0, 0, berlin), wantEnd: now, wantLabel: "This week"},
+		{name: "month", period: "month", loc: berlin, wantStart: time.Date(2024, 5, 1, 0, 0, 0, 0, berlin), wantEnd: now, wantLabel: "This month"},
+		{
+			name: "custom", period: "custom", from: "2024-03-30", to: "2024-03-31", loc: berlin,
+			wantStart: time.Date(2024, 3, 30, 0, 0, 0, 0, berlin), wantEnd: time.Date(2024, 4, 1, 0, 0, 0, 0, berlin),
+			wantLabel: "2024-03-30 to 2024-03-31",
+		},
+		{
+			name: "custom until now", period: "custom", from: "2024-05-15", loc: berlin,
+			wantStart: time.Date(2024, 5, 15, 0, 0, 0, 0, berlin), wantEnd: now, wantLabel: "2024-05-15",
+		},
+		{name: "custom without from", period: "custom", loc: time.UTC, wantErr: true},
+		{name: "custom in the future", period: "custom", from: "2024-05-15", loc: time.UTC, wantErr: true},
+		{name: "custom reversed", period: "custom", from: "2024-05-02", to: "2024-05-01", loc: time.UTC, wantErr: true},
+		{name: "custom malformed", period: "custom", from: "15/05/2024", loc: time.UTC, wantErr: true},
+		{name: "dates without custom", period: "week", from: "2024-05-01", loc: time.UTC, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, label, err := gainsPeriod(tt.period, tt.from, tt.to, now, tt.loc)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.wantStart.Equal(start), "start %v, want %v", start, tt.wantStart)
+			assert.True(t, tt.wantEnd.Equal(end), "end %v, want %v", end, tt.wantEnd)
+			assert.Equal(t, tt.wantLabel, label)
+		})
+	}
+}
+
+func TestGainsUsesCallerTimezone(t *testing.T) {
+	db, q := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	testutil.CreateTestAccountLink(t, q, 1001, "zezima", true)
+	require.NoError(t, q.UpsertUserTimezone(t.Context(), database.UpsertUserTimezoneParams{DiscordUserID: 2002, Timezone: "Pacific/Auckland"}))
+
+	session := &testutil.MockDiscordSession{}
+	wom := &testutil.MockWOMClient{}
+	session.On("InteractionRespond", mock.Anything, mock.Anything).Return(nil)
+	followups := captureFollowups(session)
+
+	auckland, err := time.LoadLocation("Pacific/Auckland")
+	require.NoError(t, err)
+	wantStart := time.Date(2024, 1, 1, 0, 0, 0, 0, auckland)
+	wantEnd := time.Date(2024, 1, 2, 0, 0, 0, 0, auckland)
+	wom.On("GetPlayerGainsBetween", mock.Anything, "zezima", mock.MatchedBy(wantStart.Equal), mock.MatchedBy(wantEnd.Equal)).
+		Return(&wiseoldman.PlayerGains{}, nil)
+
+	gc := NewGainsCommands(q, db, wom)
+	gc.HandleGains(session, gainsInteraction("2002", "1001", map[string]string{"period": "custom", "from": "2024-01-01", "to": "2024-01-01"}))
+
+	wom.AssertExpectations(t)
+	require.Len(t, *followups, 1)
+	assert.Equal(t, "📈 zezima: 2024-01-01", (*followups)[0].Embeds[0].Title)
+}
+
+func TestGainsErrors(t *testing.T) {
+	db, q := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	testutil.CreateTestAccountLink(t, q, 1001, "zezima", true)
+
+	session := &testutil.MockDiscordSession{}
+	wom := &testutil.MockWOMClient{}
+	session.On("InteractionRespond", mock.Anything, mock.Anything).Return(nil)
+	wom.On("GetPlayerGainsBetween", mock.Anything, "zezima", mock.Anything, mock.Anything).Return(nil, wiseoldman.ErrRateLimited)
+	followups := captureFollowups(session)
+
+	gc := NewGainsCommands(q, db, wom)
+	gc.HandleGains(session, gainsInteraction("1001", "", map[string]string{"period": "custom"}))
+	gc.HandleGains(session, gainsInteraction("1002", "", map[string]string{"period": "week"}))
+	gc.HandleGains(session, gainsInteraction("1001", "", map[string]string{"period": "week"}))
+	gc.HandleGains(session, gainsInteraction("1001", "not-a-snowflake", map[string]string{"period": "week"}))
+
+	text := followupText(*followups)
+	assert.Contains(t, text, "Invalid period: the custom period needs a `from` date")
+	assert.Contains(t, text, "You haven't linked a RuneScape account yet")
+	assert.Contains(t, text, "Wise Old Man is rate limiting requests right now")
+	assert.Contains(t, text, "Invalid user ID.")
+}
+
+func TestGainsAgainstFakeWOM(t *testing.T) {
+	db, q := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	wom := womtest.NewServer()
+	defer wom.Close()
+	from := wom.Now().UTC().AddDate(0, 0, -7).Truncate(24 * time.Hour)
+	wom.SetMetricAt("Zezima", "mining", from.Add(-time.Hour), 1_000_000)
+	wom.SetMetricAt("Zezima", "zulrah", from.Add(-time.Hour), 500)
+	// Dates are sent with second precision, so keep the latest values clear of the end of the window
+	wom.SetMetricAt("Zezima", "mining", wom.Now().Add(-time.Minute), 1_500_000)
+	wom.SetMetricAt("Zezima", "zulrah", wom.Now().Add(-time.Minute), 520)
+
+	testutil.CreateTestAccountLink(t, q, 1001, "zezima", true)
+
+	session := &testutil.MockDiscordSession{}
+	session.On("InteractionRespond", mock.Anything, mock.Anything).Return(nil)
+	followups := captureFollowups(session)
+
+	gc := NewGainsCommands(q, db, wom.Client())
+	gc.HandleGains(session, gainsInteraction("1001", "", map[string]string{"period": "custom", "from": from.Format(gainsDateLayout)}))
+
+	require.Len(t, *followups, 1)
+	require.Len(t, (*followups)[0].Embeds, 1)
+	fields := make(map[string]string)
+	for _, f := range (*followups)[0].Embeds[0].Fields {
+		fields[f.Name] = f.Value
+	}
+	assert.Equal(t, "+500,000", fields["Total XP"])
+	assert.Contains(t, fields["Top Skills"], "**Mining**: +500,000 XP")
+	assert.Equal(t, "**Zulrah**: +20 KC", fields["Top Bosses"])
+}
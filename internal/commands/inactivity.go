@@ -0,0 +1,352 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/embeds"
+	"github.com/kaffeed/voidling/internal/wiseoldman"
+)
+
+const (
+	// DefaultInactivityDays is how many days without XP make a member inactive unless configured.
+	DefaultInactivityDays = 30
+	// maxInactivityDays is the largest inactivity threshold that can be configured.
+	maxInactivityDays = 365
+	// InactivityReportInterval is how often the inactivity report is posted.
+	InactivityReportInterval = 7 * 24 * time.Hour
+	// activityRefreshAge is how old stored Wise Old Man activity can get before it is fetched again.
+	activityRefreshAge = 20 * time.Hour
+	// activityLookupInterval paces WOM lookups while refreshing activity.
+	activityLookupInterval = 2 * time.Second
+)
+
+// InactivityCommands handles /inactive.
+type InactivityCommands struct {
+	DB    *database.Queries
+	DBSQL *sql.DB
+}
+
+// NewInactivityCommands creates a new InactivityCommands instance.
+func NewInactivityCommands(db *database.Queries, dbSQL *sql.DB) *InactivityCommands {
+	return &InactivityCommands{
+		DB:    db,
+		DBSQL: dbSQL,
+	}
+}
+
+// HandleInactive handles /inactive, listing linked members with no XP change in the given number
+// of days (the server's threshold by default).
+func (ic *InactivityCommands) HandleInactive(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	settings, err := loadInactivitySettings(ctx, ic.DB, i.GuildID)
+	if err != nil {
+		log.Printf("Error loading inactivity settings: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to fetch configuration. Please try again."))
+		return
+	}
+
+	days := int(settings.ThresholdDays)
+	for _, opt := range i.ApplicationCommandData().Options {
+		if opt.Name == "days" {
+			days = int(opt.IntValue())
+		}
+	}
+
+	entries, unchecked, err := guildInactiveMembers(ctx, s, ic.DB, i.GuildID, days, time.Now())
+	if err != nil {
+		log.Printf("Error finding inactive members in guild %s: %v", i.GuildID, err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to check member activity. Please try again."))
+		return
+	}
+	sendEphemeralEmbed(s, i, embeds.InactivityReport(entries, days, unchecked))
+}
+
+// RefreshPlayerActivity stores the latest Wise Old Man activity of every actively linked RSN whose
+// stored activity is older than activityRefreshAge. It returns how many players were refreshed.
+func RefreshPlayerActivity(ctx context.Context, db *database.Queries, womClient *wiseoldman.Client, now time.Time) (int, error) {
+	links, err := db.GetActiveAccountLinks(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("fetch account links: %w", err)
+	}
+	activities, err := db.GetPlayerActivities(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("fetch player activity: %w", err)
+	}
+
+	checked := make(map[string]time.Time, len(activities))
+	for name, activity := range activitiesByName(activities) {
+		checked[name] = activity.CheckedAt
+	}
+
+	ticker := time.NewTicker(activityLookupInterval)
+	defer ticker.Stop()
+
+	refreshed := 0
+	for _, link := range links {
+		name := wiseoldman.StandardizeUsername(link.RunescapeName)
+		if at, ok := checked[name]; ok && now.Sub(at) < activityRefreshAge {
+			continue
+		}
+		checked[name] = now // Several members can share an RSN's history; look it up once
+
+		if refreshed > 0 {
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return refreshed, fmt.Errorf("refresh cancelled: %w", ctx.Err())
+			}
+		}
+
+		player, err := womClient.GetPlayer(ctx, link.RunescapeName)
+		if err != nil {
+			if !errors.Is(err, wiseoldman.ErrPlayerNotFound) {
+				log.Printf("Activity refresh: failed to fetch WOM data for %s: %v", link.RunescapeName, err)
+			}
+			continue
+		}
+
+		lastChanged := sql.NullTime{}
+		if player.LastChangedAt != nil {
+			lastChanged = sql.NullTime{Time: *player.LastChangedAt, Valid: true}
+		}
+		err = db.UpsertPlayerActivity(ctx, database.UpsertPlayerActivityParams{
+			RunescapeName: name,
+			LastChangedAt: lastChanged,
+			WomUpdatedAt:  player.UpdatedAt,
+			CheckedAt:     now,
+		})
+		if err != nil {
+			return refreshed, fmt.Errorf("save activity for %s: %w", link.RunescapeName, err)
+		}
+		refreshed++
+	}
+
+	return refreshed, nil
+}
+
+// SendInactivityReport posts the guild's inactivity report if one is due and, when enabled, DMs
+// newly inactive members. It does nothing for guilds without a report channel.
+func SendInactivityReport(ctx context.Context, s *discordgo.Session, db *database.Queries, guildID string, now time.Time) error {
+	settings, err := loadInactivitySettings(ctx, db, guildID)
+	if err != nil {
+		return fmt.Errorf("load settings: %w", err)
+	}
+	if !settings.ReportChannelID.Valid {
+		return nil
+	}
+	if settings.LastReportAt.Valid && now.Sub(settings.LastReportAt.Time) < InactivityReportInterval {
+		return nil
+	}
+
+	days := int(settings.ThresholdDays)
+	entries, unchecked, err := guildInactiveMembers(ctx, s, db, guildID, days, now)
+	if err != nil {
+		return fmt.Errorf("find inactive members: %w", err)
+	}
+
+	channelID := strconv.FormatInt(settings.ReportChannelID.Int64, 10)
+	_, err = s.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+		Embeds:          []*discordgo.MessageEmbed{embeds.InactivityReport(entries, days, unchecked)},
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	})
+	if err != nil {
+		return fmt.Errorf("post report to channel %s: %w", channelID, err)
+	}
+
+	err = db.SetInactivityReportSent(ctx, database.SetInactivityReportSentParams{
+		LastReportAt: sql.NullTime{Time: now, Valid: true},
+		GuildID:      settings.GuildID,
+	})
+	if err != nil {
+		return fmt.Errorf("save report time: %w", err)
+	}
+
+	if settings.NudgeEnabled {
+		sendInactivityNudges(ctx, s, db, settings.GuildID, entries, days, now)
+	}
+	return nil
+}
+
+// sendInactivityNudges DMs inactive members who haven't been nudged since they were last active.
+// Members whose Wise Old Man data is stale are skipped, as they may well be playing.
+func sendInactivityNudges(ctx context.Context, s *discordgo.Session, db *database.Queries, guildID int64, entries []embeds.InactiveEntry, days int, now time.Time) {
+	nudges, err := db.GetInactivityNudges(ctx, guildID)
+	if err != nil {
+		log.Printf("Error fetching inactivity nudges for guild %d: %v", guildID, err)
+		return
+	}
+	nudged := make(map[int64]time.Time, len(nudges))
+	for _, nudge := range nudges {
+		nudged[nudge.DiscordMemberID] = nudge.NudgedAt
+	}
+
+	guildName := "the clan"
+	if guild, err := s.State.Guild(strconv.FormatInt(guildID, 10)); err == nil {
+		guildName = guild.Name
+	}
+
+	for _, entry := range entries {
+		userID, err := strconv.ParseInt(entry.UserID, 10, 64)
+		if err != nil || entry.Stale {
+			continue
+		}
+		if at, ok := nudged[userID]; ok && at.After(entry.LastActive) {
+			continue
+		}
+
+		channel, err := s.UserChannelCreate(entry.UserID)
+		if err == nil {
+			_, err = s.ChannelMessageSendEmbed(channel.ID, embeds.InactivityNudge(guildName, days))
+		}
+		if err != nil {
+			// Members with DMs closed are still recorded so they aren't retried every report
+			log.Printf("Error sending inactivity nudge to %s: %v", entry.UserID, err)
+		}
+
+		err = db.SetInactivityNudge(ctx, database.SetInactivityNudgeParams{
+			GuildID:         guildID,
+			DiscordMemberID: userID,
+			NudgedAt:        now,
+		})
+		if err != nil {
+			log.Printf("Error saving inactivity nudge for %s: %v", entry.UserID, err)
+		}
+	}
+}
+
+// guildInactiveMembers finds the guild's linked members with no XP change in the last days days,
+// using the activity stored by RefreshPlayerActivity.
+func guildInactiveMembers(ctx context.Context, s *discordgo.Session, db *database.Queries, guildID string, days int, now time.Time) ([]embeds.InactiveEntry, int, error) {
+	links, err := db.GetActiveAccountLinks(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("fetch account links: %w", err)
+	}
+	activities, err := db.GetPlayerActivities(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("fetch player activity: %w", err)
+	}
+	members, err := guildMemberIDs(s, guildID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("fetch members: %w", err)
+	}
+
+	links = slices.DeleteFunc(links, func(link database.AccountLink) bool {
+		return !members[strconv.FormatInt(link.DiscordMemberID, 10)]
+	})
+	entries, unchecked := findInactiveMembers(links, activities, time.Duration(days)*24*time.Hour, now)
+	return entries, unchecked, nil
+}
+
+// activitiesByName indexes stored activity by standardized RSN, so "Lynx_Titan" and "lynx titan"
+// share one entry. Rows stored under older spellings of the same name keep the latest check.
+func activitiesByName(activities []database.PlayerActivity) map[string]database.PlayerActivity {
+	byName := make(map[string]database.PlayerActivity, len(activities))
+	for _, activity := range activities {
+		name := wiseoldman.StandardizeUsername(activity.RunescapeName)
+		if existing, ok := byName[name]; ok && existing.CheckedAt.After(activity.CheckedAt) {
+			continue
+		}
+		byName[name] = activity
+	}
+	return byName
+}
+
+// findInactiveMembers returns the linked members whose last XP change is older than threshold,
+// longest inactive first, and how many links have no stored activity. Players that never changed
+// on Wise Old Man count as inactive since they were last updated there.
+func findInactiveMembers(links []database.AccountLink, activities []database.PlayerActivity, threshold time.Duration, now time.Time) ([]embeds.InactiveEntry, int) {
+	byName := activitiesByName(activities)
+
+	var entries []embeds.InactiveEntry
+	unchecked := 0
+	for _, link := range links {
+		activity, ok := byName[wiseoldman.StandardizeUsername(link.RunescapeName)]
+		if !ok {
+			unchecked++
+			continue
+		}
+
+		lastActive := activity.WomUpdatedAt
+		if activity.LastChangedAt.Valid {
+			lastActive = activity.LastChangedAt.Time
+		}
+		if now.Sub(lastActive) < threshold {
+			continue
+		}
+
+		entries = append(entries, embeds.InactiveEntry{
+			UserID:       strconv.FormatInt(link.DiscordMemberID, 10),
+			RSN:          link.RunescapeName,
+			LastActive:   lastActive,
+			WOMUpdatedAt: activity.WomUpdatedAt,
+			Stale:        now.Sub(activity.WomUpdatedAt) >= threshold,
+		})
+	}
+
+	slices.SortStableFunc(entries, func(a, b embeds.InactiveEntry) int {
+		return a.LastActive.Compare(b.LastActive)
+	})
+	return entries, unchecked
+}
+
+// guildMemberIDs returns the IDs of everyone in the guild.
+func guildMemberIDs(s *discordgo.Session, guildID string) (map[string]bool, error) {
+	members, err := guildMembers(s, guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]bool, len(members))
+	for _, member := range members {
+		ids[member.User.ID] = true
+	}
+	return ids, nil
+}
+
+// guildMembers returns everyone in the guild, fetching as many pages as needed.
+func guildMembers(s *discordgo.Session, guildID string) ([]*discordgo.Member, error) {
+	const pageSize = 1000
+
+	var all []*discordgo.Member
+	after := ""
+	for {
+		members, err := s.GuildMembers(guildID, after, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, members...)
+		if len(members) < pageSize {
+			return all, nil
+		}
+		after = members[len(members)-1].User.ID
+	}
+}
+
+// loadInactivitySettings returns the guild's inactivity settings, or the defaults if it has none.
+func loadInactivitySettings(ctx context.Context, db *database.Queries, guildID string) (database.GuildInactivitySetting, error) {
+	id, err := strconv.ParseInt(guildID, 10, 64)
+	if err != nil {
+		return database.GuildInactivitySetting{}, fmt.Errorf("parse guild ID: %w", err)
+	}
+
+	settings, err := db.GetInactivitySettings(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return database.GuildInactivitySetting{GuildID: id, ThresholdDays: DefaultInactivityDays}, nil
+	}
+	return settings, err
+}
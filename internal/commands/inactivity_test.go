@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/testutil"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindInactiveMembers(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+
+	links := []database.AccountLink{
+		{DiscordMemberID: 1, RunescapeName: "Active"},
+		{DiscordMemberID: 2, RunescapeName: "Quit Long Ago"},
+		{DiscordMemberID: 3, RunescapeName: "Recently Quit"},
+		{DiscordMemberID: 4, RunescapeName: "Never Changed"},
+		{DiscordMemberID: 5, RunescapeName: "Not Updated"},
+		{DiscordMemberID: 6, RunescapeName: "Unchecked"},
+		{DiscordMemberID: 7, RunescapeName: "Lynx_Titan"},
+	}
+	activities := []database.PlayerActivity{
+		{RunescapeName: "active", LastChangedAt: sql.NullTime{Time: daysAgo(2), Valid: true}, WomUpdatedAt: daysAgo(1)},
+		{RunescapeName: "quit long ago", LastChangedAt: sql.NullTime{Time: daysAgo(90), Valid: true}, WomUpdatedAt: daysAgo(1)},
+		{RunescapeName: "recently quit", LastChangedAt: sql.NullTime{Time: daysAgo(40), Valid: true}, WomUpdatedAt: daysAgo(1)},
+		{RunescapeName: "never changed", WomUpdatedAt: daysAgo(35)},
+		{RunescapeName: "not updated", LastChangedAt: sql.NullTime{Time: daysAgo(60), Valid: true}, WomUpdatedAt: daysAgo(60)},
+		// Names are matched the way WOM standardizes them; the latest check wins over older spellings
+		{RunescapeName: "lynx_titan", LastChangedAt: sql.NullTime{Time: daysAgo(90), Valid: true}, WomUpdatedAt: daysAgo(90), CheckedAt: daysAgo(90)},
+		{RunescapeName: "lynx titan", LastChangedAt: sql.NullTime{Time: daysAgo(1), Valid: true}, WomUpdatedAt: daysAgo(1), CheckedAt: daysAgo(1)},
+	}
+
+	entries, unchecked := findInactiveMembers(links, activities, 30*24*time.Hour, now)
+
+	assert.Equal(t, 1, unchecked)
+	require.Len(t, entries, 4)
+
+	var rsns []string
+	for _, entry := range entries {
+		rsns = append(rsns, entry.RSN)
+	}
+	assert.Equal(t, []string{"Quit Long Ago", "Not Updated", "Recently Quit", "Never Changed"}, rsns, "longest inactive first")
+
+	assert.Equal(t, "2", entries[0].UserID)
+	assert.False(t, entries[0].Stale)
+	assert.True(t, entries[1].Stale, "WOM hasn't updated the player within the threshold")
+	assert.True(t, entries[3].Stale)
+	assert.Equal(t, daysAgo(35), entries[3].LastActive, "players without XP changes count from their last WOM update")
+}
+
+func TestLoadInactivitySettings(t *testing.T) {
+	db, q := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	ctx := t.Context()
+
+	settings, err := loadInactivitySettings(ctx, q, "42")
+	require.NoError(t, err)
+	assert.Equal(t, int64(DefaultInactivityDays), settings.ThresholdDays)
+	assert.False(t, settings.ReportChannelID.Valid)
+	assert.False(t, settings.NudgeEnabled)
+
+	require.NoError(t, q.UpsertInactivitySettings(ctx, database.UpsertInactivitySettingsParams{
+		GuildID:         42,
+		ReportChannelID: sql.NullInt64{Int64: 500, Valid: true},
+		ThresholdDays:   14,
+		NudgeEnabled:    true,
+		UpdatedBy:       7,
+	}))
+	sent := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, q.SetInactivityReportSent(ctx, database.SetInactivityReportSentParams{
+		LastReportAt: sql.NullTime{Time: sent, Valid: true},
+		GuildID:      42,
+	}))
+
+	settings, err = loadInactivitySettings(ctx, q, "42")
+	require.NoError(t, err)
+	assert.Equal(t, int64(14), settings.ThresholdDays)
+	assert.True(t, settings.NudgeEnabled)
+	assert.True(t, settings.LastReportAt.Time.Equal(sent))
+}
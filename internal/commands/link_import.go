@@ -0,0 +1,634 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/audit"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/embeds"
+)
+
+const (
+	// maxImportRows caps a single import so WOM validation finishes before the interaction token expires.
+	maxImportRows = 250
+	// maxImportFileSize is the largest CSV attachment accepted for import.
+	maxImportFileSize = 1 << 20
+	// importLookupInterval paces WOM lookups to stay under the anonymous rate limit.
+	importLookupInterval = 2 * time.Second
+	// importProgressEvery controls how often the progress message is refreshed.
+	importProgressEvery = 10
+	// pendingImportTTL is how long a validated import waits for confirmation.
+	pendingImportTTL = 15 * time.Minute
+	// maxReportProblems limits how many invalid rows are listed in the dry-run report.
+	maxReportProblems = 15
+)
+
+var (
+	// ErrEmptyImport is returned when an import file contains no usable rows.
+	ErrEmptyImport = errors.New("import file contains no rows")
+
+	// ErrTooManyImportRows is returned when an import file exceeds maxImportRows.
+	ErrTooManyImportRows = errors.New("import file has too many rows")
+
+	// ErrAttachmentTooLarge is returned when a downloaded attachment exceeds maxImportFileSize.
+	ErrAttachmentTooLarge = errors.New("attachment too large")
+
+	// ErrUnexpectedDownloadStatus is returned when Discord's CDN responds with a non-200 status.
+	ErrUnexpectedDownloadStatus = errors.New("unexpected download status")
+)
+
+// linkImportRow is a single Discord ID ↔ RSN pair read from an import file.
+type linkImportRow struct {
+	Line      int
+	DiscordID int64
+	RSN       string
+}
+
+// pendingLinkImport holds a validated import waiting for confirmation.
+type pendingLinkImport struct {
+	requesterID string
+	rows        []linkImportRow
+	expiresAt   time.Time
+}
+
+// linkExportRow is the JSON representation of an exported account link.
+type linkExportRow struct {
+	DiscordID string    `json:"discord_id"`
+	RSN       string    `json:"rsn"`
+	LinkedAt  time.Time `json:"linked_at"`
+}
+
+// importHTTPClient downloads import attachments from Discord's CDN.
+var importHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// parseLinkImportCSV reads "discord_id,rsn" rows from r. A header row is skipped if present.
+// Malformed rows are returned as human-readable problems rather than failing the whole import.
+func parseLinkImportCSV(r io.Reader) ([]linkImportRow, []string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("read csv: %w", err)
+	}
+
+	rows := make([]linkImportRow, 0, len(records))
+	problems := []string{}
+	seenMembers := make(map[int64]int)
+	seenRSNs := make(map[string]int)
+
+	for idx, record := range records {
+		line := idx + 1
+		if len(record) == 0 || (len(record) == 1 && strings.TrimSpace(record[0]) == "") {
+			continue
+		}
+
+		if len(record) < 2 {
+			problems = append(problems, fmt.Sprintf("line %d: expected `discord_id,rsn`", line))
+			continue
+		}
+
+		idStr := strings.Trim(strings.TrimSpace(record[0]), "<@!>")
+		rsn := strings.TrimSpace(record[1])
+
+		discordID, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			if idx == 0 {
+				continue // Header row
+			}
+			problems = append(problems, fmt.Sprintf("line %d: invalid Discord ID `%s`", line, idStr))
+			continue
+		}
+
+		if rsn == "" || len(rsn) > 12 {
+			problems = append(problems, fmt.Sprintf("line %d: invalid RSN `%s`", line, rsn))
+			continue
+		}
+
+		if prev, ok := seenMembers[discordID]; ok {
+			problems = append(problems, fmt.Sprintf("line %d: member %d already listed on line %d", line, discordID, prev))
+			continue
+		}
+		if prev, ok := seenRSNs[strings.ToLower(rsn)]; ok {
+			problems = append(problems, fmt.Sprintf("line %d: RSN `%s` already listed on line %d", line, rsn, prev))
+			continue
+		}
+
+		seenMembers[discordID] = line
+		seenRSNs[strings.ToLower(rsn)] = line
+		rows = append(rows, linkImportRow{Line: line, DiscordID: discordID, RSN: rsn})
+	}
+
+	if len(rows) == 0 && len(problems) == 0 {
+		return nil, nil, ErrEmptyImport
+	}
+	if len(rows) > maxImportRows {
+		return nil, nil, fmt.Errorf("%w: %d rows (max %d)", ErrTooManyImportRows, len(rows), maxImportRows)
+	}
+
+	return rows, problems, nil
+}
+
+// HandleImportLinks handles /admin import-links: validates a CSV attachment and shows a dry-run report.
+func (a *AdminCommands) HandleImportLinks(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	fileOpt := subcommandOption(i, "file")
+	if fileOpt == nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Missing file parameter. Please attach a CSV file."))
+		return
+	}
+
+	attachmentID, _ := fileOpt.Value.(string)
+	resolved := i.ApplicationCommandData().Resolved
+	if resolved == nil || resolved.Attachments[attachmentID] == nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Couldn't read the attached file. Please try again."))
+		return
+	}
+	attachment := resolved.Attachments[attachmentID]
+
+	if attachment.Size > maxImportFileSize {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("The file is too large. Please split it into smaller files."))
+		return
+	}
+
+	data, err := downloadAttachment(ctx, attachment.URL)
+	if err != nil {
+		log.Printf("Error downloading import file: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to download the attached file. Please try again."))
+		return
+	}
+
+	rows, problems, err := parseLinkImportCSV(bytes.NewReader(data))
+	if err != nil {
+		log.Printf("Error parsing import file: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(fmt.Sprintf("Couldn't parse the file: %v\n\nExpected a CSV with `discord_id,rsn` per line.", err)))
+		return
+	}
+
+	valid, unchanged, replacements, rowProblems := a.validateLinkImport(ctx, s, i, rows)
+	problems = append(problems, rowProblems...)
+
+	token := i.ID
+	a.storePendingImport(token, &pendingLinkImport{
+		requesterID: i.Member.User.ID,
+		rows:        valid,
+		expiresAt:   time.Now().Add(pendingImportTTL),
+	})
+
+	report := buildImportReport(len(valid), unchanged, replacements, problems)
+
+	var components []discordgo.MessageComponent
+	if len(valid) > 0 {
+		components = []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						Label:    fmt.Sprintf("Import %d links", len(valid)),
+						Style:    discordgo.SuccessButton,
+						CustomID: fmt.Sprintf("confirm-link-import:%s", token),
+					},
+					discordgo.Button{
+						Label:    "Cancel",
+						Style:    discordgo.SecondaryButton,
+						CustomID: fmt.Sprintf("cancel-link-import:%s", token),
+					},
+				},
+			},
+		}
+	}
+
+	empty := ""
+	_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Content:    &empty,
+		Embeds:     &[]*discordgo.MessageEmbed{embeds.InfoEmbed("📥 Link Import — Dry Run", report)},
+		Components: &components,
+	})
+	if err != nil {
+		log.Printf("Error sending import report: %v", err)
+	}
+}
+
+// validateLinkImport checks each row against the database and Wise Old Man.
+// It returns the rows that would change something, the number of rows already in place, the rows
+// that would replace a member's current RSN, and problems found.
+func (a *AdminCommands) validateLinkImport(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, rows []linkImportRow) ([]linkImportRow, int, []string, []string) {
+	valid := make([]linkImportRow, 0, len(rows))
+	unchanged := 0
+	replacements := []string{}
+	problems := []string{}
+
+	ticker := time.NewTicker(importLookupInterval)
+	defer ticker.Stop()
+
+	for idx, row := range rows {
+		if idx > 0 && idx%importProgressEvery == 0 {
+			progress := fmt.Sprintf("Validating against Wise Old Man... %d/%d", idx, len(rows))
+			_, _ = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Content: &progress})
+		}
+
+		// Imports may only link members of this server, or one guild could relink another's members
+		if _, err := s.GuildMember(i.GuildID, strconv.FormatInt(row.DiscordID, 10)); err != nil {
+			if isDiscordErrorCode(err, discordgo.ErrCodeUnknownMember) {
+				problems = append(problems, fmt.Sprintf("line %d: <@%d> is not a member of this server", row.Line, row.DiscordID))
+			} else {
+				log.Printf("Import: failed to fetch member %d: %v", row.DiscordID, err)
+				problems = append(problems, fmt.Sprintf("line %d: couldn't check whether <@%d> is a member of this server", row.Line, row.DiscordID))
+			}
+			continue
+		}
+
+		existing, err := a.DB.GetAccountLinksByUsername(ctx, row.RSN)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("line %d: database error", row.Line))
+			continue
+		}
+
+		conflict := false
+		alreadyLinked := false
+		for _, link := range existing {
+			if !link.IsActive {
+				continue
+			}
+			if link.DiscordMemberID == row.DiscordID {
+				alreadyLinked = true
+			} else {
+				conflict = true
+				problems = append(problems, fmt.Sprintf("line %d: `%s` is already linked to <@%d>", row.Line, row.RSN, link.DiscordMemberID))
+			}
+		}
+		if conflict {
+			continue
+		}
+		if alreadyLinked {
+			unchanged++
+			continue
+		}
+
+		if idx > 0 {
+			<-ticker.C
+		}
+
+		player, err := a.WOMClient.GetPlayer(ctx, row.RSN)
+		if err != nil {
+			log.Printf("Import: failed to fetch player %s: %v", row.RSN, err)
+			problems = append(problems, fmt.Sprintf("line %d: `%s` not found on Wise Old Man", row.Line, row.RSN))
+			continue
+		}
+
+		row.RSN = player.DisplayName
+		valid = append(valid, row)
+
+		// Linking deactivates the member's current RSN, so call that out before anything is written
+		current, err := a.DB.GetAccountLinkByDiscordID(ctx, row.DiscordID)
+		if err == nil && !strings.EqualFold(current.RunescapeName, row.RSN) {
+			replacements = append(replacements, fmt.Sprintf("line %d: <@%d> `%s` will replace `%s`", row.Line, row.DiscordID, row.RSN, current.RunescapeName))
+		}
+	}
+
+	return valid, unchanged, replacements, problems
+}
+
+// buildImportReport renders the dry-run summary shown before confirming an import.
+func buildImportReport(valid, unchanged int, replacements, problems []string) string {
+	var report strings.Builder
+	report.WriteString(fmt.Sprintf("**To import:** %d\n", valid))
+	report.WriteString(fmt.Sprintf("**Already linked:** %d\n", unchanged))
+	report.WriteString(fmt.Sprintf("**Replacing an RSN:** %d\n", len(replacements)))
+	report.WriteString(fmt.Sprintf("**Skipped:** %d\n", len(problems)))
+
+	writeReportList(&report, "Replaced RSNs", replacements)
+	writeReportList(&report, "Problems", problems)
+
+	if valid > 0 {
+		report.WriteString("\nNothing has been written yet. Click **Import** to apply these links.")
+	} else {
+		report.WriteString("\nThere is nothing to import.")
+	}
+
+	return report.String()
+}
+
+// writeReportList appends a titled list of report lines, listing at most maxReportProblems of them.
+func writeReportList(report *strings.Builder, title string, lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+
+	report.WriteString("\n**" + title + ":**\n")
+	for idx, line := range lines {
+		if idx >= maxReportProblems {
+			report.WriteString(fmt.Sprintf("...and %d more\n", len(lines)-maxReportProblems))
+			break
+		}
+		report.WriteString("• " + line + "\n")
+	}
+}
+
+// HandleConfirmLinkImport writes a validated import in a single transaction.
+func (a *AdminCommands) HandleConfirmLinkImport(s *discordgo.Session, i *discordgo.InteractionCreate, token string) {
+	ctx := context.Background()
+
+	pending := a.takePendingImport(token, i.Member.User.ID)
+	if pending == nil {
+		respondToInteraction(s, i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: &discordgo.InteractionResponseData{
+				Embeds:     []*discordgo.MessageEmbed{embeds.ErrorEmbed("This import has expired or was already handled. Please run `/admin import-links` again.")},
+				Components: []discordgo.MessageComponent{},
+			},
+		})
+		return
+	}
+
+	// Acknowledge the click and remove the buttons while we work
+	working := fmt.Sprintf("Importing %d links...", len(pending.rows))
+	err := respondToInteraction(s, i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    working,
+			Embeds:     []*discordgo.MessageEmbed{},
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+	if err != nil {
+		log.Printf("Error acknowledging import confirmation: %v", err)
+		return
+	}
+
+	imported, skipped, err := a.applyLinkImport(ctx, s, i.GuildID, pending.rows)
+	if err != nil {
+		log.Printf("Error importing account links: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Import failed and no links were changed. Please try again."))
+		return
+	}
+
+	log.Printf("User %s imported %d account links", i.Member.User.Username, imported)
+
+	a.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionLinkImport,
+		After:   fmt.Sprintf("%d link(s) imported", imported),
+	})
+
+	var msg strings.Builder
+	msg.WriteString(fmt.Sprintf("Imported **%d** account links.\n", imported))
+	if len(skipped) > 0 {
+		msg.WriteString(fmt.Sprintf("Skipped **%d** rows that changed since the dry run.\n", len(skipped)))
+		writeReportList(&msg, "Skipped", skipped)
+	}
+	msg.WriteString("\nRun `/config resync-nicknames` to apply the server nickname template to the imported members.")
+
+	sendEphemeralEmbed(s, i, embeds.SuccessEmbed(msg.String()))
+}
+
+// HandleCancelLinkImport discards a pending import.
+func (a *AdminCommands) HandleCancelLinkImport(s *discordgo.Session, i *discordgo.InteractionCreate, token string) {
+	a.takePendingImport(token, i.Member.User.ID)
+
+	respondToInteraction(s, i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    "Import cancelled. No links were changed.",
+			Embeds:     []*discordgo.MessageEmbed{},
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+}
+
+// applyLinkImport links every row inside one transaction; any failure rolls back the whole import.
+// The dry run may be minutes old, so membership and RSN ownership are checked again and rows that
+// no longer pass are skipped and returned as problems.
+func (a *AdminCommands) applyLinkImport(ctx context.Context, s MemberManager, guildID string, rows []linkImportRow) (int, []string, error) {
+	tx, err := a.DBSQL.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // Rollback is safe to call even after commit
+
+	qtx := a.DB.WithTx(tx)
+	imported := 0
+	skipped := []string{}
+	for _, row := range rows {
+		if _, err := s.GuildMember(guildID, strconv.FormatInt(row.DiscordID, 10)); err != nil {
+			if !isDiscordErrorCode(err, discordgo.ErrCodeUnknownMember) {
+				return 0, nil, fmt.Errorf("line %d: fetch member: %w", row.Line, err)
+			}
+			skipped = append(skipped, fmt.Sprintf("line %d: <@%d> is not a member of this server", row.Line, row.DiscordID))
+			continue
+		}
+
+		var linked *RSNLinkedError
+		err := linkAvailableAccountTx(ctx, qtx, row.DiscordID, row.RSN)
+		if errors.As(err, &linked) {
+			skipped = append(skipped, fmt.Sprintf("line %d: `%s` is already linked to <@%d>", row.Line, row.RSN, linked.DiscordMemberID))
+			continue
+		}
+		if errors.Is(err, ErrAccountAlreadyLinked) {
+			continue
+		}
+		if err != nil {
+			return 0, nil, fmt.Errorf("line %d: %w", row.Line, err)
+		}
+		imported++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return imported, skipped, nil
+}
+
+// storePendingImport remembers a validated import and drops any that have expired.
+func (a *AdminCommands) storePendingImport(token string, pending *pendingLinkImport) {
+	a.importsMu.Lock()
+	defer a.importsMu.Unlock()
+
+	now := time.Now()
+	for key, p := range a.pendingImports {
+		if now.After(p.expiresAt) {
+			delete(a.pendingImports, key)
+		}
+	}
+	a.pendingImports[token] = pending
+}
+
+// takePendingImport removes and returns a pending import if it belongs to requesterID and hasn't expired.
+func (a *AdminCommands) takePendingImport(token, requesterID string) *pendingLinkImport {
+	a.importsMu.Lock()
+	defer a.importsMu.Unlock()
+
+	pending, ok := a.pendingImports[token]
+	if !ok || pending.requesterID != requesterID {
+		return nil
+	}
+	delete(a.pendingImports, token)
+
+	if time.Now().After(pending.expiresAt) {
+		return nil
+	}
+	return pending
+}
+
+// HandleExportLinks handles /admin export-links, returning the active links of the server's members as CSV or JSON.
+func (a *AdminCommands) HandleExportLinks(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	format := "csv"
+	if opt := subcommandOption(i, "format"); opt != nil {
+		format = opt.StringValue()
+	}
+
+	links, err := a.DB.GetActiveAccountLinks(ctx)
+	if err != nil {
+		log.Printf("Error fetching account links for export: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Database error. Please try again later."))
+		return
+	}
+
+	// Links are global, so only export this server's members
+	memberIDs, err := guildMemberIDs(s, i.GuildID)
+	if err != nil {
+		log.Printf("Error fetching guild members for export: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to fetch the server's members. Please try again later."))
+		return
+	}
+	links = guildLinks(links, memberIDs)
+
+	var (
+		data        []byte
+		contentType string
+	)
+	switch format {
+	case "json":
+		data, err = exportLinksJSON(links)
+		contentType = "application/json"
+	default:
+		format = "csv"
+		data, err = exportLinksCSV(links)
+		contentType = "text/csv"
+	}
+	if err != nil {
+		log.Printf("Error encoding account link export: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to build the export file."))
+		return
+	}
+
+	sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
+		Content: fmt.Sprintf("Exported **%d** active account links.", len(links)),
+		Files: []*discordgo.File{
+			{
+				Name:        fmt.Sprintf("account-links-%s.%s", time.Now().UTC().Format("2006-01-02"), format),
+				ContentType: contentType,
+				Reader:      bytes.NewReader(data),
+			},
+		},
+		Flags: discordgo.MessageFlagsEphemeral,
+	})
+}
+
+// guildLinks returns the links that belong to the members in memberIDs.
+func guildLinks(links []database.AccountLink, memberIDs map[string]bool) []database.AccountLink {
+	filtered := make([]database.AccountLink, 0, len(links))
+	for _, link := range links {
+		if memberIDs[strconv.FormatInt(link.DiscordMemberID, 10)] {
+			filtered = append(filtered, link)
+		}
+	}
+	return filtered
+}
+
+// exportLinksCSV encodes links in the same format accepted by /admin import-links.
+func exportLinksCSV(links []database.AccountLink) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write([]string{"discord_id", "rsn", "linked_at"}); err != nil {
+		return nil, fmt.Errorf("write header: %w", err)
+	}
+	for _, link := range links {
+		record := []string{
+			strconv.FormatInt(link.DiscordMemberID, 10),
+			link.RunescapeName,
+			link.CreatedAt.UTC().Format(time.RFC3339),
+		}
+		if err := w.Write(record); err != nil {
+			return nil, fmt.Errorf("write row: %w", err)
+		}
+	}
+	w.Flush()
+
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("flush csv: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// exportLinksJSON encodes links as a JSON array. Discord IDs are strings to avoid precision loss.
+func exportLinksJSON(links []database.AccountLink) ([]byte, error) {
+	rows := make([]linkExportRow, 0, len(links))
+	for _, link := range links {
+		rows = append(rows, linkExportRow{
+			DiscordID: strconv.FormatInt(link.DiscordMemberID, 10),
+			RSN:       link.RunescapeName,
+			LinkedAt:  link.CreatedAt.UTC(),
+		})
+	}
+
+	data, err := json.MarshalIndent(rows, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal json: %w", err)
+	}
+	return data, nil
+}
+
+// downloadAttachment fetches an attachment body, refusing anything larger than maxImportFileSize.
+func downloadAttachment(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	resp, err := importHTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("http request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: status %d", ErrUnexpectedDownloadStatus, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxImportFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read body: %w", err)
+	}
+	if len(data) > maxImportFileSize {
+		return nil, ErrAttachmentTooLarge
+	}
+	return data, nil
+}
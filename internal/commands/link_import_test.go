@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLinkImportCSV(t *testing.T) {
+	t.Run("header and valid rows", func(t *testing.T) {
+		input := "discord_id,rsn\n123456789,Zezima\n<@987654321>, Lynx Titan \n"
+
+		rows, problems, err := parseLinkImportCSV(strings.NewReader(input))
+
+		require.NoError(t, err)
+		assert.Empty(t, problems)
+		require.Len(t, rows, 2)
+		assert.Equal(t, int64(123456789), rows[0].DiscordID)
+		assert.Equal(t, "Zezima", rows[0].RSN)
+		assert.Equal(t, int64(987654321), rows[1].DiscordID)
+		assert.Equal(t, "Lynx Titan", rows[1].RSN)
+	})
+
+	t.Run("invalid and duplicate rows are reported", func(t *testing.T) {
+		input := "1,Zezima\nabc,Foo\n2,ThisNameIsTooLong\n1,Other\n3,zezima\n4\n"
+
+		rows, problems, err := parseLinkImportCSV(strings.NewReader(input))
+
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Equal(t, "Zezima", rows[0].RSN)
+		assert.Len(t, problems, 5)
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		_, _, err := parseLinkImportCSV(strings.NewReader("\n"))
+
+		assert.ErrorIs(t, err, ErrEmptyImport)
+	})
+}
+
+func TestGuildLinks(t *testing.T) {
+	links := []database.AccountLink{
+		{DiscordMemberID: 1001, RunescapeName: "Zezima"},
+		{DiscordMemberID: 1002, RunescapeName: "Lynx Titan"},
+		{DiscordMemberID: 1003, RunescapeName: "Woox"},
+	}
+
+	filtered := guildLinks(links, map[string]bool{"1001": true, "1003": true, "9999": true})
+
+	require.Len(t, filtered, 2)
+	assert.Equal(t, "Zezima", filtered[0].RunescapeName)
+	assert.Equal(t, "Woox", filtered[1].RunescapeName)
+	assert.Empty(t, guildLinks(links, map[string]bool{}))
+}
+
+func TestLinkAvailableAccount(t *testing.T) {
+	db, q := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	testutil.CreateTestAccountLink(t, q, 1001, "zezima", true)
+
+	var linked *RSNLinkedError
+	err := linkAvailableAccount(t.Context(), q, db, 1002, "Zezima")
+	require.ErrorAs(t, err, &linked)
+	assert.Equal(t, int64(1001), linked.DiscordMemberID)
+
+	assert.ErrorIs(t, linkAvailableAccount(t.Context(), q, db, 1001, "zezima"), ErrAccountAlreadyLinked)
+	require.NoError(t, linkAvailableAccount(t.Context(), q, db, 1002, "woox"))
+}
+
+func TestApplyLinkImportRechecksRows(t *testing.T) {
+	db, q := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	// Linked to someone else after the dry run
+	testutil.CreateTestAccountLink(t, q, 1003, "woox", true)
+
+	session := &testutil.MockDiscordSession{}
+	session.On("GuildMember", "42", "1001").Return(&discordgo.Member{}, nil)
+	session.On("GuildMember", "42", "1002").Return(nil, &discordgo.RESTError{Message: &discordgo.APIErrorMessage{Code: discordgo.ErrCodeUnknownMember}})
+	session.On("GuildMember", "42", "1004").Return(&discordgo.Member{}, nil)
+
+	a := NewAdminCommands(q, db, nil, nil)
+	imported, skipped, err := a.applyLinkImport(t.Context(), session, "42", []linkImportRow{
+		{Line: 1, DiscordID: 1001, RSN: "zezima"},
+		{Line: 2, DiscordID: 1002, RSN: "lynx titan"},
+		{Line: 3, DiscordID: 1004, RSN: "woox"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, imported)
+	require.Len(t, skipped, 2)
+	assert.Contains(t, skipped[0], "not a member of this server")
+	assert.Contains(t, skipped[1], "already linked to <@1003>")
+
+	link, err := q.GetAccountLinkByDiscordID(t.Context(), 1001)
+	require.NoError(t, err)
+	assert.Equal(t, "zezima", link.RunescapeName)
+}
+
+func TestBuildImportReport(t *testing.T) {
+	report := buildImportReport(2, 1, []string{"line 1: <@1001> `Zezima` will replace `Old Name`"}, []string{"line 3: invalid RSN"})
+
+	assert.Contains(t, report, "**Replacing an RSN:** 1")
+	assert.Contains(t, report, "will replace `Old Name`")
+	assert.Contains(t, report, "**Problems:**\n• line 3: invalid RSN")
+	assert.Contains(t, report, "Click **Import**")
+}
@@ -0,0 +1,338 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/wiseoldman"
+)
+
+const (
+	// DefaultNicknameTemplate is used when a guild hasn't configured its own template.
+	DefaultNicknameTemplate = "{rsn}"
+	// maxNicknameLength is Discord's limit for guild nicknames.
+	maxNicknameLength = 32
+	// nicknameResyncInterval paces WOM lookups during a full resync.
+	nicknameResyncInterval = 2 * time.Second
+)
+
+var (
+	// ErrNicknameHierarchy is returned when the bot isn't allowed to change a member's nickname,
+	// usually because the member's highest role is above the bot's or they own the server.
+	ErrNicknameHierarchy = errors.New("missing permission to change nickname")
+
+	// ErrInvalidNicknameTemplate is returned when a template contains unknown placeholders.
+	ErrInvalidNicknameTemplate = errors.New("invalid nickname template")
+
+	// ErrResyncInProgress is returned when a nickname resync is already running for a guild.
+	ErrResyncInProgress = errors.New("nickname resync already in progress")
+
+	// ErrAutoNicknameDisabled is returned when a guild has switched off the auto-nickname feature.
+	ErrAutoNicknameDisabled = errors.New("auto-nickname is disabled")
+)
+
+// nicknameResyncs tracks guilds with a resync in flight so the job and command don't overlap.
+var nicknameResyncs sync.Map
+
+// nicknamePlaceholderPattern matches {placeholder} tokens in a nickname template.
+var nicknamePlaceholderPattern = regexp.MustCompile(`\{([a-z]+)\}`)
+
+// nicknamePlaceholders lists supported placeholders and whether they need Wise Old Man data.
+var nicknamePlaceholders = map[string]bool{
+	"rsn":    false,
+	"rank":   false,
+	"combat": true,
+	"total":  true,
+	"ehp":    true,
+	"ehb":    true,
+	"type":   true,
+	"build":  true,
+}
+
+// nicknameData holds the values substituted into a nickname template.
+type nicknameData struct {
+	RSN    string
+	Rank   string
+	Player *wiseoldman.Player
+}
+
+// NicknameResyncResult summarises a full nickname resync.
+type NicknameResyncResult struct {
+	Updated   int
+	Unchanged int
+	Forbidden int
+	Failed    int
+}
+
+// ValidateNicknameTemplate checks that a template only uses supported placeholders and includes {rsn}.
+func ValidateNicknameTemplate(template string) error {
+	if strings.TrimSpace(template) == "" {
+		return fmt.Errorf("%w: template is empty", ErrInvalidNicknameTemplate)
+	}
+
+	for _, match := range nicknamePlaceholderPattern.FindAllStringSubmatch(template, -1) {
+		if _, ok := nicknamePlaceholders[match[1]]; !ok {
+			return fmt.Errorf("%w: unknown placeholder {%s}", ErrInvalidNicknameTemplate, match[1])
+		}
+	}
+
+	if !strings.Contains(template, "{rsn}") {
+		return fmt.Errorf("%w: template must include {rsn}", ErrInvalidNicknameTemplate)
+	}
+
+	return nil
+}
+
+// templateNeedsPlayer reports whether rendering template requires Wise Old Man data.
+func templateNeedsPlayer(template string) bool {
+	for _, match := range nicknamePlaceholderPattern.FindAllStringSubmatch(template, -1) {
+		if nicknamePlaceholders[match[1]] {
+			return true
+		}
+	}
+	return false
+}
+
+// renderNickname fills in template and trims the result to Discord's 32 character limit.
+func renderNickname(template string, data nicknameData) string {
+	nickname := nicknamePlaceholderPattern.ReplaceAllStringFunc(template, func(token string) string {
+		return nicknameValue(strings.Trim(token, "{}"), data)
+	})
+	nickname = strings.Join(strings.Fields(nickname), " ")
+
+	runes := []rune(nickname)
+	if len(runes) > maxNicknameLength {
+		nickname = strings.TrimSpace(string(runes[:maxNicknameLength]))
+	}
+	return nickname
+}
+
+// nicknameValue returns the value for a single placeholder, or "?" if the data isn't available.
+func nicknameValue(name string, data nicknameData) string {
+	if name == "rsn" {
+		return data.RSN
+	}
+	if name == "rank" {
+		return data.Rank
+	}
+
+	player := data.Player
+	if player == nil {
+		return "?"
+	}
+
+	switch name {
+	case "combat":
+		return strconv.Itoa(player.CombatLevel)
+	case "total":
+		if overall := player.GetSkill("overall"); overall != nil {
+			return strconv.Itoa(overall.Level)
+		}
+		return "?"
+	case "ehp":
+		return strconv.FormatFloat(player.EHP, 'f', 0, 64)
+	case "ehb":
+		return strconv.FormatFloat(player.EHB, 'f', 0, 64)
+	case "type":
+		return player.Type
+	case "build":
+		return player.Build
+	default:
+		return ""
+	}
+}
+
+// highestRoleName returns the name of the member's highest positioned role.
+func highestRoleName(member *discordgo.Member, roles []*discordgo.Role) string {
+	byID := make(map[string]*discordgo.Role, len(roles))
+	for _, role := range roles {
+		byID[role.ID] = role
+	}
+
+	var highest *discordgo.Role
+	for _, roleID := range member.Roles {
+		role, ok := byID[roleID]
+		if !ok {
+			continue
+		}
+		if highest == nil || role.Position > highest.Position {
+			highest = role
+		}
+	}
+
+	if highest == nil {
+		return ""
+	}
+	return highest.Name
+}
+
+// guildNicknameTemplate returns the configured nickname template for a guild, or the default.
+func guildNicknameTemplate(ctx context.Context, db *database.Queries, guildID string) string {
+	id, err := strconv.ParseInt(guildID, 10, 64)
+	if err != nil {
+		return DefaultNicknameTemplate
+	}
+
+	config, err := db.GetGuildConfig(ctx, id)
+	if err != nil {
+		return DefaultNicknameTemplate
+	}
+	return nicknameTemplateOrDefault(config.NicknameTemplate)
+}
+
+// syncMemberNickname applies the guild's nickname template to a linked member.
+// player may be nil; it is fetched from Wise Old Man only if the template needs it.
+func syncMemberNickname(ctx context.Context, s MemberManager, db *database.Queries, womClient PlayerClient, guildID, userID, rsn string, player *wiseoldman.Player) error {
+	if guildID == "" {
+		return ErrNoGuildContext
+	}
+	if !FeatureEnabled(ctx, db, guildID, FeatureAutoNickname) {
+		return nil
+	}
+
+	member, err := s.GuildMember(guildID, userID)
+	if err != nil {
+		return fmt.Errorf("fetch member: %w", err)
+	}
+
+	roles, err := s.GuildRoles(guildID)
+	if err != nil {
+		return fmt.Errorf("fetch roles: %w", err)
+	}
+
+	template := guildNicknameTemplate(ctx, db, guildID)
+	if player == nil && templateNeedsPlayer(template) {
+		player, err = womClient.GetPlayer(ctx, rsn)
+		if err != nil {
+			log.Printf("Failed to fetch WOM data for nickname of %s: %v", rsn, err)
+		}
+	}
+
+	nickname := renderNickname(template, nicknameData{
+		RSN:    rsn,
+		Rank:   highestRoleName(member, roles),
+		Player: player,
+	})
+	if member.Nick == nickname {
+		return nil
+	}
+
+	return updateMemberNickname(s, guildID, userID, nickname)
+}
+
+// ResyncNicknames re-applies the guild's nickname template to every linked member of the guild.
+// It walks the guild's members rather than every link in the database, so links of other guilds'
+// members cost nothing.
+func ResyncNicknames(ctx context.Context, s *discordgo.Session, db *database.Queries, womClient *wiseoldman.Client, guildID string) (NicknameResyncResult, error) {
+	var result NicknameResyncResult
+
+	if !FeatureEnabled(ctx, db, guildID, FeatureAutoNickname) {
+		return result, ErrAutoNicknameDisabled
+	}
+
+	if _, running := nicknameResyncs.LoadOrStore(guildID, struct{}{}); running {
+		return result, ErrResyncInProgress
+	}
+	defer nicknameResyncs.Delete(guildID)
+
+	links, err := db.GetActiveAccountLinks(ctx)
+	if err != nil {
+		return result, fmt.Errorf("fetch account links: %w", err)
+	}
+	rsns := make(map[string]string, len(links))
+	for _, link := range links {
+		rsns[strconv.FormatInt(link.DiscordMemberID, 10)] = link.RunescapeName
+	}
+
+	members, err := guildMembers(s, guildID)
+	if err != nil {
+		return result, fmt.Errorf("fetch members: %w", err)
+	}
+
+	roles, err := s.GuildRoles(guildID)
+	if err != nil {
+		return result, fmt.Errorf("fetch roles: %w", err)
+	}
+
+	template := guildNicknameTemplate(ctx, db, guildID)
+	needsPlayer := templateNeedsPlayer(template)
+
+	ticker := time.NewTicker(nicknameResyncInterval)
+	defer ticker.Stop()
+
+	lookups := 0
+	for _, member := range members {
+		userID := member.User.ID
+		rsn, linked := rsns[userID]
+		if !linked {
+			continue
+		}
+
+		var player *wiseoldman.Player
+		if needsPlayer {
+			if lookups > 0 {
+				select {
+				case <-ticker.C:
+				case <-ctx.Done():
+					return result, fmt.Errorf("resync cancelled: %w", ctx.Err())
+				}
+			}
+			lookups++
+
+			player, err = womClient.GetPlayer(ctx, rsn)
+			if err != nil {
+				log.Printf("Nickname resync: failed to fetch WOM data for %s: %v", rsn, err)
+			}
+		}
+
+		nickname := renderNickname(template, nicknameData{
+			RSN:    rsn,
+			Rank:   highestRoleName(member, roles),
+			Player: player,
+		})
+		if member.Nick == nickname {
+			result.Unchanged++
+			continue
+		}
+
+		err = updateMemberNickname(s, guildID, userID, nickname)
+		switch {
+		case errors.Is(err, ErrNicknameHierarchy):
+			result.Forbidden++
+		case err != nil:
+			log.Printf("Nickname resync: failed to update %s: %v", userID, err)
+			result.Failed++
+		default:
+			result.Updated++
+		}
+	}
+
+	return result, nil
+}
+
+// isDiscordErrorCode reports whether err is a Discord API error with the given JSON error code.
+func isDiscordErrorCode(err error, code int) bool {
+	var restErr *discordgo.RESTError
+	if !errors.As(err, &restErr) || restErr.Message == nil {
+		return false
+	}
+	return restErr.Message.Code == code
+}
+
+// nicknameTemplateOrDefault returns the stored template, or the default when unset.
+func nicknameTemplateOrDefault(template sql.NullString) string {
+	if !template.Valid || template.String == "" {
+		return DefaultNicknameTemplate
+	}
+	return template.String
+}
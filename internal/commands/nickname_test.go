@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderNickname(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		data     nicknameData
+		expected string
+	}{
+		{"rsn only", "{rsn}", nicknameData{RSN: "Zezima"}, "Zezima"},
+		{"rank", "{rsn} | {rank}", nicknameData{RSN: "Zezima", Rank: "Captain"}, "Zezima | Captain"},
+		{"combat", "{rsn} [{combat}]", nicknameData{RSN: "Lynx Titan", Player: samplePlayer()}, "Lynx Titan [126]"},
+		{"total", "{rsn} {total}", nicknameData{RSN: "Lynx Titan", Player: samplePlayer()}, "Lynx Titan 2277"},
+		{"missing player data", "{rsn} [{combat}]", nicknameData{RSN: "Zezima"}, "Zezima [?]"},
+		{"empty rank collapses spaces", "{rsn} {rank}", nicknameData{RSN: "Zezima"}, "Zezima"},
+		{
+			"truncated to 32 characters",
+			"{rsn} | {rank}",
+			nicknameData{RSN: "Zezima", Rank: "Supreme Grand Master of Bossing"},
+			"Zezima | Supreme Grand Master of",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := renderNickname(tt.template, tt.data)
+			assert.Equal(t, tt.expected, result)
+			assert.LessOrEqual(t, len([]rune(result)), maxNicknameLength)
+		})
+	}
+}
+
+func TestValidateNicknameTemplate(t *testing.T) {
+	assert.NoError(t, ValidateNicknameTemplate("{rsn} | {rank}"))
+	assert.ErrorIs(t, ValidateNicknameTemplate("{rank}"), ErrInvalidNicknameTemplate)
+	assert.ErrorIs(t, ValidateNicknameTemplate("{rsn} {kc}"), ErrInvalidNicknameTemplate)
+	assert.ErrorIs(t, ValidateNicknameTemplate("  "), ErrInvalidNicknameTemplate)
+}
+
+func TestHighestRoleName(t *testing.T) {
+	roles := []*discordgo.Role{
+		{ID: "1", Name: "Member", Position: 1},
+		{ID: "2", Name: "Captain", Position: 5},
+		{ID: "3", Name: "Recruit", Position: 0},
+	}
+
+	assert.Equal(t, "Captain", highestRoleName(&discordgo.Member{Roles: []string{"1", "2", "3"}}, roles))
+	assert.Equal(t, "", highestRoleName(&discordgo.Member{}, roles))
+}
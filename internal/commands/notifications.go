@@ -0,0 +1,222 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/embeds"
+	"github.com/kaffeed/voidling/internal/models"
+)
+
+// NotifyAllEvents is the role picker key for the guild's default event notification role.
+const NotifyAllEvents = "all"
+
+// NotificationType is a kind of event that can have its own ping role.
+type NotificationType struct {
+	EventType models.EventType
+	Label     string
+	Emoji     string
+}
+
+// NotificationTypes lists the event types that can have their own ping role, in display order.
+var NotificationTypes = []NotificationType{
+	{models.EventTypeBossOfTheWeek, "Boss of the Week", "🐉"},
+	{models.EventTypeSkillOfTheWeek, "Skill of the Week", "📈"},
+	{models.EventTypeWildyWednesday, "Wildy Wednesday", "💀"},
+	{models.EventTypeMass, "Masses", "⚔️"},
+}
+
+// notificationRoleOption is a role members can toggle from the role picker.
+type notificationRoleOption struct {
+	Key    string // An event type, or NotifyAllEvents for the default role
+	Label  string
+	Emoji  string
+	RoleID string
+}
+
+// NotificationCommands handles the self-assignable event ping roles.
+type NotificationCommands struct {
+	DB    *database.Queries
+	DBSQL *sql.DB
+}
+
+// NewNotificationCommands creates a new NotificationCommands instance.
+func NewNotificationCommands(db *database.Queries, dbSQL *sql.DB) *NotificationCommands {
+	return &NotificationCommands{
+		DB:    db,
+		DBSQL: dbSQL,
+	}
+}
+
+// NotificationTypeChoices returns the event types that can have their own ping role as command choices.
+func NotificationTypeChoices() []*discordgo.ApplicationCommandOptionChoice {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(NotificationTypes))
+	for _, nt := range NotificationTypes {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  nt.Label,
+			Value: string(nt.EventType),
+		})
+	}
+	return choices
+}
+
+// HandleNotificationToggle handles the role picker buttons, adding or removing the chosen ping role.
+// data is "key" for the picker posted in the server, or "key,guildID" for the one sent by DM.
+func (nc *NotificationCommands) HandleNotificationToggle(s *discordgo.Session, i *discordgo.InteractionCreate, data string) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	key, guildID, _ := strings.Cut(data, ",")
+	if i.GuildID != "" {
+		guildID = i.GuildID
+	}
+	id, err := strconv.ParseInt(guildID, 10, 64)
+	if err != nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("I couldn't tell which server this is for."))
+		return
+	}
+
+	options, err := notificationRoleOptions(ctx, nc.DB, id)
+	if err != nil {
+		log.Printf("Error loading notification roles: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to fetch configuration. Please try again."))
+		return
+	}
+	idx := slices.IndexFunc(options, func(option notificationRoleOption) bool { return option.Key == key })
+	if idx < 0 {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("That ping role isn't set up anymore. Ask a server admin to post a new role picker."))
+		return
+	}
+	option := options[idx]
+
+	userID := interactionUser(i).ID
+	member, err := s.GuildMember(guildID, userID)
+	if err != nil {
+		log.Printf("Error fetching member %s in guild %s: %v", userID, guildID, err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("I couldn't find you in the server. Have you left it?"))
+		return
+	}
+
+	if slices.Contains(member.Roles, option.RoleID) {
+		if err := s.GuildMemberRoleRemove(guildID, userID, option.RoleID); err != nil {
+			log.Printf("Error removing notification role: %v", err)
+			sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to update your roles. Please ask a server admin for help."))
+			return
+		}
+		sendEphemeralEmbed(s, i, embeds.SuccessEmbed(fmt.Sprintf("You won't be pinged for **%s** anymore. Click again to turn pings back on.", option.Label)))
+		return
+	}
+
+	if err := s.GuildMemberRoleAdd(guildID, userID, option.RoleID); err != nil {
+		log.Printf("Error adding notification role: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to update your roles. Please ask a server admin for help."))
+		return
+	}
+	sendEphemeralEmbed(s, i, embeds.SuccessEmbed(fmt.Sprintf("You'll now be pinged for **%s**. Click again to turn pings off.", option.Label)))
+}
+
+// notificationRoleOptions returns the ping roles a guild offers: the default role first, then
+// each event type with its own role.
+func notificationRoleOptions(ctx context.Context, db *database.Queries, guildID int64) ([]notificationRoleOption, error) {
+	var options []notificationRoleOption
+
+	config, err := db.GetGuildConfig(ctx, guildID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("fetch guild config: %w", err)
+	}
+	if err == nil && config.EventNotificationRoleID.Valid {
+		options = append(options, notificationRoleOption{
+			Key:    NotifyAllEvents,
+			Label:  "All events",
+			Emoji:  "🔔",
+			RoleID: strconv.FormatInt(config.EventNotificationRoleID.Int64, 10),
+		})
+	}
+
+	roles, err := db.GetNotificationRoles(ctx, guildID)
+	if err != nil {
+		return nil, fmt.Errorf("fetch notification roles: %w", err)
+	}
+	for _, nt := range NotificationTypes {
+		idx := slices.IndexFunc(roles, func(role database.GuildNotificationRole) bool { return role.EventType == string(nt.EventType) })
+		if idx < 0 {
+			continue
+		}
+		options = append(options, notificationRoleOption{
+			Key:    string(nt.EventType),
+			Label:  nt.Label,
+			Emoji:  nt.Emoji,
+			RoleID: strconv.FormatInt(roles[idx].RoleID, 10),
+		})
+	}
+
+	return options, nil
+}
+
+// notificationMention returns the role mention to ping when an event of eventType is announced:
+// the event type's own role if it has one, otherwise the default event notification role.
+func notificationMention(ctx context.Context, db *database.Queries, guildID int64, eventType models.EventType) string {
+	options, err := notificationRoleOptions(ctx, db, guildID)
+	if err != nil {
+		log.Printf("Error loading notification roles for guild %d: %v", guildID, err)
+		return ""
+	}
+
+	mention := ""
+	for _, option := range options {
+		switch option.Key {
+		case string(eventType):
+			return "<@&" + option.RoleID + ">"
+		case NotifyAllEvents:
+			mention = "<@&" + option.RoleID + ">"
+		}
+	}
+	return mention
+}
+
+// notificationPicker builds the role picker message. guildID is added to the buttons when the
+// picker is sent by DM, where the interaction carries no server.
+func notificationPicker(options []notificationRoleOption, guildID string) (*discordgo.MessageEmbed, []discordgo.MessageComponent) {
+	var lines []string
+	var buttons []discordgo.MessageComponent
+	for _, option := range options {
+		lines = append(lines, fmt.Sprintf("%s **%s** → <@&%s>", option.Emoji, option.Label, option.RoleID))
+
+		customID := "notify-toggle:" + option.Key
+		if guildID != "" {
+			customID += "," + guildID
+		}
+		buttons = append(buttons, discordgo.Button{
+			Label:    option.Label,
+			Emoji:    &discordgo.ComponentEmoji{Name: option.Emoji},
+			Style:    discordgo.SecondaryButton,
+			CustomID: customID,
+		})
+	}
+
+	embed := embeds.InfoEmbed("🔔 Event Pings", "Choose which events you want to be pinged for. Click a button to add the role, click it again to remove it.\n\n"+strings.Join(lines, "\n"))
+	embed.Timestamp = ""
+	return embed, []discordgo.MessageComponent{discordgo.ActionsRow{Components: buttons}}
+}
+
+// lookupNotificationType finds the notification type for an event type.
+func lookupNotificationType(eventType string) (NotificationType, bool) {
+	for _, nt := range NotificationTypes {
+		if string(nt.EventType) == eventType {
+			return nt, true
+		}
+	}
+	return NotificationType{}, false
+}
@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/models"
+	"github.com/kaffeed/voidling/internal/testutil"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationMention(t *testing.T) {
+	db, q := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	ctx := t.Context()
+	const guildID = int64(42)
+
+	assert.Empty(t, notificationMention(ctx, q, guildID, models.EventTypeMass), "nothing configured pings nobody")
+
+	require.NoError(t, ensureGuildConfig(ctx, q, guildID))
+	require.NoError(t, q.UpdateEventNotificationRole(ctx, database.UpdateEventNotificationRoleParams{
+		EventNotificationRoleID: sql.NullInt64{Int64: 100, Valid: true},
+		GuildID:                 guildID,
+	}))
+	require.NoError(t, q.SetNotificationRole(ctx, database.SetNotificationRoleParams{
+		GuildID:   guildID,
+		EventType: string(models.EventTypeWildyWednesday),
+		RoleID:    200,
+		UpdatedBy: 7,
+	}))
+
+	assert.Equal(t, "<@&200>", notificationMention(ctx, q, guildID, models.EventTypeWildyWednesday))
+	assert.Equal(t, "<@&100>", notificationMention(ctx, q, guildID, models.EventTypeMass), "types without a role use the default")
+
+	options, err := notificationRoleOptions(ctx, q, guildID)
+	require.NoError(t, err)
+	require.Len(t, options, 2)
+	assert.Equal(t, NotifyAllEvents, options[0].Key)
+	assert.Equal(t, string(models.EventTypeWildyWednesday), options[1].Key)
+}
+
+func TestNotificationPicker(t *testing.T) {
+	options := []notificationRoleOption{
+		{Key: NotifyAllEvents, Label: "All events", Emoji: "🔔", RoleID: "100"},
+		{Key: string(models.EventTypeMass), Label: "Masses", Emoji: "⚔️", RoleID: "200"},
+	}
+
+	t.Run("posted in the server", func(t *testing.T) {
+		embed, components := notificationPicker(options, "")
+
+		buttons := components[0].(discordgo.ActionsRow).Components
+		require.Len(t, buttons, 2)
+		assert.Equal(t, "notify-toggle:all", buttons[0].(discordgo.Button).CustomID)
+		assert.Equal(t, "notify-toggle:MASS", buttons[1].(discordgo.Button).CustomID)
+		assert.Contains(t, embed.Description, "⚔️ **Masses** → <@&200>")
+	})
+
+	t.Run("sent by DM carries the guild", func(t *testing.T) {
+		_, components := notificationPicker(options, "42")
+
+		buttons := components[0].(discordgo.ActionsRow).Components
+		assert.Equal(t, "notify-toggle:MASS,42", buttons[1].(discordgo.Button).CustomID)
+	})
+}
@@ -0,0 +1,171 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/embeds"
+	"github.com/kaffeed/voidling/internal/models"
+)
+
+// ProfileCommands handles /profile.
+type ProfileCommands struct {
+	DB        *database.Queries
+	DBSQL     *sql.DB
+	WOMClient PlayerClient
+}
+
+// NewProfileCommands creates a new ProfileCommands instance.
+func NewProfileCommands(db *database.Queries, dbSQL *sql.DB, womClient PlayerClient) *ProfileCommands {
+	return &ProfileCommands{
+		DB:        db,
+		DBSQL:     dbSQL,
+		WOMClient: womClient,
+	}
+}
+
+// HandleProfile handles /profile [member], showing a member's linked account stats and clan
+// history. staff adds the member's warning count, so staff profiles are only shown to the caller.
+func (p *ProfileCommands) HandleProfile(s DiscordSession, i *discordgo.InteractionCreate, staff bool) {
+	ctx := context.Background()
+
+	target := interactionUser(i)
+	for _, opt := range i.ApplicationCommandData().Options {
+		if opt.Name == "member" {
+			target = opt.UserValue(nil)
+		}
+	}
+
+	resp := &discordgo.InteractionResponse{Type: discordgo.InteractionResponseDeferredChannelMessageWithSource}
+	var flags discordgo.MessageFlags
+	if staff {
+		flags = discordgo.MessageFlagsEphemeral
+		resp.Data = &discordgo.InteractionResponseData{Flags: flags}
+	}
+	if err := respondToInteraction(s, i.Interaction, resp); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	entry, err := p.profile(ctx, i.GuildID, target.ID, staff)
+	if errors.Is(err, sql.ErrNoRows) {
+		message := fmt.Sprintf("<@%s> hasn't linked a RuneScape account.", target.ID)
+		if target.ID == interactionUser(i).ID {
+			message = "You haven't linked a RuneScape account yet. Use `/link-rsn` to link one."
+		}
+		sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
+			Embeds:          []*discordgo.MessageEmbed{embeds.ErrorEmbed(message)},
+			Flags:           flags,
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("Error building profile for %s: %v", target.ID, err)
+		sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
+			Embeds: []*discordgo.MessageEmbed{embeds.ErrorEmbed("Failed to load the profile. Please try again.")},
+			Flags:  flags,
+		})
+		return
+	}
+
+	sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
+		Embeds:          []*discordgo.MessageEmbed{embeds.Profile(entry)},
+		Flags:           flags,
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	})
+}
+
+// profile gathers a member's profile. It returns sql.ErrNoRows if they have no active link.
+// Wise Old Man being unavailable only leaves the stats out.
+func (p *ProfileCommands) profile(ctx context.Context, guildID, userID string, staff bool) (embeds.ProfileEntry, error) {
+	guildIDInt, err := strconv.ParseInt(guildID, 10, 64)
+	if err != nil {
+		return embeds.ProfileEntry{}, fmt.Errorf("parse guild ID: %w", err)
+	}
+	userIDInt, err := strconv.ParseInt(userID, 10, 64)
+	if err != nil {
+		return embeds.ProfileEntry{}, fmt.Errorf("parse user ID: %w", err)
+	}
+
+	link, err := p.DB.GetAccountLinkByDiscordID(ctx, userIDInt)
+	if err != nil {
+		return embeds.ProfileEntry{}, err
+	}
+	entry := embeds.ProfileEntry{
+		UserID:       userID,
+		RSN:          link.RunescapeName,
+		LinkedSince:  link.CreatedAt,
+		ShowWarnings: staff,
+	}
+
+	player, err := p.WOMClient.GetPlayer(ctx, link.RunescapeName)
+	if err != nil {
+		log.Printf("Warning: failed to fetch player %s for profile: %v", link.RunescapeName, err)
+	} else {
+		entry.Player = player
+	}
+
+	results, err := p.DB.ListMemberEventResults(ctx, database.ListMemberEventResultsParams{
+		GuildID:         guildIDInt,
+		DiscordMemberID: userIDInt,
+	})
+	if err != nil {
+		return embeds.ProfileEntry{}, fmt.Errorf("list event results: %w", err)
+	}
+	for _, result := range results {
+		switch models.EventType(result.Type) {
+		case models.EventTypeBossOfTheWeek:
+			addEventResult(&entry.BOTW, result)
+		case models.EventTypeSkillOfTheWeek:
+			addEventResult(&entry.SOTW, result)
+		}
+	}
+
+	entry.MassesAttended, err = p.DB.CountAttendedMasses(ctx, database.CountAttendedMassesParams{
+		GuildID:         sql.NullInt64{Int64: guildIDInt, Valid: true},
+		DiscordMemberID: userIDInt,
+		ScheduledAt:     time.Now().UTC(),
+	})
+	if err != nil {
+		return embeds.ProfileEntry{}, fmt.Errorf("count masses: %w", err)
+	}
+
+	if staff {
+		warnings, err := p.DB.GetWarningsByUser(ctx, database.GetWarningsByUserParams{
+			GuildID: guildIDInt,
+			UserID:  userIDInt,
+		})
+		if err != nil {
+			return embeds.ProfileEntry{}, fmt.Errorf("list warnings: %w", err)
+		}
+		entry.Warnings = len(warnings)
+	}
+
+	return entry, nil
+}
+
+// addEventResult counts one finished competition towards a member's event record. Placements
+// only count when the member made progress, since everyone ties for first without any.
+func addEventResult(record *embeds.EventRecord, result database.EventResult) {
+	record.Participations++
+	if result.Gained <= 0 {
+		return
+	}
+	if result.Placement == 1 {
+		record.Wins++
+	}
+	if result.Placement <= 3 {
+		record.Podiums++
+	}
+	if record.Best == 0 || result.Placement < record.Best {
+		record.Best = result.Placement
+	}
+}
@@ -0,0 +1,143 @@
+package commands
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/models"
+	"github.com/kaffeed/voidling/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// profileInteraction builds a /profile interaction, optionally for another member.
+func profileInteraction(userID, member string) *discordgo.InteractionCreate {
+	i := testutil.CreateTestInteraction("profile", userID, "42")
+	data := discordgo.ApplicationCommandInteractionData{Name: "profile"}
+	if member != "" {
+		data.Options = []*discordgo.ApplicationCommandInteractionDataOption{
+			{Name: "member", Type: discordgo.ApplicationCommandOptionUser, Value: member},
+		}
+	}
+	i.Data = data
+	return i
+}
+
+// createMass stores a mass in guildID scheduled at scheduledAt with link signed up.
+func createMass(t *testing.T, q *database.Queries, link database.AccountLink, guildID sql.NullInt64, scheduledAt time.Time) {
+	t.Helper()
+	event, err := q.CreateSchedulableEvent(t.Context(), database.CreateSchedulableEventParams{
+		Type:        "Mass",
+		Activity:    "nex",
+		Location:    "w420",
+		ScheduledAt: scheduledAt.UTC(),
+		GuildID:     guildID,
+	})
+	require.NoError(t, err)
+	_, err = q.CreateSchedulableParticipation(t.Context(), database.CreateSchedulableParticipationParams{
+		EventID:       event.ID,
+		AccountLinkID: link.ID,
+	})
+	require.NoError(t, err)
+}
+
+func TestProfileWithHistory(t *testing.T) {
+	db, q := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+	ctx := t.Context()
+
+	link := testutil.CreateTestAccountLink(t, q, 1001, "zezima", true)
+	results := []database.UpsertEventResultParams{
+		{WomCompetitionID: 1, Type: string(models.EventTypeBossOfTheWeek), Placement: 4, Gained: 10},
+		{WomCompetitionID: 2, Type: string(models.EventTypeBossOfTheWeek), Placement: 1, Gained: 50},
+		{WomCompetitionID: 3, Type: string(models.EventTypeSkillOfTheWeek), Placement: 1, Gained: 0},
+		{WomCompetitionID: 4, Type: string(models.EventTypeBossOfTheWeek), Placement: 1, Gained: 80, GuildID: 7},
+	}
+	for _, r := range results {
+		if r.GuildID == 0 {
+			r.GuildID = testGuildID
+		}
+		r.Metric = "zulrah"
+		r.AccountLinkID = link.ID
+		require.NoError(t, q.UpsertEventResult(ctx, r))
+	}
+	for range 2 {
+		_, err := q.CreateWarning(ctx, database.CreateWarningParams{GuildID: testGuildID, UserID: 1001, ModeratorID: 1, Reason: "spam"})
+		require.NoError(t, err)
+	}
+	guild := sql.NullInt64{Int64: testGuildID, Valid: true}
+	createMass(t, q, link, guild, time.Now().Add(-24*time.Hour))
+	createMass(t, q, link, guild, time.Now().Add(24*time.Hour))
+	createMass(t, q, link, sql.NullInt64{}, time.Now().Add(-48*time.Hour)) // Scheduled before masses recorded their guild
+	createMass(t, q, link, sql.NullInt64{Int64: 7, Valid: true}, time.Now().Add(-48*time.Hour))
+
+	session := &testutil.MockDiscordSession{}
+	wom := &testutil.MockWOMClient{}
+	session.On("InteractionRespond", mock.Anything, mock.Anything).Return(nil)
+	wom.On("GetPlayer", mock.Anything, "zezima").Return(testutil.CreateTestPlayer("Zezima"), nil)
+	followups := captureFollowups(session)
+
+	pc := NewProfileCommands(q, db, wom)
+	pc.HandleProfile(session, profileInteraction("2002", "1001"), true)
+
+	require.Len(t, *followups, 1)
+	assert.Equal(t, discordgo.MessageFlagsEphemeral, (*followups)[0].Flags)
+	require.Len(t, (*followups)[0].Embeds, 1)
+	fields := make(map[string]string)
+	for _, f := range (*followups)[0].Embeds[0].Fields {
+		fields[f.Name] = f.Value
+	}
+	assert.Equal(t, "2 event(s)\n1 win(s), 1 podium(s)\nBest: #1", fields["Boss of the Week"])
+	assert.Equal(t, "1 event(s)\n0 win(s), 0 podium(s)", fields["Skill of the Week"])
+	assert.Equal(t, "2", fields["Masses Attended"])
+	assert.Equal(t, "2", fields["Warnings"])
+	assert.Contains(t, fields, "Top Bosses")
+}
+
+func TestProfileForMembers(t *testing.T) {
+	db, q := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	testutil.CreateTestAccountLink(t, q, 1001, "zezima", true)
+
+	session := &testutil.MockDiscordSession{}
+	wom := &testutil.MockWOMClient{}
+	session.On("InteractionRespond", mock.Anything, mock.Anything).Return(nil)
+	wom.On("GetPlayer", mock.Anything, "zezima").Return(nil, errors.New("wom down"))
+	followups := captureFollowups(session)
+
+	pc := NewProfileCommands(q, db, wom)
+	pc.HandleProfile(session, profileInteraction("1001", ""), false)
+
+	require.Len(t, *followups, 1)
+	assert.Zero(t, (*followups)[0].Flags)
+	embed := (*followups)[0].Embeds[0]
+	assert.Equal(t, "Stats", embed.Fields[0].Name)
+	for _, f := range embed.Fields {
+		assert.NotEqual(t, "Warnings", f.Name)
+	}
+}
+
+func TestProfileNotLinked(t *testing.T) {
+	db, q := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	session := &testutil.MockDiscordSession{}
+	wom := &testutil.MockWOMClient{}
+	session.On("InteractionRespond", mock.Anything, mock.Anything).Return(nil)
+	followups := captureFollowups(session)
+
+	pc := NewProfileCommands(q, db, wom)
+	pc.HandleProfile(session, profileInteraction("1001", ""), false)
+	pc.HandleProfile(session, profileInteraction("1001", "1002"), false)
+
+	text := followupText(*followups)
+	assert.Contains(t, text, "You haven't linked a RuneScape account yet")
+	assert.Contains(t, text, "<@1002> hasn't linked a RuneScape account.")
+	wom.AssertNotCalled(t, "GetPlayer", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,187 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/audit"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/embeds"
+	"github.com/kaffeed/voidling/internal/wiseoldman"
+)
+
+// maxWOMRoleLength is the longest Wise Old Man group role name accepted in a rank role mapping.
+const maxWOMRoleLength = 32
+
+// ErrNoRankRoles is returned when a guild hasn't mapped any Wise Old Man ranks to Discord roles.
+var ErrNoRankRoles = errors.New("no rank roles configured")
+
+// womRolePattern matches Wise Old Man group role names, e.g. "captain" or "dragon_slayer".
+var womRolePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// RankRoleSyncResult describes the rank role changes a sync made or, for a preview, would make.
+type RankRoleSyncResult struct {
+	Changes []embeds.RankRoleChange
+	// Failed counts changes Discord rejected, usually because of the role hierarchy.
+	Failed int
+}
+
+// SyncRankRoles gives linked members the Discord roles mapped to their rank in the guild's Wise Old
+// Man group and removes mapped roles they no longer hold. Members without a link are left alone.
+// Unless apply is set nothing changes and the planned changes are returned. Every change made is
+// audited as actorID.
+func SyncRankRoles(ctx context.Context, s *discordgo.Session, db *database.Queries, womClient *wiseoldman.Client, auditLog *audit.Logger, guildID, actorID string, apply bool) (RankRoleSyncResult, error) {
+	group, err := loadWOMGroup(ctx, db, guildID)
+	if err != nil {
+		return RankRoleSyncResult{}, err
+	}
+	mappings, err := db.GetRankRoles(ctx, group.GuildID)
+	if err != nil {
+		return RankRoleSyncResult{}, fmt.Errorf("fetch rank roles: %w", err)
+	}
+	if len(mappings) == 0 {
+		return RankRoleSyncResult{}, ErrNoRankRoles
+	}
+
+	links, err := db.GetActiveAccountLinks(ctx)
+	if err != nil {
+		return RankRoleSyncResult{}, fmt.Errorf("fetch account links: %w", err)
+	}
+	members, err := guildMembers(s, guildID)
+	if err != nil {
+		return RankRoleSyncResult{}, fmt.Errorf("fetch members: %w", err)
+	}
+	memberships, err := womClient.GetGroupMembers(ctx, group.GroupID)
+	if err != nil {
+		return RankRoleSyncResult{}, fmt.Errorf("fetch group %d: %w", group.GroupID, err)
+	}
+
+	changes := planRankRoleChanges(links, memberships, mappings, members)
+	if !apply {
+		return RankRoleSyncResult{Changes: changes}, nil
+	}
+
+	result := RankRoleSyncResult{}
+	for _, change := range changes {
+		action := audit.ActionRankRoleAdd
+		if change.Add {
+			err = s.GuildMemberRoleAdd(guildID, change.UserID, change.RoleID)
+		} else {
+			action = audit.ActionRankRoleRemove
+			err = s.GuildMemberRoleRemove(guildID, change.UserID, change.RoleID)
+		}
+		if err != nil {
+			log.Printf("Error changing rank role %s for %s in guild %s: %v", change.RoleID, change.UserID, guildID, err)
+			result.Failed++
+			continue
+		}
+
+		rank := "not in the group"
+		if change.Rank != "" {
+			rank = change.Rank
+		}
+		auditLog.Record(ctx, s, audit.Entry{
+			GuildID: guildID,
+			ActorID: actorID,
+			Action:  action,
+			Target:  fmt.Sprintf("<@%s> (%s)", change.UserID, change.RSN),
+			After:   fmt.Sprintf("<@&%s> (rank: %s)", change.RoleID, rank),
+		})
+		result.Changes = append(result.Changes, change)
+	}
+	return result, nil
+}
+
+// planRankRoleChanges works out which mapped roles each linked member should gain or lose. A member
+// should hold the roles mapped to the ranks of all their linked RSNs; other mapped roles are removed.
+// Changes are ordered by member, then role.
+func planRankRoleChanges(links []database.AccountLink, memberships []wiseoldman.Membership, mappings []database.GuildRankRole, members []*discordgo.Member) []embeds.RankRoleChange {
+	rolesByRank := make(map[string][]string)
+	var managed []string
+	for _, mapping := range mappings {
+		roleID := strconv.FormatInt(mapping.RoleID, 10)
+		rolesByRank[mapping.WomRole] = append(rolesByRank[mapping.WomRole], roleID)
+		if !slices.Contains(managed, roleID) {
+			managed = append(managed, roleID)
+		}
+	}
+	slices.Sort(managed)
+
+	rankByName := make(map[string]string, len(memberships))
+	for _, membership := range memberships {
+		rankByName[wiseoldman.StandardizeUsername(membership.Player.Username)] = membership.Role
+	}
+
+	linksByUser := make(map[string][]database.AccountLink)
+	for _, link := range links {
+		userID := strconv.FormatInt(link.DiscordMemberID, 10)
+		linksByUser[userID] = append(linksByUser[userID], link)
+	}
+
+	members = slices.Clone(members)
+	slices.SortFunc(members, func(a, b *discordgo.Member) int { return strings.Compare(a.User.ID, b.User.ID) })
+
+	var changes []embeds.RankRoleChange
+	for _, member := range members {
+		userLinks, ok := linksByUser[member.User.ID]
+		if !ok || member.User.Bot {
+			continue
+		}
+
+		// Which link (and so RSN and rank) each wanted role comes from
+		wanted := make(map[string]database.AccountLink)
+		rank := ""
+		for _, link := range userLinks {
+			linkRank := rankByName[wiseoldman.StandardizeUsername(link.RunescapeName)]
+			if linkRank != "" && rank == "" {
+				rank = linkRank
+			}
+			for _, roleID := range rolesByRank[linkRank] {
+				if _, ok := wanted[roleID]; !ok {
+					wanted[roleID] = link
+				}
+			}
+		}
+
+		for _, roleID := range managed {
+			has := slices.Contains(member.Roles, roleID)
+			link, want := wanted[roleID]
+			switch {
+			case want && !has:
+				changes = append(changes, embeds.RankRoleChange{
+					UserID: member.User.ID,
+					RoleID: roleID,
+					RSN:    link.RunescapeName,
+					Rank:   rankByName[wiseoldman.StandardizeUsername(link.RunescapeName)],
+					Add:    true,
+				})
+			case !want && has:
+				changes = append(changes, embeds.RankRoleChange{
+					UserID: member.User.ID,
+					RoleID: roleID,
+					RSN:    userLinks[0].RunescapeName,
+					Rank:   rank,
+				})
+			}
+		}
+	}
+	return changes
+}
+
+// normalizeWOMRole turns a typed rank such as "Dragon Slayer" into Wise Old Man's role name.
+func normalizeWOMRole(role string) string {
+	replacer := strings.NewReplacer(" ", "_", "-", "_")
+	return replacer.Replace(strings.ToLower(strings.TrimSpace(role)))
+}
+
+// validWOMRole reports whether role looks like a Wise Old Man group role name.
+func validWOMRole(role string) bool {
+	return len(role) <= maxWOMRoleLength && womRolePattern.MatchString(role)
+}
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/embeds"
+	"github.com/kaffeed/voidling/internal/wiseoldman"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanRankRoleChanges(t *testing.T) {
+	links := []database.AccountLink{
+		{DiscordMemberID: 1, RunescapeName: "Zezima"},
+		{DiscordMemberID: 2, RunescapeName: "Lynx_Titan"},
+		{DiscordMemberID: 3, RunescapeName: "Quitter"},
+		{DiscordMemberID: 4, RunescapeName: "Woox"},
+		{DiscordMemberID: 5, RunescapeName: "Bot Account"},
+	}
+	membership := func(username, role string) wiseoldman.Membership {
+		return wiseoldman.Membership{Role: role, Player: wiseoldman.Player{Username: username}}
+	}
+	memberships := []wiseoldman.Membership{
+		membership("zezima", "captain"),
+		membership("lynx titan", "member"),
+		membership("woox", "member"),
+		membership("bot account", "captain"),
+	}
+	mappings := []database.GuildRankRole{
+		{WomRole: "captain", RoleID: 100},
+		{WomRole: "member", RoleID: 200},
+	}
+	member := func(id string, roles ...string) *discordgo.Member {
+		return &discordgo.Member{User: &discordgo.User{ID: id}, Roles: roles}
+	}
+	members := []*discordgo.Member{
+		member("2", "100"), // Demoted: gains member, loses captain
+		member("1", "999"), // New captain
+		member("3", "200"), // Left the group
+		member("4", "200"), // Already in sync
+		member("6", "100"), // Not linked, left alone
+		{User: &discordgo.User{ID: "5", Bot: true}},
+	}
+
+	changes := planRankRoleChanges(links, memberships, mappings, members)
+
+	assert.Equal(t, []embeds.RankRoleChange{
+		{UserID: "1", RoleID: "100", RSN: "Zezima", Rank: "captain", Add: true},
+		{UserID: "2", RoleID: "100", RSN: "Lynx_Titan", Rank: "member"},
+		{UserID: "2", RoleID: "200", RSN: "Lynx_Titan", Rank: "member", Add: true},
+		{UserID: "3", RoleID: "200", RSN: "Quitter"},
+	}, changes)
+}
+
+func TestNormalizeWOMRole(t *testing.T) {
+	assert.Equal(t, "dragon_slayer", normalizeWOMRole(" Dragon Slayer "))
+	assert.Equal(t, "red_topaz", normalizeWOMRole("red-topaz"))
+
+	assert.True(t, validWOMRole("captain"))
+	assert.False(t, validWOMRole(""))
+	assert.False(t, validWOMRole("captain!"))
+}
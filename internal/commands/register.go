@@ -14,21 +14,36 @@ import (
 	"github.com/bwmarrin/discordgo"
 	"github.com/kaffeed/voidling/internal/database"
 	"github.com/kaffeed/voidling/internal/embeds"
-	"github.com/kaffeed/voidling/internal/wiseoldman"
 )
 
-// ErrNoGuildContext is returned when a guild context is required but not available.
-var ErrNoGuildContext = errors.New("no guild context")
+var (
+	// ErrNoGuildContext is returned when a guild context is required but not available.
+	ErrNoGuildContext = errors.New("no guild context")
+
+	// ErrAccountAlreadyLinked is returned when the requested account link is already active.
+	ErrAccountAlreadyLinked = errors.New("account already linked")
+)
+
+// RSNLinkedError is returned when an RSN is actively linked to a different Discord member.
+type RSNLinkedError struct {
+	RSN             string
+	DiscordMemberID int64
+}
+
+// Error implements error.
+func (e *RSNLinkedError) Error() string {
+	return fmt.Sprintf("%s is linked to Discord member %d", e.RSN, e.DiscordMemberID)
+}
 
 // RegisterCommands holds the handlers for account registration commands.
 type RegisterCommands struct {
 	DB        *database.Queries
 	DBSQL     *sql.DB
-	WOMClient *wiseoldman.Client
+	WOMClient PlayerClient
 }
 
 // NewRegisterCommands creates a new RegisterCommands instance.
-func NewRegisterCommands(db *database.Queries, dbSQL *sql.DB, womClient *wiseoldman.Client) *RegisterCommands {
+func NewRegisterCommands(db *database.Queries, dbSQL *sql.DB, womClient PlayerClient) *RegisterCommands {
 	return &RegisterCommands{
 		DB:        db,
 		DBSQL:     dbSQL,
@@ -37,11 +52,23 @@ func NewRegisterCommands(db *database.Queries, dbSQL *sql.DB, womClient *wiseold
 }
 
 // HandleLinkRSN shows the modal for linking a RuneScape account.
-func (r *RegisterCommands) HandleLinkRSN(s *discordgo.Session, i *discordgo.InteractionCreate) {
+func (r *RegisterCommands) HandleLinkRSN(s DiscordSession, i *discordgo.InteractionCreate) {
+	r.showLinkModal(s, i, "link-rsn-modal")
+}
+
+// HandleDMLinkRSN shows the link modal from the welcome DM button. The guild ID is carried
+// through the modal and confirmation buttons so the nickname and linked role can still be
+// applied in that server.
+func (r *RegisterCommands) HandleDMLinkRSN(s DiscordSession, i *discordgo.InteractionCreate, guildID string) {
+	r.showLinkModal(s, i, "link-rsn-modal:"+guildID)
+}
+
+// showLinkModal shows the RSN modal with the given custom ID.
+func (r *RegisterCommands) showLinkModal(s DiscordSession, i *discordgo.InteractionCreate, customID string) {
 	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseModal,
 		Data: &discordgo.InteractionResponseData{
-			CustomID: "link-rsn-modal",
+			CustomID: customID,
 			Title:    "Link RuneScape Account",
 			Components: []discordgo.MessageComponent{
 				discordgo.ActionsRow{
@@ -66,7 +93,8 @@ func (r *RegisterCommands) HandleLinkRSN(s *discordgo.Session, i *discordgo.Inte
 }
 
 // HandleLinkRSNModal processes the modal submission for linking.
-func (r *RegisterCommands) HandleLinkRSNModal(s *discordgo.Session, i *discordgo.InteractionCreate) {
+// guildID is set when the modal was opened from the welcome DM.
+func (r *RegisterCommands) HandleLinkRSNModal(s DiscordSession, i *discordgo.InteractionCreate, guildID string) {
 	if err := r.deferEphemeralResponse(s, i); err != nil {
 		return
 	}
@@ -77,7 +105,7 @@ func (r *RegisterCommands) HandleLinkRSNModal(s *discordgo.Session, i *discordgo
 		return
 	}
 
-	log.Printf("User %s wants to link RSN: %s", i.Member.User.Username, username)
+	log.Printf("User %s wants to link RSN: %s", interactionUser(i).Username, username)
 
 	// Fetch player from Wise Old Man API
 	ctx := context.Background()
@@ -86,26 +114,30 @@ func (r *RegisterCommands) HandleLinkRSNModal(s *discordgo.Session, i *discordgo
 		log.Printf("Error fetching player %s: %v", username, err)
 		s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
 			Embeds: []*discordgo.MessageEmbed{
-				embeds.ErrorEmbed(fmt.Sprintf("Failed to fetch player data for '%s'. Make sure the username is correct and try again.", username)),
+				embeds.ErrorEmbed(womErrorMessage(err, fmt.Sprintf("Failed to fetch player data for '%s'. Make sure the username is correct and try again.", username))),
 			},
 			Flags: discordgo.MessageFlagsEphemeral,
 		})
 		return
 	}
 
-	// Create confirmation buttons
+	// Create confirmation buttons; "username,guildID" keeps the server context in DMs
+	data := username
+	if i.GuildID == "" && guildID != "" {
+		data += "," + guildID
+	}
 	components := []discordgo.MessageComponent{
 		discordgo.ActionsRow{
 			Components: []discordgo.MessageComponent{
 				discordgo.Button{
 					Label:    "That's me!",
 					Style:    discordgo.SuccessButton,
-					CustomID: fmt.Sprintf("confirm-rsn:%s", username),
+					CustomID: fmt.Sprintf("confirm-rsn:%s", data),
 				},
 				discordgo.Button{
 					Label:    "Not me",
 					Style:    discordgo.DangerButton,
-					CustomID: fmt.Sprintf("cancel-rsn:%s", username),
+					CustomID: fmt.Sprintf("cancel-rsn:%s", data),
 				},
 			},
 		},
@@ -120,13 +152,18 @@ func (r *RegisterCommands) HandleLinkRSNModal(s *discordgo.Session, i *discordgo
 }
 
 // HandleConfirmRSN handles the confirmation button for linking.
-func (r *RegisterCommands) HandleConfirmRSN(s *discordgo.Session, i *discordgo.InteractionCreate, username string) {
+// data is the RSN, followed by ",guildID" when the link was started from the welcome DM.
+func (r *RegisterCommands) HandleConfirmRSN(s DiscordSession, i *discordgo.InteractionCreate, data string) {
 	if err := r.deferEphemeralResponse(s, i); err != nil {
 		return
 	}
 
 	ctx := context.Background()
+	username, dmGuildID, _ := strings.Cut(data, ",")
 	userID, guildID := r.getUserAndGuildIDs(i)
+	if guildID == "" {
+		guildID = dmGuildID
+	}
 
 	discordID, err := r.parseDiscordID(userID)
 	if err != nil {
@@ -137,66 +174,18 @@ func (r *RegisterCommands) HandleConfirmRSN(s *discordgo.Session, i *discordgo.I
 
 	log.Printf("Confirming RSN link for Discord user %s (%d) with RSN: %s", userID, discordID, username)
 
-	// Start a transaction
-	tx, err := r.DBSQL.BeginTx(ctx, nil)
-	if err != nil {
-		log.Printf("Error starting transaction: %v", err)
-		r.sendEmbedFollowup(s, i, embeds.ErrorEmbed("Database error. Please try again later."))
-		return
-	}
-	defer func() { _ = tx.Rollback() }() // Rollback is safe to call even after commit
-
-	qtx := r.DB.WithTx(tx)
-
-	// Check if this exact account link already exists and is active
-	existingLink, err := qtx.GetExistingAccountLink(ctx, database.GetExistingAccountLinkParams{
-		DiscordMemberID: discordID,
-		LOWER:           strings.ToLower(username),
-	})
-	linkExists := (err == nil)
-
-	if linkExists && existingLink.IsActive {
+	err = linkAccount(ctx, r.DB, r.DBSQL, discordID, username)
+	if errors.Is(err, ErrAccountAlreadyLinked) {
 		log.Printf("Account link already exists and is active for user %d", discordID)
-		_ = tx.Rollback()
 		s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
 			Content: "This account is already linked and active!",
 			Flags:   discordgo.MessageFlagsEphemeral,
 		})
 		return
 	}
-
-	// Deactivate all existing links for this user
-	if err = qtx.DeactivateAllAccountLinksForUser(ctx, discordID); err != nil {
-		log.Printf("Error deactivating existing links: %v", err)
-		r.sendEmbedFollowup(s, i, embeds.ErrorEmbed("Failed to update account links. Please try again."))
-		return
-	}
-
-	// If the link exists but was inactive, reactivate it; otherwise create new
-	if linkExists {
-		log.Printf("Reactivating existing account link: %d", existingLink.ID)
-		if err = qtx.ActivateAccountLink(ctx, existingLink.ID); err != nil {
-			log.Printf("Error reactivating account link: %v", err)
-			r.sendEmbedFollowup(s, i, embeds.ErrorEmbed("Failed to activate account link. Please try again."))
-			return
-		}
-	} else {
-		log.Printf("Creating new account link for user %d with RSN %s", discordID, username)
-		if _, err = qtx.CreateAccountLink(ctx, database.CreateAccountLinkParams{
-			DiscordMemberID: discordID,
-			RunescapeName:   username,
-			IsActive:        true,
-		}); err != nil {
-			log.Printf("Error creating account link: %v", err)
-			r.sendEmbedFollowup(s, i, embeds.ErrorEmbed("Failed to link account. Please try again."))
-			return
-		}
-	}
-
-	// Commit transaction
-	if err := tx.Commit(); err != nil {
-		log.Printf("Error committing transaction: %v", err)
-		r.sendEmbedFollowup(s, i, embeds.ErrorEmbed("Failed to save changes. Please try again."))
+	if err != nil {
+		log.Printf("Error linking account: %v", err)
+		r.sendEmbedFollowup(s, i, embeds.ErrorEmbed("Failed to link account. Please try again."))
 		return
 	}
 
@@ -213,26 +202,37 @@ func (r *RegisterCommands) HandleConfirmRSN(s *discordgo.Session, i *discordgo.I
 }
 
 // buildSuccessMessage creates a success message and attempts nickname update.
-func (r *RegisterCommands) buildSuccessMessage(s *discordgo.Session, guildID, userID, username string) string {
-	baseMsg := fmt.Sprintf("Successfully linked your account to **%s**!", username)
+func (r *RegisterCommands) buildSuccessMessage(s DiscordSession, guildID, userID, username string) string {
+	ctx := context.Background()
+	msg := fmt.Sprintf("Successfully linked your account to **%s**!", username)
 
 	if guildID == "" {
-		return baseMsg
+		return msg
 	}
 
 	// Attempt to update nickname
-	if err := r.updateMemberNickname(s, guildID, userID, username); err != nil {
-		log.Printf("Failed to update nickname for user %s in guild %s: %v", userID, guildID, err)
-		return baseMsg + "\n\n*Note: I couldn't update your server nickname automatically. Please ask a server admin to update it.*"
+	if FeatureEnabled(ctx, r.DB, guildID, FeatureAutoNickname) {
+		if err := syncMemberNickname(ctx, s, r.DB, r.WOMClient, guildID, userID, username, nil); err != nil {
+			log.Printf("Failed to update nickname for user %s in guild %s: %v", userID, guildID, err)
+			msg += "\n\n*Note: I couldn't update your server nickname automatically. Please ask a server admin to update it.*"
+		} else {
+			log.Printf("Updated nickname for user %s (RSN %s) in guild %s", userID, username, guildID)
+			msg += " Your server nickname has been updated too!"
+		}
 	}
 
-	log.Printf("Updated nickname for user %s to %s in guild %s", userID, username, guildID)
-	return baseMsg + " Your server nickname has been updated too!"
+	if err := syncLinkedRole(ctx, s, r.DB, guildID, userID, true); err != nil {
+		log.Printf("Failed to assign linked role to user %s in guild %s: %v", userID, guildID, err)
+		msg += "\n\n*Note: I couldn't give you the linked member role. Please ask a server admin.*"
+	}
+
+	return msg
 }
 
 // HandleCancelRSN handles the cancel button for linking.
-func (r *RegisterCommands) HandleCancelRSN(s *discordgo.Session, i *discordgo.InteractionCreate, username string) {
-	log.Printf("User %s cancelled linking RSN: %s", i.Member.User.Username, username)
+func (r *RegisterCommands) HandleCancelRSN(s DiscordSession, i *discordgo.InteractionCreate, data string) {
+	username, _, _ := strings.Cut(data, ",")
+	log.Printf("User %s cancelled linking RSN: %s", interactionUser(i).Username, username)
 
 	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseUpdateMessage,
@@ -246,7 +246,7 @@ func (r *RegisterCommands) HandleCancelRSN(s *discordgo.Session, i *discordgo.In
 }
 
 // HandleUnlinkRSN handles unlinking a RuneScape account.
-func (r *RegisterCommands) HandleUnlinkRSN(s *discordgo.Session, i *discordgo.InteractionCreate) {
+func (r *RegisterCommands) HandleUnlinkRSN(s DiscordSession, i *discordgo.InteractionCreate) {
 	if err := r.deferEphemeralResponse(s, i); err != nil {
 		return
 	}
@@ -282,13 +282,17 @@ func (r *RegisterCommands) HandleUnlinkRSN(s *discordgo.Session, i *discordgo.In
 	}
 
 	log.Printf("Successfully unlinked RSN %s from Discord user %d", activeLink.RunescapeName, discordID)
+
+	if err := syncLinkedRole(ctx, s, r.DB, i.GuildID, i.Member.User.ID, false); err != nil {
+		log.Printf("Failed to remove linked role from user %s in guild %s: %v", i.Member.User.ID, i.GuildID, err)
+	}
 	r.sendEmbedFollowup(s, i, embeds.SuccessEmbed(fmt.Sprintf("Successfully unlinked your account from **%s**.", activeLink.RunescapeName)))
 }
 
 // Helper methods for cleaner code
 
 // deferEphemeralResponse defers an ephemeral response for the interaction.
-func (r *RegisterCommands) deferEphemeralResponse(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+func (r *RegisterCommands) deferEphemeralResponse(s DiscordSession, i *discordgo.InteractionCreate) error {
 	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
@@ -316,7 +320,7 @@ func (r *RegisterCommands) extractUsernameFromModal(i *discordgo.InteractionCrea
 }
 
 // sendErrorFollowup sends an error message as a followup.
-func (r *RegisterCommands) sendErrorFollowup(s *discordgo.Session, i *discordgo.InteractionCreate, message string) {
+func (r *RegisterCommands) sendErrorFollowup(s DiscordSession, i *discordgo.InteractionCreate, message string) {
 	s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
 		Content: message,
 		Flags:   discordgo.MessageFlagsEphemeral,
@@ -324,7 +328,7 @@ func (r *RegisterCommands) sendErrorFollowup(s *discordgo.Session, i *discordgo.
 }
 
 // sendEmbedFollowup sends an embed as a followup message.
-func (r *RegisterCommands) sendEmbedFollowup(s *discordgo.Session, i *discordgo.InteractionCreate, embed *discordgo.MessageEmbed) {
+func (r *RegisterCommands) sendEmbedFollowup(s DiscordSession, i *discordgo.InteractionCreate, embed *discordgo.MessageEmbed) {
 	s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
 		Embeds: []*discordgo.MessageEmbed{embed},
 		Flags:  discordgo.MessageFlagsEphemeral,
@@ -383,9 +387,107 @@ func (r *RegisterCommands) parseDiscordID(idStr string) (int64, error) {
 }
 
 // updateMemberNickname attempts to update a guild member's nickname.
-func (r *RegisterCommands) updateMemberNickname(s *discordgo.Session, guildID, userID, nickname string) error {
+// Returns ErrNicknameHierarchy if Discord refuses because of role hierarchy or ownership.
+func updateMemberNickname(s MemberManager, guildID, userID, nickname string) error {
 	if guildID == "" {
 		return ErrNoGuildContext
 	}
-	return s.GuildMemberNickname(guildID, userID, nickname)
+
+	err := s.GuildMemberNickname(guildID, userID, nickname)
+	if isDiscordErrorCode(err, discordgo.ErrCodeMissingPermissions) {
+		return ErrNicknameHierarchy
+	}
+	return err
+}
+
+// linkAccount makes username the only active account link for a Discord member.
+// Existing links are deactivated; a previously used link is reactivated rather than duplicated.
+// Returns ErrAccountAlreadyLinked if the link is already active.
+func linkAccount(ctx context.Context, db *database.Queries, dbSQL *sql.DB, discordID int64, username string) error {
+	tx, err := dbSQL.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // Rollback is safe to call even after commit
+
+	if err := linkAccountTx(ctx, db.WithTx(tx), discordID, username); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// linkAvailableAccount is linkAccount for coordinators linking other members. It returns an
+// *RSNLinkedError instead of linking an RSN that is actively linked to someone else.
+func linkAvailableAccount(ctx context.Context, db *database.Queries, dbSQL *sql.DB, discordID int64, username string) error {
+	tx, err := dbSQL.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // Rollback is safe to call even after commit
+
+	if err := linkAvailableAccountTx(ctx, db.WithTx(tx), discordID, username); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// linkAvailableAccountTx performs the work of linkAvailableAccount using an existing transaction,
+// so the ownership check and the link can't be split by a concurrent link.
+func linkAvailableAccountTx(ctx context.Context, qtx *database.Queries, discordID int64, username string) error {
+	existing, err := qtx.GetAccountLinksByUsername(ctx, username)
+	if err != nil {
+		return fmt.Errorf("look up existing links: %w", err)
+	}
+	for _, link := range existing {
+		if link.IsActive && link.DiscordMemberID != discordID {
+			return &RSNLinkedError{RSN: link.RunescapeName, DiscordMemberID: link.DiscordMemberID}
+		}
+	}
+	return linkAccountTx(ctx, qtx, discordID, username)
+}
+
+// linkAccountTx performs the work of linkAccount using an existing transaction.
+func linkAccountTx(ctx context.Context, qtx *database.Queries, discordID int64, username string) error {
+	// Check if this exact account link already exists and is active
+	existingLink, err := qtx.GetExistingAccountLink(ctx, database.GetExistingAccountLinkParams{
+		DiscordMemberID: discordID,
+		LOWER:           strings.ToLower(username),
+	})
+	linkExists := (err == nil)
+
+	if linkExists && existingLink.IsActive {
+		return ErrAccountAlreadyLinked
+	}
+
+	// Deactivate all existing links for this user
+	if err = qtx.DeactivateAllAccountLinksForUser(ctx, discordID); err != nil {
+		return fmt.Errorf("deactivate existing links: %w", err)
+	}
+
+	// If the link exists but was inactive, reactivate it; otherwise create new
+	if linkExists {
+		log.Printf("Reactivating existing account link: %d", existingLink.ID)
+		if err = qtx.ActivateAccountLink(ctx, existingLink.ID); err != nil {
+			return fmt.Errorf("reactivate account link: %w", err)
+		}
+		return nil
+	}
+
+	log.Printf("Creating new account link for user %d with RSN %s", discordID, username)
+	if _, err = qtx.CreateAccountLink(ctx, database.CreateAccountLinkParams{
+		DiscordMemberID: discordID,
+		RunescapeName:   username,
+		IsActive:        true,
+	}); err != nil {
+		return fmt.Errorf("create account link: %w", err)
+	}
+	return nil
 }
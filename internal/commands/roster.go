@@ -0,0 +1,204 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/audit"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/embeds"
+	"github.com/kaffeed/voidling/internal/wiseoldman"
+)
+
+// womGroupMemberRole is the group role given to players the roster sync adds.
+const womGroupMemberRole = "member"
+
+// ErrNoWOMGroup is returned when a guild hasn't configured a Wise Old Man group.
+var ErrNoWOMGroup = errors.New("no Wise Old Man group configured")
+
+// RosterResult describes what a roster sync changed in the guild's Wise Old Man group.
+type RosterResult struct {
+	GroupID int64
+	// Added lists the linked RSNs added to the group.
+	Added []string
+	// Unlinked lists group members that no Discord member has linked.
+	Unlinked []string
+}
+
+// RosterCommands handles /roster.
+type RosterCommands struct {
+	DB        *database.Queries
+	DBSQL     *sql.DB
+	WOMClient *wiseoldman.Client
+	Audit     *audit.Logger
+}
+
+// NewRosterCommands creates a new RosterCommands instance.
+func NewRosterCommands(db *database.Queries, dbSQL *sql.DB, womClient *wiseoldman.Client, auditLog *audit.Logger) *RosterCommands {
+	return &RosterCommands{
+		DB:        db,
+		DBSQL:     dbSQL,
+		WOMClient: womClient,
+		Audit:     auditLog,
+	}
+}
+
+// HandleSync handles /roster sync, adding linked RSNs missing from the guild's Wise Old Man group
+// and listing group members without a Discord link.
+func (rc *RosterCommands) HandleSync(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	result, err := SyncRoster(ctx, s, rc.DB, rc.WOMClient, i.GuildID)
+	if errors.Is(err, ErrNoWOMGroup) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("No Wise Old Man group is configured. An administrator can set one with `/config wom-group set`."))
+		return
+	}
+	if err != nil {
+		log.Printf("Error syncing roster for guild %s: %v", i.GuildID, err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(womErrorMessage(err, "Failed to sync the roster with Wise Old Man. Check the group's verification code and try again.")))
+		return
+	}
+
+	if len(result.Added) > 0 {
+		rc.Audit.Record(ctx, s, audit.Entry{
+			GuildID: i.GuildID,
+			ActorID: i.Member.User.ID,
+			Action:  audit.ActionRosterSync,
+			Target:  fmt.Sprintf("WOM group #%d", result.GroupID),
+			After:   "added " + strings.Join(result.Added, ", "),
+		})
+	}
+
+	sendEphemeralEmbed(s, i, embeds.RosterSync(result.GroupID, result.Added, result.Unlinked))
+}
+
+// HandleUpdateAll handles /roster update-all, asking Wise Old Man to update every outdated group member.
+func (rc *RosterCommands) HandleUpdateAll(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	group, err := loadWOMGroup(ctx, rc.DB, i.GuildID)
+	if errors.Is(err, ErrNoWOMGroup) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("No Wise Old Man group is configured. An administrator can set one with `/config wom-group set`."))
+		return
+	}
+	if err != nil {
+		log.Printf("Error loading WOM group: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to fetch configuration. Please try again."))
+		return
+	}
+
+	resp, err := rc.WOMClient.UpdateAllGroupMembers(ctx, group.GroupID, group.VerificationCode)
+	if err != nil {
+		log.Printf("Error updating WOM group %d: %v", group.GroupID, err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(womErrorMessage(err, "Wise Old Man didn't accept the update. Members updated in the last few minutes are skipped, so try again later.")))
+		return
+	}
+
+	sendEphemeralEmbed(s, i, embeds.SuccessEmbed(fmt.Sprintf("Queued updates for %d group member(s) on Wise Old Man. %s", resp.Count, resp.Message)))
+}
+
+// SyncRoster adds the RSNs linked by the guild's members to its Wise Old Man group and reports group
+// members nobody has linked. It returns ErrNoWOMGroup if the guild has no group.
+func SyncRoster(ctx context.Context, s *discordgo.Session, db *database.Queries, womClient *wiseoldman.Client, guildID string) (RosterResult, error) {
+	group, err := loadWOMGroup(ctx, db, guildID)
+	if err != nil {
+		return RosterResult{}, err
+	}
+
+	links, err := db.GetActiveAccountLinks(ctx)
+	if err != nil {
+		return RosterResult{}, fmt.Errorf("fetch account links: %w", err)
+	}
+	members, err := guildMemberIDs(s, guildID)
+	if err != nil {
+		return RosterResult{}, fmt.Errorf("fetch members: %w", err)
+	}
+	links = slices.DeleteFunc(links, func(link database.AccountLink) bool {
+		return !members[strconv.FormatInt(link.DiscordMemberID, 10)]
+	})
+
+	memberships, err := womClient.GetGroupMembers(ctx, group.GroupID)
+	if err != nil {
+		return RosterResult{}, fmt.Errorf("fetch group %d: %w", group.GroupID, err)
+	}
+
+	toAdd, unlinked := planRosterSync(links, memberships)
+	result := RosterResult{GroupID: group.GroupID, Unlinked: unlinked}
+	if len(toAdd) == 0 {
+		return result, nil
+	}
+
+	additions := make([]wiseoldman.GroupMember, 0, len(toAdd))
+	for _, rsn := range toAdd {
+		additions = append(additions, wiseoldman.GroupMember{Username: rsn, Role: womGroupMemberRole})
+	}
+	if _, err := womClient.AddGroupMembers(ctx, group.GroupID, additions, group.VerificationCode); err != nil {
+		return result, fmt.Errorf("add members to group %d: %w", group.GroupID, err)
+	}
+	result.Added = toAdd
+	return result, nil
+}
+
+// planRosterSync compares linked RSNs with the group's members. It returns the linked RSNs missing
+// from the group and the group members nobody has linked, both sorted.
+func planRosterSync(links []database.AccountLink, memberships []wiseoldman.Membership) ([]string, []string) {
+	inGroup := make(map[string]bool, len(memberships))
+	for _, membership := range memberships {
+		inGroup[wiseoldman.StandardizeUsername(membership.Player.Username)] = true
+	}
+
+	linked := make(map[string]bool, len(links))
+	var toAdd []string
+	for _, link := range links {
+		name := wiseoldman.StandardizeUsername(link.RunescapeName)
+		if linked[name] {
+			continue
+		}
+		linked[name] = true
+		if !inGroup[name] {
+			toAdd = append(toAdd, link.RunescapeName)
+		}
+	}
+
+	var unlinked []string
+	for _, membership := range memberships {
+		if !linked[wiseoldman.StandardizeUsername(membership.Player.Username)] {
+			unlinked = append(unlinked, membership.Player.DisplayName)
+		}
+	}
+
+	slices.SortFunc(toAdd, func(a, b string) int { return strings.Compare(strings.ToLower(a), strings.ToLower(b)) })
+	slices.SortFunc(unlinked, func(a, b string) int { return strings.Compare(strings.ToLower(a), strings.ToLower(b)) })
+	return toAdd, unlinked
+}
+
+// loadWOMGroup returns the guild's Wise Old Man group, or ErrNoWOMGroup if it has none.
+func loadWOMGroup(ctx context.Context, db *database.Queries, guildID string) (database.GuildWomGroup, error) {
+	id, err := strconv.ParseInt(guildID, 10, 64)
+	if err != nil {
+		return database.GuildWomGroup{}, fmt.Errorf("parse guild ID: %w", err)
+	}
+
+	group, err := db.GetWOMGroup(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return database.GuildWomGroup{}, ErrNoWOMGroup
+	}
+	return group, err
+}
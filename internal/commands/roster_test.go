@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/testutil"
+	"github.com/kaffeed/voidling/internal/wiseoldman"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanRosterSync(t *testing.T) {
+	links := []database.AccountLink{
+		{DiscordMemberID: 1, RunescapeName: "Zezima"},
+		{DiscordMemberID: 2, RunescapeName: "Lynx_Titan"},
+		{DiscordMemberID: 3, RunescapeName: "new member"},
+		{DiscordMemberID: 4, RunescapeName: "ZEZIMA"}, // Shared RSN is added once
+		{DiscordMemberID: 5, RunescapeName: "b0aty"},
+	}
+	membership := func(username, displayName string) wiseoldman.Membership {
+		return wiseoldman.Membership{Player: wiseoldman.Player{Username: username, DisplayName: displayName}}
+	}
+	memberships := []wiseoldman.Membership{
+		membership("zezima", "Zezima"),
+		membership("lynx titan", "Lynx Titan"),
+		membership("woox", "Woox"),
+		membership("alt account", "Alt Account"),
+	}
+
+	toAdd, unlinked := planRosterSync(links, memberships)
+
+	assert.Equal(t, []string{"b0aty", "new member"}, toAdd)
+	assert.Equal(t, []string{"Alt Account", "Woox"}, unlinked)
+}
+
+func TestLoadWOMGroup(t *testing.T) {
+	db, q := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	ctx := t.Context()
+
+	_, err := loadWOMGroup(ctx, q, "42")
+	assert.ErrorIs(t, err, ErrNoWOMGroup)
+
+	require.NoError(t, q.UpsertWOMGroup(ctx, database.UpsertWOMGroupParams{
+		GuildID:          42,
+		GroupID:          139,
+		VerificationCode: "123-456-789",
+		UpdatedBy:        7,
+	}))
+
+	group, err := loadWOMGroup(ctx, q, "42")
+	require.NoError(t, err)
+	assert.Equal(t, int64(139), group.GroupID)
+	assert.Equal(t, "123-456-789", group.VerificationCode)
+
+	require.NoError(t, q.DeleteWOMGroup(ctx, 42))
+	_, err = loadWOMGroup(ctx, q, "42")
+	assert.ErrorIs(t, err, ErrNoWOMGroup)
+}
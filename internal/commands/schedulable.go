@@ -9,22 +9,29 @@ import (
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/audit"
 	"github.com/kaffeed/voidling/internal/database"
 	"github.com/kaffeed/voidling/internal/embeds"
+	"github.com/kaffeed/voidling/internal/models"
 	"github.com/kaffeed/voidling/internal/timezone"
 )
 
+// MassReminderLead is how long before a mass starts its participants are reminded.
+const MassReminderLead = 30 * time.Minute
+
 // SchedulableCommands handles Mass and Wildy Wednesday event commands.
 type SchedulableCommands struct {
 	DB    *database.Queries
 	DBSQL *sql.DB
+	Audit *audit.Logger
 }
 
 // NewSchedulableCommands creates a new SchedulableCommands instance.
-func NewSchedulableCommands(db *database.Queries, dbSQL *sql.DB) *SchedulableCommands {
+func NewSchedulableCommands(db *database.Queries, dbSQL *sql.DB, auditLog *audit.Logger) *SchedulableCommands {
 	return &SchedulableCommands{
 		DB:    db,
 		DBSQL: dbSQL,
+		Audit: auditLog,
 	}
 }
 
@@ -37,24 +44,29 @@ func (sc *SchedulableCommands) getEffectiveTimezone(ctx context.Context, guildID
 		}
 	}
 
-	// 2. Try user preference
-	userPref, err := sc.DB.GetUserTimezone(ctx, userID)
+	return memberTimezone(ctx, sc.DB, guildID, userID)
+}
+
+// memberTimezone returns a member's timezone preference, falling back to the guild default and then UTC.
+func memberTimezone(ctx context.Context, db *database.Queries, guildID, userID int64) string {
+	// Try user preference
+	userPref, err := db.GetUserTimezone(ctx, userID)
 	if err == nil {
 		return userPref.Timezone
 	}
 
-	// 3. Try guild default
-	guildConfig, err := sc.DB.GetGuildConfig(ctx, guildID)
+	// Try guild default
+	guildConfig, err := db.GetGuildConfig(ctx, guildID)
 	if err == nil && guildConfig.DefaultTimezone.Valid {
 		return guildConfig.DefaultTimezone.String
 	}
 
-	// 4. Fallback to UTC
+	// Fallback to UTC
 	return "UTC"
 }
 
 // HandleMassEvent handles /mass command.
-func (sc *SchedulableCommands) HandleMassEvent(s *discordgo.Session, i *discordgo.InteractionCreate) {
+func (sc *SchedulableCommands) HandleMassEvent(s DiscordSession, i *discordgo.InteractionCreate) {
 	ctx := context.Background()
 
 	// Defer the response
@@ -138,21 +150,27 @@ func (sc *SchedulableCommands) HandleMassEvent(s *discordgo.Session, i *discordg
 		Type:           "Mass",
 		Activity:       activity,
 		Location:       location,
-		ScheduledAt:    scheduledTime,
+		ScheduledAt:    scheduledTime.UTC(), // UTC so time range queries compare correctly; tz keeps the original zone
 		DiscordEventID: discordEvent.ID,
 		Timezone:       sql.NullString{String: tz, Valid: true},
+		GuildID:        sql.NullInt64{Int64: guildID, Valid: guildID != 0},
 	})
 	if err != nil {
 		log.Printf("Error storing event in database: %v", err)
 		// Event created in Discord, but failed to store - not critical
 	}
 
+	sc.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionMassCreate,
+		Target:  fmt.Sprintf("%s at %s (event %s)", eventName, location, discordEvent.ID),
+		After:   fmt.Sprintf("%s %s, %d minutes", scheduledTime.Format("2006-01-02 15:04"), tz, durationMinutes),
+	})
+
 	// Get notification role if configured
-	content := ""
+	content := notificationMention(ctx, sc.DB, guildID, models.EventTypeMass)
 	guildConfig, err := sc.DB.GetGuildConfig(ctx, guildID)
-	if err == nil && guildConfig.EventNotificationRoleID.Valid {
-		content = fmt.Sprintf("<@&%d>", guildConfig.EventNotificationRoleID.Int64)
-	}
 
 	embed := embeds.MassEventWithTimezone(activity, location, scheduledTime, tz)
 
@@ -210,7 +228,7 @@ func (sc *SchedulableCommands) HandleMassEvent(s *discordgo.Session, i *discordg
 }
 
 // HandleParticipateInMass handles mass event participation button clicks.
-func (sc *SchedulableCommands) HandleParticipateInMass(s *discordgo.Session, i *discordgo.InteractionCreate, discordEventID string) {
+func (sc *SchedulableCommands) HandleParticipateInMass(s DiscordSession, i *discordgo.InteractionCreate, discordEventID string) {
 	ctx := context.Background()
 
 	// Defer the response
@@ -303,7 +321,7 @@ func (sc *SchedulableCommands) HandleParticipateInMass(s *discordgo.Session, i *
 }
 
 // HandleListParticipantsMass handles listing mass event participants.
-func (sc *SchedulableCommands) HandleListParticipantsMass(s *discordgo.Session, i *discordgo.InteractionCreate, discordEventID string) {
+func (sc *SchedulableCommands) HandleListParticipantsMass(s DiscordSession, i *discordgo.InteractionCreate, discordEventID string) {
 	ctx := context.Background()
 
 	// Defer the response
@@ -363,3 +381,57 @@ func (sc *SchedulableCommands) HandleListParticipantsMass(s *discordgo.Session,
 		Flags:   discordgo.MessageFlagsEphemeral,
 	})
 }
+
+// SendMassReminders DMs every participant of a mass starting within MassReminderLead of now
+// who hasn't been reminded yet, and returns how many reminders were sent. Guilds that disabled
+// reminders are skipped. Participants are only tried once, so closed DMs don't cause retries.
+func SendMassReminders(ctx context.Context, s MessageSender, db *database.Queries, now time.Time) (int, error) {
+	now = now.UTC()
+	participations, err := db.GetUnnotifiedParticipations(ctx, database.GetUnnotifiedParticipationsParams{
+		ScheduledAt:   now,
+		ScheduledAt_2: now.Add(MassReminderLead),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("fetch unnotified participations: %w", err)
+	}
+
+	enabled := make(map[int64]bool)
+	sent := 0
+	for _, p := range participations {
+		if p.GuildID.Valid {
+			on, ok := enabled[p.GuildID.Int64]
+			if !ok {
+				on = FeatureEnabled(ctx, db, strconv.FormatInt(p.GuildID.Int64, 10), FeatureReminders)
+				enabled[p.GuildID.Int64] = on
+			}
+			if !on {
+				continue
+			}
+		}
+
+		if err := sendMassReminder(s, p); err != nil {
+			log.Printf("Error sending mass reminder to %d: %v", p.DiscordMemberID, err)
+		} else {
+			sent++
+		}
+
+		if err := db.MarkParticipationAsNotified(ctx, p.ID); err != nil {
+			return sent, fmt.Errorf("mark participation notified: %w", err)
+		}
+	}
+	return sent, nil
+}
+
+// sendMassReminder DMs a single participant about their upcoming mass.
+func sendMassReminder(s MessageSender, p database.GetUnnotifiedParticipationsRow) error {
+	channel, err := s.UserChannelCreate(strconv.FormatInt(p.DiscordMemberID, 10))
+	if err != nil {
+		return fmt.Errorf("create DM channel: %w", err)
+	}
+
+	embed := embeds.ScheduledEventReminder(models.EventTypeMass, models.HiscoreField(FormatActivityName(p.Activity)), p.Location, p.ScheduledAt)
+	if _, err := s.ChannelMessageSendEmbed(channel.ID, embed); err != nil {
+		return fmt.Errorf("send DM: %w", err)
+	}
+	return nil
+}
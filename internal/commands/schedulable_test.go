@@ -0,0 +1,161 @@
+package commands
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/audit"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/testutil"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// massInteraction builds a /mass command with the given options.
+func massInteraction(activity, location, when string, duration int, tz string) *discordgo.InteractionCreate {
+	i := testutil.CreateTestInteraction("mass", "1001", "42")
+	options := []*discordgo.ApplicationCommandInteractionDataOption{
+		{Name: "activity", Type: discordgo.ApplicationCommandOptionString, Value: activity},
+		{Name: "location", Type: discordgo.ApplicationCommandOptionString, Value: location},
+		{Name: "time", Type: discordgo.ApplicationCommandOptionString, Value: when},
+		{Name: "duration", Type: discordgo.ApplicationCommandOptionInteger, Value: float64(duration)},
+	}
+	if tz != "" {
+		options = append(options, &discordgo.ApplicationCommandInteractionDataOption{
+			Name: "timezone", Type: discordgo.ApplicationCommandOptionString, Value: tz,
+		})
+	}
+	i.Data = discordgo.ApplicationCommandInteractionData{Name: "mass", Options: options}
+	return i
+}
+
+func TestHandleMassEvent(t *testing.T) {
+	db, q := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+	ctx := t.Context()
+
+	session := &testutil.MockDiscordSession{}
+	session.On("InteractionRespond", mock.Anything, mock.Anything).Return(nil)
+	var params *discordgo.GuildScheduledEventParams
+	session.On("GuildScheduledEventCreate", "42", mock.Anything).
+		Run(func(args mock.Arguments) { params = args.Get(1).(*discordgo.GuildScheduledEventParams) }).
+		Return(&discordgo.GuildScheduledEvent{ID: "event-1"}, nil)
+	followups := captureFollowups(session)
+
+	sc := NewSchedulableCommands(q, db, audit.NewLogger(q))
+	sc.HandleMassEvent(session, massInteraction("Nex", "World 444", "2099-01-15 20:00", 90, "Europe/London"))
+
+	session.AssertExpectations(t)
+	require.NotNil(t, params)
+	assert.Equal(t, "Mass: Nex", params.Name)
+	assert.Equal(t, "World 444", params.EntityMetadata.Location)
+	start := time.Date(2099, 1, 15, 20, 0, 0, 0, time.UTC) // London is on GMT in January
+	assert.True(t, params.ScheduledStartTime.Equal(start))
+	assert.True(t, params.ScheduledEndTime.Equal(start.Add(90*time.Minute)))
+
+	// Without a notification channel the announcement is the followup
+	require.Len(t, *followups, 1)
+	row := (*followups)[0].Components[0].(discordgo.ActionsRow)
+	assert.Equal(t, "participate-mass:event-1", row.Components[0].(discordgo.Button).CustomID)
+
+	event, err := q.GetSchedulableEventByDiscordID(ctx, "event-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Nex", event.Activity)
+	assert.True(t, event.ScheduledAt.Equal(start))
+	assert.Equal(t, sql.NullString{String: "Europe/London", Valid: true}, event.Timezone)
+}
+
+func TestHandleMassEventRejectsPastTime(t *testing.T) {
+	db, q := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	session := &testutil.MockDiscordSession{}
+	session.On("InteractionRespond", mock.Anything, mock.Anything).Return(nil)
+	followups := captureFollowups(session)
+
+	sc := NewSchedulableCommands(q, db, audit.NewLogger(q))
+	sc.HandleMassEvent(session, massInteraction("Nex", "World 444", "2001-01-15 20:00", 60, "UTC"))
+
+	assert.Contains(t, followupText(*followups), "Event time must be in the future!")
+	session.AssertNotCalled(t, "GuildScheduledEventCreate", mock.Anything, mock.Anything)
+}
+
+func TestHandleParticipateInMass(t *testing.T) {
+	db, q := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+	ctx := t.Context()
+
+	event, err := q.CreateSchedulableEvent(ctx, database.CreateSchedulableEventParams{
+		Type:           "Mass",
+		Activity:       "Nex",
+		Location:       "World 444",
+		ScheduledAt:    time.Now().Add(time.Hour).UTC(),
+		DiscordEventID: "event-1",
+		GuildID:        sql.NullInt64{Int64: testGuildID, Valid: true},
+	})
+	require.NoError(t, err)
+
+	session := &testutil.MockDiscordSession{}
+	session.On("InteractionRespond", mock.Anything, mock.Anything).Return(nil)
+	followups := captureFollowups(session)
+	sc := NewSchedulableCommands(q, db, audit.NewLogger(q))
+	i := testutil.CreateTestButtonInteraction("participate-mass:event-1", "1001", "42")
+
+	// Unlinked members are turned away
+	sc.HandleParticipateInMass(session, i, "event-1")
+	assert.Contains(t, followupText(*followups), "You must link your RuneScape account first!")
+
+	link := testutil.CreateTestAccountLink(t, q, 1001, "Zezima", true)
+	sc.HandleParticipateInMass(session, i, "event-1")
+	sc.HandleParticipateInMass(session, i, "event-1")
+
+	require.Len(t, *followups, 3)
+	assert.Contains(t, followupText((*followups)[1:2]), "You're registered for **Nex**!")
+	assert.Contains(t, followupText((*followups)[2:]), "You're already registered for this event!")
+
+	_, err = q.GetSchedulableParticipation(ctx, database.GetSchedulableParticipationParams{
+		EventID:       event.ID,
+		AccountLinkID: link.ID,
+	})
+	assert.NoError(t, err)
+}
+
+func TestMigrationNormalizesMassTimes(t *testing.T) {
+	db, err := sql.Open("sqlite3", "file:mass_times?mode=memory")
+	require.NoError(t, err)
+	defer testutil.CleanupTestDB(t, db)
+	db.SetMaxOpenConns(1)
+	q := database.New(db)
+	ctx := t.Context()
+
+	require.NoError(t, goose.SetDialect("sqlite3"))
+	require.NoError(t, goose.UpTo(db, "../../migrations", 22))
+
+	// Before masses were stored in UTC, they kept the scheduler's offset
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	start := time.Date(2099, 5, 15, 20, 0, 0, 0, berlin)
+	_, err = q.CreateSchedulableEvent(ctx, database.CreateSchedulableEventParams{
+		Type: "Mass", Activity: "nex", Location: "w420", ScheduledAt: start,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, goose.Up(db, "../../migrations"))
+
+	var stored string
+	require.NoError(t, db.QueryRowContext(ctx, "SELECT scheduled_at || '' FROM schedulable_events").Scan(&stored))
+	assert.Equal(t, "2099-05-15 18:00:00+00:00", stored)
+
+	events, err := q.GetSchedulableEventsInTimeRange(ctx, database.GetSchedulableEventsInTimeRangeParams{
+		ScheduledAt:   start.UTC().Add(-time.Minute),
+		ScheduledAt_2: start.UTC().Add(time.Minute),
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.True(t, events[0].ScheduledAt.Equal(start))
+}
@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/wiseoldman"
+)
+
+// InteractionResponder answers slash commands, buttons and modals.
+type InteractionResponder interface {
+	InteractionRespond(interaction *discordgo.Interaction, resp *discordgo.InteractionResponse, options ...discordgo.RequestOption) error
+	InteractionResponseEdit(interaction *discordgo.Interaction, edit *discordgo.WebhookEdit, options ...discordgo.RequestOption) (*discordgo.Message, error)
+	FollowupMessageCreate(interaction *discordgo.Interaction, wait bool, data *discordgo.WebhookParams, options ...discordgo.RequestOption) (*discordgo.Message, error)
+}
+
+// MessageSender posts to channels, threads and DMs.
+type MessageSender interface {
+	ChannelMessageSend(channelID, content string, options ...discordgo.RequestOption) (*discordgo.Message, error)
+	ChannelMessageSendComplex(channelID string, data *discordgo.MessageSend, options ...discordgo.RequestOption) (*discordgo.Message, error)
+	ChannelMessageSendEmbed(channelID string, embed *discordgo.MessageEmbed, options ...discordgo.RequestOption) (*discordgo.Message, error)
+	ThreadStartComplex(channelID string, data *discordgo.ThreadStart, options ...discordgo.RequestOption) (*discordgo.Channel, error)
+	UserChannelCreate(recipientID string, options ...discordgo.RequestOption) (*discordgo.Channel, error)
+}
+
+// MemberManager reads guild members and changes their nicknames and roles.
+type MemberManager interface {
+	GuildMember(guildID, userID string, options ...discordgo.RequestOption) (*discordgo.Member, error)
+	GuildRoles(guildID string, options ...discordgo.RequestOption) ([]*discordgo.Role, error)
+	GuildMemberNickname(guildID, userID, nickname string, options ...discordgo.RequestOption) error
+	GuildMemberRoleAdd(guildID, userID, roleID string, options ...discordgo.RequestOption) error
+	GuildMemberRoleRemove(guildID, userID, roleID string, options ...discordgo.RequestOption) error
+}
+
+// DiscordSession is the part of the Discord API used by the event and account linking handlers.
+// *discordgo.Session implements it; tests use testutil.MockDiscordSession.
+type DiscordSession interface {
+	InteractionResponder
+	MessageSender
+	MemberManager
+	GuildScheduledEventCreate(guildID string, event *discordgo.GuildScheduledEventParams, options ...discordgo.RequestOption) (*discordgo.GuildScheduledEvent, error)
+}
+
+var _ DiscordSession = (*discordgo.Session)(nil)
+
+// PlayerClient looks up players on Wise Old Man.
+type PlayerClient interface {
+	GetPlayer(ctx context.Context, username string) (*wiseoldman.Player, error)
+}
+
+// CompetitionClient runs the Wise Old Man competitions behind BOTW and SOTW.
+type CompetitionClient interface {
+	GetPlayer(ctx context.Context, username string) (*wiseoldman.Player, error)
+	UpdatePlayer(ctx context.Context, username string) (*wiseoldman.Player, error)
+	CreateCompetition(ctx context.Context, req wiseoldman.CreateCompetitionRequest) (*wiseoldman.CreateCompetitionResponse, error)
+	GetCompetition(ctx context.Context, competitionID int64) (*wiseoldman.Competition, error)
+	EditCompetition(ctx context.Context, competitionID int64, req wiseoldman.EditCompetitionRequest) (*wiseoldman.Competition, error)
+	AddParticipants(ctx context.Context, competitionID int64, usernames []string, verificationCode string) (*wiseoldman.CompetitionUpdateResponse, error)
+	AddGroupMembers(ctx context.Context, groupID int64, members []wiseoldman.GroupMember, verificationCode string) (*wiseoldman.GroupUpdateResponse, error)
+}
+
+// GainsClient looks up what players gained on Wise Old Man.
+type GainsClient interface {
+	GetPlayerGainsBetween(ctx context.Context, username string, start, end time.Time) (*wiseoldman.PlayerGains, error)
+}
+
+var (
+	_ PlayerClient      = (*wiseoldman.Client)(nil)
+	_ CompetitionClient = (*wiseoldman.Client)(nil)
+	_ GainsClient       = (*wiseoldman.Client)(nil)
+)
@@ -0,0 +1,635 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/audit"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/embeds"
+	"github.com/kaffeed/voidling/internal/timezone"
+)
+
+const (
+	// setupSessionTTL is how long an unfinished /setup flow stays usable.
+	setupSessionTTL = 15 * time.Minute
+	// maxSelectOptions is Discord's limit on options in a string select menu.
+	maxSelectOptions = 25
+)
+
+// Steps of the /setup wizard, in order.
+const (
+	setupStepCoordinatorRole = iota
+	setupStepNotificationChannel
+	setupStepNotificationRole
+	setupStepCompetitionChannel
+	setupStepTimezone
+	setupStepWarningChannel
+	setupStepSummary
+)
+
+// channelPostPermissions are what the bot needs to post embeds in a channel.
+const channelPostPermissions = discordgo.PermissionViewChannel | discordgo.PermissionSendMessages | discordgo.PermissionEmbedLinks
+
+// permissionNames labels the permissions /setup and /config doctor verify.
+var permissionNames = []struct {
+	bit  int64
+	name string
+}{
+	{discordgo.PermissionViewChannel, "View Channel"},
+	{discordgo.PermissionSendMessages, "Send Messages"},
+	{discordgo.PermissionEmbedLinks, "Embed Links"},
+	{discordgo.PermissionMentionEveryone, "Mention All Roles"},
+	{discordgo.PermissionCreatePublicThreads, "Create Public Threads"},
+	{discordgo.PermissionSendMessagesInThreads, "Send Messages in Threads"},
+	{discordgo.PermissionManageEvents, "Manage Events"},
+	{discordgo.PermissionManageNicknames, "Manage Nicknames"},
+	{discordgo.PermissionManageRoles, "Manage Roles"},
+	{discordgo.PermissionModerateMembers, "Timeout Members"},
+}
+
+// setupStep describes one page of the wizard.
+type setupStep struct {
+	title       string
+	description string
+}
+
+var setupSteps = []setupStep{
+	setupStepCoordinatorRole:     {"Coordinator Role", "Pick the role allowed to run BOTW/SOTW events and manage account links."},
+	setupStepNotificationChannel: {"Event Notification Channel", "Pick the channel new events are announced in."},
+	setupStepNotificationRole:    {"Event Notification Role", "Pick the role pinged when an event is announced."},
+	setupStepCompetitionChannel:  {"Competition Code Channel", "Pick the private channel Wise Old Man verification codes are posted to."},
+	setupStepTimezone:            {"Default Timezone", "Pick the timezone used for mass events when a member hasn't set their own. Use `/config set-default-timezone` for zones not listed here."},
+	setupStepWarningChannel:      {"Warning Channel (optional)", "Pick the moderation channel warnings are posted to."},
+}
+
+// setupSession holds the choices made so far in one /setup flow.
+type setupSession struct {
+	// mu serializes clicks on the wizard, so quick Skip/Back presses can't race on the step
+	mu sync.Mutex
+
+	requesterID string
+	guildID     int64
+	step        int
+	expiresAt   time.Time
+
+	// Current settings, shown as defaults and kept when a step is skipped
+	current guildSettings
+
+	coordinatorRoleID     string
+	notificationChannelID string
+	notificationRoleID    string
+	competitionChannelID  string
+	timezone              string
+	warningChannelID      string
+}
+
+// SetupCommands handles the /setup wizard.
+type SetupCommands struct {
+	DB    *database.Queries
+	DBSQL *sql.DB
+	Audit *audit.Logger
+
+	sessionsMu sync.Mutex
+	sessions   map[string]*setupSession
+}
+
+// NewSetupCommands creates a new SetupCommands instance.
+func NewSetupCommands(db *database.Queries, dbSQL *sql.DB, auditLog *audit.Logger) *SetupCommands {
+	return &SetupCommands{
+		DB:       db,
+		DBSQL:    dbSQL,
+		Audit:    auditLog,
+		sessions: make(map[string]*setupSession),
+	}
+}
+
+// HandleSetup handles /setup, starting the wizard at its first step.
+func (sc *SetupCommands) HandleSetup(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	if !isServerOwnerOrAdmin(s, i) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Only the server owner or administrators can run the setup wizard."))
+		return
+	}
+
+	guildID, err := strconv.ParseInt(i.GuildID, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing guild ID: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse guild ID."))
+		return
+	}
+
+	current, err := loadGuildSettings(ctx, sc.DB, guildID)
+	if err != nil {
+		log.Printf("Error loading guild settings for setup: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Database error. Please try again later."))
+		return
+	}
+
+	token := i.ID
+	session := &setupSession{
+		requesterID: i.Member.User.ID,
+		guildID:     guildID,
+		step:        setupStepCoordinatorRole,
+		expiresAt:   time.Now().Add(setupSessionTTL),
+		current:     current,
+	}
+	sc.storeSession(token, session)
+
+	embed, components := renderSetupStep(token, session, nil)
+	empty := ""
+	_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Content:    &empty,
+		Embeds:     &[]*discordgo.MessageEmbed{embed},
+		Components: &components,
+	})
+	if err != nil {
+		log.Printf("Error sending setup wizard: %v", err)
+	}
+}
+
+// HandleSetupSelect records the value picked in the current step's select menu and advances.
+func (sc *SetupCommands) HandleSetupSelect(s *discordgo.Session, i *discordgo.InteractionCreate, token string) {
+	session := sc.session(token, i.Member.User.ID)
+	if session == nil {
+		respondSetupExpired(s, i)
+		return
+	}
+	session.mu.Lock()
+	defer session.mu.Unlock()
+
+	data := i.MessageComponentData()
+	if len(data.Values) == 0 {
+		sc.respondStep(s, i, token, session)
+		return
+	}
+	value := data.Values[0]
+
+	switch session.step {
+	case setupStepCoordinatorRole:
+		session.coordinatorRoleID = value
+	case setupStepNotificationChannel:
+		session.notificationChannelID = value
+	case setupStepNotificationRole:
+		session.notificationRoleID = value
+	case setupStepCompetitionChannel:
+		session.competitionChannelID = value
+	case setupStepTimezone:
+		if err := timezone.ValidateTimezone(value); err != nil {
+			sc.respondStep(s, i, token, session)
+			return
+		}
+		session.timezone = value
+	case setupStepWarningChannel:
+		session.warningChannelID = value
+	}
+
+	session.step++
+	sc.respondStep(s, i, token, session)
+}
+
+// HandleSetupSkip keeps the current value for this step and advances.
+func (sc *SetupCommands) HandleSetupSkip(s *discordgo.Session, i *discordgo.InteractionCreate, token string) {
+	session := sc.session(token, i.Member.User.ID)
+	if session == nil {
+		respondSetupExpired(s, i)
+		return
+	}
+	session.mu.Lock()
+	defer session.mu.Unlock()
+
+	if session.step < setupStepSummary {
+		session.step++
+	}
+	sc.respondStep(s, i, token, session)
+}
+
+// HandleSetupBack returns to the previous step.
+func (sc *SetupCommands) HandleSetupBack(s *discordgo.Session, i *discordgo.InteractionCreate, token string) {
+	session := sc.session(token, i.Member.User.ID)
+	if session == nil {
+		respondSetupExpired(s, i)
+		return
+	}
+	session.mu.Lock()
+	defer session.mu.Unlock()
+
+	if session.step > setupStepCoordinatorRole {
+		session.step--
+	}
+	sc.respondStep(s, i, token, session)
+}
+
+// HandleSetupSave writes the wizard's choices in a single transaction.
+func (sc *SetupCommands) HandleSetupSave(s *discordgo.Session, i *discordgo.InteractionCreate, token string) {
+	ctx := context.Background()
+
+	session := sc.takeSession(token, i.Member.User.ID)
+	if session == nil {
+		respondSetupExpired(s, i)
+		return
+	}
+	session.mu.Lock()
+	defer session.mu.Unlock()
+
+	changes := session.changes()
+	if len(changes) == 0 {
+		respondSetupMessage(s, i, embeds.InfoEmbed("🧙 Server Setup", "Nothing was changed; every step kept its current setting."))
+		return
+	}
+
+	recordConfigVersion(ctx, sc.DB, session.guildID, i.Member.User.ID, "/setup")
+
+	if err := sc.applySetup(ctx, session); err != nil {
+		log.Printf("Error saving setup for guild %d: %v", session.guildID, err)
+		respondSetupMessage(s, i, embeds.ErrorEmbed("Saving failed and nothing was changed. Please run `/setup` again."))
+		return
+	}
+
+	log.Printf("User %s completed /setup for guild %d", i.Member.User.Username, session.guildID)
+
+	sc.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionSetup,
+		After:   strings.Join(changes, "\n"),
+	})
+
+	respondSetupMessage(s, i, embeds.SuccessEmbed("Setup saved!\n\n"+strings.Join(changes, "\n")+
+		"\n\nUse `/config show` to review the configuration or `/config history` to undo this."))
+}
+
+// HandleSetupCancel discards the wizard without saving.
+func (sc *SetupCommands) HandleSetupCancel(s *discordgo.Session, i *discordgo.InteractionCreate, token string) {
+	sc.takeSession(token, i.Member.User.ID)
+
+	respondToInteraction(s, i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    "Setup cancelled. No settings were changed.",
+			Embeds:     []*discordgo.MessageEmbed{},
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+}
+
+// respondStep replaces the wizard message with the session's current step.
+func (sc *SetupCommands) respondStep(s *discordgo.Session, i *discordgo.InteractionCreate, token string, session *setupSession) {
+	var problems []string
+	if session.step == setupStepSummary {
+		problems = verifySetupPermissions(s, session)
+	}
+
+	embed, components := renderSetupStep(token, session, problems)
+	err := respondToInteraction(s, i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    "",
+			Embeds:     []*discordgo.MessageEmbed{embed},
+			Components: components,
+		},
+	})
+	if err != nil {
+		log.Printf("Error updating setup wizard: %v", err)
+	}
+}
+
+// applySetup saves every step that was answered; skipped steps are left untouched.
+func (sc *SetupCommands) applySetup(ctx context.Context, session *setupSession) error {
+	tx, err := sc.DBSQL.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // Rollback is safe to call even after commit
+
+	qtx := sc.DB.WithTx(tx)
+	guildID := session.guildID
+
+	if err := ensureGuildConfig(ctx, qtx, guildID); err != nil {
+		return err
+	}
+
+	if id := settingID(session.coordinatorRoleID); id.Valid {
+		if err := qtx.UpdateCoordinatorRole(ctx, database.UpdateCoordinatorRoleParams{CoordinatorRoleID: id, GuildID: guildID}); err != nil {
+			return fmt.Errorf("update coordinator role: %w", err)
+		}
+	}
+	if id := settingID(session.notificationChannelID); id.Valid {
+		if err := qtx.UpdateEventNotificationChannel(ctx, database.UpdateEventNotificationChannelParams{EventNotificationChannelID: id, GuildID: guildID}); err != nil {
+			return fmt.Errorf("update event notification channel: %w", err)
+		}
+	}
+	if id := settingID(session.notificationRoleID); id.Valid {
+		if err := qtx.UpdateEventNotificationRole(ctx, database.UpdateEventNotificationRoleParams{EventNotificationRoleID: id, GuildID: guildID}); err != nil {
+			return fmt.Errorf("update event notification role: %w", err)
+		}
+	}
+	if id := settingID(session.competitionChannelID); id.Valid {
+		if err := qtx.UpdateCompetitionCodeChannel(ctx, database.UpdateCompetitionCodeChannelParams{CompetitionCodeChannelID: id, GuildID: guildID}); err != nil {
+			return fmt.Errorf("update competition code channel: %w", err)
+		}
+	}
+	if session.timezone != "" {
+		if err := qtx.UpdateDefaultTimezone(ctx, database.UpdateDefaultTimezoneParams{
+			DefaultTimezone: sql.NullString{String: session.timezone, Valid: true},
+			GuildID:         guildID,
+		}); err != nil {
+			return fmt.Errorf("update default timezone: %w", err)
+		}
+	}
+	if id := settingID(session.warningChannelID); id.Valid {
+		if _, err := qtx.SetGuildWarningChannel(ctx, database.SetGuildWarningChannelParams{GuildID: guildID, ChannelID: id.Int64}); err != nil {
+			return fmt.Errorf("set warning channel: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// changes lists the settings this session would change, one line each.
+func (session *setupSession) changes() []string {
+	var lines []string
+	add := func(label, chosen, current string, format func(string) string) {
+		if chosen != "" && chosen != current {
+			lines = append(lines, fmt.Sprintf("**%s:** %s → %s", label, format(current), format(chosen)))
+		}
+	}
+
+	add("Coordinator role", session.coordinatorRoleID, session.current.CoordinatorRoleID, roleMention)
+	add("Event notification channel", session.notificationChannelID, session.current.EventNotificationChannelID, channelMention)
+	add("Event notification role", session.notificationRoleID, session.current.EventNotificationRoleID, roleMention)
+	add("Competition code channel", session.competitionChannelID, session.current.CompetitionCodeChannelID, channelMention)
+	add("Default timezone", session.timezone, session.current.DefaultTimezone, codeValue)
+	add("Warning channel", session.warningChannelID, session.current.WarningChannelID, channelMention)
+	return lines
+}
+
+// renderSetupStep builds the embed and components for the session's current step.
+func renderSetupStep(token string, session *setupSession, problems []string) (*discordgo.MessageEmbed, []discordgo.MessageComponent) {
+	if session.step == setupStepSummary {
+		return renderSetupSummary(token, session, problems)
+	}
+
+	step := setupSteps[session.step]
+	title := fmt.Sprintf("🧙 Server Setup — Step %d/%d: %s", session.step+1, len(setupSteps), step.title)
+	description := step.description + "\n\n**Current:** " + session.currentValue(session.step) +
+		"\n\nPick a value below, or **Skip** to keep the current setting."
+
+	menu := discordgo.SelectMenu{
+		CustomID:  "setup-select:" + token,
+		MaxValues: 1,
+	}
+	switch session.step {
+	case setupStepCoordinatorRole, setupStepNotificationRole:
+		menu.MenuType = discordgo.RoleSelectMenu
+		menu.Placeholder = "Choose a role"
+		if id := session.currentID(session.step); id != "" {
+			menu.DefaultValues = []discordgo.SelectMenuDefaultValue{{ID: id, Type: discordgo.SelectMenuDefaultValueRole}}
+		}
+	case setupStepTimezone:
+		menu.MenuType = discordgo.StringSelectMenu
+		menu.Placeholder = "Choose a timezone"
+		for _, tz := range timezone.CommonTimezones() {
+			if len(menu.Options) == maxSelectOptions {
+				break
+			}
+			menu.Options = append(menu.Options, discordgo.SelectMenuOption{
+				Label:   tz,
+				Value:   tz,
+				Default: tz == session.currentID(session.step),
+			})
+		}
+	default:
+		menu.MenuType = discordgo.ChannelSelectMenu
+		menu.Placeholder = "Choose a channel"
+		menu.ChannelTypes = []discordgo.ChannelType{discordgo.ChannelTypeGuildText, discordgo.ChannelTypeGuildNews}
+		if id := session.currentID(session.step); id != "" {
+			menu.DefaultValues = []discordgo.SelectMenuDefaultValue{{ID: id, Type: discordgo.SelectMenuDefaultValueChannel}}
+		}
+	}
+
+	buttons := []discordgo.MessageComponent{}
+	if session.step > setupStepCoordinatorRole {
+		buttons = append(buttons, discordgo.Button{Label: "Back", Style: discordgo.SecondaryButton, CustomID: "setup-back:" + token})
+	}
+	buttons = append(buttons,
+		discordgo.Button{Label: "Skip", Style: discordgo.SecondaryButton, CustomID: "setup-skip:" + token},
+		discordgo.Button{Label: "Cancel", Style: discordgo.DangerButton, CustomID: "setup-cancel:" + token},
+	)
+
+	components := []discordgo.MessageComponent{
+		discordgo.ActionsRow{Components: []discordgo.MessageComponent{menu}},
+		discordgo.ActionsRow{Components: buttons},
+	}
+	return embeds.InfoEmbed(title, description), components
+}
+
+// renderSetupSummary shows the pending changes and permission problems before saving.
+func renderSetupSummary(token string, session *setupSession, problems []string) (*discordgo.MessageEmbed, []discordgo.MessageComponent) {
+	var sb strings.Builder
+
+	changes := session.changes()
+	if len(changes) == 0 {
+		sb.WriteString("No changes; every step keeps its current setting.\n")
+	} else {
+		sb.WriteString("**Changes:**\n" + strings.Join(changes, "\n") + "\n")
+	}
+
+	if len(problems) == 0 {
+		sb.WriteString("\n✅ The bot has the permissions it needs in every configured channel.")
+	} else {
+		sb.WriteString("\n**⚠️ Permission problems:**\n" + formatProblems(problems))
+		sb.WriteString("\nYou can save anyway and fix these in the channel settings afterwards.")
+	}
+
+	components := []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{Label: "Back", Style: discordgo.SecondaryButton, CustomID: "setup-back:" + token},
+				discordgo.Button{Label: "Save", Style: discordgo.SuccessButton, CustomID: "setup-save:" + token, Disabled: len(changes) == 0},
+				discordgo.Button{Label: "Cancel", Style: discordgo.DangerButton, CustomID: "setup-cancel:" + token},
+			},
+		},
+	}
+	return embeds.InfoEmbed("🧙 Server Setup — Summary", sb.String()), components
+}
+
+// currentID returns the value a step will save: the new choice, or the existing setting.
+func (session *setupSession) currentID(step int) string {
+	chosen, existing := "", ""
+	switch step {
+	case setupStepCoordinatorRole:
+		chosen, existing = session.coordinatorRoleID, session.current.CoordinatorRoleID
+	case setupStepNotificationChannel:
+		chosen, existing = session.notificationChannelID, session.current.EventNotificationChannelID
+	case setupStepNotificationRole:
+		chosen, existing = session.notificationRoleID, session.current.EventNotificationRoleID
+	case setupStepCompetitionChannel:
+		chosen, existing = session.competitionChannelID, session.current.CompetitionCodeChannelID
+	case setupStepTimezone:
+		chosen, existing = session.timezone, session.current.DefaultTimezone
+	case setupStepWarningChannel:
+		chosen, existing = session.warningChannelID, session.current.WarningChannelID
+	}
+	if chosen != "" {
+		return chosen
+	}
+	return existing
+}
+
+// currentValue renders currentID for display.
+func (session *setupSession) currentValue(step int) string {
+	id := session.currentID(step)
+	switch step {
+	case setupStepCoordinatorRole, setupStepNotificationRole:
+		return roleMention(id)
+	case setupStepTimezone:
+		return codeValue(id)
+	default:
+		return channelMention(id)
+	}
+}
+
+// verifySetupPermissions checks that the bot can post in every channel the guild will use.
+func verifySetupPermissions(s *discordgo.Session, session *setupSession) []string {
+	var problems []string
+
+	check := func(label, channelID string, required int64) {
+		if channelID == "" {
+			return
+		}
+		if missing := missingChannelPermissions(s, channelID, required); len(missing) > 0 {
+			problems = append(problems, fmt.Sprintf("%s <#%s>: missing %s", label, channelID, strings.Join(missing, ", ")))
+		}
+	}
+
+	notificationPerms := int64(channelPostPermissions)
+	if roleID := session.currentID(setupStepNotificationRole); roleID != "" && !roleMentionable(s, session.guildID, roleID) {
+		// Pinging a role that isn't mentionable needs Mention All Roles
+		notificationPerms |= discordgo.PermissionMentionEveryone
+	}
+
+	check("Event notification channel", session.currentID(setupStepNotificationChannel), notificationPerms)
+	check("Competition code channel", session.currentID(setupStepCompetitionChannel), channelPostPermissions)
+	check("Warning channel", session.currentID(setupStepWarningChannel), channelPostPermissions)
+
+	return problems
+}
+
+// roleMentionable reports whether anyone can ping roleID. Unknown roles count as not mentionable.
+func roleMentionable(s *discordgo.Session, guildID int64, roleID string) bool {
+	roles, err := s.GuildRoles(strconv.FormatInt(guildID, 10))
+	if err != nil {
+		log.Printf("Error fetching guild roles: %v", err)
+		return false
+	}
+	for _, role := range roles {
+		if role.ID == roleID {
+			return role.Mentionable
+		}
+	}
+	return false
+}
+
+// missingChannelPermissions lists the names of required permissions the bot lacks in channelID.
+func missingChannelPermissions(s *discordgo.Session, channelID string, required int64) []string {
+	perms, err := s.UserChannelPermissions(s.State.User.ID, channelID)
+	if err != nil {
+		log.Printf("Error checking bot permissions in channel %s: %v", channelID, err)
+		return []string{"permissions could not be checked"}
+	}
+	return missingPermissions(perms, required)
+}
+
+// missingPermissions lists the names of the bits in required that perms doesn't grant.
+func missingPermissions(perms, required int64) []string {
+	if perms&discordgo.PermissionAdministrator != 0 {
+		return nil
+	}
+
+	var missing []string
+	for _, p := range permissionNames {
+		if required&p.bit != 0 && perms&p.bit == 0 {
+			missing = append(missing, p.name)
+		}
+	}
+	return missing
+}
+
+// storeSession remembers a wizard session and drops any that have expired.
+func (sc *SetupCommands) storeSession(token string, session *setupSession) {
+	sc.sessionsMu.Lock()
+	defer sc.sessionsMu.Unlock()
+
+	now := time.Now()
+	for key, s := range sc.sessions {
+		if now.After(s.expiresAt) {
+			delete(sc.sessions, key)
+		}
+	}
+	sc.sessions[token] = session
+}
+
+// session returns a wizard session if it belongs to requesterID and hasn't expired.
+func (sc *SetupCommands) session(token, requesterID string) *setupSession {
+	sc.sessionsMu.Lock()
+	defer sc.sessionsMu.Unlock()
+
+	session, ok := sc.sessions[token]
+	if !ok || session.requesterID != requesterID || time.Now().After(session.expiresAt) {
+		return nil
+	}
+	return session
+}
+
+// takeSession removes and returns a wizard session if it belongs to requesterID and hasn't expired.
+func (sc *SetupCommands) takeSession(token, requesterID string) *setupSession {
+	sc.sessionsMu.Lock()
+	defer sc.sessionsMu.Unlock()
+
+	session, ok := sc.sessions[token]
+	if !ok || session.requesterID != requesterID {
+		return nil
+	}
+	delete(sc.sessions, token)
+
+	if time.Now().After(session.expiresAt) {
+		return nil
+	}
+	return session
+}
+
+// respondSetupExpired tells the user their wizard is no longer usable.
+func respondSetupExpired(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	respondSetupMessage(s, i, embeds.ErrorEmbed("This setup has expired or was already finished. Please run `/setup` again."))
+}
+
+// respondSetupMessage replaces the wizard with a final message and removes its components.
+func respondSetupMessage(s *discordgo.Session, i *discordgo.InteractionCreate, embed *discordgo.MessageEmbed) {
+	err := respondToInteraction(s, i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{embed},
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+	if err != nil {
+		log.Printf("Error updating setup wizard: %v", err)
+	}
+}
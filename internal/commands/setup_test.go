@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMissingPermissions(t *testing.T) {
+	t.Run("all granted", func(t *testing.T) {
+		assert.Empty(t, missingPermissions(channelPostPermissions, channelPostPermissions))
+	})
+
+	t.Run("administrator grants everything", func(t *testing.T) {
+		assert.Empty(t, missingPermissions(discordgo.PermissionAdministrator, channelPostPermissions|discordgo.PermissionMentionEveryone))
+	})
+
+	t.Run("missing permissions are named", func(t *testing.T) {
+		missing := missingPermissions(discordgo.PermissionViewChannel, channelPostPermissions|discordgo.PermissionMentionEveryone)
+
+		assert.Equal(t, []string{"Send Messages", "Embed Links", "Mention All Roles"}, missing)
+	})
+}
+
+func TestSetupSessionChanges(t *testing.T) {
+	session := &setupSession{
+		current: guildSettings{
+			CoordinatorRoleID: "1",
+			DefaultTimezone:   "UTC",
+		},
+	}
+
+	assert.Empty(t, session.changes(), "nothing chosen yet")
+	assert.Equal(t, "1", session.currentID(setupStepCoordinatorRole), "falls back to the current setting")
+
+	session.coordinatorRoleID = "1"
+	session.timezone = "Europe/Berlin"
+	session.warningChannelID = "5"
+
+	assert.Equal(t, []string{
+		"**Default timezone:** `UTC` → `Europe/Berlin`",
+		"**Warning channel:** Not configured → <#5>",
+	}, session.changes())
+	assert.Equal(t, "Europe/Berlin", session.currentID(setupStepTimezone))
+}
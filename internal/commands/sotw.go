@@ -7,11 +7,9 @@ import (
 
 // HandleSOTWStart handles /sotw start command.
 func (t *TrackableCommands) HandleSOTWStart(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	data := i.ApplicationCommandData()
-	// Options[0] is the subcommand, Options[0].Options[0] is the skill parameter
-	skill := data.Options[0].Options[0].StringValue()
+	skill := subcommandOption(i, "skill").StringValue()
 
-	err := t.StartEvent(s, i, models.EventTypeSkillOfTheWeek, skill)
+	err := t.StartEvent(s, i, models.EventTypeSkillOfTheWeek, models.EventTypeSkillOfTheWeek, skill, teamSetupOption(i))
 	if err != nil {
 		return
 	}
@@ -0,0 +1,639 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/audit"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/embeds"
+	"github.com/kaffeed/voidling/internal/models"
+	"github.com/kaffeed/voidling/internal/wiseoldman"
+)
+
+// maxTeams is the most teams a team event can have.
+const maxTeams = 10
+
+// maxTeamNameLength is the longest team name Wise Old Man accepts.
+const maxTeamNameLength = 30
+
+// defaultTeamNames are used for balanced events started without team names.
+var defaultTeamNames = []string{"Team 1", "Team 2"}
+
+// TeamSetup configures a team event, in which members join teams and the team with the most
+// progress wins. A setup without team names starts a classic competition.
+type TeamSetup struct {
+	Names []string
+	// Balanced events place members on the team with the lowest combined KC or XP instead of
+	// the smallest team.
+	Balanced bool
+}
+
+// parseTeamSetup reads comma-separated team names. Balanced events without names get two teams.
+func parseTeamSetup(names string, balanced bool) TeamSetup {
+	setup := TeamSetup{Balanced: balanced}
+	for _, name := range strings.Split(names, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			setup.Names = append(setup.Names, name)
+		}
+	}
+	if balanced && len(setup.Names) == 0 {
+		setup.Names = defaultTeamNames
+	}
+	return setup
+}
+
+// teamSetupOption reads the teams and balance options of a /botw or /sotw subcommand.
+func teamSetupOption(i *discordgo.InteractionCreate) TeamSetup {
+	var names string
+	var balanced bool
+	if opt := subcommandOption(i, "teams"); opt != nil {
+		names = opt.StringValue()
+	}
+	if opt := subcommandOption(i, "balance"); opt != nil {
+		balanced = opt.BoolValue()
+	}
+	return parseTeamSetup(names, balanced)
+}
+
+// enabled reports whether the setup starts a team event.
+func (ts TeamSetup) enabled() bool {
+	return len(ts.Names) > 0
+}
+
+// validate checks the team names are usable on Wise Old Man.
+func (ts TeamSetup) validate() error {
+	if !ts.enabled() {
+		return nil
+	}
+	if len(ts.Names) < 2 || len(ts.Names) > maxTeams {
+		return fmt.Errorf("a team event needs between 2 and %d teams", maxTeams)
+	}
+	seen := make(map[string]bool)
+	for _, name := range ts.Names {
+		if utf8.RuneCountInString(name) > maxTeamNameLength {
+			return fmt.Errorf("team name %q is longer than %d characters", name, maxTeamNameLength)
+		}
+		key := strings.ToLower(name)
+		if seen[key] {
+			return fmt.Errorf("team name %q is used twice", name)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// startTeamEvent stores a team event for the event thread and announces it. Wise Old Man only
+// creates team competitions with players on a team, so the competition is created when the first
+// member joins. Team events don't use the guild's WOM group.
+func (t *TrackableCommands) startTeamEvent(ctx context.Context, s DiscordSession, i *discordgo.InteractionCreate, eventType, pingType models.EventType, activity, eventName, threadID string, teams TeamSetup) error {
+	endsAt := time.Now().UTC().Add(1*time.Minute + 7*24*time.Hour)
+	event, err := t.createTeamEvent(ctx, i, database.CreateTeamEventParams{
+		Type:            string(eventType),
+		Metric:          activity,
+		Title:           eventName,
+		DiscordThreadID: threadID,
+		Balanced:        teams.Balanced,
+		EndsAt:          endsAt,
+	}, teams.Names)
+	if err != nil {
+		log.Printf("Error storing team event: %v", err)
+		sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
+			Embeds: []*discordgo.MessageEmbed{
+				embeds.ErrorEmbed("Failed to save the team event. Please try again."),
+			},
+		})
+		return err
+	}
+
+	t.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionEventStart,
+		Target:  fmt.Sprintf("%s (team event #%d)", eventName, event.ID),
+		After:   fmt.Sprintf("%s until %s", strings.Join(teams.Names, " vs "), endsAt.Format(time.RFC3339)),
+	})
+
+	threadStarterMsg := fmt.Sprintf("**%s** team event has started!\n\nTeams: %s\n\nClick the Join a Team button in the channel to take part. The Wise Old Man competition starts when the first member joins.",
+		eventName, strings.Join(teams.Names, " vs "))
+	if _, err := s.ChannelMessageSend(threadID, threadStarterMsg); err != nil {
+		log.Printf("Error sending thread starter message: %v", err)
+	}
+
+	embed := embeds.TeamEvent(eventType, models.HiscoreField(activity), teams.Names, teams.Balanced)
+	components := []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "Join a Team",
+					Style:    discordgo.SuccessButton,
+					CustomID: fmt.Sprintf("join-team-event:%d", event.ID),
+				},
+				discordgo.Button{
+					Label:    "List Teams",
+					Style:    discordgo.SecondaryButton,
+					CustomID: fmt.Sprintf("list-team-event:%d", event.ID),
+				},
+			},
+		},
+	}
+
+	return t.postAnnouncement(ctx, s, i, pingType, embed, components)
+}
+
+// createTeamEvent stores a team event and its teams inside one transaction.
+func (t *TrackableCommands) createTeamEvent(ctx context.Context, i *discordgo.InteractionCreate, params database.CreateTeamEventParams, teamNames []string) (database.TeamEvent, error) {
+	guildID, err := strconv.ParseInt(i.GuildID, 10, 64)
+	if err != nil {
+		return database.TeamEvent{}, fmt.Errorf("parse guild ID: %w", err)
+	}
+	actorID, err := strconv.ParseInt(i.Member.User.ID, 10, 64)
+	if err != nil {
+		return database.TeamEvent{}, fmt.Errorf("parse actor ID: %w", err)
+	}
+	params.GuildID = guildID
+	params.CreatedBy = actorID
+
+	tx, err := t.DBSQL.BeginTx(ctx, nil)
+	if err != nil {
+		return database.TeamEvent{}, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // Rollback is safe to call even after commit
+
+	qtx := t.DB.WithTx(tx)
+	event, err := qtx.CreateTeamEvent(ctx, params)
+	if err != nil {
+		return database.TeamEvent{}, fmt.Errorf("create team event: %w", err)
+	}
+	for position, name := range teamNames {
+		err := qtx.AddTeamEventTeam(ctx, database.AddTeamEventTeamParams{
+			TeamEventID: event.ID,
+			Position:    int64(position),
+			Name:        name,
+		})
+		if err != nil {
+			return database.TeamEvent{}, fmt.Errorf("add team %q: %w", name, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return database.TeamEvent{}, fmt.Errorf("commit: %w", err)
+	}
+	return event, nil
+}
+
+// RegisterForTeamEvent handles join button clicks on a team event. The member is placed on a
+// team and the event's WOM competition is updated to match.
+func (t *TrackableCommands) RegisterForTeamEvent(s DiscordSession, i *discordgo.InteractionCreate, teamEventID int64) error {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		return fmt.Errorf("defer response: %w", err)
+	}
+	reply := func(content string) {
+		sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+	}
+	replyError := func(err error) {
+		sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
+			Embeds: []*discordgo.MessageEmbed{
+				embeds.ErrorEmbed(womErrorMessage(err, "Failed to join the team event. Please try again.")),
+			},
+			Flags: discordgo.MessageFlagsEphemeral,
+		})
+	}
+
+	discordID, err := strconv.ParseInt(i.Member.User.ID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("parse discord id: %w", err)
+	}
+	link, err := t.DB.GetAccountLinkByDiscordID(ctx, discordID)
+	if err != nil {
+		reply("You need to link your RuneScape account first using `/link-rsn`")
+		return err
+	}
+
+	// Every join rewrites the competition's teams, so joins are handled one at a time. The event
+	// is loaded under the lock so a join can't slip in while the event is being finished.
+	t.teamMu.Lock()
+	defer t.teamMu.Unlock()
+
+	event, err := t.DB.GetTeamEvent(ctx, teamEventID)
+	if err != nil {
+		reply("Team event not found. It may have been deleted.")
+		return err
+	}
+	if event.FinishedAt.Valid || !time.Now().Before(event.EndsAt) {
+		reply("This team event has ended.")
+		return nil
+	}
+
+	teams, err := t.DB.ListTeamEventTeams(ctx, event.ID)
+	if err != nil {
+		log.Printf("Error loading teams of team event %d: %v", event.ID, err)
+		replyError(err)
+		return err
+	}
+	members, err := t.DB.ListTeamEventMembers(ctx, event.ID)
+	if err != nil {
+		log.Printf("Error loading members of team event %d: %v", event.ID, err)
+		replyError(err)
+		return err
+	}
+	for _, member := range members {
+		if member.AccountLinkID == link.ID {
+			reply(fmt.Sprintf("You're already on team **%s**!", teamName(teams, member.TeamPosition)))
+			return nil
+		}
+	}
+
+	// Update player on WOM (ensures fresh data). Balanced events fall back to the last known stats
+	player, err := t.WOMClient.UpdatePlayer(ctx, link.RunescapeName)
+	if err != nil {
+		log.Printf("Warning: failed to update player %s: %v", link.RunescapeName, err)
+		if event.Balanced {
+			player, err = t.WOMClient.GetPlayer(ctx, link.RunescapeName)
+			if err != nil {
+				log.Printf("Warning: failed to fetch player %s, balancing them as unranked: %v", link.RunescapeName, err)
+			}
+		}
+	}
+	var score int64
+	if err == nil {
+		score = metricScore(player, event.Metric)
+	}
+
+	position := pickTeam(teams, members, event.Balanced)
+	added, err := t.DB.AddTeamEventMember(ctx, database.AddTeamEventMemberParams{
+		TeamEventID:   event.ID,
+		AccountLinkID: link.ID,
+		TeamPosition:  position,
+		Score:         score,
+	})
+	if err != nil || added == 0 {
+		if err == nil {
+			err = fmt.Errorf("account link %d already joined team event %d", link.ID, event.ID)
+		}
+		log.Printf("Error adding member to team event: %v", err)
+		replyError(err)
+		return err
+	}
+	members = append(members, database.ListTeamEventMembersRow{
+		TeamEventID:     event.ID,
+		AccountLinkID:   link.ID,
+		TeamPosition:    position,
+		Score:           score,
+		DiscordMemberID: link.DiscordMemberID,
+		RunescapeName:   link.RunescapeName,
+	})
+
+	if err := t.syncTeamCompetition(ctx, s, event, teams, members); err != nil {
+		log.Printf("Error updating team competition for team event %d: %v", event.ID, err)
+		removeErr := t.DB.RemoveTeamEventMember(ctx, database.RemoveTeamEventMemberParams{
+			TeamEventID:   event.ID,
+			AccountLinkID: link.ID,
+		})
+		if removeErr != nil {
+			log.Printf("Error removing member from team event %d: %v", event.ID, removeErr)
+		}
+		replyError(err)
+		return err
+	}
+
+	message := fmt.Sprintf("**%s** joined team **%s** for **%s**!", link.RunescapeName, teamName(teams, position), FormatActivityName(event.Metric))
+	if _, err := s.ChannelMessageSend(event.DiscordThreadID, message); err != nil {
+		log.Printf("Error sending message to thread: %v", err)
+	}
+	reply(message)
+
+	return nil
+}
+
+// syncTeamCompetition makes the teams of a team event's WOM competition match members. The
+// competition is created for the first member, starting a minute later and ending with the event.
+func (t *TrackableCommands) syncTeamCompetition(ctx context.Context, s MessageSender, event database.TeamEvent, teams []database.TeamEventTeam, members []database.ListTeamEventMembersRow) error {
+	// Wise Old Man rejects empty teams, so they're left out until someone joins them
+	womTeams := make([]wiseoldman.Team, 0, len(teams))
+	for _, team := range teams {
+		var participants []string
+		for _, member := range members {
+			if member.TeamPosition == team.Position {
+				participants = append(participants, member.RunescapeName)
+			}
+		}
+		if len(participants) > 0 {
+			womTeams = append(womTeams, wiseoldman.Team{Name: team.Name, Participants: participants})
+		}
+	}
+
+	if event.WomCompetitionID.Valid {
+		comp, err := t.DB.GetWOMCompetitionByWOMID(ctx, event.WomCompetitionID.Int64)
+		if err != nil {
+			return fmt.Errorf("get competition: %w", err)
+		}
+		_, err = t.WOMClient.EditCompetition(ctx, comp.WomCompetitionID, wiseoldman.EditCompetitionRequest{
+			VerificationCode: comp.VerificationCode,
+			Teams:            womTeams,
+		})
+		return err
+	}
+
+	startsAt := time.Now().UTC().Add(1 * time.Minute)
+	if !startsAt.Before(event.EndsAt) {
+		return fmt.Errorf("team event %d ends before its competition could start", event.ID)
+	}
+	resp, err := t.WOMClient.CreateCompetition(ctx, wiseoldman.CreateCompetitionRequest{
+		Title:    event.Title,
+		Metric:   event.Metric,
+		StartsAt: startsAt.Format(time.RFC3339),
+		EndsAt:   event.EndsAt.UTC().Format(time.RFC3339),
+		Teams:    womTeams,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := t.saveTeamCompetition(ctx, event, resp); err != nil {
+		return err
+	}
+
+	t.SendCompetitionCode(s, event.GuildID, event.Title, resp.VerificationCode, resp.Competition.ID)
+
+	womURL := fmt.Sprintf("https://wiseoldman.net/competitions/%d", resp.Competition.ID)
+	if _, err := s.ChannelMessageSend(event.DiscordThreadID, fmt.Sprintf("The team competition is live!\n\n🔗 [View on Wise Old Man](%s)", womURL)); err != nil {
+		log.Printf("Error sending competition link to thread: %v", err)
+	}
+	return nil
+}
+
+// saveTeamCompetition stores a team event's new WOM competition inside one transaction.
+func (t *TrackableCommands) saveTeamCompetition(ctx context.Context, event database.TeamEvent, resp *wiseoldman.CreateCompetitionResponse) error {
+	tx, err := t.DBSQL.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // Rollback is safe to call even after commit
+
+	qtx := t.DB.WithTx(tx)
+	_, err = qtx.CreateWOMCompetition(ctx, database.CreateWOMCompetitionParams{
+		WomCompetitionID: resp.Competition.ID,
+		VerificationCode: resp.VerificationCode,
+		DiscordThreadID:  event.DiscordThreadID,
+		Metric:           event.Metric,
+		Type:             event.Type,
+	})
+	if err != nil {
+		return fmt.Errorf("store competition: %w", err)
+	}
+	err = qtx.SetTeamEventCompetition(ctx, database.SetTeamEventCompetitionParams{
+		WomCompetitionID: sql.NullInt64{Int64: resp.Competition.ID, Valid: true},
+		ID:               event.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("set team event competition: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+	return nil
+}
+
+// ListTeamEvent shows a team event's teams with their members and progress so far.
+func (t *TrackableCommands) ListTeamEvent(s DiscordSession, i *discordgo.InteractionCreate, teamEventID int64) error {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		return fmt.Errorf("defer response: %w", err)
+	}
+	reply := func(content string) {
+		sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+	}
+
+	event, err := t.DB.GetTeamEvent(ctx, teamEventID)
+	if err != nil {
+		reply("Team event not found. It may have been deleted.")
+		return err
+	}
+	teams, err := t.DB.ListTeamEventTeams(ctx, event.ID)
+	if err != nil {
+		return fmt.Errorf("list teams: %w", err)
+	}
+	members, err := t.DB.ListTeamEventMembers(ctx, event.ID)
+	if err != nil {
+		return fmt.Errorf("list members: %w", err)
+	}
+	if len(members) == 0 {
+		reply("No one has joined yet! Be the first to join a team!")
+		return nil
+	}
+
+	// Progress is only known once the competition exists
+	gains := make(map[string]int64)
+	if event.WomCompetitionID.Valid {
+		competition, err := t.WOMClient.GetCompetition(ctx, event.WomCompetitionID.Int64)
+		if err != nil {
+			log.Printf("Error fetching competition, listing teams without progress: %v", err)
+		} else {
+			for _, p := range competition.Participations {
+				gains[p.Player.Username] = participationGained(p)
+			}
+		}
+	}
+
+	roster := make([]embeds.TeamRosterEntry, 0, len(teams))
+	for _, team := range teams {
+		entry := embeds.TeamRosterEntry{Name: team.Name}
+		for _, member := range members {
+			if member.TeamPosition != team.Position {
+				continue
+			}
+			gained := gains[wiseoldman.StandardizeUsername(member.RunescapeName)]
+			entry.Gained += gained
+			entry.Members = append(entry.Members, fmt.Sprintf("- %s - %d gained", member.RunescapeName, gained))
+		}
+		roster = append(roster, entry)
+	}
+
+	sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
+		Embeds: []*discordgo.MessageEmbed{embeds.TeamRoster(event.Title, roster)},
+		Flags:  discordgo.MessageFlagsEphemeral,
+	})
+	return nil
+}
+
+// announceTeamResults announces the winning team of a team competition and each team's MVP.
+func (t *TrackableCommands) announceTeamResults(ctx context.Context, s DiscordSession, i *discordgo.InteractionCreate, eventType models.EventType, metric string, competition *wiseoldman.Competition) error {
+	standings := competition.TeamStandings()
+	if len(standings) == 0 || standings[0].Gained <= 0 {
+		sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
+			Content: "No one made any progress during this competition!",
+		})
+		return nil
+	}
+
+	unit := "KC"
+	if eventType == models.EventTypeSkillOfTheWeek {
+		unit = "XP"
+	}
+
+	results := make([]embeds.TeamResult, 0, len(standings))
+	var winners []string
+	for _, team := range standings {
+		result := embeds.TeamResult{
+			Name:    team.Name,
+			Gained:  team.Gained,
+			Members: len(team.Participations),
+		}
+		if mvp := team.Participations[0]; participationGained(mvp) > 0 {
+			result.MVP = mvp.Player.DisplayName
+			result.MVPGained = participationGained(mvp)
+			result.MVPDiscordID = t.linkedDiscordID(ctx, mvp.Player.Username)
+		}
+		results = append(results, result)
+
+		if team.Gained == standings[0].Gained {
+			winners = append(winners, fmt.Sprintf("**%s**", team.Name))
+		}
+	}
+
+	content := fmt.Sprintf("Team %s wins this week's %s with **%d %s**! Congratulations!",
+		winners[0], getEventDisplayName(eventType), standings[0].Gained, unit)
+	if len(winners) > 1 {
+		content = fmt.Sprintf("It's a tie! Teams %s share this week's %s with **%d %s** each! Congratulations!",
+			strings.Join(winners, " and "), getEventDisplayName(eventType), standings[0].Gained, unit)
+	}
+
+	_, err := sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
+		Content: content,
+	})
+	if err != nil {
+		log.Printf("Error sending team winner announcement: %v", err)
+	}
+
+	_, err = sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
+		Embeds: []*discordgo.MessageEmbed{
+			embeds.TeamResults(eventType, models.HiscoreField(metric), results),
+		},
+		AllowedMentions: &discordgo.MessageAllowedMentions{
+			Parse: []discordgo.AllowedMentionType{discordgo.AllowedMentionTypeUsers},
+		},
+	})
+	return err
+}
+
+// pendingTeamEvent returns the guild's latest team event of eventType if nobody has joined it yet
+// and it's at least as new as latest, the latest competition of that type.
+func (t *TrackableCommands) pendingTeamEvent(ctx context.Context, guildID string, eventType models.EventType, latest database.WomCompetition, latestErr error) (database.TeamEvent, bool) {
+	id, err := strconv.ParseInt(guildID, 10, 64)
+	if err != nil {
+		return database.TeamEvent{}, false
+	}
+	event, err := t.DB.GetLatestTeamEventByType(ctx, database.GetLatestTeamEventByTypeParams{
+		GuildID: id,
+		Type:    string(eventType),
+	})
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("Error loading latest team event: %v", err)
+		}
+		return database.TeamEvent{}, false
+	}
+	if event.WomCompetitionID.Valid || event.FinishedAt.Valid {
+		return database.TeamEvent{}, false
+	}
+	return event, latestErr != nil || !event.CreatedAt.Before(latest.CreatedAt)
+}
+
+// finishTeamEvent closes a team event nobody joined, so its join button stops working.
+func (t *TrackableCommands) finishTeamEvent(ctx context.Context, teamEventID int64) error {
+	t.teamMu.Lock()
+	defer t.teamMu.Unlock()
+	return t.DB.FinishTeamEvent(ctx, teamEventID)
+}
+
+// finishTeamCompetition closes the team event behind a finished WOM competition, so later joins
+// don't edit its teams.
+func (t *TrackableCommands) finishTeamCompetition(ctx context.Context, womCompetitionID int64) error {
+	t.teamMu.Lock()
+	defer t.teamMu.Unlock()
+	return t.DB.FinishTeamEventByCompetition(ctx, sql.NullInt64{Int64: womCompetitionID, Valid: true})
+}
+
+// linkedDiscordID returns the Discord ID linked to a WOM username, or 0 if it isn't linked.
+func (t *TrackableCommands) linkedDiscordID(ctx context.Context, username string) uint64 {
+	link, err := t.DB.GetAccountLinkByUsername(ctx, username)
+	if err != nil || link.DiscordMemberID < 0 {
+		return 0
+	}
+	return uint64(link.DiscordMemberID)
+}
+
+// pickTeam returns the position of the team a new member joins: the team with the lowest combined
+// score for balanced events, otherwise the smallest team. Ties go to the smaller team, then to the
+// team named first.
+func pickTeam(teams []database.TeamEventTeam, members []database.ListTeamEventMembersRow, balanced bool) int64 {
+	sizes := make(map[int64]int)
+	totals := make(map[int64]int64)
+	for _, member := range members {
+		sizes[member.TeamPosition]++
+		totals[member.TeamPosition] += member.Score
+	}
+
+	best := teams[0].Position
+	for _, team := range teams[1:] {
+		p := team.Position
+		if balanced && totals[p] != totals[best] {
+			if totals[p] < totals[best] {
+				best = p
+			}
+			continue
+		}
+		if sizes[p] < sizes[best] {
+			best = p
+		}
+	}
+	return best
+}
+
+// teamName returns the name of the team at position.
+func teamName(teams []database.TeamEventTeam, position int64) string {
+	for _, team := range teams {
+		if team.Position == position {
+			return team.Name
+		}
+	}
+	return fmt.Sprintf("Team %d", position+1)
+}
+
+// metricScore returns a player's current KC or XP for metric, or 0 if they're unranked.
+func metricScore(player *wiseoldman.Player, metric string) int64 {
+	if skill := player.GetSkill(metric); skill != nil {
+		return max(skill.Experience, 0)
+	}
+	if boss := player.GetBoss(metric); boss != nil {
+		return int64(max(boss.Kills, 0))
+	}
+	return 0
+}
+
+// participationGained returns the progress a participant has made, or 0 before any is known.
+func participationGained(p wiseoldman.CompetitionParticipation) int64 {
+	if p.Progress == nil {
+		return 0
+	}
+	return p.Progress.Gained
+}
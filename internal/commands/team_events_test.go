@@ -0,0 +1,197 @@
+package commands
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/audit"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/models"
+	"github.com/kaffeed/voidling/internal/testutil"
+	"github.com/kaffeed/voidling/internal/wiseoldman/womtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTeamSetup(t *testing.T) {
+	tests := []struct {
+		name     string
+		names    string
+		balanced bool
+		want     TeamSetup
+		wantErr  bool
+	}{
+		{name: "classic", want: TeamSetup{}},
+		{name: "named", names: " Red , Blue,, ", want: TeamSetup{Names: []string{"Red", "Blue"}}},
+		{name: "balanced defaults", balanced: true, want: TeamSetup{Names: []string{"Team 1", "Team 2"}, Balanced: true}},
+		{name: "one team", names: "Red", want: TeamSetup{Names: []string{"Red"}}, wantErr: true},
+		{name: "duplicate", names: "Red, red", want: TeamSetup{Names: []string{"Red", "red"}}, wantErr: true},
+		{name: "too many", names: "1,2,3,4,5,6,7,8,9,10,11", wantErr: true},
+		{name: "too long", names: "Red, A team name well over thirty characters", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup := parseTeamSetup(tt.names, tt.balanced)
+			if tt.want.Names != nil || !tt.wantErr {
+				assert.Equal(t, tt.want, setup)
+			}
+			if tt.wantErr {
+				assert.Error(t, setup.validate())
+			} else {
+				assert.NoError(t, setup.validate())
+			}
+		})
+	}
+}
+
+func TestPickTeam(t *testing.T) {
+	teams := []database.TeamEventTeam{{Position: 0, Name: "Red"}, {Position: 1, Name: "Blue"}, {Position: 2, Name: "Green"}}
+	members := []database.ListTeamEventMembersRow{
+		{TeamPosition: 0, Score: 100},
+		{TeamPosition: 0, Score: 100},
+		{TeamPosition: 1, Score: 500},
+	}
+
+	assert.Equal(t, int64(2), pickTeam(teams, members, false))
+	assert.Equal(t, int64(2), pickTeam(teams, members, true))
+
+	members = append(members, database.ListTeamEventMembersRow{TeamPosition: 2, Score: 150})
+	assert.Equal(t, int64(1), pickTeam(teams, members, false), "smallest team")
+	assert.Equal(t, int64(2), pickTeam(teams, members, true), "lowest combined score")
+
+	members = []database.ListTeamEventMembersRow{
+		{TeamPosition: 0, Score: 100},
+		{TeamPosition: 0, Score: 100},
+		{TeamPosition: 1, Score: 200},
+	}
+	assert.Equal(t, int64(1), pickTeam(teams[:2], members, true), "ties go to the smaller team")
+}
+
+func TestStartTeamEventRejectsInvalidTeams(t *testing.T) {
+	db, q := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	session := &testutil.MockDiscordSession{}
+	wom := &testutil.MockWOMClient{}
+	session.On("InteractionRespond", mock.Anything, mock.Anything).Return(nil)
+	followups := captureFollowups(session)
+
+	tc := NewTrackableCommands(q, db, wom, audit.NewLogger(q))
+	i := testutil.CreateTestInteraction("botw", "1001", "42")
+	assert.Error(t, tc.StartEvent(session, i, models.EventTypeBossOfTheWeek, models.EventTypeBossOfTheWeek, "zulrah", parseTeamSetup("Red", false)))
+
+	assert.Contains(t, followupText(*followups), "Invalid teams")
+	session.AssertNotCalled(t, "ThreadStartComplex", mock.Anything, mock.Anything)
+}
+
+func TestFinishTeamEventWithoutMembers(t *testing.T) {
+	db, q := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	wom := womtest.NewServer()
+	defer wom.Close()
+
+	session := &testutil.MockDiscordSession{}
+	session.On("InteractionRespond", mock.Anything, mock.Anything).Return(nil)
+	session.On("ThreadStartComplex", mock.Anything, mock.Anything).Return(&discordgo.Channel{ID: "thread-1"}, nil)
+	session.On("ChannelMessageSend", "thread-1", mock.Anything).Return(&discordgo.Message{}, nil)
+	followups := captureFollowups(session)
+
+	tc := NewTrackableCommands(q, db, wom.Client(), audit.NewLogger(q))
+	require.NoError(t, tc.StartEvent(session, testutil.CreateTestInteraction("sotw", "1001", "42"),
+		models.EventTypeSkillOfTheWeek, models.EventTypeSkillOfTheWeek, "mining", parseTeamSetup("Red, Blue", false)))
+
+	*followups = nil
+	require.NoError(t, tc.FinishEvent(session, testutil.CreateTestInteraction("sotw", "1001", "42"), models.EventTypeSkillOfTheWeek))
+	assert.Contains(t, followupText(*followups), "Sadly there were no participants this time!")
+
+	// The join button of a finished event no longer starts a competition
+	testutil.CreateTestAccountLink(t, q, 1001, "zezima", true)
+	*followups = nil
+	require.NoError(t, tc.RegisterForTeamEvent(session, testutil.CreateTestButtonInteraction("join-team-event", "1001", "42"), 1))
+	assert.Contains(t, followupText(*followups), "This team event has ended.")
+	event, err := q.GetTeamEvent(t.Context(), 1)
+	require.NoError(t, err)
+	assert.True(t, event.FinishedAt.Valid)
+	assert.False(t, event.WomCompetitionID.Valid)
+}
+
+func TestTeamEventAgainstFakeWOM(t *testing.T) {
+	db, q := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+	ctx := t.Context()
+
+	wom := womtest.NewServer()
+	defer wom.Close()
+	wom.SetMetric("Zezima", "zulrah", 1000)
+	wom.SetMetric("Lynx Titan", "zulrah", 100)
+	wom.SetMetric("Woox", "zulrah", 200)
+
+	session := &testutil.MockDiscordSession{}
+	session.On("InteractionRespond", mock.Anything, mock.Anything).Return(nil)
+	session.On("ThreadStartComplex", mock.Anything, mock.Anything).Return(&discordgo.Channel{ID: "thread-1"}, nil)
+	session.On("ChannelMessageSend", "thread-1", mock.Anything).Return(&discordgo.Message{}, nil)
+	followups := captureFollowups(session)
+
+	tc := NewTrackableCommands(q, db, wom.Client(), audit.NewLogger(q))
+	require.NoError(t, tc.StartEvent(session, testutil.CreateTestInteraction("botw", "1001", "42"),
+		models.EventTypeBossOfTheWeek, models.EventTypeBossOfTheWeek, "zulrah", parseTeamSetup("Red, Blue", true)))
+
+	require.NotEmpty(t, *followups)
+	row := (*followups)[len(*followups)-1].Components[0].(discordgo.ActionsRow)
+	assert.Equal(t, "join-team-event:1", row.Components[0].(discordgo.Button).CustomID)
+
+	members := []struct {
+		id   int64
+		name string
+	}{{1001, "zezima"}, {1002, "lynx titan"}, {1003, "woox"}}
+	for _, m := range members {
+		testutil.CreateTestAccountLink(t, q, m.id, m.name, true)
+		i := testutil.CreateTestButtonInteraction("join-team-event", fmt.Sprint(m.id), "42")
+		require.NoError(t, tc.RegisterForTeamEvent(session, i, 1))
+	}
+
+	// Balancing puts the two lower scores together against Zezima
+	event, err := q.GetTeamEvent(ctx, 1)
+	require.NoError(t, err)
+	require.True(t, event.WomCompetitionID.Valid)
+	assert.Equal(t, map[string][]string{
+		"Red":  {"zezima"},
+		"Blue": {"lynx titan", "woox"},
+	}, wom.Teams(event.WomCompetitionID.Int64))
+
+	*followups = nil
+	i := testutil.CreateTestButtonInteraction("join-team-event", "1002", "42")
+	require.NoError(t, tc.RegisterForTeamEvent(session, i, 1))
+	assert.Contains(t, followupText(*followups), "You're already on team **Blue**!")
+
+	wom.Advance(2 * time.Minute)
+	wom.Gain("Zezima", "zulrah", 40)
+	wom.Gain("Lynx Titan", "zulrah", 30)
+	wom.Gain("Woox", "zulrah", 25)
+
+	*followups = nil
+	require.NoError(t, tc.FinishEvent(session, testutil.CreateTestInteraction("botw", "1001", "42"), models.EventTypeBossOfTheWeek))
+
+	require.Len(t, *followups, 2)
+	assert.Equal(t, "Team **Blue** wins this week's Boss of the Week with **55 KC**! Congratulations!", (*followups)[0].Content)
+	require.Len(t, (*followups)[1].Embeds, 1)
+	fields := (*followups)[1].Embeds[0].Fields
+	require.Len(t, fields, 2)
+	assert.Contains(t, fields[0].Value, "MVP: <@1002> with **30 KC**")
+	assert.Contains(t, fields[1].Value, "MVP: <@1001> with **40 KC**")
+
+	// Joining after the finish leaves the finished competition's teams alone
+	testutil.CreateTestAccountLink(t, q, 1004, "b0aty", true)
+	*followups = nil
+	require.NoError(t, tc.RegisterForTeamEvent(session, testutil.CreateTestButtonInteraction("join-team-event", "1004", "42"), 1))
+	assert.Contains(t, followupText(*followups), "This team event has ended.")
+	assert.Equal(t, map[string][]string{
+		"Red":  {"zezima"},
+		"Blue": {"lynx titan", "woox"},
+	}, wom.Teams(event.WomCompetitionID.Int64))
+}
@@ -1,15 +1,20 @@
 package commands
 
 import (
+	"cmp"
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/audit"
 	"github.com/kaffeed/voidling/internal/database"
 	"github.com/kaffeed/voidling/internal/embeds"
 	"github.com/kaffeed/voidling/internal/models"
@@ -20,20 +25,27 @@ import (
 type TrackableCommands struct {
 	DB        *database.Queries
 	DBSQL     *sql.DB
-	WOMClient *wiseoldman.Client
+	Audit     *audit.Logger
+	WOMClient CompetitionClient
+
+	// teamMu serialises joins to team events, whose WOM competition is rewritten on every join.
+	teamMu sync.Mutex
 }
 
 // NewTrackableCommands creates a new TrackableCommands instance.
-func NewTrackableCommands(db *database.Queries, dbSQL *sql.DB, womClient *wiseoldman.Client) *TrackableCommands {
+func NewTrackableCommands(db *database.Queries, dbSQL *sql.DB, womClient CompetitionClient, auditLog *audit.Logger) *TrackableCommands {
 	return &TrackableCommands{
 		DB:        db,
 		DBSQL:     dbSQL,
+		Audit:     auditLog,
 		WOMClient: womClient,
 	}
 }
 
 // StartEvent creates a new WOM competition with thread and registration buttons.
-func (t *TrackableCommands) StartEvent(s *discordgo.Session, i *discordgo.InteractionCreate, eventType models.EventType, activity string) error {
+// The announcement pings the notification role for pingType, which differs from eventType
+// for themed events such as Wildy Wednesday. With teams set up, a team event is started instead.
+func (t *TrackableCommands) StartEvent(s DiscordSession, i *discordgo.InteractionCreate, eventType, pingType models.EventType, activity string, teams TeamSetup) error {
 	ctx := context.Background()
 
 	// Defer the response
@@ -44,6 +56,15 @@ func (t *TrackableCommands) StartEvent(s *discordgo.Session, i *discordgo.Intera
 		return fmt.Errorf("defer response: %w", err)
 	}
 
+	if err := teams.validate(); err != nil {
+		sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
+			Embeds: []*discordgo.MessageEmbed{
+				embeds.ErrorEmbed(fmt.Sprintf("Invalid teams: %v.", err)),
+			},
+		})
+		return err
+	}
+
 	// Create thread for event
 	eventName := fmt.Sprintf("%s - %s", getEventDisplayName(eventType), FormatActivityName(activity))
 	thread, err := s.ThreadStartComplex(i.ChannelID, &discordgo.ThreadStart{
@@ -62,36 +83,59 @@ func (t *TrackableCommands) StartEvent(s *discordgo.Session, i *discordgo.Intera
 		return err
 	}
 
+	if teams.enabled() {
+		return t.startTeamEvent(ctx, s, i, eventType, pingType, activity, eventName, thread.ID, teams)
+	}
+
 	// Create WOM competition
 	// Competition runs for 1 week starting 1 minute from now (WOM requires future dates)
 	// Use UTC for WOM API
 	startsAt := time.Now().UTC().Add(1 * time.Minute)
 	endsAt := startsAt.Add(7 * 24 * time.Hour)
 
-	womResp, err := t.WOMClient.CreateCompetition(ctx, wiseoldman.CreateCompetitionRequest{
+	compReq := wiseoldman.CreateCompetitionRequest{
 		Title:    eventName,
 		Metric:   activity,
 		StartsAt: startsAt.Format(time.RFC3339),
 		EndsAt:   endsAt.Format(time.RFC3339),
-	})
+	}
+
+	// With a WOM group configured, every group member takes part automatically
+	group, err := loadWOMGroup(ctx, t.DB, i.GuildID)
+	isGroupCompetition := err == nil
+	if isGroupCompetition {
+		compReq.GroupID = &group.GroupID
+		compReq.GroupVerificationCode = group.VerificationCode
+	} else if !errors.Is(err, ErrNoWOMGroup) {
+		log.Printf("Error loading WOM group, creating an open competition: %v", err)
+	}
+
+	womResp, err := t.WOMClient.CreateCompetition(ctx, compReq)
 	if err != nil {
 		log.Printf("Error creating WOM competition: %v", err)
 		sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
 			Embeds: []*discordgo.MessageEmbed{
-				embeds.ErrorEmbed("Failed to create competition on Wise Old Man. Please try again."),
+				embeds.ErrorEmbed(womErrorMessage(err, "Failed to create competition on Wise Old Man. Please try again.")),
 			},
 		})
 		return err
 	}
 
-	// Store competition in database
+	// Store competition in database. Group competitions are managed with the group's code
+	verificationCode := womResp.VerificationCode
+	var womGroupID sql.NullInt64
+	if isGroupCompetition {
+		verificationCode = group.VerificationCode
+		womGroupID = sql.NullInt64{Int64: group.GroupID, Valid: true}
+	}
 	eventTypeStr := string(eventType)
 	_, err = t.DB.CreateWOMCompetition(ctx, database.CreateWOMCompetitionParams{
 		WomCompetitionID: womResp.Competition.ID,
-		VerificationCode: womResp.VerificationCode,
+		VerificationCode: verificationCode,
 		DiscordThreadID:  thread.ID,
 		Metric:           activity,
 		Type:             eventTypeStr,
+		WomGroupID:       womGroupID,
 	})
 	if err != nil {
 		log.Printf("Error storing WOM competition: %v", err)
@@ -103,9 +147,20 @@ func (t *TrackableCommands) StartEvent(s *discordgo.Session, i *discordgo.Intera
 		return err
 	}
 
-	// Send competition code to configured channel (if configured)
+	t.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionEventStart,
+		Target:  fmt.Sprintf("%s (WOM #%d)", eventName, womResp.Competition.ID),
+		After:   fmt.Sprintf("%s → %s", startsAt.Format(time.RFC3339), endsAt.Format(time.RFC3339)),
+	})
+
+	// Send competition code to configured channel (if configured). A group competition's code is
+	// the group's, which would let anyone change the group's members, so it isn't posted
 	guildID, err := strconv.ParseInt(i.GuildID, 10, 64)
-	if err == nil {
+	if isGroupCompetition {
+		log.Printf("Skipping competition code notification for group competition %d", womResp.Competition.ID)
+	} else if err == nil {
 		t.SendCompetitionCode(s, guildID, eventName, womResp.VerificationCode, womResp.Competition.ID)
 	} else {
 		log.Printf("Error parsing guild ID for competition code notification: %v", err)
@@ -149,13 +204,16 @@ func (t *TrackableCommands) StartEvent(s *discordgo.Session, i *discordgo.Intera
 		},
 	}
 
+	return t.postAnnouncement(ctx, s, i, pingType, embed, components)
+}
+
+// postAnnouncement posts an event announcement to the notification channel, or in the command
+// channel if none is configured, pinging the notification role for pingType.
+func (t *TrackableCommands) postAnnouncement(ctx context.Context, s DiscordSession, i *discordgo.InteractionCreate, pingType models.EventType, embed *discordgo.MessageEmbed, components []discordgo.MessageComponent) error {
 	// Get notification role if configured
-	content := ""
 	guildIDInt, _ := strconv.ParseInt(i.GuildID, 10, 64)
+	content := notificationMention(ctx, t.DB, guildIDInt, pingType)
 	guildConfig, err := t.DB.GetGuildConfig(ctx, guildIDInt)
-	if err == nil && guildConfig.EventNotificationRoleID.Valid {
-		content = fmt.Sprintf("<@&%d>", guildConfig.EventNotificationRoleID.Int64)
-	}
 
 	// If notification channel is configured, post there. Otherwise post in command channel
 	if err == nil && guildConfig.EventNotificationChannelID.Valid {
@@ -199,7 +257,7 @@ func (t *TrackableCommands) StartEvent(s *discordgo.Session, i *discordgo.Intera
 }
 
 // SendCompetitionCode sends the WOM verification code to the configured channel.
-func (t *TrackableCommands) SendCompetitionCode(s *discordgo.Session, guildID int64, eventName string, verificationCode string, competitionID int64) {
+func (t *TrackableCommands) SendCompetitionCode(s MessageSender, guildID int64, eventName string, verificationCode string, competitionID int64) {
 	ctx := context.Background()
 
 	// Get guild config to find competition code channel
@@ -233,7 +291,7 @@ func (t *TrackableCommands) SendCompetitionCode(s *discordgo.Session, guildID in
 }
 
 // RegisterForEvent handles registration button clicks.
-func (t *TrackableCommands) RegisterForEvent(s *discordgo.Session, i *discordgo.InteractionCreate, womCompetitionID int64, threadID string, eventType models.EventType) error {
+func (t *TrackableCommands) RegisterForEvent(s DiscordSession, i *discordgo.InteractionCreate, womCompetitionID int64, threadID string, eventType models.EventType) error {
 	ctx := context.Background()
 
 	// Defer the response
@@ -278,13 +336,28 @@ func (t *TrackableCommands) RegisterForEvent(s *discordgo.Session, i *discordgo.
 		log.Printf("Warning: failed to update player %s: %v", link.RunescapeName, err)
 	}
 
-	// Add participant to WOM competition
-	resp, err := t.WOMClient.AddParticipants(ctx, womCompetitionID, []string{link.RunescapeName}, comp.VerificationCode)
+	// Add participant to WOM competition. Group competitions include every group member, so
+	// registering adds the player to the group instead
+	var resultMessage string
+	if comp.WomGroupID.Valid {
+		member := wiseoldman.GroupMember{Username: link.RunescapeName, Role: womGroupMemberRole}
+		var resp *wiseoldman.GroupUpdateResponse
+		resp, err = t.WOMClient.AddGroupMembers(ctx, comp.WomGroupID.Int64, []wiseoldman.GroupMember{member}, comp.VerificationCode)
+		if err == nil {
+			resultMessage = resp.Message
+		}
+	} else {
+		var resp *wiseoldman.CompetitionUpdateResponse
+		resp, err = t.WOMClient.AddParticipants(ctx, womCompetitionID, []string{link.RunescapeName}, comp.VerificationCode)
+		if err == nil {
+			resultMessage = resp.Message
+		}
+	}
 	if err != nil {
 		log.Printf("Error adding participant to WOM: %v", err)
 		sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
 			Embeds: []*discordgo.MessageEmbed{
-				embeds.ErrorEmbed("Failed to register for competition. Please try again."),
+				embeds.ErrorEmbed(womErrorMessage(err, "Failed to register for competition. Please try again.")),
 			},
 			Flags: discordgo.MessageFlagsEphemeral,
 		})
@@ -292,7 +365,7 @@ func (t *TrackableCommands) RegisterForEvent(s *discordgo.Session, i *discordgo.
 	}
 
 	// Send confirmation
-	message := fmt.Sprintf("Registered **%s** for **%s**! %s", link.RunescapeName, FormatActivityName(comp.Metric), resp.Message)
+	message := fmt.Sprintf("Registered **%s** for **%s**! %s", link.RunescapeName, FormatActivityName(comp.Metric), resultMessage)
 
 	// Post in thread
 	_, err = s.ChannelMessageSend(threadID, message)
@@ -310,7 +383,7 @@ func (t *TrackableCommands) RegisterForEvent(s *discordgo.Session, i *discordgo.
 }
 
 // ListParticipants shows the list of participants for a WOM competition.
-func (t *TrackableCommands) ListParticipants(s *discordgo.Session, i *discordgo.InteractionCreate, womCompetitionID int64) error {
+func (t *TrackableCommands) ListParticipants(s DiscordSession, i *discordgo.InteractionCreate, womCompetitionID int64) error {
 	ctx := context.Background()
 
 	// Defer the response
@@ -330,7 +403,7 @@ func (t *TrackableCommands) ListParticipants(s *discordgo.Session, i *discordgo.
 		log.Printf("Error fetching competition: %v", err)
 		sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
 			Embeds: []*discordgo.MessageEmbed{
-				embeds.ErrorEmbed("Failed to fetch competition details."),
+				embeds.ErrorEmbed(womErrorMessage(err, "Failed to fetch competition details.")),
 			},
 			Flags: discordgo.MessageFlagsEphemeral,
 		})
@@ -365,7 +438,7 @@ func (t *TrackableCommands) ListParticipants(s *discordgo.Session, i *discordgo.
 }
 
 // FinishEvent ends a WOM competition and announces winners.
-func (t *TrackableCommands) FinishEvent(s *discordgo.Session, i *discordgo.InteractionCreate, eventType models.EventType) error {
+func (t *TrackableCommands) FinishEvent(s DiscordSession, i *discordgo.InteractionCreate, eventType models.EventType) error {
 	ctx := context.Background()
 
 	// Defer the response
@@ -379,6 +452,22 @@ func (t *TrackableCommands) FinishEvent(s *discordgo.Session, i *discordgo.Inter
 	// Get latest competition of this type
 	eventTypeStr := string(eventType)
 	comp, err := t.DB.GetLatestWOMCompetitionByType(ctx, eventTypeStr)
+	if event, ok := t.pendingTeamEvent(ctx, i.GuildID, eventType, comp, err); ok {
+		if err := t.finishTeamEvent(ctx, event.ID); err != nil {
+			log.Printf("Error finishing team event %d: %v", event.ID, err)
+		}
+		t.Audit.Record(ctx, s, audit.Entry{
+			GuildID: i.GuildID,
+			ActorID: i.Member.User.ID,
+			Action:  audit.ActionEventFinish,
+			Target:  fmt.Sprintf("%s (team event #%d)", event.Title, event.ID),
+			After:   "0 participant(s)",
+		})
+		sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
+			Content: "Sadly there were no participants this time! :(",
+		})
+		return nil
+	}
 	if err != nil {
 		sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
 			Content: fmt.Sprintf("There's no active %s competition ongoing!", getEventDisplayName(eventType)),
@@ -392,12 +481,28 @@ func (t *TrackableCommands) FinishEvent(s *discordgo.Session, i *discordgo.Inter
 		log.Printf("Error fetching competition: %v", err)
 		sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
 			Embeds: []*discordgo.MessageEmbed{
-				embeds.ErrorEmbed("Failed to fetch competition details. Please try again."),
+				embeds.ErrorEmbed(womErrorMessage(err, "Failed to fetch competition details. Please try again.")),
 			},
 		})
 		return err
 	}
 
+	t.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionEventFinish,
+		Target:  fmt.Sprintf("%s (WOM #%d)", competition.Title, comp.WomCompetitionID),
+		After:   fmt.Sprintf("%d participant(s)", len(competition.Participations)),
+	})
+
+	if competition.IsTeamCompetition() {
+		if err := t.finishTeamCompetition(ctx, comp.WomCompetitionID); err != nil {
+			log.Printf("Error finishing team event of competition %d: %v", comp.WomCompetitionID, err)
+		}
+	}
+
+	t.recordEventResults(ctx, i.GuildID, comp, competition)
+
 	if len(competition.Participations) == 0 {
 		sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
 			Content: "Sadly there were no participants this time! :(",
@@ -405,6 +510,10 @@ func (t *TrackableCommands) FinishEvent(s *discordgo.Session, i *discordgo.Inter
 		return nil
 	}
 
+	if competition.IsTeamCompetition() {
+		return t.announceTeamResults(ctx, s, i, eventType, comp.Metric, competition)
+	}
+
 	// Sort participations by progress (WOM API should return them sorted)
 	// Get top 3 winners
 	winnersData := make([]embeds.WinnerData, 0, 3)
@@ -482,6 +591,45 @@ func (t *TrackableCommands) FinishEvent(s *discordgo.Session, i *discordgo.Inter
 	return err
 }
 
+// recordEventResults stores the final placement of every linked participant for their profile.
+// Participants with equal gains share a placement.
+func (t *TrackableCommands) recordEventResults(ctx context.Context, guildID string, comp database.WomCompetition, competition *wiseoldman.Competition) {
+	guildIDInt, err := strconv.ParseInt(guildID, 10, 64)
+	if err != nil {
+		return
+	}
+
+	participations := slices.Clone(competition.Participations)
+	slices.SortStableFunc(participations, func(a, b wiseoldman.CompetitionParticipation) int {
+		return cmp.Compare(participationGained(b), participationGained(a))
+	})
+
+	placement := 0
+	for idx, p := range participations {
+		gained := participationGained(p)
+		if idx == 0 || gained != participationGained(participations[idx-1]) {
+			placement = idx + 1
+		}
+
+		link, err := t.DB.GetAccountLinkByUsername(ctx, p.Player.Username)
+		if err != nil {
+			continue
+		}
+		err = t.DB.UpsertEventResult(ctx, database.UpsertEventResultParams{
+			GuildID:          guildIDInt,
+			WomCompetitionID: comp.WomCompetitionID,
+			Type:             comp.Type,
+			Metric:           comp.Metric,
+			AccountLinkID:    link.ID,
+			Placement:        int64(placement),
+			Gained:           gained,
+		})
+		if err != nil {
+			log.Printf("Error recording event result for %s: %v", p.Player.Username, err)
+		}
+	}
+}
+
 func getEventDisplayName(eventType models.EventType) string {
 	switch eventType {
 	case models.EventTypeBossOfTheWeek:
@@ -0,0 +1,382 @@
+package commands
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/audit"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/models"
+	"github.com/kaffeed/voidling/internal/testutil"
+	"github.com/kaffeed/voidling/internal/wiseoldman"
+	"github.com/kaffeed/voidling/internal/wiseoldman/womtest"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ DiscordSession    = (*testutil.MockDiscordSession)(nil)
+	_ PlayerClient      = (*testutil.MockWOMClient)(nil)
+	_ CompetitionClient = (*testutil.MockWOMClient)(nil)
+)
+
+const testGuildID = int64(42)
+
+// captureFollowups records every followup message sent through session.
+func captureFollowups(session *testutil.MockDiscordSession) *[]*discordgo.WebhookParams {
+	var sent []*discordgo.WebhookParams
+	session.On("FollowupMessageCreate", mock.Anything, true, mock.Anything).
+		Run(func(args mock.Arguments) {
+			sent = append(sent, args.Get(2).(*discordgo.WebhookParams))
+		}).
+		Return(&discordgo.Message{}, nil)
+	return &sent
+}
+
+// followupText joins the content and embed descriptions of followups for matching.
+func followupText(followups []*discordgo.WebhookParams) string {
+	var sb strings.Builder
+	for _, params := range followups {
+		sb.WriteString(params.Content)
+		for _, embed := range params.Embeds {
+			sb.WriteString(embed.Description)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func TestStartEventOpenCompetition(t *testing.T) {
+	db, q := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+	ctx := t.Context()
+
+	require.NoError(t, ensureGuildConfig(ctx, q, testGuildID))
+	require.NoError(t, q.UpdateEventNotificationChannel(ctx, database.UpdateEventNotificationChannelParams{
+		EventNotificationChannelID: sql.NullInt64{Int64: 500, Valid: true},
+		GuildID:                    testGuildID,
+	}))
+	require.NoError(t, q.UpdateCompetitionCodeChannel(ctx, database.UpdateCompetitionCodeChannelParams{
+		CompetitionCodeChannelID: sql.NullInt64{Int64: 600, Valid: true},
+		GuildID:                  testGuildID,
+	}))
+
+	session := &testutil.MockDiscordSession{}
+	wom := &testutil.MockWOMClient{}
+	session.On("InteractionRespond", mock.Anything, mock.Anything).Return(nil)
+	session.On("ThreadStartComplex", "test-channel-123", mock.MatchedBy(func(start *discordgo.ThreadStart) bool {
+		return start.Name == "Boss of the Week - Zulrah"
+	})).Return(&discordgo.Channel{ID: "thread-1"}, nil)
+	wom.On("CreateCompetition", mock.Anything, mock.MatchedBy(func(req wiseoldman.CreateCompetitionRequest) bool {
+		return req.Metric == "zulrah" && req.GroupID == nil
+	})).Return(testutil.CreateTestCompetitionResponse("Boss of the Week - Zulrah", "zulrah", 77), nil)
+	session.On("ChannelMessageSendEmbed", "600", mock.Anything).Return(&discordgo.Message{}, nil)
+	session.On("ChannelMessageSend", "thread-1", mock.Anything).Return(&discordgo.Message{}, nil)
+	var announcement *discordgo.MessageSend
+	session.On("ChannelMessageSendComplex", "500", mock.Anything).
+		Run(func(args mock.Arguments) { announcement = args.Get(1).(*discordgo.MessageSend) }).
+		Return(&discordgo.Message{}, nil)
+	followups := captureFollowups(session)
+
+	tc := NewTrackableCommands(q, db, wom, audit.NewLogger(q))
+	i := testutil.CreateTestInteraction("botw", "1001", "42")
+	err := tc.StartEvent(session, i, models.EventTypeBossOfTheWeek, models.EventTypeBossOfTheWeek, "zulrah", TeamSetup{})
+	require.NoError(t, err)
+
+	session.AssertExpectations(t)
+	wom.AssertExpectations(t)
+
+	require.NotNil(t, announcement)
+	row := announcement.Components[0].(discordgo.ActionsRow)
+	assert.Equal(t, "register-for-botw:77,thread-1", row.Components[0].(discordgo.Button).CustomID)
+	assert.Equal(t, "list-participants-botw:77", row.Components[1].(discordgo.Button).CustomID)
+	assert.Contains(t, followupText(*followups), "Event created! Check <#500>")
+
+	comp, err := q.GetWOMCompetitionByWOMID(ctx, 77)
+	require.NoError(t, err)
+	assert.Equal(t, "thread-1", comp.DiscordThreadID)
+	assert.Equal(t, "test-verification-code-123", comp.VerificationCode)
+	assert.Equal(t, string(models.EventTypeBossOfTheWeek), comp.Type)
+	assert.False(t, comp.WomGroupID.Valid)
+}
+
+func TestStartEventGroupCompetition(t *testing.T) {
+	db, q := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+	ctx := t.Context()
+
+	require.NoError(t, q.UpsertWOMGroup(ctx, database.UpsertWOMGroupParams{
+		GuildID:          testGuildID,
+		GroupID:          9,
+		VerificationCode: "group-code",
+		UpdatedBy:        1,
+	}))
+
+	session := &testutil.MockDiscordSession{}
+	wom := &testutil.MockWOMClient{}
+	session.On("InteractionRespond", mock.Anything, mock.Anything).Return(nil)
+	session.On("ThreadStartComplex", mock.Anything, mock.Anything).Return(&discordgo.Channel{ID: "thread-1"}, nil)
+	wom.On("CreateCompetition", mock.Anything, mock.MatchedBy(func(req wiseoldman.CreateCompetitionRequest) bool {
+		return req.GroupID != nil && *req.GroupID == 9 && req.GroupVerificationCode == "group-code"
+	})).Return(testutil.CreateTestCompetitionResponse("Skill of the Week - Mining", "mining", 78), nil)
+	session.On("ChannelMessageSend", "thread-1", mock.Anything).Return(&discordgo.Message{}, nil)
+	followups := captureFollowups(session)
+
+	tc := NewTrackableCommands(q, db, wom, audit.NewLogger(q))
+	i := testutil.CreateTestInteraction("sotw", "1001", "42")
+	err := tc.StartEvent(session, i, models.EventTypeSkillOfTheWeek, models.EventTypeSkillOfTheWeek, "mining", TeamSetup{})
+	require.NoError(t, err)
+
+	wom.AssertExpectations(t)
+	session.AssertNotCalled(t, "ChannelMessageSendEmbed", mock.Anything, mock.Anything)
+
+	// Without a notification channel the announcement is the followup
+	require.Len(t, *followups, 1)
+	row := (*followups)[0].Components[0].(discordgo.ActionsRow)
+	assert.Equal(t, "register-for-sotw:78,thread-1", row.Components[0].(discordgo.Button).CustomID)
+
+	comp, err := q.GetWOMCompetitionByWOMID(ctx, 78)
+	require.NoError(t, err)
+	assert.Equal(t, "group-code", comp.VerificationCode)
+	assert.Equal(t, sql.NullInt64{Int64: 9, Valid: true}, comp.WomGroupID)
+}
+
+func TestStartEventRateLimited(t *testing.T) {
+	db, q := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	session := &testutil.MockDiscordSession{}
+	wom := &testutil.MockWOMClient{}
+	session.On("InteractionRespond", mock.Anything, mock.Anything).Return(nil)
+	session.On("ThreadStartComplex", mock.Anything, mock.Anything).Return(&discordgo.Channel{ID: "thread-1"}, nil)
+	wom.On("CreateCompetition", mock.Anything, mock.Anything).
+		Return(nil, &wiseoldman.StatusError{StatusCode: http.StatusTooManyRequests})
+	followups := captureFollowups(session)
+
+	tc := NewTrackableCommands(q, db, wom, audit.NewLogger(q))
+	i := testutil.CreateTestInteraction("botw", "1001", "42")
+	err := tc.StartEvent(session, i, models.EventTypeBossOfTheWeek, models.EventTypeBossOfTheWeek, "zulrah", TeamSetup{})
+
+	assert.ErrorIs(t, err, wiseoldman.ErrRateLimited)
+	assert.Contains(t, followupText(*followups), "rate limiting")
+	_, err = q.GetWOMCompetitionByWOMID(t.Context(), 77)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestRegisterForEvent(t *testing.T) {
+	tests := []struct {
+		name    string
+		groupID sql.NullInt64
+		expect  func(wom *testutil.MockWOMClient)
+	}{
+		{
+			name: "open competition",
+			expect: func(wom *testutil.MockWOMClient) {
+				wom.On("AddParticipants", mock.Anything, int64(77), []string{"Zezima"}, "comp-code").
+					Return(&wiseoldman.CompetitionUpdateResponse{Count: 1, Message: "Added 1 participant."}, nil)
+			},
+		},
+		{
+			name:    "group competition",
+			groupID: sql.NullInt64{Int64: 9, Valid: true},
+			expect: func(wom *testutil.MockWOMClient) {
+				members := []wiseoldman.GroupMember{{Username: "Zezima", Role: womGroupMemberRole}}
+				wom.On("AddGroupMembers", mock.Anything, int64(9), members, "comp-code").
+					Return(&wiseoldman.GroupUpdateResponse{Count: 1, Message: "Added 1 member."}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, q := testutil.SetupTestDB(t)
+			defer testutil.CleanupTestDB(t, db)
+			ctx := t.Context()
+
+			_, err := q.CreateWOMCompetition(ctx, database.CreateWOMCompetitionParams{
+				WomCompetitionID: 77,
+				VerificationCode: "comp-code",
+				DiscordThreadID:  "thread-1",
+				Metric:           "zulrah",
+				Type:             string(models.EventTypeBossOfTheWeek),
+				WomGroupID:       tt.groupID,
+			})
+			require.NoError(t, err)
+			testutil.CreateTestAccountLink(t, q, 1001, "Zezima", true)
+
+			session := &testutil.MockDiscordSession{}
+			wom := &testutil.MockWOMClient{}
+			session.On("InteractionRespond", mock.Anything, mock.Anything).Return(nil)
+			wom.On("UpdatePlayer", mock.Anything, "Zezima").Return(testutil.CreateTestPlayer("Zezima"), nil)
+			tt.expect(wom)
+			session.On("ChannelMessageSend", "thread-1", mock.MatchedBy(func(content string) bool {
+				return strings.HasPrefix(content, "Registered **Zezima** for **Zulrah**!")
+			})).Return(&discordgo.Message{}, nil)
+			followups := captureFollowups(session)
+
+			tc := NewTrackableCommands(q, db, wom, audit.NewLogger(q))
+			i := testutil.CreateTestButtonInteraction("register-for-botw:77,thread-1", "1001", "42")
+			require.NoError(t, tc.RegisterForEvent(session, i, 77, "thread-1", models.EventTypeBossOfTheWeek))
+
+			wom.AssertExpectations(t)
+			session.AssertExpectations(t)
+			require.Len(t, *followups, 1)
+			assert.Equal(t, discordgo.MessageFlagsEphemeral, (*followups)[0].Flags)
+		})
+	}
+}
+
+func TestRegisterForEventRequiresLink(t *testing.T) {
+	db, q := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	_, err := q.CreateWOMCompetition(t.Context(), database.CreateWOMCompetitionParams{
+		WomCompetitionID: 77,
+		VerificationCode: "comp-code",
+		DiscordThreadID:  "thread-1",
+		Metric:           "zulrah",
+		Type:             string(models.EventTypeBossOfTheWeek),
+	})
+	require.NoError(t, err)
+
+	session := &testutil.MockDiscordSession{}
+	wom := &testutil.MockWOMClient{}
+	session.On("InteractionRespond", mock.Anything, mock.Anything).Return(nil)
+	followups := captureFollowups(session)
+
+	tc := NewTrackableCommands(q, db, wom, audit.NewLogger(q))
+	i := testutil.CreateTestButtonInteraction("register-for-botw:77,thread-1", "1001", "42")
+	assert.Error(t, tc.RegisterForEvent(session, i, 77, "thread-1", models.EventTypeBossOfTheWeek))
+
+	assert.Contains(t, followupText(*followups), "link your RuneScape account")
+	wom.AssertNotCalled(t, "UpdatePlayer", mock.Anything, mock.Anything)
+	wom.AssertNotCalled(t, "AddParticipants", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFinishEvent(t *testing.T) {
+	db, q := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+	ctx := t.Context()
+
+	_, err := q.CreateWOMCompetition(ctx, database.CreateWOMCompetitionParams{
+		WomCompetitionID: 77,
+		VerificationCode: "comp-code",
+		DiscordThreadID:  "thread-1",
+		Metric:           "zulrah",
+		Type:             string(models.EventTypeBossOfTheWeek),
+	})
+	require.NoError(t, err)
+	testutil.CreateTestAccountLink(t, q, 1001, "zezima", true)
+
+	participant := func(username, displayName string, gained int64) wiseoldman.CompetitionParticipation {
+		return wiseoldman.CompetitionParticipation{
+			Player:   wiseoldman.Player{Username: username, DisplayName: displayName},
+			Progress: &wiseoldman.ParticipationProgress{Gained: gained},
+		}
+	}
+	session := &testutil.MockDiscordSession{}
+	wom := &testutil.MockWOMClient{}
+	session.On("InteractionRespond", mock.Anything, mock.Anything).Return(nil)
+	wom.On("GetCompetition", mock.Anything, int64(77)).Return(&wiseoldman.Competition{
+		ID:    77,
+		Title: "Boss of the Week - Zulrah",
+		Participations: []wiseoldman.CompetitionParticipation{
+			participant("zezima", "Zezima", 50),
+			participant("lynx titan", "Lynx Titan", 30),
+			participant("woox", "Woox", 0),
+		},
+	}, nil)
+	followups := captureFollowups(session)
+
+	tc := NewTrackableCommands(q, db, wom, audit.NewLogger(q))
+	i := testutil.CreateTestInteraction("botw", "1001", "42")
+	require.NoError(t, tc.FinishEvent(session, i, models.EventTypeBossOfTheWeek))
+
+	require.Len(t, *followups, 2)
+	assert.Equal(t, "Winner of this week's Boss of the Week is <@1001> with **50 KC**! Congratulations!", (*followups)[0].Content)
+	require.Len(t, (*followups)[1].Embeds, 1)
+
+	entries, err := q.ListAuditLog(ctx, database.ListAuditLogParams{GuildID: testGuildID, RowLimit: 10})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, audit.ActionEventFinish, entries[0].Action)
+}
+
+func TestFinishEventWithoutCompetition(t *testing.T) {
+	db, q := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	session := &testutil.MockDiscordSession{}
+	wom := &testutil.MockWOMClient{}
+	session.On("InteractionRespond", mock.Anything, mock.Anything).Return(nil)
+	followups := captureFollowups(session)
+
+	tc := NewTrackableCommands(q, db, wom, audit.NewLogger(q))
+	i := testutil.CreateTestInteraction("sotw", "1001", "42")
+	assert.Error(t, tc.FinishEvent(session, i, models.EventTypeSkillOfTheWeek))
+
+	assert.Contains(t, followupText(*followups), "There's no active Skill of the Week competition ongoing!")
+	wom.AssertNotCalled(t, "GetCompetition", mock.Anything, mock.Anything)
+}
+
+func TestEventAgainstFakeWOM(t *testing.T) {
+	db, q := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+	ctx := t.Context()
+
+	wom := womtest.NewServer()
+	defer wom.Close()
+	wom.SetMetric("Zezima", "zulrah", 100)
+	wom.SetMetric("Lynx Titan", "zulrah", 400)
+
+	session := &testutil.MockDiscordSession{}
+	session.On("InteractionRespond", mock.Anything, mock.Anything).Return(nil)
+	session.On("ThreadStartComplex", mock.Anything, mock.Anything).Return(&discordgo.Channel{ID: "thread-1"}, nil)
+	session.On("ChannelMessageSend", "thread-1", mock.Anything).Return(&discordgo.Message{}, nil)
+	followups := captureFollowups(session)
+
+	tc := NewTrackableCommands(q, db, wom.Client(), audit.NewLogger(q))
+	require.NoError(t, tc.StartEvent(session, testutil.CreateTestInteraction("botw", "1001", "42"),
+		models.EventTypeBossOfTheWeek, models.EventTypeBossOfTheWeek, "zulrah", TeamSetup{}))
+
+	comp, err := q.GetLatestWOMCompetitionByType(ctx, string(models.EventTypeBossOfTheWeek))
+	require.NoError(t, err)
+
+	members := []struct {
+		id   int64
+		name string
+	}{{1001, "zezima"}, {1002, "lynx titan"}}
+	for _, m := range members {
+		testutil.CreateTestAccountLink(t, q, m.id, m.name, true)
+		i := testutil.CreateTestButtonInteraction("register-for-botw", fmt.Sprint(m.id), "42")
+		require.NoError(t, tc.RegisterForEvent(session, i, comp.WomCompetitionID, "thread-1", models.EventTypeBossOfTheWeek))
+	}
+	assert.Equal(t, []string{"lynx titan", "zezima"}, wom.Participants(comp.WomCompetitionID))
+
+	// Kills before the competition starts don't count
+	wom.Advance(2 * time.Minute)
+	wom.Gain("Zezima", "zulrah", 50)
+	wom.Gain("Lynx Titan", "zulrah", 30)
+	wom.Advance(24 * time.Hour)
+	wom.Gain("Zezima", "zulrah", 25)
+
+	*followups = nil
+	require.NoError(t, tc.FinishEvent(session, testutil.CreateTestInteraction("botw", "1001", "42"), models.EventTypeBossOfTheWeek))
+
+	require.Len(t, *followups, 2)
+	assert.Equal(t, "Winner of this week's Boss of the Week is <@1001> with **75 KC**! Congratulations!", (*followups)[0].Content)
+
+	// Final placements are kept for member profiles
+	results, err := q.ListMemberEventResults(ctx, database.ListMemberEventResultsParams{GuildID: testGuildID, DiscordMemberID: 1002})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, int64(2), results[0].Placement)
+	assert.Equal(t, int64(30), results[0].Gained)
+}
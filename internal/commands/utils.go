@@ -1,19 +1,21 @@
 package commands
 
 import (
+	"errors"
 	"log/slog"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/wiseoldman"
 )
 
 // respondToInteraction sends an initial response to an interaction.
-func respondToInteraction(s *discordgo.Session, i *discordgo.Interaction, resp *discordgo.InteractionResponse) error {
+func respondToInteraction(s InteractionResponder, i *discordgo.Interaction, resp *discordgo.InteractionResponse) error {
 	return s.InteractionRespond(i, resp)
 }
 
 // sendFollowup sends a followup message to an interaction.
 // Errors are logged internally, so callers can safely ignore the return value.
-func sendFollowup(s *discordgo.Session, i *discordgo.Interaction, params *discordgo.WebhookParams) (*discordgo.Message, error) {
+func sendFollowup(s InteractionResponder, i *discordgo.Interaction, params *discordgo.WebhookParams) (*discordgo.Message, error) {
 	msg, err := s.FollowupMessageCreate(i, true, params)
 	if err != nil {
 		slog.Error("failed to send Discord followup message",
@@ -24,3 +26,81 @@ func sendFollowup(s *discordgo.Session, i *discordgo.Interaction, params *discor
 	}
 	return msg, err
 }
+
+// subcommandOption returns the named option of the invoked subcommand, or nil if it was not provided.
+// Subcommands nested in a subcommand group are handled too.
+func subcommandOption(i *discordgo.InteractionCreate, name string) *discordgo.ApplicationCommandInteractionDataOption {
+	options := i.ApplicationCommandData().Options
+	if len(options) == 0 {
+		return nil
+	}
+	sub := options[0]
+	if sub.Type == discordgo.ApplicationCommandOptionSubCommandGroup {
+		if len(sub.Options) == 0 {
+			return nil
+		}
+		sub = sub.Options[0]
+	}
+	for _, opt := range sub.Options {
+		if opt.Name == name {
+			return opt
+		}
+	}
+	return nil
+}
+
+// deferEphemeral defers the interaction response as an ephemeral message.
+func deferEphemeral(s InteractionResponder, i *discordgo.InteractionCreate) error {
+	return respondToInteraction(s, i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// sendEphemeralEmbed sends a single embed as an ephemeral followup without pinging anyone.
+func sendEphemeralEmbed(s InteractionResponder, i *discordgo.InteractionCreate, embed *discordgo.MessageEmbed) {
+	sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
+		Embeds:          []*discordgo.MessageEmbed{embed},
+		Flags:           discordgo.MessageFlagsEphemeral,
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	})
+}
+
+// interactionUser returns the user who triggered an interaction, in a guild or a DM.
+func interactionUser(i *discordgo.InteractionCreate) *discordgo.User {
+	if i.Member != nil && i.Member.User != nil {
+		return i.Member.User
+	}
+	return i.User
+}
+
+// modalValue returns the value of the text input with customID in a modal submission.
+func modalValue(i *discordgo.InteractionCreate, customID string) string {
+	for _, component := range i.ModalSubmitData().Components {
+		row, ok := component.(*discordgo.ActionsRow)
+		if !ok {
+			continue
+		}
+		for _, c := range row.Components {
+			if input, ok := c.(*discordgo.TextInput); ok && input.CustomID == customID {
+				return input.Value
+			}
+		}
+	}
+	return ""
+}
+
+// womErrorMessage explains a failed Wise Old Man request to the user. Rate limits and outages get
+// their own message, since retrying straight away won't help; anything else uses fallback.
+func womErrorMessage(err error, fallback string) string {
+	switch {
+	case errors.Is(err, wiseoldman.ErrRateLimited):
+		return "Wise Old Man is rate limiting requests right now. Please try again in a minute."
+	case errors.Is(err, wiseoldman.ErrServerError):
+		return "Wise Old Man is having problems right now. Please try again later."
+	default:
+		return fallback
+	}
+}
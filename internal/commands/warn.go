@@ -0,0 +1,357 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/audit"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/embeds"
+)
+
+// WarnCommands handles the moderation warning commands.
+type WarnCommands struct {
+	DB    *database.Queries
+	DBSQL *sql.DB
+	Audit *audit.Logger
+}
+
+// NewWarnCommands creates a new WarnCommands instance.
+func NewWarnCommands(db *database.Queries, dbSQL *sql.DB, auditLog *audit.Logger) *WarnCommands {
+	return &WarnCommands{
+		DB:    db,
+		DBSQL: dbSQL,
+		Audit: auditLog,
+	}
+}
+
+// HandleWarnAdd handles /warn add, recording a warning and notifying the member and warning channel.
+func (w *WarnCommands) HandleWarnAdd(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	userOpt := subcommandOption(i, "user")
+	reasonOpt := subcommandOption(i, "reason")
+	if userOpt == nil || reasonOpt == nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Missing user or reason parameter."))
+		return
+	}
+	target := userOpt.UserValue(nil)
+	if resolved := i.ApplicationCommandData().Resolved; resolved != nil && resolved.Users[target.ID] != nil {
+		target = resolved.Users[target.ID]
+	}
+	reason := reasonOpt.StringValue()
+
+	if target.Bot {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Bots can't be warned."))
+		return
+	}
+
+	guildID, userID, moderatorID, err := parseWarnIDs(i.GuildID, target.ID, i.Member.User.ID)
+	if err != nil {
+		log.Printf("Error parsing IDs for warning: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse IDs."))
+		return
+	}
+
+	warning, err := w.DB.CreateWarning(ctx, database.CreateWarningParams{
+		GuildID:     guildID,
+		UserID:      userID,
+		ModeratorID: moderatorID,
+		Reason:      reason,
+	})
+	if err != nil {
+		log.Printf("Error creating warning: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to record warning. Please try again."))
+		return
+	}
+
+	total, err := w.activeWarningCount(ctx, guildID, userID)
+	if err != nil {
+		log.Printf("Error counting active warnings: %v", err)
+	}
+
+	log.Printf("User %s warned %s (%s): %s", i.Member.User.Username, target.Username, target.ID, reason)
+
+	w.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionWarningAdd,
+		Target:  fmt.Sprintf("<@%s> (warning #%d)", target.ID, warning.ID),
+		After:   reason,
+	})
+
+	msg := fmt.Sprintf("Warning #%d recorded for <@%s>. They now have **%d** active warning(s).", warning.ID, target.ID, total)
+
+	err = w.postToWarningChannel(ctx, s, guildID, embeds.WarningIssued(target.ID, i.Member.User.ID, toWarningEntry(warning), int(total)))
+	switch {
+	case errors.Is(err, ErrNoWarningChannel):
+		msg += "\n\n*No warning channel is configured. Use `/warn channel` to set one.*"
+	case err != nil:
+		log.Printf("Error posting warning #%d: %v", warning.ID, err)
+		msg += "\n\n*I couldn't post this warning to the warning channel. Check that I can send messages there.*"
+	}
+
+	if actions := w.escalate(ctx, s, warning, total); len(actions) > 0 {
+		msg += "\n\n**Automatic actions:**\n" + strings.Join(actions, "\n")
+	}
+
+	guildName := "the server"
+	if guild, err := s.Guild(i.GuildID); err == nil {
+		guildName = guild.Name
+	}
+	if err := sendDirectEmbed(s, target.ID, embeds.WarningNotice(guildName, reason, int(total))); err != nil {
+		log.Printf("Failed to DM warning to user %s: %v", target.ID, err)
+		msg += "\n\n*I couldn't DM the member about this warning. They may have DMs disabled.*"
+	}
+
+	sendEphemeralEmbed(s, i, embeds.SuccessEmbed(msg))
+}
+
+// HandleWarnList handles /warn list, showing a member's warning history.
+func (w *WarnCommands) HandleWarnList(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	userOpt := subcommandOption(i, "user")
+	if userOpt == nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Missing user parameter."))
+		return
+	}
+
+	w.sendWarningHistory(s, i, userOpt.UserValue(nil).ID)
+}
+
+// HandleViewWarnings handles the "View Warnings" user context menu command.
+func (w *WarnCommands) HandleViewWarnings(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	w.sendWarningHistory(s, i, i.ApplicationCommandData().TargetID)
+}
+
+// HandleWarnRemove handles /warn remove, deleting a warning by ID.
+func (w *WarnCommands) HandleWarnRemove(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	idOpt := subcommandOption(i, "id")
+	if idOpt == nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Missing warning ID."))
+		return
+	}
+	warningID := idOpt.IntValue()
+
+	guildID, err := strconv.ParseInt(i.GuildID, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing guild ID: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse guild ID."))
+		return
+	}
+
+	warning, err := w.DB.GetWarningByID(ctx, warningID)
+	if err != nil || warning.GuildID != guildID {
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("Error fetching warning %d: %v", warningID, err)
+		}
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(fmt.Sprintf("Warning #%d not found.", warningID)))
+		return
+	}
+
+	if _, err := w.DB.DeleteWarning(ctx, database.DeleteWarningParams{
+		ID:      warningID,
+		GuildID: guildID,
+	}); err != nil {
+		log.Printf("Error deleting warning %d: %v", warningID, err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to remove warning. Please try again."))
+		return
+	}
+
+	log.Printf("User %s removed warning #%d for user %d", i.Member.User.Username, warningID, warning.UserID)
+
+	w.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionWarningRemove,
+		Target:  fmt.Sprintf("<@%d> (warning #%d)", warning.UserID, warningID),
+		Before:  warning.Reason,
+	})
+
+	sendEphemeralEmbed(s, i, embeds.SuccessEmbed(fmt.Sprintf("Removed warning #%d for <@%d>.\n\n**Reason was:** %s", warningID, warning.UserID, warning.Reason)))
+}
+
+// HandleWarnChannel handles /warn channel, setting or clearing the channel warnings are posted to.
+func (w *WarnCommands) HandleWarnChannel(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	guildID, err := strconv.ParseInt(i.GuildID, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing guild ID: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse guild ID."))
+		return
+	}
+
+	before := ""
+	if current, err := w.DB.GetGuildWarningChannel(ctx, guildID); err == nil {
+		before = fmt.Sprintf("<#%d>", current.ChannelID)
+	}
+
+	recordConfigVersion(ctx, w.DB, guildID, i.Member.User.ID, "/warn channel")
+
+	channelOpt := subcommandOption(i, "channel")
+	if channelOpt == nil {
+		if err := w.DB.DeleteGuildWarningChannel(ctx, guildID); err != nil {
+			log.Printf("Error clearing warning channel: %v", err)
+			sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to save configuration. Please try again."))
+			return
+		}
+		w.Audit.Record(ctx, s, audit.Entry{
+			GuildID: i.GuildID,
+			ActorID: i.Member.User.ID,
+			Action:  audit.ActionWarningChannel,
+			Before:  before,
+		})
+		sendEphemeralEmbed(s, i, embeds.SuccessEmbed("Warning channel cleared. Warnings will no longer be posted to a channel."))
+		return
+	}
+
+	channel := channelOpt.ChannelValue(nil)
+	channelID, err := strconv.ParseInt(channel.ID, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing channel ID: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse channel ID."))
+		return
+	}
+
+	if _, err := w.DB.SetGuildWarningChannel(ctx, database.SetGuildWarningChannelParams{
+		GuildID:   guildID,
+		ChannelID: channelID,
+	}); err != nil {
+		log.Printf("Error setting warning channel: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to save configuration. Please try again."))
+		return
+	}
+
+	w.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionWarningChannel,
+		Before:  before,
+		After:   "<#" + channel.ID + ">",
+	})
+
+	sendEphemeralEmbed(s, i, embeds.SuccessEmbed(fmt.Sprintf("Warning channel set to <#%s>\n\nNew warnings will be posted there.", channel.ID)))
+}
+
+// sendWarningHistory replies with the warning history for userID.
+func (w *WarnCommands) sendWarningHistory(s *discordgo.Session, i *discordgo.InteractionCreate, userID string) {
+	ctx := context.Background()
+
+	guildID, memberID, _, err := parseWarnIDs(i.GuildID, userID, i.Member.User.ID)
+	if err != nil {
+		log.Printf("Error parsing IDs for warning history: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse IDs."))
+		return
+	}
+
+	warnings, err := w.DB.GetWarningsByUser(ctx, database.GetWarningsByUserParams{
+		GuildID: guildID,
+		UserID:  memberID,
+	})
+	if err != nil {
+		log.Printf("Error fetching warnings: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Database error. Please try again later."))
+		return
+	}
+
+	cutoff := w.warningExpiryCutoff(ctx, guildID)
+	entries := make([]embeds.WarningEntry, 0, len(warnings))
+	for _, warning := range warnings {
+		entry := toWarningEntry(warning)
+		entry.Expired = warning.CreatedAt.Before(cutoff)
+		entries = append(entries, entry)
+	}
+
+	sendEphemeralEmbed(s, i, embeds.WarningHistory(userID, entries))
+}
+
+// postToWarningChannel posts embed to the guild's warning channel.
+// Returns ErrNoWarningChannel if none is configured.
+func (w *WarnCommands) postToWarningChannel(ctx context.Context, s *discordgo.Session, guildID int64, embed *discordgo.MessageEmbed) error {
+	channel, err := w.DB.GetGuildWarningChannel(ctx, guildID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNoWarningChannel
+	}
+	if err != nil {
+		return fmt.Errorf("fetch warning channel: %w", err)
+	}
+
+	_, err = s.ChannelMessageSendComplex(strconv.FormatInt(channel.ChannelID, 10), &discordgo.MessageSend{
+		Embeds:          []*discordgo.MessageEmbed{embed},
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	})
+	if err != nil {
+		return fmt.Errorf("post to warning channel %d: %w", channel.ChannelID, err)
+	}
+	return nil
+}
+
+// sendDirectEmbed sends an embed to a user's DMs.
+func sendDirectEmbed(s *discordgo.Session, userID string, embed *discordgo.MessageEmbed) error {
+	dmChannel, err := s.UserChannelCreate(userID)
+	if err != nil {
+		return fmt.Errorf("create DM channel: %w", err)
+	}
+
+	if _, err := s.ChannelMessageSendEmbed(dmChannel.ID, embed); err != nil {
+		return fmt.Errorf("send DM: %w", err)
+	}
+	return nil
+}
+
+// parseWarnIDs parses the guild, member and moderator IDs used by warning queries.
+func parseWarnIDs(guildIDStr, userIDStr, moderatorIDStr string) (guildID, userID, moderatorID int64, err error) {
+	if guildID, err = strconv.ParseInt(guildIDStr, 10, 64); err != nil {
+		return 0, 0, 0, fmt.Errorf("parse guild ID: %w", err)
+	}
+	if userID, err = strconv.ParseInt(userIDStr, 10, 64); err != nil {
+		return 0, 0, 0, fmt.Errorf("parse user ID: %w", err)
+	}
+	if moderatorID, err = strconv.ParseInt(moderatorIDStr, 10, 64); err != nil {
+		return 0, 0, 0, fmt.Errorf("parse moderator ID: %w", err)
+	}
+	return guildID, userID, moderatorID, nil
+}
+
+// toWarningEntry converts a database warning for display.
+func toWarningEntry(warning database.Warning) embeds.WarningEntry {
+	return embeds.WarningEntry{
+		ID:          warning.ID,
+		ModeratorID: warning.ModeratorID,
+		Reason:      warning.Reason,
+		CreatedAt:   warning.CreatedAt,
+	}
+}
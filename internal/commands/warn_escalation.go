@@ -0,0 +1,595 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/audit"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/embeds"
+)
+
+// Escalation actions that can be attached to a warning threshold.
+const (
+	EscalationTimeout        = "timeout"
+	EscalationRemoveRole     = "remove_role"
+	EscalationKickSuggestion = "kick_suggestion"
+)
+
+// Warning action statuses recorded in warning_actions.
+const (
+	actionStatusApplied   = "applied"
+	actionStatusSuggested = "suggested"
+	actionStatusFailed    = "failed"
+)
+
+// maxTimeoutMinutes is the longest timeout Discord allows (28 days).
+const maxTimeoutMinutes = 28 * 24 * 60
+
+var (
+	// ErrMemberMissingRole is returned when a remove_role rule targets a role the member doesn't have.
+	ErrMemberMissingRole = errors.New("member does not have the role")
+
+	// ErrActionNotRevertible is returned when a warning action has already been reverted or never applied.
+	ErrActionNotRevertible = errors.New("action cannot be reverted")
+
+	// ErrUnknownEscalationAction is returned when a rule has an action this version doesn't support.
+	ErrUnknownEscalationAction = errors.New("unknown escalation action")
+
+	// ErrNoWarningChannel is returned when a guild has no warning channel configured.
+	ErrNoWarningChannel = errors.New("no warning channel configured")
+)
+
+// warningExpiryCutoff returns the creation time after which warnings still count as active.
+// A zero time means warnings never expire.
+func (w *WarnCommands) warningExpiryCutoff(ctx context.Context, guildID int64) time.Time {
+	config, err := w.DB.GetGuildConfig(ctx, guildID)
+	if err != nil || !config.WarningExpiryDays.Valid || config.WarningExpiryDays.Int64 <= 0 {
+		return time.Time{}
+	}
+	return time.Now().UTC().AddDate(0, 0, -int(config.WarningExpiryDays.Int64))
+}
+
+// activeWarningCount returns how many unexpired warnings a member has.
+func (w *WarnCommands) activeWarningCount(ctx context.Context, guildID, userID int64) (int64, error) {
+	count, err := w.DB.CountActiveWarnings(ctx, database.CountActiveWarningsParams{
+		GuildID:   guildID,
+		UserID:    userID,
+		CreatedAt: w.warningExpiryCutoff(ctx, guildID),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("count active warnings: %w", err)
+	}
+	return count, nil
+}
+
+// escalate runs every rule whose threshold equals the member's active warning count and records the outcome.
+// It returns a human-readable line per action taken.
+func (w *WarnCommands) escalate(ctx context.Context, s *discordgo.Session, warning database.Warning, activeCount int64) []string {
+	rules, err := w.DB.GetEscalationRulesForThreshold(ctx, database.GetEscalationRulesForThresholdParams{
+		GuildID:   warning.GuildID,
+		Threshold: activeCount,
+	})
+	if err != nil {
+		log.Printf("Error fetching escalation rules: %v", err)
+		return nil
+	}
+
+	guildID := strconv.FormatInt(warning.GuildID, 10)
+	userID := strconv.FormatInt(warning.UserID, 10)
+	reason := fmt.Sprintf("Reached %d active warnings (warning #%d)", activeCount, warning.ID)
+
+	lines := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		status, actionErr := w.applyEscalation(ctx, s, guildID, userID, rule, reason)
+
+		errText := sql.NullString{}
+		if actionErr != nil {
+			log.Printf("Escalation rule %d failed for user %s: %v", rule.ID, userID, actionErr)
+			errText = sql.NullString{String: actionErr.Error(), Valid: true}
+		}
+
+		action, err := w.DB.CreateWarningAction(ctx, database.CreateWarningActionParams{
+			GuildID:         warning.GuildID,
+			UserID:          warning.UserID,
+			WarningID:       warning.ID,
+			RuleID:          sql.NullInt64{Int64: rule.ID, Valid: true},
+			Action:          rule.Action,
+			DurationMinutes: rule.DurationMinutes,
+			RoleID:          rule.RoleID,
+			Status:          status,
+			Error:           errText,
+		})
+		if err != nil {
+			log.Printf("Error recording warning action: %v", err)
+			continue
+		}
+
+		lines = append(lines, formatWarningAction(action))
+	}
+
+	return lines
+}
+
+// applyEscalation performs a single rule against a member and returns the resulting status.
+func (w *WarnCommands) applyEscalation(ctx context.Context, s *discordgo.Session, guildID, userID string, rule database.WarningEscalationRule, reason string) (string, error) {
+	switch rule.Action {
+	case EscalationTimeout:
+		until := time.Now().Add(time.Duration(rule.DurationMinutes.Int64) * time.Minute)
+		if err := s.GuildMemberTimeout(guildID, userID, &until, discordgo.WithAuditLogReason(reason)); err != nil {
+			return actionStatusFailed, fmt.Errorf("timeout member: %w", err)
+		}
+		return actionStatusApplied, nil
+
+	case EscalationRemoveRole:
+		roleID := strconv.FormatInt(rule.RoleID.Int64, 10)
+		member, err := s.GuildMember(guildID, userID)
+		if err != nil {
+			return actionStatusFailed, fmt.Errorf("fetch member: %w", err)
+		}
+		if !memberHasRole(member, roleID) {
+			return actionStatusFailed, ErrMemberMissingRole
+		}
+		if err := s.GuildMemberRoleRemove(guildID, userID, roleID, discordgo.WithAuditLogReason(reason)); err != nil {
+			return actionStatusFailed, fmt.Errorf("remove role: %w", err)
+		}
+		return actionStatusApplied, nil
+
+	case EscalationKickSuggestion:
+		id, _ := strconv.ParseInt(guildID, 10, 64)
+		embed := embeds.InfoEmbed("🥾 Kick Suggested",
+			fmt.Sprintf("<@%s> has reached **%d** active warnings.\n\nThe warning policy suggests removing them from the server. A moderator should review their history with `/warn list`.", userID, rule.Threshold))
+		embed.Color = embeds.ColorError
+		return actionStatusSuggested, w.postToWarningChannel(ctx, s, id, embed)
+	}
+
+	return actionStatusFailed, fmt.Errorf("%w: %q", ErrUnknownEscalationAction, rule.Action)
+}
+
+// revertWarningAction undoes an automatic action on Discord.
+func revertWarningAction(s *discordgo.Session, action database.WarningAction, reason string) error {
+	if action.Status != actionStatusApplied && action.Status != actionStatusSuggested {
+		return ErrActionNotRevertible
+	}
+
+	guildID := strconv.FormatInt(action.GuildID, 10)
+	userID := strconv.FormatInt(action.UserID, 10)
+
+	switch action.Action {
+	case EscalationTimeout:
+		if err := s.GuildMemberTimeout(guildID, userID, nil, discordgo.WithAuditLogReason(reason)); err != nil {
+			return fmt.Errorf("clear timeout: %w", err)
+		}
+	case EscalationRemoveRole:
+		roleID := strconv.FormatInt(action.RoleID.Int64, 10)
+		if err := s.GuildMemberRoleAdd(guildID, userID, roleID, discordgo.WithAuditLogReason(reason)); err != nil {
+			return fmt.Errorf("restore role: %w", err)
+		}
+	case EscalationKickSuggestion:
+		// Nothing was done on Discord; reverting just dismisses the suggestion
+	}
+
+	return nil
+}
+
+// HandleWarnActions handles /warn actions, listing automatic actions taken against a member.
+func (w *WarnCommands) HandleWarnActions(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	userOpt := subcommandOption(i, "user")
+	if userOpt == nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Missing user parameter."))
+		return
+	}
+	target := userOpt.UserValue(nil)
+
+	guildID, userID, _, err := parseWarnIDs(i.GuildID, target.ID, i.Member.User.ID)
+	if err != nil {
+		log.Printf("Error parsing IDs for warning actions: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse IDs."))
+		return
+	}
+
+	actions, err := w.DB.GetWarningActionsByUser(ctx, database.GetWarningActionsByUserParams{
+		GuildID: guildID,
+		UserID:  userID,
+	})
+	if err != nil {
+		log.Printf("Error fetching warning actions: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Database error. Please try again later."))
+		return
+	}
+
+	if len(actions) == 0 {
+		sendEphemeralEmbed(s, i, embeds.InfoEmbed("Automatic Actions", fmt.Sprintf("No automatic actions have been taken against <@%s>.", target.ID)))
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Actions taken against <@%s>:\n\n", target.ID))
+	for _, action := range actions {
+		sb.WriteString(formatWarningAction(action))
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\nUse `/warn revert id:<action>` to undo an action.")
+
+	sendEphemeralEmbed(s, i, embeds.InfoEmbed("Automatic Actions", sb.String()))
+}
+
+// HandleWarnRevert handles /warn revert, undoing an automatic escalation action.
+func (w *WarnCommands) HandleWarnRevert(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	idOpt := subcommandOption(i, "id")
+	if idOpt == nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Missing action ID."))
+		return
+	}
+	actionID := idOpt.IntValue()
+
+	guildID, moderatorID, _, err := parseWarnIDs(i.GuildID, i.Member.User.ID, i.Member.User.ID)
+	if err != nil {
+		log.Printf("Error parsing IDs for revert: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse IDs."))
+		return
+	}
+
+	action, err := w.DB.GetWarningActionByID(ctx, actionID)
+	if err != nil || action.GuildID != guildID {
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("Error fetching warning action %d: %v", actionID, err)
+		}
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(fmt.Sprintf("Action #%d not found.", actionID)))
+		return
+	}
+
+	reason := fmt.Sprintf("Reverted by %s", i.Member.User.Username)
+	if err := revertWarningAction(s, action, reason); err != nil {
+		if errors.Is(err, ErrActionNotRevertible) {
+			sendEphemeralEmbed(s, i, embeds.ErrorEmbed(fmt.Sprintf("Action #%d is **%s** and can't be reverted.", actionID, action.Status)))
+			return
+		}
+		log.Printf("Error reverting warning action %d: %v", actionID, err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to revert the action on Discord. Check my role is above the member's and try again."))
+		return
+	}
+
+	if err := w.DB.MarkWarningActionReverted(ctx, database.MarkWarningActionRevertedParams{
+		RevertedBy: sql.NullInt64{Int64: moderatorID, Valid: true},
+		ID:         actionID,
+	}); err != nil {
+		log.Printf("Error marking warning action %d reverted: %v", actionID, err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("The action was reverted on Discord but I couldn't record it."))
+		return
+	}
+
+	log.Printf("User %s reverted warning action #%d (%s) for user %d", i.Member.User.Username, actionID, action.Action, action.UserID)
+
+	w.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionWarningRevert,
+		Target:  fmt.Sprintf("<@%d> (action #%d)", action.UserID, actionID),
+		Before:  describeEscalation(action.Action, action.DurationMinutes, action.RoleID),
+	})
+
+	sendEphemeralEmbed(s, i, embeds.SuccessEmbed(fmt.Sprintf("Reverted action #%d (%s) for <@%d>.", actionID, describeEscalation(action.Action, action.DurationMinutes, action.RoleID), action.UserID)))
+}
+
+// HandleWarnPolicyAdd handles /warn policy add, creating an escalation rule.
+func (w *WarnCommands) HandleWarnPolicyAdd(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	if !isServerOwnerOrAdmin(s, i) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Only the server owner or administrators can change warning policies."))
+		return
+	}
+
+	thresholdOpt := subcommandOption(i, "warnings")
+	actionOpt := subcommandOption(i, "action")
+	if thresholdOpt == nil || actionOpt == nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Missing warnings or action parameter."))
+		return
+	}
+	action := actionOpt.StringValue()
+
+	duration := sql.NullInt64{}
+	role := sql.NullInt64{}
+
+	switch action {
+	case EscalationTimeout:
+		durationOpt := subcommandOption(i, "duration_hours")
+		if durationOpt == nil {
+			sendEphemeralEmbed(s, i, embeds.ErrorEmbed("A timeout rule needs `duration_hours`."))
+			return
+		}
+		minutes := durationOpt.IntValue() * 60
+		if minutes <= 0 || minutes > maxTimeoutMinutes {
+			sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Timeouts must be between 1 hour and 28 days (672 hours)."))
+			return
+		}
+		duration = sql.NullInt64{Int64: minutes, Valid: true}
+	case EscalationRemoveRole:
+		roleOpt := subcommandOption(i, "role")
+		if roleOpt == nil {
+			sendEphemeralEmbed(s, i, embeds.ErrorEmbed("A remove-role rule needs `role`."))
+			return
+		}
+		roleID, err := strconv.ParseInt(roleOpt.RoleValue(nil, i.GuildID).ID, 10, 64)
+		if err != nil {
+			sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse role ID."))
+			return
+		}
+		role = sql.NullInt64{Int64: roleID, Valid: true}
+	}
+
+	guildID, moderatorID, _, err := parseWarnIDs(i.GuildID, i.Member.User.ID, i.Member.User.ID)
+	if err != nil {
+		log.Printf("Error parsing IDs for policy: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse IDs."))
+		return
+	}
+
+	recordConfigVersion(ctx, w.DB, guildID, i.Member.User.ID, "/warn policy add")
+
+	rule, err := w.DB.CreateEscalationRule(ctx, database.CreateEscalationRuleParams{
+		GuildID:         guildID,
+		Threshold:       thresholdOpt.IntValue(),
+		Action:          action,
+		DurationMinutes: duration,
+		RoleID:          role,
+		CreatedBy:       moderatorID,
+	})
+	if err != nil {
+		log.Printf("Error creating escalation rule: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to save the rule. Please try again."))
+		return
+	}
+
+	w.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionWarningPolicy,
+		Target:  fmt.Sprintf("rule #%d", rule.ID),
+		After:   fmt.Sprintf("at %d warnings → %s", rule.Threshold, describeEscalation(rule.Action, rule.DurationMinutes, rule.RoleID)),
+	})
+
+	sendEphemeralEmbed(s, i, embeds.SuccessEmbed(fmt.Sprintf("Rule #%d added: at **%d** active warnings → %s.",
+		rule.ID, rule.Threshold, describeEscalation(rule.Action, rule.DurationMinutes, rule.RoleID))))
+}
+
+// HandleWarnPolicyRemove handles /warn policy remove, deleting an escalation rule.
+func (w *WarnCommands) HandleWarnPolicyRemove(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	if !isServerOwnerOrAdmin(s, i) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Only the server owner or administrators can change warning policies."))
+		return
+	}
+
+	idOpt := subcommandOption(i, "id")
+	if idOpt == nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Missing rule ID."))
+		return
+	}
+
+	guildID, err := strconv.ParseInt(i.GuildID, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing guild ID: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse guild ID."))
+		return
+	}
+
+	recordConfigVersion(ctx, w.DB, guildID, i.Member.User.ID, "/warn policy remove")
+
+	removed, err := w.DB.DeleteEscalationRule(ctx, database.DeleteEscalationRuleParams{
+		ID:      idOpt.IntValue(),
+		GuildID: guildID,
+	})
+	if err != nil {
+		log.Printf("Error deleting escalation rule: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to remove the rule. Please try again."))
+		return
+	}
+	if removed == 0 {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(fmt.Sprintf("Rule #%d not found.", idOpt.IntValue())))
+		return
+	}
+
+	w.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionWarningPolicy,
+		Target:  fmt.Sprintf("rule #%d", idOpt.IntValue()),
+		Before:  "removed",
+	})
+
+	sendEphemeralEmbed(s, i, embeds.SuccessEmbed(fmt.Sprintf("Rule #%d removed.", idOpt.IntValue())))
+}
+
+// HandleWarnPolicyList handles /warn policy list, showing expiry and escalation rules.
+func (w *WarnCommands) HandleWarnPolicyList(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	guildID, err := strconv.ParseInt(i.GuildID, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing guild ID: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse guild ID."))
+		return
+	}
+
+	rules, err := w.DB.GetEscalationRules(ctx, guildID)
+	if err != nil {
+		log.Printf("Error fetching escalation rules: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Database error. Please try again later."))
+		return
+	}
+
+	var sb strings.Builder
+	expiry := "Never"
+	if config, err := w.DB.GetGuildConfig(ctx, guildID); err == nil {
+		expiry = formatExpiryDays(config.WarningExpiryDays)
+	}
+	sb.WriteString(fmt.Sprintf("**Warnings expire after:** %s\n\n", expiry))
+
+	if len(rules) == 0 {
+		sb.WriteString("No escalation rules. Add one with `/warn policy add`.")
+	}
+	for _, rule := range rules {
+		sb.WriteString(fmt.Sprintf("`#%d` at **%d** warnings → %s\n", rule.ID, rule.Threshold, describeEscalation(rule.Action, rule.DurationMinutes, rule.RoleID)))
+	}
+
+	sendEphemeralEmbed(s, i, embeds.InfoEmbed("⚖️ Warning Policy", sb.String()))
+}
+
+// HandleWarnPolicyExpiry handles /warn policy expiry, setting how long warnings stay active.
+func (w *WarnCommands) HandleWarnPolicyExpiry(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	if !isServerOwnerOrAdmin(s, i) {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Only the server owner or administrators can change warning policies."))
+		return
+	}
+
+	daysOpt := subcommandOption(i, "days")
+	if daysOpt == nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Missing days parameter."))
+		return
+	}
+	days := daysOpt.IntValue()
+
+	guildID, err := strconv.ParseInt(i.GuildID, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing guild ID: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse guild ID."))
+		return
+	}
+
+	if err := ensureGuildConfig(ctx, w.DB, guildID); err != nil {
+		log.Printf("Error ensuring guild config: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to fetch configuration. Please try again."))
+		return
+	}
+
+	before, _ := w.DB.GetGuildConfig(ctx, guildID)
+
+	recordConfigVersion(ctx, w.DB, guildID, i.Member.User.ID, "/warn policy expiry")
+
+	expiry := sql.NullInt64{}
+	if days > 0 {
+		expiry = sql.NullInt64{Int64: days, Valid: true}
+	}
+
+	if err := w.DB.UpdateWarningExpiryDays(ctx, database.UpdateWarningExpiryDaysParams{
+		WarningExpiryDays: expiry,
+		GuildID:           guildID,
+	}); err != nil {
+		log.Printf("Error updating warning expiry: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to save configuration. Please try again."))
+		return
+	}
+
+	w.Audit.Record(ctx, s, audit.Entry{
+		GuildID: i.GuildID,
+		ActorID: i.Member.User.ID,
+		Action:  audit.ActionWarningExpiry,
+		Before:  formatExpiryDays(before.WarningExpiryDays),
+		After:   formatExpiryDays(expiry),
+	})
+
+	if days <= 0 {
+		sendEphemeralEmbed(s, i, embeds.SuccessEmbed("Warnings no longer expire."))
+		return
+	}
+	sendEphemeralEmbed(s, i, embeds.SuccessEmbed(fmt.Sprintf("Warnings now expire after **%d** days and stop counting towards escalation rules.", days)))
+}
+
+// formatExpiryDays renders the warning expiry setting for display.
+func formatExpiryDays(days sql.NullInt64) string {
+	if !days.Valid || days.Int64 <= 0 {
+		return "Never"
+	}
+	return fmt.Sprintf("%d days", days.Int64)
+}
+
+// describeEscalation renders an escalation action for display.
+func describeEscalation(action string, durationMinutes, roleID sql.NullInt64) string {
+	switch action {
+	case EscalationTimeout:
+		return fmt.Sprintf("timeout for %s", formatMinutes(durationMinutes.Int64))
+	case EscalationRemoveRole:
+		return fmt.Sprintf("remove <@&%d>", roleID.Int64)
+	case EscalationKickSuggestion:
+		return "suggest a kick to moderators"
+	default:
+		return action
+	}
+}
+
+// formatWarningAction renders a recorded action as a single line.
+func formatWarningAction(action database.WarningAction) string {
+	line := fmt.Sprintf("`#%d` %s — **%s** <t:%d:R>",
+		action.ID, describeEscalation(action.Action, action.DurationMinutes, action.RoleID), action.Status, action.CreatedAt.Unix())
+	if action.Error.Valid {
+		line += fmt.Sprintf(" (%s)", action.Error.String)
+	}
+	return line
+}
+
+// formatMinutes renders a duration in minutes as hours or days.
+func formatMinutes(minutes int64) string {
+	if minutes%(24*60) == 0 {
+		return fmt.Sprintf("%dd", minutes/(24*60))
+	}
+	if minutes%60 == 0 {
+		return fmt.Sprintf("%dh", minutes/60)
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
+
+// memberHasRole reports whether a member has roleID.
+func memberHasRole(member *discordgo.Member, roleID string) bool {
+	for _, r := range member.Roles {
+		if r == roleID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,399 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/embeds"
+	"github.com/kaffeed/voidling/internal/timezone"
+)
+
+// Onboarding checklist steps offered in the welcome DM.
+const (
+	OnboardingLinkRSN           = "link-rsn"
+	OnboardingSetTimezone       = "set-timezone"
+	OnboardingNotificationRoles = "notification-roles"
+)
+
+const (
+	// DefaultWelcomeChannelMessage is posted in the welcome channel when no message is configured.
+	DefaultWelcomeChannelMessage = "Welcome to **{server}**, {user}! 👋"
+	// maxWelcomeTitleLength is Discord's limit for embed titles.
+	maxWelcomeTitleLength = 256
+	// maxWelcomeMessageLength is the longest DM text a modal text input accepts.
+	maxWelcomeMessageLength = 4000
+	// maxWelcomeChannelMessageLength is Discord's limit for message content.
+	maxWelcomeChannelMessageLength = 2000
+)
+
+// ErrInvalidWelcomeTemplate is returned when a welcome template contains unknown placeholders.
+var ErrInvalidWelcomeTemplate = errors.New("invalid welcome template")
+
+// onboardingStep describes a checklist step and the DM button that completes it.
+type onboardingStep struct {
+	Name        string
+	Label       string
+	Description string
+	Button      string
+	CustomID    string
+}
+
+// onboardingSteps lists every checklist step in the order they're shown.
+var onboardingSteps = []onboardingStep{
+	{OnboardingLinkRSN, "Link RSN", "Link your RuneScape account", "🔗 Link My RuneScape Account", "dm-link-rsn"},
+	{OnboardingSetTimezone, "Set timezone", "Set your timezone so event times are shown correctly", "🕐 Set My Timezone", "onboarding-timezone"},
+	{OnboardingNotificationRoles, "Event pings", "Choose which events you want to be pinged for", "🔔 Event Pings", "onboarding-notifications"},
+}
+
+// defaultOnboardingChecklist is used for guilds that haven't configured a checklist.
+var defaultOnboardingChecklist = []string{OnboardingLinkRSN}
+
+// welcomePlaceholderPattern matches {placeholder} tokens in welcome templates.
+var welcomePlaceholderPattern = regexp.MustCompile(`\{([a-z]+)\}`)
+
+// welcomePlaceholders lists the placeholders welcome templates support.
+var welcomePlaceholders = []string{"user", "name", "server", "members"}
+
+// welcomeData holds the values substituted into welcome templates.
+type welcomeData struct {
+	UserID      string
+	Name        string
+	Server      string
+	MemberCount int
+}
+
+// WelcomeCommands handles onboarding of new members: the welcome DM, the public welcome
+// message and the checklist buttons in the DM.
+type WelcomeCommands struct {
+	DB    *database.Queries
+	DBSQL *sql.DB
+}
+
+// NewWelcomeCommands creates a new WelcomeCommands instance.
+func NewWelcomeCommands(db *database.Queries, dbSQL *sql.DB) *WelcomeCommands {
+	return &WelcomeCommands{
+		DB:    db,
+		DBSQL: dbSQL,
+	}
+}
+
+// HandleGuildMemberAdd greets a new member in the welcome channel and, unless the guild
+// disabled it, by DM with the onboarding checklist.
+func (wc *WelcomeCommands) HandleGuildMemberAdd(s *discordgo.Session, m *discordgo.GuildMemberAdd) {
+	ctx := context.Background()
+
+	guild, err := s.State.Guild(m.GuildID)
+	if err != nil {
+		guild, err = s.Guild(m.GuildID)
+	}
+	if err != nil {
+		log.Printf("Error fetching guild info for greeting: %v", err)
+		return
+	}
+
+	settings, err := loadWelcomeSettings(ctx, wc.DB, m.GuildID)
+	if err != nil {
+		log.Printf("Error loading welcome settings for guild %s: %v", m.GuildID, err)
+		return
+	}
+
+	data := welcomeData{
+		UserID:      m.User.ID,
+		Name:        displayName(m.User),
+		Server:      guild.Name,
+		MemberCount: guild.MemberCount,
+	}
+
+	if settings.ChannelID.Valid {
+		channelID := strconv.FormatInt(settings.ChannelID.Int64, 10)
+		_, err := s.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+			Content:         renderWelcome(welcomeChannelTemplate(settings), data),
+			AllowedMentions: &discordgo.MessageAllowedMentions{Users: []string{m.User.ID}},
+		})
+		if err != nil {
+			log.Printf("Error posting welcome message in channel %s: %v", channelID, err)
+		}
+	}
+
+	if !FeatureEnabled(ctx, wc.DB, m.GuildID, FeatureWelcomeDM) {
+		return
+	}
+
+	dmChannel, err := s.UserChannelCreate(m.User.ID)
+	if err != nil {
+		log.Printf("Error creating DM channel for user %s: %v", m.User.Username, err)
+		return
+	}
+
+	embed, components := welcomeDM(m.GuildID, settings, data)
+	_, err = s.ChannelMessageSendComplex(dmChannel.ID, &discordgo.MessageSend{
+		Embeds:     []*discordgo.MessageEmbed{embed},
+		Components: components,
+	})
+	if err != nil {
+		// User likely has DMs disabled, fail silently
+		log.Printf("Error sending greeting DM to user %s: %v", m.User.Username, err)
+		return
+	}
+
+	log.Printf("Sent greeting DM to new member: %s (ID: %s) in guild: %s", m.User.Username, m.User.ID, guild.Name)
+}
+
+// HandleTimezoneButton shows a modal asking the member for their timezone.
+func (wc *WelcomeCommands) HandleTimezoneButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID: "onboarding-timezone-modal",
+			Title:    "Set Your Timezone",
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.TextInput{
+							CustomID:    "timezone-input",
+							Label:       "Timezone (e.g. Europe/Berlin)",
+							Style:       discordgo.TextInputShort,
+							Placeholder: "America/New_York",
+							Required:    true,
+							MaxLength:   64,
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		log.Printf("Error showing timezone modal: %v", err)
+	}
+}
+
+// HandleTimezoneModal saves the timezone entered in the onboarding modal.
+func (wc *WelcomeCommands) HandleTimezoneModal(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	tz := strings.TrimSpace(modalValue(i, "timezone-input"))
+	if err := timezone.ValidateTimezone(tz); err != nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed(fmt.Sprintf("`%s` isn't a timezone I know. Use a name like `Europe/Berlin` or `America/New_York`.", tz)))
+		return
+	}
+
+	userID, err := strconv.ParseInt(interactionUser(i).ID, 10, 64)
+	if err != nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to parse user ID."))
+		return
+	}
+
+	if err := wc.DB.UpsertUserTimezone(ctx, database.UpsertUserTimezoneParams{DiscordUserID: userID, Timezone: tz}); err != nil {
+		log.Printf("Error saving user timezone: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to save timezone preference."))
+		return
+	}
+
+	sendEphemeralEmbed(s, i, embeds.SuccessEmbed(fmt.Sprintf("Your timezone is set to **%s**. You can change it any time with `/config set-my-timezone`.", tz)))
+}
+
+// HandleNotificationsButton sends the guild's event ping role picker to the member.
+func (wc *WelcomeCommands) HandleNotificationsButton(s *discordgo.Session, i *discordgo.InteractionCreate, guildID string) {
+	ctx := context.Background()
+
+	if err := deferEphemeral(s, i); err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return
+	}
+
+	if i.GuildID != "" {
+		guildID = i.GuildID
+	}
+	id, err := strconv.ParseInt(guildID, 10, 64)
+	if err != nil {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("I couldn't tell which server this is for."))
+		return
+	}
+
+	options, err := notificationRoleOptions(ctx, wc.DB, id)
+	if err != nil {
+		log.Printf("Error loading notification roles: %v", err)
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("Failed to fetch configuration. Please try again."))
+		return
+	}
+	if len(options) == 0 {
+		sendEphemeralEmbed(s, i, embeds.ErrorEmbed("This server hasn't set up event pings yet."))
+		return
+	}
+
+	embed, components := notificationPicker(options, guildID)
+	sendFollowup(s, i.Interaction, &discordgo.WebhookParams{
+		Embeds:          []*discordgo.MessageEmbed{embed},
+		Components:      components,
+		Flags:           discordgo.MessageFlagsEphemeral,
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	})
+}
+
+// welcomeDM builds the welcome DM with its onboarding checklist and buttons.
+func welcomeDM(guildID string, settings database.GuildWelcomeSetting, data welcomeData) (*discordgo.MessageEmbed, []discordgo.MessageComponent) {
+	embed := embeds.WelcomeGreeting(data.Server)
+	if settings.DmTitle.Valid {
+		embed.Title = renderWelcome(settings.DmTitle.String, data)
+	}
+	if settings.DmMessage.Valid {
+		embed.Description = renderWelcome(settings.DmMessage.String, data)
+	}
+
+	steps := checklistSteps(parseChecklist(settings.Checklist))
+	if len(steps) == 0 {
+		return embed, nil
+	}
+
+	var lines []string
+	var buttons []discordgo.MessageComponent
+	for idx, step := range steps {
+		lines = append(lines, fmt.Sprintf("%d. %s", idx+1, step.Description))
+		buttons = append(buttons, discordgo.Button{
+			Label:    step.Button,
+			Style:    discordgo.PrimaryButton,
+			CustomID: step.CustomID + ":" + guildID, // Guild ID gives the DM button its server context
+		})
+	}
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:  "✅ Getting Started",
+		Value: strings.Join(lines, "\n"),
+	})
+
+	return embed, []discordgo.MessageComponent{discordgo.ActionsRow{Components: buttons}}
+}
+
+// welcomeChannelTemplate returns the public welcome message template, or the default.
+func welcomeChannelTemplate(settings database.GuildWelcomeSetting) string {
+	if settings.ChannelMessage.Valid {
+		return settings.ChannelMessage.String
+	}
+	return DefaultWelcomeChannelMessage
+}
+
+// ValidateWelcomeTemplate checks that a welcome template only uses supported placeholders.
+func ValidateWelcomeTemplate(template string) error {
+	for _, match := range welcomePlaceholderPattern.FindAllStringSubmatch(template, -1) {
+		if !slices.Contains(welcomePlaceholders, match[1]) {
+			return fmt.Errorf("%w: unknown placeholder {%s}", ErrInvalidWelcomeTemplate, match[1])
+		}
+	}
+	return nil
+}
+
+// renderWelcome fills in a welcome template.
+func renderWelcome(template string, data welcomeData) string {
+	return welcomePlaceholderPattern.ReplaceAllStringFunc(template, func(token string) string {
+		switch strings.Trim(token, "{}") {
+		case "user":
+			return "<@" + data.UserID + ">"
+		case "name":
+			return data.Name
+		case "server":
+			return data.Server
+		case "members":
+			return strconv.Itoa(data.MemberCount)
+		}
+		return token
+	})
+}
+
+// welcomePlaceholderList returns the supported placeholders for help text.
+func welcomePlaceholderList() string {
+	return "`{user}` `{name}` `{server}` `{members}`"
+}
+
+// loadWelcomeSettings returns the guild's welcome settings, or the defaults if it has none.
+func loadWelcomeSettings(ctx context.Context, db *database.Queries, guildID string) (database.GuildWelcomeSetting, error) {
+	id, err := strconv.ParseInt(guildID, 10, 64)
+	if err != nil {
+		return database.GuildWelcomeSetting{}, fmt.Errorf("parse guild ID: %w", err)
+	}
+
+	settings, err := db.GetWelcomeSettings(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return database.GuildWelcomeSetting{GuildID: id, Checklist: strings.Join(defaultOnboardingChecklist, ",")}, nil
+	}
+	return settings, err
+}
+
+// parseChecklist splits a stored checklist into step names.
+func parseChecklist(checklist string) []string {
+	if checklist == "" {
+		return []string{}
+	}
+	return strings.Split(checklist, ",")
+}
+
+// checklistSteps returns the known steps in names, in display order.
+func checklistSteps(names []string) []onboardingStep {
+	var steps []onboardingStep
+	for _, step := range onboardingSteps {
+		if slices.Contains(names, step.Name) {
+			steps = append(steps, step)
+		}
+	}
+	return steps
+}
+
+// formatChecklist renders step names as their labels, e.g. "Link RSN, Set timezone".
+func formatChecklist(names []string) string {
+	steps := checklistSteps(names)
+	if len(steps) == 0 {
+		return "None"
+	}
+	labels := make([]string, 0, len(steps))
+	for _, step := range steps {
+		labels = append(labels, step.Label)
+	}
+	return strings.Join(labels, ", ")
+}
+
+// syncLinkedRole gives a member the guild's linked-member role after they link an account,
+// or takes it away after they unlink. Guilds without a linked role are left alone.
+func syncLinkedRole(ctx context.Context, s MemberManager, db *database.Queries, guildID, userID string, linked bool) error {
+	if guildID == "" {
+		return ErrNoGuildContext
+	}
+
+	settings, err := loadWelcomeSettings(ctx, db, guildID)
+	if err != nil {
+		return fmt.Errorf("load welcome settings: %w", err)
+	}
+	if !settings.LinkedRoleID.Valid {
+		return nil
+	}
+
+	roleID := strconv.FormatInt(settings.LinkedRoleID.Int64, 10)
+	if linked {
+		err = s.GuildMemberRoleAdd(guildID, userID, roleID)
+	} else {
+		err = s.GuildMemberRoleRemove(guildID, userID, roleID)
+	}
+	if err != nil {
+		return fmt.Errorf("update linked role: %w", err)
+	}
+	return nil
+}
+
+// displayName returns the name a user shows up as, preferring their global display name.
+func displayName(user *discordgo.User) string {
+	if user.GlobalName != "" {
+		return user.GlobalName
+	}
+	return user.Username
+}
@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kaffeed/voidling/internal/database"
+	"github.com/kaffeed/voidling/internal/testutil"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderWelcome(t *testing.T) {
+	data := welcomeData{UserID: "42", Name: "Zezima", Server: "Void Clan", MemberCount: 150}
+
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{"mention", "Welcome {user}!", "Welcome <@42>!"},
+		{"all placeholders", "{name} joined {server} as member #{members}", "Zezima joined Void Clan as member #150"},
+		{"no placeholders", "Hello there", "Hello there"},
+		{"unknown placeholders are left alone", "Hi {rsn}", "Hi {rsn}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, renderWelcome(tt.template, data))
+		})
+	}
+}
+
+func TestValidateWelcomeTemplate(t *testing.T) {
+	assert.NoError(t, ValidateWelcomeTemplate(""))
+	assert.NoError(t, ValidateWelcomeTemplate("Welcome {user} to {server}, you're member #{members}"))
+	assert.ErrorIs(t, ValidateWelcomeTemplate("Hi {rsn}"), ErrInvalidWelcomeTemplate)
+}
+
+func TestWelcomeDM(t *testing.T) {
+	data := welcomeData{UserID: "42", Name: "Zezima", Server: "Void Clan"}
+
+	t.Run("default checklist", func(t *testing.T) {
+		settings := database.GuildWelcomeSetting{Checklist: OnboardingLinkRSN}
+
+		embed, components := welcomeDM("7", settings, data)
+
+		require.Len(t, components, 1)
+		buttons := components[0].(discordgo.ActionsRow).Components
+		require.Len(t, buttons, 1)
+		assert.Equal(t, "dm-link-rsn:7", buttons[0].(discordgo.Button).CustomID)
+		require.Len(t, embed.Fields, 1)
+		assert.Contains(t, embed.Fields[0].Value, "Link your RuneScape account")
+	})
+
+	t.Run("custom text and steps in display order", func(t *testing.T) {
+		settings := database.GuildWelcomeSetting{
+			DmTitle:   sql.NullString{String: "Hey {name}", Valid: true},
+			DmMessage: sql.NullString{String: "Glad to have you in {server}.", Valid: true},
+			Checklist: OnboardingNotificationRoles + "," + OnboardingSetTimezone,
+		}
+
+		embed, components := welcomeDM("7", settings, data)
+
+		assert.Equal(t, "Hey Zezima", embed.Title)
+		assert.Equal(t, "Glad to have you in Void Clan.", embed.Description)
+		buttons := components[0].(discordgo.ActionsRow).Components
+		require.Len(t, buttons, 2)
+		assert.Equal(t, "onboarding-timezone:7", buttons[0].(discordgo.Button).CustomID)
+		assert.Equal(t, "onboarding-notifications:7", buttons[1].(discordgo.Button).CustomID)
+	})
+
+	t.Run("empty checklist has no buttons", func(t *testing.T) {
+		embed, components := welcomeDM("7", database.GuildWelcomeSetting{}, data)
+
+		assert.Nil(t, components)
+		assert.Empty(t, embed.Fields)
+	})
+}
+
+func TestLoadWelcomeSettings(t *testing.T) {
+	db, q := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	ctx := t.Context()
+
+	settings, err := loadWelcomeSettings(ctx, q, "42")
+	require.NoError(t, err)
+	assert.Equal(t, defaultOnboardingChecklist, parseChecklist(settings.Checklist))
+	assert.False(t, settings.LinkedRoleID.Valid)
+
+	err = q.UpsertWelcomeSettings(ctx, database.UpsertWelcomeSettingsParams{
+		GuildID:      42,
+		Checklist:    "",
+		LinkedRoleID: sql.NullInt64{Int64: 600, Valid: true},
+		UpdatedBy:    7,
+	})
+	require.NoError(t, err)
+
+	settings, err = loadWelcomeSettings(ctx, q, "42")
+	require.NoError(t, err)
+	assert.Empty(t, parseChecklist(settings.Checklist))
+	assert.Equal(t, int64(600), settings.LinkedRoleID.Int64)
+}
@@ -128,6 +128,78 @@ func (q *Queries) GetAccountLinkByUsername(ctx context.Context, runescapeName st
 	return i, err
 }
 
+const getAccountLinksByUsername = `-- name: GetAccountLinksByUsername :many
+SELECT id, discord_member_id, runescape_name, is_active, created_at, updated_at FROM account_links
+WHERE LOWER(runescape_name) = LOWER(?)
+ORDER BY created_at DESC
+`
+
+func (q *Queries) GetAccountLinksByUsername(ctx context.Context, lower string) ([]AccountLink, error) {
+	rows, err := q.db.QueryContext(ctx, getAccountLinksByUsername, lower)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AccountLink{}
+	for rows.Next() {
+		var i AccountLink
+		if err := rows.Scan(
+			&i.ID,
+			&i.DiscordMemberID,
+			&i.RunescapeName,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getActiveAccountLinks = `-- name: GetActiveAccountLinks :many
+SELECT id, discord_member_id, runescape_name, is_active, created_at, updated_at FROM account_links
+WHERE is_active = 1
+ORDER BY runescape_name
+`
+
+func (q *Queries) GetActiveAccountLinks(ctx context.Context) ([]AccountLink, error) {
+	rows, err := q.db.QueryContext(ctx, getActiveAccountLinks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AccountLink{}
+	for rows.Next() {
+		var i AccountLink
+		if err := rows.Scan(
+			&i.ID,
+			&i.DiscordMemberID,
+			&i.RunescapeName,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getAllAccountLinksForUser = `-- name: GetAllAccountLinksForUser :many
 SELECT id, discord_member_id, runescape_name, is_active, created_at, updated_at FROM account_links
 WHERE discord_member_id = ?
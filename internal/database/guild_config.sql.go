@@ -13,7 +13,7 @@ import (
 const createGuildConfig = `-- name: CreateGuildConfig :one
 INSERT INTO guild_config (guild_id, coordinator_role_id)
 VALUES (?, ?)
-RETURNING id, guild_id, coordinator_role_id, created_at, updated_at, competition_code_channel_id, default_timezone, event_notification_role_id, event_notification_channel_id
+RETURNING id, guild_id, coordinator_role_id, created_at, updated_at, competition_code_channel_id, default_timezone, event_notification_role_id, event_notification_channel_id, nickname_template, warning_expiry_days, audit_log_channel_id
 `
 
 type CreateGuildConfigParams struct {
@@ -34,12 +34,15 @@ func (q *Queries) CreateGuildConfig(ctx context.Context, arg CreateGuildConfigPa
 		&i.DefaultTimezone,
 		&i.EventNotificationRoleID,
 		&i.EventNotificationChannelID,
+		&i.NicknameTemplate,
+		&i.WarningExpiryDays,
+		&i.AuditLogChannelID,
 	)
 	return i, err
 }
 
 const getGuildConfig = `-- name: GetGuildConfig :one
-SELECT id, guild_id, coordinator_role_id, created_at, updated_at, competition_code_channel_id, default_timezone, event_notification_role_id, event_notification_channel_id FROM guild_config
+SELECT id, guild_id, coordinator_role_id, created_at, updated_at, competition_code_channel_id, default_timezone, event_notification_role_id, event_notification_channel_id, nickname_template, warning_expiry_days, audit_log_channel_id FROM guild_config
 WHERE guild_id = ?
 LIMIT 1
 `
@@ -57,10 +60,70 @@ func (q *Queries) GetGuildConfig(ctx context.Context, guildID int64) (GuildConfi
 		&i.DefaultTimezone,
 		&i.EventNotificationRoleID,
 		&i.EventNotificationChannelID,
+		&i.NicknameTemplate,
+		&i.WarningExpiryDays,
+		&i.AuditLogChannelID,
 	)
 	return i, err
 }
 
+const replaceGuildConfigSettings = `-- name: ReplaceGuildConfigSettings :exec
+UPDATE guild_config
+SET coordinator_role_id = ?,
+    competition_code_channel_id = ?,
+    default_timezone = ?,
+    event_notification_role_id = ?,
+    event_notification_channel_id = ?,
+    nickname_template = ?,
+    warning_expiry_days = ?,
+    audit_log_channel_id = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE guild_id = ?
+`
+
+type ReplaceGuildConfigSettingsParams struct {
+	CoordinatorRoleID          sql.NullInt64  `json:"coordinator_role_id"`
+	CompetitionCodeChannelID   sql.NullInt64  `json:"competition_code_channel_id"`
+	DefaultTimezone            sql.NullString `json:"default_timezone"`
+	EventNotificationRoleID    sql.NullInt64  `json:"event_notification_role_id"`
+	EventNotificationChannelID sql.NullInt64  `json:"event_notification_channel_id"`
+	NicknameTemplate           sql.NullString `json:"nickname_template"`
+	WarningExpiryDays          sql.NullInt64  `json:"warning_expiry_days"`
+	AuditLogChannelID          sql.NullInt64  `json:"audit_log_channel_id"`
+	GuildID                    int64          `json:"guild_id"`
+}
+
+func (q *Queries) ReplaceGuildConfigSettings(ctx context.Context, arg ReplaceGuildConfigSettingsParams) error {
+	_, err := q.db.ExecContext(ctx, replaceGuildConfigSettings,
+		arg.CoordinatorRoleID,
+		arg.CompetitionCodeChannelID,
+		arg.DefaultTimezone,
+		arg.EventNotificationRoleID,
+		arg.EventNotificationChannelID,
+		arg.NicknameTemplate,
+		arg.WarningExpiryDays,
+		arg.AuditLogChannelID,
+		arg.GuildID,
+	)
+	return err
+}
+
+const updateAuditLogChannel = `-- name: UpdateAuditLogChannel :exec
+UPDATE guild_config
+SET audit_log_channel_id = ?, updated_at = CURRENT_TIMESTAMP
+WHERE guild_id = ?
+`
+
+type UpdateAuditLogChannelParams struct {
+	AuditLogChannelID sql.NullInt64 `json:"audit_log_channel_id"`
+	GuildID           int64         `json:"guild_id"`
+}
+
+func (q *Queries) UpdateAuditLogChannel(ctx context.Context, arg UpdateAuditLogChannelParams) error {
+	_, err := q.db.ExecContext(ctx, updateAuditLogChannel, arg.AuditLogChannelID, arg.GuildID)
+	return err
+}
+
 const updateCompetitionCodeChannel = `-- name: UpdateCompetitionCodeChannel :exec
 UPDATE guild_config
 SET competition_code_channel_id = ?, updated_at = CURRENT_TIMESTAMP
@@ -141,6 +204,38 @@ func (q *Queries) UpdateEventNotificationRole(ctx context.Context, arg UpdateEve
 	return err
 }
 
+const updateNicknameTemplate = `-- name: UpdateNicknameTemplate :exec
+UPDATE guild_config
+SET nickname_template = ?, updated_at = CURRENT_TIMESTAMP
+WHERE guild_id = ?
+`
+
+type UpdateNicknameTemplateParams struct {
+	NicknameTemplate sql.NullString `json:"nickname_template"`
+	GuildID          int64          `json:"guild_id"`
+}
+
+func (q *Queries) UpdateNicknameTemplate(ctx context.Context, arg UpdateNicknameTemplateParams) error {
+	_, err := q.db.ExecContext(ctx, updateNicknameTemplate, arg.NicknameTemplate, arg.GuildID)
+	return err
+}
+
+const updateWarningExpiryDays = `-- name: UpdateWarningExpiryDays :exec
+UPDATE guild_config
+SET warning_expiry_days = ?, updated_at = CURRENT_TIMESTAMP
+WHERE guild_id = ?
+`
+
+type UpdateWarningExpiryDaysParams struct {
+	WarningExpiryDays sql.NullInt64 `json:"warning_expiry_days"`
+	GuildID           int64         `json:"guild_id"`
+}
+
+func (q *Queries) UpdateWarningExpiryDays(ctx context.Context, arg UpdateWarningExpiryDaysParams) error {
+	_, err := q.db.ExecContext(ctx, updateWarningExpiryDays, arg.WarningExpiryDays, arg.GuildID)
+	return err
+}
+
 const upsertGuildConfig = `-- name: UpsertGuildConfig :exec
 INSERT INTO guild_config (guild_id, coordinator_role_id, competition_code_channel_id, default_timezone, event_notification_role_id)
 VALUES (?, ?, ?, ?, ?)
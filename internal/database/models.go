@@ -18,6 +18,71 @@ type AccountLink struct {
 	UpdatedAt       time.Time `json:"updated_at"`
 }
 
+type AuditLog struct {
+	ID          int64          `json:"id"`
+	GuildID     int64          `json:"guild_id"`
+	ActorID     int64          `json:"actor_id"`
+	Action      string         `json:"action"`
+	Target      sql.NullString `json:"target"`
+	BeforeValue sql.NullString `json:"before_value"`
+	AfterValue  sql.NullString `json:"after_value"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+type ClanApplication struct {
+	ID                int64          `json:"id"`
+	GuildID           int64          `json:"guild_id"`
+	DiscordUserID     int64          `json:"discord_user_id"`
+	Rsn               string         `json:"rsn"`
+	ClaimedTotalLevel int64          `json:"claimed_total_level"`
+	Playtime          string         `json:"playtime"`
+	Referral          string         `json:"referral"`
+	TotalLevel        int64          `json:"total_level"`
+	CombatLevel       int64          `json:"combat_level"`
+	Ehp               float64        `json:"ehp"`
+	MeetsRequirements bool           `json:"meets_requirements"`
+	Status            string         `json:"status"`
+	ReviewChannelID   sql.NullInt64  `json:"review_channel_id"`
+	ReviewMessageID   sql.NullInt64  `json:"review_message_id"`
+	ReviewedBy        sql.NullInt64  `json:"reviewed_by"`
+	ReviewReason      sql.NullString `json:"review_reason"`
+	ReviewedAt        sql.NullTime   `json:"reviewed_at"`
+	CreatedAt         time.Time      `json:"created_at"`
+}
+
+type CommandPermission struct {
+	ID          int64     `json:"id"`
+	GuildID     int64     `json:"guild_id"`
+	Command     string    `json:"command"`
+	SubjectType string    `json:"subject_type"`
+	SubjectID   int64     `json:"subject_id"`
+	CreatedBy   int64     `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type EventResult struct {
+	ID               int64     `json:"id"`
+	GuildID          int64     `json:"guild_id"`
+	WomCompetitionID int64     `json:"wom_competition_id"`
+	Type             string    `json:"type"`
+	Metric           string    `json:"metric"`
+	AccountLinkID    int64     `json:"account_link_id"`
+	Placement        int64     `json:"placement"`
+	Gained           int64     `json:"gained"`
+	FinishedAt       time.Time `json:"finished_at"`
+}
+
+type GuildApplicationSetting struct {
+	GuildID         int64         `json:"guild_id"`
+	ReviewChannelID sql.NullInt64 `json:"review_channel_id"`
+	ApprovedRoleID  sql.NullInt64 `json:"approved_role_id"`
+	MinTotalLevel   int64         `json:"min_total_level"`
+	MinCombatLevel  int64         `json:"min_combat_level"`
+	MinEhp          float64       `json:"min_ehp"`
+	UpdatedBy       int64         `json:"updated_by"`
+	UpdatedAt       time.Time     `json:"updated_at"`
+}
+
 type GuildConfig struct {
 	ID                         int64          `json:"id"`
 	GuildID                    int64          `json:"guild_id"`
@@ -28,6 +93,51 @@ type GuildConfig struct {
 	DefaultTimezone            sql.NullString `json:"default_timezone"`
 	EventNotificationRoleID    sql.NullInt64  `json:"event_notification_role_id"`
 	EventNotificationChannelID sql.NullInt64  `json:"event_notification_channel_id"`
+	NicknameTemplate           sql.NullString `json:"nickname_template"`
+	WarningExpiryDays          sql.NullInt64  `json:"warning_expiry_days"`
+	AuditLogChannelID          sql.NullInt64  `json:"audit_log_channel_id"`
+}
+
+type GuildConfigHistory struct {
+	ID        int64     `json:"id"`
+	GuildID   int64     `json:"guild_id"`
+	Settings  string    `json:"settings"`
+	Reason    string    `json:"reason"`
+	ChangedBy int64     `json:"changed_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type GuildDisabledFeature struct {
+	GuildID    int64     `json:"guild_id"`
+	Feature    string    `json:"feature"`
+	DisabledBy int64     `json:"disabled_by"`
+	DisabledAt time.Time `json:"disabled_at"`
+}
+
+type GuildInactivitySetting struct {
+	GuildID         int64         `json:"guild_id"`
+	ReportChannelID sql.NullInt64 `json:"report_channel_id"`
+	ThresholdDays   int64         `json:"threshold_days"`
+	NudgeEnabled    bool          `json:"nudge_enabled"`
+	LastReportAt    sql.NullTime  `json:"last_report_at"`
+	UpdatedBy       int64         `json:"updated_by"`
+	UpdatedAt       time.Time     `json:"updated_at"`
+}
+
+type GuildNotificationRole struct {
+	GuildID   int64     `json:"guild_id"`
+	EventType string    `json:"event_type"`
+	RoleID    int64     `json:"role_id"`
+	UpdatedBy int64     `json:"updated_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type GuildRankRole struct {
+	GuildID   int64     `json:"guild_id"`
+	WomRole   string    `json:"wom_role"`
+	RoleID    int64     `json:"role_id"`
+	UpdatedBy int64     `json:"updated_by"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 type GuildWarningChannel struct {
@@ -37,6 +147,39 @@ type GuildWarningChannel struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+type GuildWelcomeSetting struct {
+	GuildID        int64          `json:"guild_id"`
+	DmTitle        sql.NullString `json:"dm_title"`
+	DmMessage      sql.NullString `json:"dm_message"`
+	ChannelID      sql.NullInt64  `json:"channel_id"`
+	ChannelMessage sql.NullString `json:"channel_message"`
+	Checklist      string         `json:"checklist"`
+	LinkedRoleID   sql.NullInt64  `json:"linked_role_id"`
+	UpdatedBy      int64          `json:"updated_by"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+type GuildWomGroup struct {
+	GuildID          int64     `json:"guild_id"`
+	GroupID          int64     `json:"group_id"`
+	VerificationCode string    `json:"verification_code"`
+	UpdatedBy        int64     `json:"updated_by"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+type InactivityNudge struct {
+	GuildID         int64     `json:"guild_id"`
+	DiscordMemberID int64     `json:"discord_member_id"`
+	NudgedAt        time.Time `json:"nudged_at"`
+}
+
+type PlayerActivity struct {
+	RunescapeName string       `json:"runescape_name"`
+	LastChangedAt sql.NullTime `json:"last_changed_at"`
+	WomUpdatedAt  time.Time    `json:"wom_updated_at"`
+	CheckedAt     time.Time    `json:"checked_at"`
+}
+
 type SchedulableEvent struct {
 	ID             int64          `json:"id"`
 	Type           string         `json:"type"`
@@ -46,6 +189,7 @@ type SchedulableEvent struct {
 	CreatedAt      time.Time      `json:"created_at"`
 	DiscordEventID string         `json:"discord_event_id"`
 	Timezone       sql.NullString `json:"timezone"`
+	GuildID        sql.NullInt64  `json:"guild_id"`
 }
 
 type SchedulableEventParticipation struct {
@@ -56,6 +200,35 @@ type SchedulableEventParticipation struct {
 	CreatedAt     time.Time `json:"created_at"`
 }
 
+type TeamEvent struct {
+	ID               int64         `json:"id"`
+	GuildID          int64         `json:"guild_id"`
+	Type             string        `json:"type"`
+	Metric           string        `json:"metric"`
+	Title            string        `json:"title"`
+	DiscordThreadID  string        `json:"discord_thread_id"`
+	Balanced         bool          `json:"balanced"`
+	EndsAt           time.Time     `json:"ends_at"`
+	WomCompetitionID sql.NullInt64 `json:"wom_competition_id"`
+	CreatedBy        int64         `json:"created_by"`
+	CreatedAt        time.Time     `json:"created_at"`
+	FinishedAt       sql.NullTime  `json:"finished_at"`
+}
+
+type TeamEventMember struct {
+	TeamEventID   int64     `json:"team_event_id"`
+	AccountLinkID int64     `json:"account_link_id"`
+	TeamPosition  int64     `json:"team_position"`
+	Score         int64     `json:"score"`
+	JoinedAt      time.Time `json:"joined_at"`
+}
+
+type TeamEventTeam struct {
+	TeamEventID int64  `json:"team_event_id"`
+	Position    int64  `json:"position"`
+	Name        string `json:"name"`
+}
+
 type TrackableEvent struct {
 	ID        int64     `json:"id"`
 	Type      string    `json:"type"`
@@ -97,12 +270,40 @@ type Warning struct {
 	CreatedAt   time.Time `json:"created_at"`
 }
 
+type WarningAction struct {
+	ID              int64          `json:"id"`
+	GuildID         int64          `json:"guild_id"`
+	UserID          int64          `json:"user_id"`
+	WarningID       int64          `json:"warning_id"`
+	RuleID          sql.NullInt64  `json:"rule_id"`
+	Action          string         `json:"action"`
+	DurationMinutes sql.NullInt64  `json:"duration_minutes"`
+	RoleID          sql.NullInt64  `json:"role_id"`
+	Status          string         `json:"status"`
+	Error           sql.NullString `json:"error"`
+	RevertedBy      sql.NullInt64  `json:"reverted_by"`
+	RevertedAt      sql.NullTime   `json:"reverted_at"`
+	CreatedAt       time.Time      `json:"created_at"`
+}
+
+type WarningEscalationRule struct {
+	ID              int64         `json:"id"`
+	GuildID         int64         `json:"guild_id"`
+	Threshold       int64         `json:"threshold"`
+	Action          string        `json:"action"`
+	DurationMinutes sql.NullInt64 `json:"duration_minutes"`
+	RoleID          sql.NullInt64 `json:"role_id"`
+	CreatedBy       int64         `json:"created_by"`
+	CreatedAt       time.Time     `json:"created_at"`
+}
+
 type WomCompetition struct {
-	ID               int64     `json:"id"`
-	WomCompetitionID int64     `json:"wom_competition_id"`
-	VerificationCode string    `json:"verification_code"`
-	DiscordThreadID  string    `json:"discord_thread_id"`
-	Metric           string    `json:"metric"`
-	Type             string    `json:"type"`
-	CreatedAt        time.Time `json:"created_at"`
+	ID               int64         `json:"id"`
+	WomCompetitionID int64         `json:"wom_competition_id"`
+	VerificationCode string        `json:"verification_code"`
+	DiscordThreadID  string        `json:"discord_thread_id"`
+	Metric           string        `json:"metric"`
+	Type             string        `json:"type"`
+	CreatedAt        time.Time     `json:"created_at"`
+	WomGroupID       sql.NullInt64 `json:"wom_group_id"`
 }
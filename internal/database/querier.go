@@ -6,48 +6,96 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"time"
 )
 
 type Querier interface {
 	ActivateAccountLink(ctx context.Context, id int64) error
+	AddCommandPermission(ctx context.Context, arg AddCommandPermissionParams) (int64, error)
+	AddTeamEventMember(ctx context.Context, arg AddTeamEventMemberParams) (int64, error)
+	AddTeamEventTeam(ctx context.Context, arg AddTeamEventTeamParams) error
+	CountActiveWarnings(ctx context.Context, arg CountActiveWarningsParams) (int64, error)
+	CountAttendedMasses(ctx context.Context, arg CountAttendedMassesParams) (int64, error)
 	CreateAccountLink(ctx context.Context, arg CreateAccountLinkParams) (AccountLink, error)
+	CreateApplication(ctx context.Context, arg CreateApplicationParams) (ClanApplication, error)
+	CreateAuditLogEntry(ctx context.Context, arg CreateAuditLogEntryParams) (AuditLog, error)
+	CreateEscalationRule(ctx context.Context, arg CreateEscalationRuleParams) (WarningEscalationRule, error)
 	CreateGuildConfig(ctx context.Context, arg CreateGuildConfigParams) (GuildConfig, error)
+	CreateGuildConfigVersion(ctx context.Context, arg CreateGuildConfigVersionParams) (GuildConfigHistory, error)
 	CreateSchedulableEvent(ctx context.Context, arg CreateSchedulableEventParams) (SchedulableEvent, error)
 	CreateSchedulableParticipation(ctx context.Context, arg CreateSchedulableParticipationParams) (SchedulableEventParticipation, error)
+	CreateTeamEvent(ctx context.Context, arg CreateTeamEventParams) (TeamEvent, error)
 	CreateTrackableEvent(ctx context.Context, arg CreateTrackableEventParams) (TrackableEvent, error)
 	CreateTrackableParticipation(ctx context.Context, arg CreateTrackableParticipationParams) (TrackableEventParticipation, error)
 	CreateTrackableProgress(ctx context.Context, arg CreateTrackableProgressParams) error
 	CreateUserTimezone(ctx context.Context, arg CreateUserTimezoneParams) (UserTimezonePreference, error)
 	CreateWOMCompetition(ctx context.Context, arg CreateWOMCompetitionParams) (WomCompetition, error)
 	CreateWarning(ctx context.Context, arg CreateWarningParams) (Warning, error)
+	CreateWarningAction(ctx context.Context, arg CreateWarningActionParams) (WarningAction, error)
 	DeactivateAccountLink(ctx context.Context, id int64) error
 	DeactivateAllAccountLinksForUser(ctx context.Context, discordMemberID int64) error
 	DeactivateTrackableEvent(ctx context.Context, id int64) error
+	DeleteApplication(ctx context.Context, id int64) error
+	DeleteApplicationSettings(ctx context.Context, guildID int64) error
+	DeleteCommandPermissionsByGuild(ctx context.Context, guildID int64) error
+	DeleteDisabledGuildFeaturesByGuild(ctx context.Context, guildID int64) error
+	DeleteEscalationRule(ctx context.Context, arg DeleteEscalationRuleParams) (int64, error)
+	DeleteEscalationRulesByGuild(ctx context.Context, guildID int64) error
 	DeleteGuildWarningChannel(ctx context.Context, guildID int64) error
+	DeleteInactivitySettings(ctx context.Context, guildID int64) error
+	DeleteNotificationRole(ctx context.Context, arg DeleteNotificationRoleParams) (int64, error)
+	DeleteNotificationRolesByGuild(ctx context.Context, guildID int64) error
+	DeleteRankRole(ctx context.Context, arg DeleteRankRoleParams) (int64, error)
+	DeleteRankRolesByGuild(ctx context.Context, guildID int64) error
 	DeleteSchedulableEvent(ctx context.Context, id int64) error
 	DeleteUserTimezone(ctx context.Context, discordUserID int64) error
 	DeleteWOMCompetition(ctx context.Context, id int64) error
+	DeleteWOMGroup(ctx context.Context, guildID int64) error
+	DeleteWarning(ctx context.Context, arg DeleteWarningParams) (int64, error)
+	DeleteWelcomeSettings(ctx context.Context, guildID int64) error
+	DisableGuildFeature(ctx context.Context, arg DisableGuildFeatureParams) (int64, error)
+	EnableGuildFeature(ctx context.Context, arg EnableGuildFeatureParams) (int64, error)
+	FinishTeamEvent(ctx context.Context, id int64) error
+	FinishTeamEventByCompetition(ctx context.Context, womCompetitionID sql.NullInt64) error
 	GetAccountLinkByDiscordID(ctx context.Context, discordMemberID int64) (AccountLink, error)
 	GetAccountLinkByID(ctx context.Context, id int64) (AccountLink, error)
 	GetAccountLinkByUsername(ctx context.Context, runescapeName string) (AccountLink, error)
+	GetAccountLinksByUsername(ctx context.Context, lower string) ([]AccountLink, error)
+	GetActiveAccountLinks(ctx context.Context) ([]AccountLink, error)
 	GetActiveTrackableEvents(ctx context.Context) ([]TrackableEvent, error)
 	GetActiveTrackableEventsByType(ctx context.Context, type_ string) ([]TrackableEvent, error)
 	GetAllAccountLinksForUser(ctx context.Context, discordMemberID int64) ([]AccountLink, error)
 	GetAllEventWinnersByType(ctx context.Context, type_ string) ([]GetAllEventWinnersByTypeRow, error)
+	GetApplication(ctx context.Context, id int64) (ClanApplication, error)
+	GetApplicationSettings(ctx context.Context, guildID int64) (GuildApplicationSetting, error)
+	GetCommandPermissions(ctx context.Context, guildID int64) ([]CommandPermission, error)
+	GetDisabledGuildFeatures(ctx context.Context, guildID int64) ([]string, error)
+	GetEscalationRules(ctx context.Context, guildID int64) ([]WarningEscalationRule, error)
+	GetEscalationRulesForThreshold(ctx context.Context, arg GetEscalationRulesForThresholdParams) ([]WarningEscalationRule, error)
 	GetEventWinners(ctx context.Context, eventID int64) ([]GetEventWinnersRow, error)
 	GetExistingAccountLink(ctx context.Context, arg GetExistingAccountLinkParams) (AccountLink, error)
 	GetGuildConfig(ctx context.Context, guildID int64) (GuildConfig, error)
+	GetGuildConfigHistory(ctx context.Context, arg GetGuildConfigHistoryParams) ([]GuildConfigHistory, error)
+	GetGuildConfigVersion(ctx context.Context, arg GetGuildConfigVersionParams) (GuildConfigHistory, error)
 	GetGuildWarningChannel(ctx context.Context, guildID int64) (GuildWarningChannel, error)
+	GetInactivityNudges(ctx context.Context, guildID int64) ([]InactivityNudge, error)
+	GetInactivitySettings(ctx context.Context, guildID int64) (GuildInactivitySetting, error)
 	GetLastActiveEventByType(ctx context.Context, type_ string) (TrackableEvent, error)
+	GetLatestTeamEventByType(ctx context.Context, arg GetLatestTeamEventByTypeParams) (TeamEvent, error)
 	GetLatestWOMCompetitionByType(ctx context.Context, type_ string) (WomCompetition, error)
+	GetNotificationRoles(ctx context.Context, guildID int64) ([]GuildNotificationRole, error)
+	GetPendingApplicationByUser(ctx context.Context, arg GetPendingApplicationByUserParams) (ClanApplication, error)
+	GetPlayerActivities(ctx context.Context) ([]PlayerActivity, error)
 	GetProgressForParticipation(ctx context.Context, participationID int64) ([]TrackableEventProgress, error)
+	GetRankRoles(ctx context.Context, guildID int64) ([]GuildRankRole, error)
 	GetSchedulableEventByDiscordID(ctx context.Context, discordEventID string) (SchedulableEvent, error)
 	GetSchedulableEventByID(ctx context.Context, id int64) (SchedulableEvent, error)
 	GetSchedulableEvents(ctx context.Context) ([]SchedulableEvent, error)
 	GetSchedulableEventsInTimeRange(ctx context.Context, arg GetSchedulableEventsInTimeRangeParams) ([]SchedulableEvent, error)
 	GetSchedulableParticipation(ctx context.Context, arg GetSchedulableParticipationParams) (SchedulableEventParticipation, error)
 	GetSchedulableParticipationsByEvent(ctx context.Context, eventID int64) ([]GetSchedulableParticipationsByEventRow, error)
+	GetTeamEvent(ctx context.Context, id int64) (TeamEvent, error)
 	GetTrackableEventByID(ctx context.Context, id int64) (TrackableEvent, error)
 	GetTrackableParticipation(ctx context.Context, arg GetTrackableParticipationParams) (TrackableEventParticipation, error)
 	GetTrackableParticipationsByEvent(ctx context.Context, eventID int64) ([]GetTrackableParticipationsByEventRow, error)
@@ -58,20 +106,50 @@ type Querier interface {
 	GetWOMCompetitionByThreadID(ctx context.Context, discordThreadID string) (WomCompetition, error)
 	GetWOMCompetitionByWOMID(ctx context.Context, womCompetitionID int64) (WomCompetition, error)
 	GetWOMCompetitionsByType(ctx context.Context, type_ string) ([]WomCompetition, error)
+	GetWOMGroup(ctx context.Context, guildID int64) (GuildWomGroup, error)
+	GetWOMGroups(ctx context.Context) ([]GuildWomGroup, error)
+	GetWarningActionByID(ctx context.Context, id int64) (WarningAction, error)
+	GetWarningActionsByUser(ctx context.Context, arg GetWarningActionsByUserParams) ([]WarningAction, error)
 	GetWarningByID(ctx context.Context, id int64) (Warning, error)
 	GetWarningsByGuild(ctx context.Context, guildID int64) ([]Warning, error)
 	GetWarningsByUser(ctx context.Context, arg GetWarningsByUserParams) ([]Warning, error)
+	GetWelcomeSettings(ctx context.Context, guildID int64) (GuildWelcomeSetting, error)
+	ListAuditLog(ctx context.Context, arg ListAuditLogParams) ([]AuditLog, error)
+	ListMemberEventResults(ctx context.Context, arg ListMemberEventResultsParams) ([]EventResult, error)
+	ListTeamEventMembers(ctx context.Context, teamEventID int64) ([]ListTeamEventMembersRow, error)
+	ListTeamEventTeams(ctx context.Context, teamEventID int64) ([]TeamEventTeam, error)
 	MarkParticipationAsNotified(ctx context.Context, id int64) error
+	MarkWarningActionReverted(ctx context.Context, arg MarkWarningActionRevertedParams) error
+	RemoveCommandPermission(ctx context.Context, arg RemoveCommandPermissionParams) (int64, error)
+	RemoveTeamEventMember(ctx context.Context, arg RemoveTeamEventMemberParams) error
+	ReplaceGuildConfigSettings(ctx context.Context, arg ReplaceGuildConfigSettingsParams) error
+	ResetCommandPermissions(ctx context.Context, arg ResetCommandPermissionsParams) (int64, error)
+	ReviewApplication(ctx context.Context, arg ReviewApplicationParams) (int64, error)
+	SetApplicationReviewMessage(ctx context.Context, arg SetApplicationReviewMessageParams) error
 	SetGuildWarningChannel(ctx context.Context, arg SetGuildWarningChannelParams) (GuildWarningChannel, error)
+	SetInactivityNudge(ctx context.Context, arg SetInactivityNudgeParams) error
+	SetInactivityReportSent(ctx context.Context, arg SetInactivityReportSentParams) error
+	SetNotificationRole(ctx context.Context, arg SetNotificationRoleParams) error
+	SetRankRole(ctx context.Context, arg SetRankRoleParams) error
+	SetTeamEventCompetition(ctx context.Context, arg SetTeamEventCompetitionParams) error
+	UpdateAuditLogChannel(ctx context.Context, arg UpdateAuditLogChannelParams) error
 	UpdateCompetitionCodeChannel(ctx context.Context, arg UpdateCompetitionCodeChannelParams) error
 	UpdateCoordinatorRole(ctx context.Context, arg UpdateCoordinatorRoleParams) error
 	UpdateDefaultTimezone(ctx context.Context, arg UpdateDefaultTimezoneParams) error
 	UpdateEventNotificationChannel(ctx context.Context, arg UpdateEventNotificationChannelParams) error
 	UpdateEventNotificationRole(ctx context.Context, arg UpdateEventNotificationRoleParams) error
+	UpdateNicknameTemplate(ctx context.Context, arg UpdateNicknameTemplateParams) error
 	UpdateTrackableParticipationEndPoint(ctx context.Context, arg UpdateTrackableParticipationEndPointParams) error
 	UpdateUserTimezone(ctx context.Context, arg UpdateUserTimezoneParams) error
+	UpdateWarningExpiryDays(ctx context.Context, arg UpdateWarningExpiryDaysParams) error
+	UpsertApplicationSettings(ctx context.Context, arg UpsertApplicationSettingsParams) error
+	UpsertEventResult(ctx context.Context, arg UpsertEventResultParams) error
 	UpsertGuildConfig(ctx context.Context, arg UpsertGuildConfigParams) error
+	UpsertInactivitySettings(ctx context.Context, arg UpsertInactivitySettingsParams) error
+	UpsertPlayerActivity(ctx context.Context, arg UpsertPlayerActivityParams) error
 	UpsertUserTimezone(ctx context.Context, arg UpsertUserTimezoneParams) error
+	UpsertWOMGroup(ctx context.Context, arg UpsertWOMGroupParams) error
+	UpsertWelcomeSettings(ctx context.Context, arg UpsertWelcomeSettingsParams) error
 }
 
 var _ Querier = (*Queries)(nil)
@@ -12,9 +12,9 @@ import (
 )
 
 const createSchedulableEvent = `-- name: CreateSchedulableEvent :one
-INSERT INTO schedulable_events (type, activity, location, scheduled_at, discord_event_id, timezone)
-VALUES (?, ?, ?, ?, ?, ?)
-RETURNING id, type, activity, location, scheduled_at, created_at, discord_event_id, timezone
+INSERT INTO schedulable_events (type, activity, location, scheduled_at, discord_event_id, timezone, guild_id)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING id, type, activity, location, scheduled_at, created_at, discord_event_id, timezone, guild_id
 `
 
 type CreateSchedulableEventParams struct {
@@ -24,6 +24,7 @@ type CreateSchedulableEventParams struct {
 	ScheduledAt    time.Time      `json:"scheduled_at"`
 	DiscordEventID string         `json:"discord_event_id"`
 	Timezone       sql.NullString `json:"timezone"`
+	GuildID        sql.NullInt64  `json:"guild_id"`
 }
 
 func (q *Queries) CreateSchedulableEvent(ctx context.Context, arg CreateSchedulableEventParams) (SchedulableEvent, error) {
@@ -34,6 +35,7 @@ func (q *Queries) CreateSchedulableEvent(ctx context.Context, arg CreateSchedula
 		arg.ScheduledAt,
 		arg.DiscordEventID,
 		arg.Timezone,
+		arg.GuildID,
 	)
 	var i SchedulableEvent
 	err := row.Scan(
@@ -45,6 +47,7 @@ func (q *Queries) CreateSchedulableEvent(ctx context.Context, arg CreateSchedula
 		&i.CreatedAt,
 		&i.DiscordEventID,
 		&i.Timezone,
+		&i.GuildID,
 	)
 	return i, err
 }
@@ -85,7 +88,7 @@ func (q *Queries) DeleteSchedulableEvent(ctx context.Context, id int64) error {
 }
 
 const getSchedulableEventByDiscordID = `-- name: GetSchedulableEventByDiscordID :one
-SELECT id, type, activity, location, scheduled_at, created_at, discord_event_id, timezone FROM schedulable_events
+SELECT id, type, activity, location, scheduled_at, created_at, discord_event_id, timezone, guild_id FROM schedulable_events
 WHERE discord_event_id = ?
 LIMIT 1
 `
@@ -102,12 +105,13 @@ func (q *Queries) GetSchedulableEventByDiscordID(ctx context.Context, discordEve
 		&i.CreatedAt,
 		&i.DiscordEventID,
 		&i.Timezone,
+		&i.GuildID,
 	)
 	return i, err
 }
 
 const getSchedulableEventByID = `-- name: GetSchedulableEventByID :one
-SELECT id, type, activity, location, scheduled_at, created_at, discord_event_id, timezone FROM schedulable_events
+SELECT id, type, activity, location, scheduled_at, created_at, discord_event_id, timezone, guild_id FROM schedulable_events
 WHERE id = ?
 LIMIT 1
 `
@@ -124,12 +128,13 @@ func (q *Queries) GetSchedulableEventByID(ctx context.Context, id int64) (Schedu
 		&i.CreatedAt,
 		&i.DiscordEventID,
 		&i.Timezone,
+		&i.GuildID,
 	)
 	return i, err
 }
 
 const getSchedulableEvents = `-- name: GetSchedulableEvents :many
-SELECT id, type, activity, location, scheduled_at, created_at, discord_event_id, timezone FROM schedulable_events
+SELECT id, type, activity, location, scheduled_at, created_at, discord_event_id, timezone, guild_id FROM schedulable_events
 ORDER BY scheduled_at DESC
 `
 
@@ -151,6 +156,7 @@ func (q *Queries) GetSchedulableEvents(ctx context.Context) ([]SchedulableEvent,
 			&i.CreatedAt,
 			&i.DiscordEventID,
 			&i.Timezone,
+			&i.GuildID,
 		); err != nil {
 			return nil, err
 		}
@@ -166,7 +172,7 @@ func (q *Queries) GetSchedulableEvents(ctx context.Context) ([]SchedulableEvent,
 }
 
 const getSchedulableEventsInTimeRange = `-- name: GetSchedulableEventsInTimeRange :many
-SELECT id, type, activity, location, scheduled_at, created_at, discord_event_id, timezone FROM schedulable_events
+SELECT id, type, activity, location, scheduled_at, created_at, discord_event_id, timezone, guild_id FROM schedulable_events
 WHERE scheduled_at >= ? AND scheduled_at < ?
 ORDER BY scheduled_at ASC
 `
@@ -194,6 +200,7 @@ func (q *Queries) GetSchedulableEventsInTimeRange(ctx context.Context, arg GetSc
 			&i.CreatedAt,
 			&i.DiscordEventID,
 			&i.Timezone,
+			&i.GuildID,
 		); err != nil {
 			return nil, err
 		}
@@ -282,7 +289,7 @@ func (q *Queries) GetSchedulableParticipationsByEvent(ctx context.Context, event
 }
 
 const getUnnotifiedParticipations = `-- name: GetUnnotifiedParticipations :many
-SELECT sep.id, sep.event_id, sep.account_link_id, sep.notified, sep.created_at, al.discord_member_id, al.runescape_name, se.activity, se.location, se.scheduled_at, se.type
+SELECT sep.id, sep.event_id, sep.account_link_id, sep.notified, sep.created_at, al.discord_member_id, al.runescape_name, se.activity, se.location, se.scheduled_at, se.type, se.guild_id
 FROM schedulable_event_participations sep
 JOIN account_links al ON sep.account_link_id = al.id
 JOIN schedulable_events se ON sep.event_id = se.id
@@ -296,17 +303,18 @@ type GetUnnotifiedParticipationsParams struct {
 }
 
 type GetUnnotifiedParticipationsRow struct {
-	ID              int64     `json:"id"`
-	EventID         int64     `json:"event_id"`
-	AccountLinkID   int64     `json:"account_link_id"`
-	Notified        bool      `json:"notified"`
-	CreatedAt       time.Time `json:"created_at"`
-	DiscordMemberID int64     `json:"discord_member_id"`
-	RunescapeName   string    `json:"runescape_name"`
-	Activity        string    `json:"activity"`
-	Location        string    `json:"location"`
-	ScheduledAt     time.Time `json:"scheduled_at"`
-	Type            string    `json:"type"`
+	ID              int64         `json:"id"`
+	EventID         int64         `json:"event_id"`
+	AccountLinkID   int64         `json:"account_link_id"`
+	Notified        bool          `json:"notified"`
+	CreatedAt       time.Time     `json:"created_at"`
+	DiscordMemberID int64         `json:"discord_member_id"`
+	RunescapeName   string        `json:"runescape_name"`
+	Activity        string        `json:"activity"`
+	Location        string        `json:"location"`
+	ScheduledAt     time.Time     `json:"scheduled_at"`
+	Type            string        `json:"type"`
+	GuildID         sql.NullInt64 `json:"guild_id"`
 }
 
 func (q *Queries) GetUnnotifiedParticipations(ctx context.Context, arg GetUnnotifiedParticipationsParams) ([]GetUnnotifiedParticipationsRow, error) {
@@ -330,6 +338,7 @@ func (q *Queries) GetUnnotifiedParticipations(ctx context.Context, arg GetUnnoti
 			&i.Location,
 			&i.ScheduledAt,
 			&i.Type,
+			&i.GuildID,
 		); err != nil {
 			return nil, err
 		}
@@ -345,7 +354,7 @@ func (q *Queries) GetUnnotifiedParticipations(ctx context.Context, arg GetUnnoti
 }
 
 const getUpcomingSchedulableEvents = `-- name: GetUpcomingSchedulableEvents :many
-SELECT id, type, activity, location, scheduled_at, created_at, discord_event_id, timezone FROM schedulable_events
+SELECT id, type, activity, location, scheduled_at, created_at, discord_event_id, timezone, guild_id FROM schedulable_events
 WHERE scheduled_at > ?
 ORDER BY scheduled_at ASC
 `
@@ -368,6 +377,7 @@ func (q *Queries) GetUpcomingSchedulableEvents(ctx context.Context, scheduledAt
 			&i.CreatedAt,
 			&i.DiscordEventID,
 			&i.Timezone,
+			&i.GuildID,
 		); err != nil {
 			return nil, err
 		}
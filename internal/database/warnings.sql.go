@@ -7,8 +7,27 @@ package database
 
 import (
 	"context"
+	"time"
 )
 
+const countActiveWarnings = `-- name: CountActiveWarnings :one
+SELECT COUNT(*) FROM warnings
+WHERE guild_id = ? AND user_id = ? AND created_at >= ?
+`
+
+type CountActiveWarningsParams struct {
+	GuildID   int64     `json:"guild_id"`
+	UserID    int64     `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (q *Queries) CountActiveWarnings(ctx context.Context, arg CountActiveWarningsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countActiveWarnings, arg.GuildID, arg.UserID, arg.CreatedAt)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createWarning = `-- name: CreateWarning :one
 INSERT INTO warnings (guild_id, user_id, moderator_id, reason)
 VALUES (?, ?, ?, ?)
@@ -51,6 +70,24 @@ func (q *Queries) DeleteGuildWarningChannel(ctx context.Context, guildID int64)
 	return err
 }
 
+const deleteWarning = `-- name: DeleteWarning :execrows
+DELETE FROM warnings
+WHERE id = ? AND guild_id = ?
+`
+
+type DeleteWarningParams struct {
+	ID      int64 `json:"id"`
+	GuildID int64 `json:"guild_id"`
+}
+
+func (q *Queries) DeleteWarning(ctx context.Context, arg DeleteWarningParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteWarning, arg.ID, arg.GuildID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const getGuildWarningChannel = `-- name: GetGuildWarningChannel :one
 SELECT id, guild_id, channel_id, created_at FROM guild_warning_channels
 WHERE guild_id = ?
@@ -7,20 +7,22 @@ package database
 
 import (
 	"context"
+	"database/sql"
 )
 
 const createWOMCompetition = `-- name: CreateWOMCompetition :one
-INSERT INTO wom_competitions (wom_competition_id, verification_code, discord_thread_id, metric, type)
-VALUES (?, ?, ?, ?, ?)
-RETURNING id, wom_competition_id, verification_code, discord_thread_id, metric, type, created_at
+INSERT INTO wom_competitions (wom_competition_id, verification_code, discord_thread_id, metric, type, wom_group_id)
+VALUES (?, ?, ?, ?, ?, ?)
+RETURNING id, wom_competition_id, verification_code, discord_thread_id, metric, type, created_at, wom_group_id
 `
 
 type CreateWOMCompetitionParams struct {
-	WomCompetitionID int64  `json:"wom_competition_id"`
-	VerificationCode string `json:"verification_code"`
-	DiscordThreadID  string `json:"discord_thread_id"`
-	Metric           string `json:"metric"`
-	Type             string `json:"type"`
+	WomCompetitionID int64         `json:"wom_competition_id"`
+	VerificationCode string        `json:"verification_code"`
+	DiscordThreadID  string        `json:"discord_thread_id"`
+	Metric           string        `json:"metric"`
+	Type             string        `json:"type"`
+	WomGroupID       sql.NullInt64 `json:"wom_group_id"`
 }
 
 func (q *Queries) CreateWOMCompetition(ctx context.Context, arg CreateWOMCompetitionParams) (WomCompetition, error) {
@@ -30,6 +32,7 @@ func (q *Queries) CreateWOMCompetition(ctx context.Context, arg CreateWOMCompeti
 		arg.DiscordThreadID,
 		arg.Metric,
 		arg.Type,
+		arg.WomGroupID,
 	)
 	var i WomCompetition
 	err := row.Scan(
@@ -40,6 +43,7 @@ func (q *Queries) CreateWOMCompetition(ctx context.Context, arg CreateWOMCompeti
 		&i.Metric,
 		&i.Type,
 		&i.CreatedAt,
+		&i.WomGroupID,
 	)
 	return i, err
 }
@@ -55,7 +59,7 @@ func (q *Queries) DeleteWOMCompetition(ctx context.Context, id int64) error {
 }
 
 const getLatestWOMCompetitionByType = `-- name: GetLatestWOMCompetitionByType :one
-SELECT id, wom_competition_id, verification_code, discord_thread_id, metric, type, created_at FROM wom_competitions
+SELECT id, wom_competition_id, verification_code, discord_thread_id, metric, type, created_at, wom_group_id FROM wom_competitions
 WHERE type = ?
 ORDER BY created_at DESC
 LIMIT 1
@@ -72,12 +76,13 @@ func (q *Queries) GetLatestWOMCompetitionByType(ctx context.Context, type_ strin
 		&i.Metric,
 		&i.Type,
 		&i.CreatedAt,
+		&i.WomGroupID,
 	)
 	return i, err
 }
 
 const getWOMCompetitionByID = `-- name: GetWOMCompetitionByID :one
-SELECT id, wom_competition_id, verification_code, discord_thread_id, metric, type, created_at FROM wom_competitions
+SELECT id, wom_competition_id, verification_code, discord_thread_id, metric, type, created_at, wom_group_id FROM wom_competitions
 WHERE id = ?
 LIMIT 1
 `
@@ -93,12 +98,13 @@ func (q *Queries) GetWOMCompetitionByID(ctx context.Context, id int64) (WomCompe
 		&i.Metric,
 		&i.Type,
 		&i.CreatedAt,
+		&i.WomGroupID,
 	)
 	return i, err
 }
 
 const getWOMCompetitionByThreadID = `-- name: GetWOMCompetitionByThreadID :one
-SELECT id, wom_competition_id, verification_code, discord_thread_id, metric, type, created_at FROM wom_competitions
+SELECT id, wom_competition_id, verification_code, discord_thread_id, metric, type, created_at, wom_group_id FROM wom_competitions
 WHERE discord_thread_id = ?
 LIMIT 1
 `
@@ -114,12 +120,13 @@ func (q *Queries) GetWOMCompetitionByThreadID(ctx context.Context, discordThread
 		&i.Metric,
 		&i.Type,
 		&i.CreatedAt,
+		&i.WomGroupID,
 	)
 	return i, err
 }
 
 const getWOMCompetitionByWOMID = `-- name: GetWOMCompetitionByWOMID :one
-SELECT id, wom_competition_id, verification_code, discord_thread_id, metric, type, created_at FROM wom_competitions
+SELECT id, wom_competition_id, verification_code, discord_thread_id, metric, type, created_at, wom_group_id FROM wom_competitions
 WHERE wom_competition_id = ?
 LIMIT 1
 `
@@ -135,12 +142,13 @@ func (q *Queries) GetWOMCompetitionByWOMID(ctx context.Context, womCompetitionID
 		&i.Metric,
 		&i.Type,
 		&i.CreatedAt,
+		&i.WomGroupID,
 	)
 	return i, err
 }
 
 const getWOMCompetitionsByType = `-- name: GetWOMCompetitionsByType :many
-SELECT id, wom_competition_id, verification_code, discord_thread_id, metric, type, created_at FROM wom_competitions
+SELECT id, wom_competition_id, verification_code, discord_thread_id, metric, type, created_at, wom_group_id FROM wom_competitions
 WHERE type = ?
 ORDER BY created_at DESC
 `
@@ -162,6 +170,7 @@ func (q *Queries) GetWOMCompetitionsByType(ctx context.Context, type_ string) ([
 			&i.Metric,
 			&i.Type,
 			&i.CreatedAt,
+			&i.WomGroupID,
 		); err != nil {
 			return nil, err
 		}
@@ -3,8 +3,12 @@
 package embeds
 
 import (
+	"cmp"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/kaffeed/voidling/internal/models"
@@ -111,6 +115,176 @@ func PlayerInfo(player *wiseoldman.Player) *discordgo.MessageEmbed {
 	}
 }
 
+// profileTopBosses is how many bosses a profile lists.
+const profileTopBosses = 5
+
+// EventRecord summarises a member's Boss or Skill of the Week results.
+type EventRecord struct {
+	Participations int
+	Wins           int
+	Podiums        int   // Top three finishes, wins included
+	Best           int64 // Best placement, 0 if they never placed
+}
+
+// ProfileEntry holds a member's linked account and clan history for display.
+type ProfileEntry struct {
+	UserID         string
+	RSN            string
+	Player         *wiseoldman.Player // nil if Wise Old Man couldn't be reached
+	LinkedSince    time.Time
+	BOTW           EventRecord
+	SOTW           EventRecord
+	MassesAttended int64
+	ShowWarnings   bool // Warnings are only shown to staff
+	Warnings       int
+}
+
+// Profile creates the /profile embed with a member's account stats and clan history.
+func Profile(p ProfileEntry) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title:       "👤 " + p.RSN,
+		URL:         "https://wiseoldman.net/players/" + strings.ReplaceAll(p.RSN, " ", "%20"),
+		Description: fmt.Sprintf("<@%s> • linked since <t:%d:D>", p.UserID, p.LinkedSince.Unix()),
+		Color:       ColorInfo,
+		Timestamp:   time.Now().Format(time.RFC3339),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Stats from Wise Old Man",
+		},
+	}
+
+	var overall *wiseoldman.SkillData
+	if p.Player != nil {
+		overall = p.Player.GetSkill("overall")
+	}
+	if overall == nil {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  "Stats",
+			Value: "Stats are unavailable from Wise Old Man right now.",
+		})
+	} else {
+		embed.Title = "👤 " + p.Player.DisplayName
+		embed.Fields = append(embed.Fields,
+			&discordgo.MessageEmbedField{Name: "Total Level", Value: fmt.Sprintf("%d", overall.Level), Inline: true},
+			&discordgo.MessageEmbedField{Name: "Total XP", Value: formatNumber(overall.Experience), Inline: true},
+			&discordgo.MessageEmbedField{Name: "Combat Level", Value: fmt.Sprintf("%d", p.Player.CombatLevel), Inline: true},
+			&discordgo.MessageEmbedField{Name: "EHP", Value: fmt.Sprintf("%.1f", p.Player.EHP), Inline: true},
+			&discordgo.MessageEmbedField{Name: "EHB", Value: fmt.Sprintf("%.1f", p.Player.EHB), Inline: true},
+		)
+
+		bosses := topBosses(p.Player, profileTopBosses)
+		value := "No boss kills yet."
+		if len(bosses) > 0 {
+			lines := make([]string, 0, len(bosses))
+			for _, boss := range bosses {
+				lines = append(lines, fmt.Sprintf("**%s**: %s", formatActivityName(boss.Metric), formatNumber(int64(boss.Kills))))
+			}
+			value = strings.Join(lines, "\n")
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: "Top Bosses", Value: value})
+	}
+
+	embed.Fields = append(embed.Fields,
+		&discordgo.MessageEmbedField{Name: "Boss of the Week", Value: eventRecordValue(p.BOTW), Inline: true},
+		&discordgo.MessageEmbedField{Name: "Skill of the Week", Value: eventRecordValue(p.SOTW), Inline: true},
+		&discordgo.MessageEmbedField{Name: "Masses Attended", Value: fmt.Sprintf("%d", p.MassesAttended), Inline: true},
+	)
+	if p.ShowWarnings {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "Warnings",
+			Value:  fmt.Sprintf("%d", p.Warnings),
+			Inline: true,
+		})
+	}
+
+	return embed
+}
+
+// topBosses returns a player's n bosses with the most kills, most first.
+func topBosses(player *wiseoldman.Player, n int) []wiseoldman.BossData {
+	if player.LatestSnapshot == nil {
+		return nil
+	}
+	var bosses []wiseoldman.BossData
+	for _, boss := range player.LatestSnapshot.Data.Bosses {
+		if boss.Kills > 0 {
+			bosses = append(bosses, boss)
+		}
+	}
+	slices.SortFunc(bosses, func(a, b wiseoldman.BossData) int {
+		if c := cmp.Compare(b.Kills, a.Kills); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Metric, b.Metric)
+	})
+	return bosses[:min(n, len(bosses))]
+}
+
+// eventRecordValue formats a member's results in one event type.
+func eventRecordValue(r EventRecord) string {
+	if r.Participations == 0 {
+		return "No events yet"
+	}
+	value := fmt.Sprintf("%d event(s)\n%d win(s), %d podium(s)", r.Participations, r.Wins, r.Podiums)
+	if r.Best > 0 {
+		value += fmt.Sprintf("\nBest: #%d", r.Best)
+	}
+	return value
+}
+
+// gainsTopMetrics is how many skills and bosses a gains embed lists.
+const gainsTopMetrics = 10
+
+// Gains creates the /gains embed with what a member gained over period, e.g. "This week".
+func Gains(userID, rsn, period string, gains *wiseoldman.PlayerGains) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("📈 %s: %s", rsn, period),
+		URL:         "https://wiseoldman.net/players/" + strings.ReplaceAll(rsn, " ", "%20") + "/gained",
+		Description: fmt.Sprintf("Gains of <@%s>", userID),
+		Color:       ColorSuccess,
+		Timestamp:   time.Now().Format(time.RFC3339),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Data from Wise Old Man",
+		},
+	}
+	if gains.StartsAt != nil && gains.EndsAt != nil {
+		embed.Description += fmt.Sprintf(" from <t:%d:f> to <t:%d:f>", gains.StartsAt.Unix(), gains.EndsAt.Unix())
+	}
+
+	skills, bosses := gains.TopSkills(gainsTopMetrics), gains.TopBosses(gainsTopMetrics)
+	if len(skills) == 0 && len(bosses) == 0 {
+		embed.Color = ColorInfo
+		embed.Description += "\n\nNo gains in this period. Gains only show once Wise Old Man has updated the account, so try updating it on https://wiseoldman.net."
+		return embed
+	}
+
+	embed.Fields = append(embed.Fields,
+		&discordgo.MessageEmbedField{Name: "Total XP", Value: "+" + formatNumber(gains.Data.Skills["overall"].Experience.Gained), Inline: true},
+		&discordgo.MessageEmbedField{Name: "EHP", Value: fmt.Sprintf("+%.2f", gains.Data.Computed["ehp"].Value.Gained), Inline: true},
+		&discordgo.MessageEmbedField{Name: "EHB", Value: fmt.Sprintf("+%.2f", gains.Data.Computed["ehb"].Value.Gained), Inline: true},
+	)
+
+	if len(skills) > 0 {
+		lines := make([]string, 0, len(skills))
+		for _, skill := range skills {
+			line := fmt.Sprintf("**%s**: +%s XP", formatActivityName(skill.Metric), formatNumber(skill.Experience.Gained))
+			if skill.Level.Gained > 0 {
+				line += fmt.Sprintf(" (%d → %d)", skill.Level.Start, skill.Level.End)
+			}
+			lines = append(lines, line)
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: "Top Skills", Value: strings.Join(lines, "\n")})
+	}
+	if len(bosses) > 0 {
+		lines := make([]string, 0, len(bosses))
+		for _, boss := range bosses {
+			lines = append(lines, fmt.Sprintf("**%s**: +%s KC", formatActivityName(boss.Metric), formatNumber(boss.Kills.Gained)))
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: "Top Bosses", Value: strings.Join(lines, "\n")})
+	}
+
+	return embed
+}
+
 // BossOfTheWeek creates an embed for Boss of the Week events.
 func BossOfTheWeek(activity models.HiscoreField, womCompetitionID int64) *discordgo.MessageEmbed {
 	womURL := fmt.Sprintf("https://wiseoldman.net/competitions/%d", womCompetitionID)
@@ -228,6 +402,140 @@ func EventWinners(eventType models.EventType, activity models.HiscoreField, winn
 	}
 }
 
+// TeamEvent creates an embed announcing a team Boss or Skill of the Week. Balanced events place
+// members on the team with the lowest combined KC or XP, others on the smallest team.
+func TeamEvent(eventType models.EventType, activity models.HiscoreField, teams []string, balanced bool) *discordgo.MessageEmbed {
+	title, color, unit := "🏆 Boss of the Week - Teams", ColorBOTW, "KC"
+	if eventType == models.EventTypeSkillOfTheWeek {
+		title, color, unit = "📚 Skill of the Week - Teams", ColorSOTW, "XP"
+	}
+
+	placement := "Members are placed on the smallest team."
+	if balanced {
+		placement = fmt.Sprintf("Members are placed on the team with the lowest combined %s, keeping the teams balanced.", unit)
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       title,
+		Description: fmt.Sprintf("This week's team challenge: **%s**\n\nClick the button below to join a team!", activity),
+		Color:       color,
+		Thumbnail: &discordgo.MessageEmbedThumbnail{
+			URL: "https://oldschool.runescape.wiki/images/OSRS_icon.png",
+		},
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   "Teams",
+				Value:  strings.Join(teams, " vs "),
+				Inline: false,
+			},
+			{
+				Name:   "How it works",
+				Value:  fmt.Sprintf("%s At the end of the week, the team with the most %s gained wins, and each team's MVP gets a shout-out!", placement, unit),
+				Inline: false,
+			},
+		},
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+}
+
+// TeamRosterEntry holds a team's members and progress so far for display.
+type TeamRosterEntry struct {
+	Name    string
+	Gained  int64
+	Members []string // One line per member
+}
+
+// TeamRoster creates the member list of a running team event, one field per team. Long teams are
+// cut short with "...and N more" so every team fits within the embed's limits.
+func TeamRoster(title string, teams []TeamRosterEntry) *discordgo.MessageEmbed {
+	const maxFieldLength = 1024
+
+	embed := &discordgo.MessageEmbed{
+		Title:     truncate("👥 Teams for "+title, 256),
+		Color:     ColorInfo,
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+
+	// Share the embed's length evenly, leaving room for the title and field names
+	budget := maxFieldLength
+	if len(teams) > 0 {
+		budget = min(budget, (maxEmbedLength-utf8.RuneCountInString(embed.Title))/len(teams)-100)
+	}
+
+	for _, team := range teams {
+		var value strings.Builder
+		for idx, line := range team.Members {
+			more := fmt.Sprintf("...and %d more", len(team.Members)-idx)
+			if utf8.RuneCountInString(value.String()+line+"\n"+more) > budget {
+				value.WriteString(more)
+				break
+			}
+			value.WriteString(line + "\n")
+		}
+		if value.Len() == 0 {
+			value.WriteString("No members yet")
+		}
+
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  truncate(fmt.Sprintf("%s (%d member(s), %s gained)", team.Name, len(team.Members), formatNumber(team.Gained)), 100),
+			Value: strings.TrimSpace(value.String()),
+		})
+	}
+
+	return embed
+}
+
+// TeamResult holds a team's result for display.
+type TeamResult struct {
+	Name         string
+	Gained       int64
+	Members      int
+	MVP          string
+	MVPDiscordID uint64
+	MVPGained    int64
+}
+
+// TeamResults creates an embed showing a team event's standings and each team's MVP, best team
+// first.
+func TeamResults(eventType models.EventType, activity models.HiscoreField, teams []TeamResult) *discordgo.MessageEmbed {
+	title, color, unit := "🏆 Boss of the Week - Team Results", ColorBOTW, "KC"
+	if eventType == models.EventTypeSkillOfTheWeek {
+		title, color, unit = "📚 Skill of the Week - Team Results", ColorSOTW, "XP"
+	}
+
+	medals := []string{"🥇", "🥈", "🥉"}
+	fields := make([]*discordgo.MessageEmbedField, 0, len(teams))
+	for i, team := range teams {
+		name := team.Name
+		if i < len(medals) {
+			name = medals[i] + " " + name
+		}
+
+		value := fmt.Sprintf("Gained: **%s %s** by %d member(s)", formatNumber(team.Gained), unit, team.Members)
+		if team.MVP != "" {
+			mvp := team.MVP
+			if team.MVPDiscordID > 0 {
+				mvp = fmt.Sprintf("<@%d>", team.MVPDiscordID)
+			}
+			value += fmt.Sprintf("\nMVP: %s with **%s %s**", mvp, formatNumber(team.MVPGained), unit)
+		}
+
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   name,
+			Value:  value,
+			Inline: false,
+		})
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       title,
+		Description: fmt.Sprintf("**%s** has concluded!\n\nHere's how the teams did:", activity),
+		Color:       color,
+		Fields:      fields,
+		Timestamp:   time.Now().Format(time.RFC3339),
+	}
+}
+
 // MassEvent creates an embed for mass events.
 func MassEvent(activity string, location string, scheduledAt time.Time) *discordgo.MessageEmbed {
 	return &discordgo.MessageEmbed{
@@ -315,6 +623,16 @@ func SuccessEmbed(message string) *discordgo.MessageEmbed {
 	}
 }
 
+// InfoEmbed creates a generic informational embed.
+func InfoEmbed(title, message string) *discordgo.MessageEmbed {
+	return &discordgo.MessageEmbed{
+		Title:       title,
+		Description: message,
+		Color:       ColorInfo,
+		Timestamp:   time.Now().Format(time.RFC3339),
+	}
+}
+
 // CompetitionCodeEmbed creates an embed for WOM competition verification codes.
 func CompetitionCodeEmbed(eventName string, verificationCode string, competitionID int64) *discordgo.MessageEmbed {
 	womURL := fmt.Sprintf("https://wiseoldman.net/competitions/%d", competitionID)
@@ -511,3 +829,463 @@ func WelcomeGreeting(guildName string) *discordgo.MessageEmbed {
 		Timestamp: time.Now().Format(time.RFC3339),
 	}
 }
+
+// WarningEntry holds a single warning for display.
+type WarningEntry struct {
+	ID          int64
+	ModeratorID int64
+	Reason      string
+	CreatedAt   time.Time
+	Expired     bool
+}
+
+// WarningIssued creates the embed posted to the warning channel when a member is warned.
+func WarningIssued(userID, moderatorID string, warning WarningEntry, total int) *discordgo.MessageEmbed {
+	return &discordgo.MessageEmbed{
+		Title: "⚠️ Member Warned",
+		Color: ColorWarning,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "Member", Value: fmt.Sprintf("<@%s>", userID), Inline: true},
+			{Name: "Moderator", Value: fmt.Sprintf("<@%s>", moderatorID), Inline: true},
+			{Name: "Total Warnings", Value: fmt.Sprintf("%d", total), Inline: true},
+			{Name: "Reason", Value: warning.Reason},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Warning #%d", warning.ID),
+		},
+		Timestamp: warning.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// WarningNotice creates the DM sent to a member when they receive a warning.
+func WarningNotice(guildName, reason string, total int) *discordgo.MessageEmbed {
+	return &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("⚠️ You received a warning in %s", guildName),
+		Description: fmt.Sprintf("**Reason:** %s\n\nYou now have **%d** warning(s) on record. If you have questions, please reach out to a coordinator.", reason, total),
+		Color:       ColorWarning,
+		Timestamp:   time.Now().Format(time.RFC3339),
+	}
+}
+
+// WarningHistory creates an embed listing a member's warnings, newest first.
+func WarningHistory(userID string, warnings []WarningEntry) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title:       "📋 Warning History",
+		Description: fmt.Sprintf("<@%s> has **%d** warning(s).", userID, len(warnings)),
+		Color:       ColorWarning,
+		Timestamp:   time.Now().Format(time.RFC3339),
+	}
+
+	if len(warnings) == 0 {
+		embed.Color = ColorSuccess
+		embed.Description = fmt.Sprintf("<@%s> has no warnings.", userID)
+		return embed
+	}
+
+	// Discord allows at most 25 fields and 6000 characters per embed, so long histories only show
+	// the most recent warnings. The footer's length is reserved up front.
+	const (
+		maxWarningFields    = 25
+		maxWarningReason    = 300
+		warningFooterLength = 64
+	)
+	length := utf8.RuneCountInString(embed.Title) + utf8.RuneCountInString(embed.Description) + warningFooterLength
+	for _, w := range warnings {
+		name := fmt.Sprintf("#%d • %s", w.ID, w.CreatedAt.Format("2006-01-02"))
+		if w.Expired {
+			name += " (expired)"
+		}
+		value := fmt.Sprintf("%s\n*by <@%d> <t:%d:R>*", truncate(w.Reason, maxWarningReason), w.ModeratorID, w.CreatedAt.Unix())
+
+		length += utf8.RuneCountInString(name) + utf8.RuneCountInString(value)
+		if len(embed.Fields) == maxWarningFields || length > maxEmbedLength {
+			break
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: name, Value: value})
+	}
+	if len(embed.Fields) < len(warnings) {
+		embed.Footer = &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Showing the %d most recent of %d warnings", len(embed.Fields), len(warnings)),
+		}
+	}
+
+	return embed
+}
+
+// ApplicationEntry holds a clan application for display.
+type ApplicationEntry struct {
+	ID                int64
+	UserID            string
+	RSN               string
+	ClaimedTotalLevel int64
+	Playtime          string
+	Referral          string
+	TotalLevel        int64
+	CombatLevel       int64
+	EHP               float64
+	Unmet             []string // Requirements the applicant doesn't meet
+	CreatedAt         time.Time
+}
+
+// ApplicationReview creates the embed posted to the staff channel for a new clan application.
+func ApplicationReview(app ApplicationEntry) *discordgo.MessageEmbed {
+	requirements := "✅ Meets all requirements"
+	color := ColorInfo
+	if len(app.Unmet) > 0 {
+		requirements = "❌ " + strings.Join(app.Unmet, "\n❌ ")
+		color = ColorWarning
+	}
+
+	return &discordgo.MessageEmbed{
+		Title: "📝 Clan Application: " + app.RSN,
+		URL:   "https://wiseoldman.net/players/" + strings.ReplaceAll(app.RSN, " ", "%20"),
+		Color: color,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "Applicant", Value: fmt.Sprintf("<@%s>", app.UserID), Inline: true},
+			{Name: "Total Level", Value: fmt.Sprintf("%d (says %d)", app.TotalLevel, app.ClaimedTotalLevel), Inline: true},
+			{Name: "Combat / EHP", Value: fmt.Sprintf("%d / %.1f", app.CombatLevel, app.EHP), Inline: true},
+			{Name: "Playtime", Value: orDash(truncateField(app.Playtime))},
+			{Name: "How they found us", Value: orDash(truncateField(app.Referral))},
+			{Name: "Requirements", Value: requirements},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Application #%d", app.ID),
+		},
+		Timestamp: app.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// ApplicationDecision creates the DM sent to an applicant once their application is reviewed.
+func ApplicationDecision(guildName string, approved bool, reason string) *discordgo.MessageEmbed {
+	if approved {
+		return &discordgo.MessageEmbed{
+			Title:       fmt.Sprintf("🎉 Welcome to %s!", guildName),
+			Description: "Your clan application was approved and your RuneScape account has been linked. See you in game!",
+			Color:       ColorSuccess,
+			Timestamp:   time.Now().Format(time.RFC3339),
+		}
+	}
+
+	description := "Unfortunately your clan application wasn't accepted this time."
+	if reason != "" {
+		description += "\n\n**Reason:** " + reason
+	}
+	return &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("Your application to %s", guildName),
+		Description: description,
+		Color:       ColorError,
+		Timestamp:   time.Now().Format(time.RFC3339),
+	}
+}
+
+// InactiveEntry holds an inactive linked member for display.
+type InactiveEntry struct {
+	UserID       string
+	RSN          string
+	LastActive   time.Time // Last XP change on Wise Old Man
+	WOMUpdatedAt time.Time
+	Stale        bool // Wise Old Man hasn't updated the player recently, so they may be active after all
+}
+
+// InactivityReport creates the list of members with no XP change in the last days days, longest
+// inactive first. unchecked counts linked members without Wise Old Man data yet.
+func InactivityReport(entries []InactiveEntry, days, unchecked int) *discordgo.MessageEmbed {
+	const maxDescriptionLength = 4096
+
+	embed := &discordgo.MessageEmbed{
+		Title:     fmt.Sprintf("💤 Inactive Members (%d+ days)", days),
+		Color:     ColorWarning,
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+	if unchecked > 0 {
+		embed.Footer = &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("%d linked member(s) haven't been checked on Wise Old Man yet", unchecked),
+		}
+	}
+
+	if len(entries) == 0 {
+		embed.Color = ColorSuccess
+		embed.Description = fmt.Sprintf("Every checked member gained XP in the last %d days.", days)
+		return embed
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("**%d** member(s) haven't gained XP in %d days.\n\n", len(entries), days))
+	for idx, e := range entries {
+		line := fmt.Sprintf("• <@%s> **%s**: last XP gain <t:%d:R>", e.UserID, e.RSN, e.LastActive.Unix())
+		if e.Stale {
+			line += fmt.Sprintf(" ❔ *not updated on Wise Old Man since <t:%d:R>*", e.WOMUpdatedAt.Unix())
+		}
+		line += "\n"
+
+		more := fmt.Sprintf("...and %d more", len(entries)-idx)
+		if utf8.RuneCountInString(sb.String()+line+more) > maxDescriptionLength {
+			sb.WriteString(more)
+			break
+		}
+		sb.WriteString(line)
+	}
+	embed.Description = strings.TrimSpace(sb.String())
+
+	return embed
+}
+
+// Whois creates the /admin whois result: header followed by one account link per line, cut short
+// with "...and N more" when the links don't fit in the description.
+func Whois(header string, lines []string) *discordgo.MessageEmbed {
+	const maxDescriptionLength = 4096
+
+	var sb strings.Builder
+	sb.WriteString(header + "\n\n")
+	for idx, line := range lines {
+		more := fmt.Sprintf("...and %d more", len(lines)-idx)
+		if utf8.RuneCountInString(sb.String()+line+more) > maxDescriptionLength {
+			sb.WriteString(more)
+			break
+		}
+		sb.WriteString(line)
+	}
+
+	return InfoEmbed("🔎 Whois", strings.TrimSpace(sb.String()))
+}
+
+// InactivityNudge creates the DM sent to a member who hasn't gained XP in a while.
+func InactivityNudge(guildName string, days int) *discordgo.MessageEmbed {
+	return &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("👋 We miss you in %s!", guildName),
+		Description: fmt.Sprintf("Your account hasn't gained any XP in over %d days. Hope everything is alright! Come say hi and join one of our events when you're back.\n\n*If you have been playing, update yourself on https://wiseoldman.net so we can see it.*", days),
+		Color:       ColorInfo,
+		Timestamp:   time.Now().Format(time.RFC3339),
+	}
+}
+
+// RosterSync creates the /roster sync summary: RSNs added to the WOM group and group members with
+// no Discord link.
+func RosterSync(groupID int64, added, unlinked []string) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title:       "📋 Roster Sync",
+		Description: fmt.Sprintf("Compared linked accounts with [Wise Old Man group #%d](https://wiseoldman.net/groups/%d).", groupID, groupID),
+		Color:       ColorSuccess,
+		Timestamp:   time.Now().Format(time.RFC3339),
+	}
+
+	addedValue := "Every linked RSN is already in the group."
+	if len(added) > 0 {
+		addedValue = strings.Join(added, ", ")
+	}
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:  fmt.Sprintf("Added to group (%d)", len(added)),
+		Value: truncateField(addedValue),
+	})
+
+	unlinkedValue := "Every group member has linked their Discord account."
+	if len(unlinked) > 0 {
+		embed.Color = ColorWarning
+		unlinkedValue = strings.Join(unlinked, ", ")
+	}
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:  fmt.Sprintf("In group but not linked (%d)", len(unlinked)),
+		Value: truncateField(unlinkedValue),
+	})
+
+	return embed
+}
+
+// RankRoleChange is a rank role given to or taken from a linked member.
+type RankRoleChange struct {
+	UserID string
+	RoleID string
+	RSN    string
+	Rank   string // The member's WOM group role, or "" if they aren't in the group
+	Add    bool
+}
+
+// RankRoleSync creates the rank role preview or sync summary. dryRun shows the changes that would be
+// made; failed counts changes Discord rejected.
+func RankRoleSync(changes []RankRoleChange, dryRun bool, failed int) *discordgo.MessageEmbed {
+	const maxDescriptionLength = 4096
+
+	embed := &discordgo.MessageEmbed{
+		Title:     "🎖️ Rank Role Sync",
+		Color:     ColorSuccess,
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+	if dryRun {
+		embed.Title = "🎖️ Rank Role Preview"
+		embed.Color = ColorInfo
+	}
+	if failed > 0 {
+		embed.Color = ColorWarning
+		embed.Footer = &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("%d change(s) failed; check that my role is above the rank roles", failed),
+		}
+	}
+
+	if len(changes) == 0 {
+		embed.Description = "Every linked member already has the roles for their rank."
+		return embed
+	}
+
+	var sb strings.Builder
+	if dryRun {
+		sb.WriteString(fmt.Sprintf("**%d** change(s) would be made. Run `/config rank-roles sync` to apply them.\n\n", len(changes)))
+	} else {
+		sb.WriteString(fmt.Sprintf("**%d** change(s) made.\n\n", len(changes)))
+	}
+	for idx, c := range changes {
+		rank := "not in the group"
+		if c.Rank != "" {
+			rank = c.Rank
+		}
+		sign := "➖"
+		if c.Add {
+			sign = "➕"
+		}
+		line := fmt.Sprintf("%s <@&%s> for <@%s> (**%s**, %s)\n", sign, c.RoleID, c.UserID, c.RSN, rank)
+
+		more := fmt.Sprintf("...and %d more", len(changes)-idx)
+		if utf8.RuneCountInString(sb.String()+line+more) > maxDescriptionLength {
+			sb.WriteString(more)
+			break
+		}
+		sb.WriteString(line)
+	}
+	embed.Description = strings.TrimSpace(sb.String())
+
+	return embed
+}
+
+// AuditEntry holds a single audit log record for display.
+type AuditEntry struct {
+	ID        int64
+	ActorID   int64
+	Action    string
+	Target    string
+	Before    string
+	After     string
+	CreatedAt time.Time
+}
+
+// AuditLogEntry creates the embed mirrored to the audit log channel for a recorded action.
+func AuditLogEntry(entry AuditEntry) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title: "📝 " + entry.Action,
+		Color: ColorInfo,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "Actor", Value: fmt.Sprintf("<@%d>", entry.ActorID), Inline: true},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Audit #%d", entry.ID),
+		},
+		Timestamp: entry.CreatedAt.Format(time.RFC3339),
+	}
+
+	if entry.Target != "" {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: "Target", Value: entry.Target, Inline: true})
+	}
+	if entry.Before != "" {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: "Before", Value: truncateField(entry.Before)})
+	}
+	if entry.After != "" {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: "After", Value: truncateField(entry.After)})
+	}
+
+	return embed
+}
+
+// AuditLogList creates an embed listing audit log records, newest first.
+func AuditLogList(entries []AuditEntry, filters string) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title:     "📝 Audit Log",
+		Color:     ColorInfo,
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+
+	if filters != "" {
+		embed.Description = "**Filters:** " + filters
+	}
+
+	if len(entries) == 0 {
+		embed.Description = strings.TrimSpace(embed.Description + "\n\nNo matching audit log entries.")
+		return embed
+	}
+
+	// Discord allows at most 25 fields per embed
+	for idx, e := range entries {
+		if idx >= 25 {
+			embed.Footer = &discordgo.MessageEmbedFooter{
+				Text: fmt.Sprintf("Showing the 25 most recent of %d entries", len(entries)),
+			}
+			break
+		}
+
+		value := fmt.Sprintf("by <@%d> <t:%d:R>", e.ActorID, e.CreatedAt.Unix())
+		if e.Target != "" {
+			value += "\n**Target:** " + e.Target
+		}
+		if e.Before != "" || e.After != "" {
+			value += fmt.Sprintf("\n%s → %s", orDash(e.Before), orDash(e.After))
+		}
+
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  fmt.Sprintf("#%d • %s", e.ID, e.Action),
+			Value: truncateField(value),
+		})
+	}
+
+	return embed
+}
+
+// maxEmbedLength is Discord's limit on the combined length of an embed's texts.
+const maxEmbedLength = 6000
+
+// truncateField trims a value to Discord's 1024 character field limit.
+func truncateField(value string) string {
+	const maxFieldLength = 1024
+	return truncate(value, maxFieldLength)
+}
+
+// truncate trims value to at most limit characters, ending it with "…" when cut.
+func truncate(value string, limit int) string {
+	runes := []rune(value)
+	if len(runes) <= limit {
+		return value
+	}
+	return string(runes[:limit-1]) + "…"
+}
+
+// orDash returns "—" for empty values.
+func orDash(value string) string {
+	if value == "" {
+		return "—"
+	}
+	return value
+}
+
+// HealthCheck creates the /config doctor report. The color reflects the worst result.
+func HealthCheck(report string, failures, warnings int) *discordgo.MessageEmbed {
+	const maxDescriptionLength = 4096
+
+	summary := "Everything looks good!"
+	color := ColorSuccess
+	switch {
+	case failures > 0:
+		summary = fmt.Sprintf("**%d problem(s), %d warning(s)**", failures, warnings)
+		color = ColorError
+	case warnings > 0:
+		summary = fmt.Sprintf("**%d warning(s)**", warnings)
+		color = ColorWarning
+	}
+
+	description := []rune(summary + "\n\n" + report)
+	if len(description) > maxDescriptionLength {
+		description = append(description[:maxDescriptionLength-1], '…')
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       "🩺 Health Check",
+		Description: string(description),
+		Color:       color,
+		Timestamp:   time.Now().Format(time.RFC3339),
+	}
+}
@@ -1,11 +1,15 @@
 package embeds
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/kaffeed/voidling/internal/models"
 	"github.com/kaffeed/voidling/internal/testutil"
+	"github.com/kaffeed/voidling/internal/wiseoldman"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -31,6 +35,83 @@ func TestPlayerInfo(t *testing.T) {
 	})
 }
 
+func TestProfile(t *testing.T) {
+	t.Run("staff view", func(t *testing.T) {
+		embed := Profile(ProfileEntry{
+			UserID:         "1001",
+			RSN:            "TestUser",
+			Player:         testutil.CreateTestPlayer("TestUser"),
+			LinkedSince:    time.Unix(1700000000, 0),
+			BOTW:           EventRecord{Participations: 3, Wins: 1, Podiums: 2, Best: 1},
+			MassesAttended: 4,
+			ShowWarnings:   true,
+			Warnings:       2,
+		})
+
+		assert.Contains(t, embed.Description, "<@1001>")
+		assert.Contains(t, embed.Description, "<t:1700000000:D>")
+		fields := make(map[string]string)
+		for _, f := range embed.Fields {
+			fields[f.Name] = f.Value
+		}
+		assert.Equal(t, "**Chambers Of Xeric**: 500\n**Theatre Of Blood**: 300\n**Corporeal Beast**: 250\n**Nex**: 100", fields["Top Bosses"])
+		assert.Equal(t, "3 event(s)\n1 win(s), 2 podium(s)\nBest: #1", fields["Boss of the Week"])
+		assert.Equal(t, "No events yet", fields["Skill of the Week"])
+		assert.Equal(t, "4", fields["Masses Attended"])
+		assert.Equal(t, "2", fields["Warnings"])
+	})
+
+	t.Run("member view without stats", func(t *testing.T) {
+		embed := Profile(ProfileEntry{UserID: "1001", RSN: "Test User", Warnings: 2})
+
+		assert.Equal(t, "https://wiseoldman.net/players/Test%20User", embed.URL)
+		for _, f := range embed.Fields {
+			assert.NotEqual(t, "Warnings", f.Name)
+			assert.NotEqual(t, "Top Bosses", f.Name)
+		}
+		assert.Equal(t, "Stats", embed.Fields[0].Name)
+	})
+}
+
+func TestGains(t *testing.T) {
+	t.Run("with gains", func(t *testing.T) {
+		start, end := time.Unix(1700000000, 0), time.Unix(1700600000, 0)
+		gains := &wiseoldman.PlayerGains{
+			StartsAt: &start,
+			EndsAt:   &end,
+			Data: wiseoldman.GainsData{
+				Skills: map[string]wiseoldman.SkillGains{
+					"overall": {Metric: "overall", Experience: wiseoldman.Delta{Gained: 1_250_000}},
+					"mining":  {Metric: "mining", Experience: wiseoldman.Delta{Gained: 1_000_000}, Level: wiseoldman.Delta{Start: 80, End: 84, Gained: 4}},
+					"attack":  {Metric: "attack", Experience: wiseoldman.Delta{Gained: 250_000}},
+				},
+				Bosses: map[string]wiseoldman.BossGains{
+					"tombs_of_amascut": {Metric: "tombs_of_amascut", Kills: wiseoldman.Delta{Gained: 12}},
+				},
+			},
+		}
+
+		embed := Gains("1001", "Zezima", "This week", gains)
+
+		assert.Equal(t, "📈 Zezima: This week", embed.Title)
+		assert.Contains(t, embed.Description, "<t:1700000000:f>")
+		fields := make(map[string]string)
+		for _, f := range embed.Fields {
+			fields[f.Name] = f.Value
+		}
+		assert.Equal(t, "+1,250,000", fields["Total XP"])
+		assert.Equal(t, "**Mining**: +1,000,000 XP (80 → 84)\n**Attack**: +250,000 XP", fields["Top Skills"])
+		assert.Equal(t, "**Tombs Of Amascut**: +12 KC", fields["Top Bosses"])
+	})
+
+	t.Run("without gains", func(t *testing.T) {
+		embed := Gains("1001", "Zezima", "Today", &wiseoldman.PlayerGains{})
+
+		assert.Empty(t, embed.Fields)
+		assert.Contains(t, embed.Description, "No gains in this period")
+	})
+}
+
 func TestFormatNumber(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -185,6 +266,59 @@ func TestEventWinners(t *testing.T) {
 	})
 }
 
+func TestTeamEvent(t *testing.T) {
+	embed := TeamEvent(models.EventTypeSkillOfTheWeek, "mining", []string{"Red", "Blue"}, true)
+
+	require.NotNil(t, embed)
+	assert.Equal(t, "📚 Skill of the Week - Teams", embed.Title)
+	assert.Equal(t, ColorSOTW, embed.Color)
+	assert.Equal(t, "Red vs Blue", embed.Fields[0].Value)
+	assert.Contains(t, embed.Fields[1].Value, "lowest combined XP")
+}
+
+func TestTeamRoster(t *testing.T) {
+	teams := make([]TeamRosterEntry, 10)
+	for idx := range teams {
+		teams[idx] = TeamRosterEntry{Name: fmt.Sprintf("Team %d", idx+1), Gained: 1500}
+		for range 100 {
+			teams[idx].Members = append(teams[idx].Members, "- Some Player - 150 gained")
+		}
+	}
+	teams[9].Members = nil
+
+	embed := TeamRoster("BOTW - Zulrah", teams)
+
+	require.Len(t, embed.Fields, 10)
+	total := utf8.RuneCountInString(embed.Title)
+	for _, field := range embed.Fields {
+		assert.LessOrEqual(t, utf8.RuneCountInString(field.Value), 1024)
+		total += utf8.RuneCountInString(field.Name) + utf8.RuneCountInString(field.Value)
+	}
+	assert.LessOrEqual(t, total, 6000)
+	assert.Equal(t, "Team 1 (100 member(s), 1,500 gained)", embed.Fields[0].Name)
+	assert.Contains(t, embed.Fields[0].Value, "more")
+	assert.Equal(t, "No members yet", embed.Fields[9].Value)
+}
+
+func TestTeamResults(t *testing.T) {
+	embed := TeamResults(models.EventTypeBossOfTheWeek, "zulrah", []TeamResult{
+		{Name: "Red", Gained: 1500, Members: 2, MVP: "Zezima", MVPDiscordID: 123, MVPGained: 1000},
+		{Name: "Blue", Gained: 40, Members: 1, MVP: "Woox", MVPGained: 40},
+		{Name: "Green", Members: 1},
+		{Name: "Yellow"},
+	})
+
+	require.NotNil(t, embed)
+	assert.Equal(t, "🏆 Boss of the Week - Team Results", embed.Title)
+	require.Len(t, embed.Fields, 4)
+	assert.Equal(t, "🥇 Red", embed.Fields[0].Name)
+	assert.Contains(t, embed.Fields[0].Value, "**1,500 KC**")
+	assert.Contains(t, embed.Fields[0].Value, "MVP: <@123> with **1,000 KC**")
+	assert.Contains(t, embed.Fields[1].Value, "MVP: Woox")
+	assert.NotContains(t, embed.Fields[2].Value, "MVP")
+	assert.Equal(t, "Yellow", embed.Fields[3].Name)
+}
+
 func TestMassEvent(t *testing.T) {
 	activity := "Nex"
 	location := "World 416"
@@ -230,6 +364,129 @@ func TestWelcomeGreeting(t *testing.T) {
 	assert.NotNil(t, embed.Footer)
 }
 
+func TestWarningHistory(t *testing.T) {
+	warnings := make([]WarningEntry, 40)
+	for idx := range warnings {
+		warnings[idx] = WarningEntry{ID: int64(idx + 1), ModeratorID: 2002, Reason: strings.Repeat("x", 1000), CreatedAt: time.Now()}
+	}
+
+	embed := WarningHistory("1001", warnings)
+
+	length := utf8.RuneCountInString(embed.Title + embed.Description + embed.Footer.Text)
+	for _, f := range embed.Fields {
+		length += utf8.RuneCountInString(f.Name + f.Value)
+	}
+	assert.LessOrEqual(t, length, 6000)
+	assert.NotEmpty(t, embed.Fields)
+	assert.Equal(t, fmt.Sprintf("Showing the %d most recent of 40 warnings", len(embed.Fields)), embed.Footer.Text)
+	assert.Contains(t, embed.Fields[0].Value, "x…\n")
+
+	embed = WarningHistory("1001", warnings[:2])
+	assert.Len(t, embed.Fields, 2)
+	assert.Nil(t, embed.Footer)
+}
+
+func TestApplicationReview(t *testing.T) {
+	app := ApplicationEntry{ID: 12, UserID: "42", RSN: "Iron Man", TotalLevel: 1500, ClaimedTotalLevel: 1600}
+
+	embed := ApplicationReview(app)
+	require.NotNil(t, embed)
+	assert.Equal(t, "https://wiseoldman.net/players/Iron%20Man", embed.URL)
+	assert.Equal(t, ColorInfo, embed.Color)
+	assert.Equal(t, "Application #12", embed.Footer.Text)
+	assert.Equal(t, "1500 (says 1600)", embed.Fields[1].Value)
+
+	app.Unmet = []string{"Total level 1500 (needs 1750)"}
+	embed = ApplicationReview(app)
+	assert.Equal(t, ColorWarning, embed.Color)
+	assert.Contains(t, embed.Fields[len(embed.Fields)-1].Value, "needs 1750")
+}
+
+func TestInactivityReport(t *testing.T) {
+	t.Run("nobody inactive", func(t *testing.T) {
+		embed := InactivityReport(nil, 30, 2)
+
+		assert.Equal(t, ColorSuccess, embed.Color)
+		require.NotNil(t, embed.Footer)
+		assert.Contains(t, embed.Footer.Text, "2 linked member(s)")
+	})
+
+	t.Run("long lists are truncated", func(t *testing.T) {
+		entries := make([]InactiveEntry, 100)
+		for idx := range entries {
+			entries[idx] = InactiveEntry{UserID: "123456789012345678", RSN: "Some Player", LastActive: time.Unix(1700000000, 0), Stale: true}
+		}
+
+		embed := InactivityReport(entries, 30, 0)
+
+		assert.Equal(t, ColorWarning, embed.Color)
+		assert.LessOrEqual(t, len([]rune(embed.Description)), 4096)
+		assert.Contains(t, embed.Description, "more")
+		assert.Nil(t, embed.Footer)
+	})
+}
+
+func TestWhois(t *testing.T) {
+	lines := make([]string, 200)
+	for idx := range lines {
+		lines[idx] = "▫️ <@123456789012345678> — linked <t:1700000000:D>, inactive since <t:1700000000:D>\n"
+	}
+
+	embed := Whois("Members of this server linked to **Zezima**:", lines)
+
+	assert.LessOrEqual(t, len([]rune(embed.Description)), 4096)
+	assert.True(t, strings.HasPrefix(embed.Description, "Members of this server linked to **Zezima**:\n\n"))
+	assert.Contains(t, embed.Description, "more")
+}
+
+func TestRosterSync(t *testing.T) {
+	t.Run("in sync", func(t *testing.T) {
+		embed := RosterSync(42, nil, nil)
+
+		assert.Equal(t, ColorSuccess, embed.Color)
+		require.Len(t, embed.Fields, 2)
+		assert.Equal(t, "Added to group (0)", embed.Fields[0].Name)
+	})
+
+	t.Run("unlinked members", func(t *testing.T) {
+		unlinked := make([]string, 200)
+		for idx := range unlinked {
+			unlinked[idx] = "Some Player"
+		}
+
+		embed := RosterSync(42, []string{"Zezima"}, unlinked)
+
+		assert.Equal(t, ColorWarning, embed.Color)
+		assert.Equal(t, "Zezima", embed.Fields[0].Value)
+		assert.Equal(t, "In group but not linked (200)", embed.Fields[1].Name)
+		assert.LessOrEqual(t, len([]rune(embed.Fields[1].Value)), 1024)
+	})
+}
+
+func TestRankRoleSync(t *testing.T) {
+	changes := []RankRoleChange{
+		{UserID: "1", RoleID: "100", RSN: "Zezima", Rank: "captain", Add: true},
+		{UserID: "3", RoleID: "200", RSN: "Quitter"},
+	}
+
+	t.Run("preview", func(t *testing.T) {
+		embed := RankRoleSync(changes, true, 0)
+
+		assert.Equal(t, ColorInfo, embed.Color)
+		assert.Contains(t, embed.Description, "would be made")
+		assert.Contains(t, embed.Description, "➕ <@&100> for <@1> (**Zezima**, captain)")
+		assert.Contains(t, embed.Description, "➖ <@&200> for <@3> (**Quitter**, not in the group)")
+	})
+
+	t.Run("failures", func(t *testing.T) {
+		embed := RankRoleSync(nil, false, 2)
+
+		assert.Equal(t, ColorWarning, embed.Color)
+		require.NotNil(t, embed.Footer)
+		assert.Contains(t, embed.Footer.Text, "2 change(s) failed")
+	})
+}
+
 func TestCompetitionCodeEmbed(t *testing.T) {
 	eventName := "Boss of the Week - Nex"
 	verificationCode := "test-code-123"
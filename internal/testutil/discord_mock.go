@@ -5,75 +5,128 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
-// MockDiscordSession is a mock implementation of discordgo.Session for testing.
+// MockDiscordSession is a mock implementation of discordgo.Session for testing. Request options
+// are accepted but not recorded.
 type MockDiscordSession struct {
 	mock.Mock
 }
 
 // InteractionRespond mocks the Discord interaction response.
-func (m *MockDiscordSession) InteractionRespond(i *discordgo.Interaction, resp *discordgo.InteractionResponse) error {
+func (m *MockDiscordSession) InteractionRespond(i *discordgo.Interaction, resp *discordgo.InteractionResponse, _ ...discordgo.RequestOption) error {
 	args := m.Called(i, resp)
 	return args.Error(0)
 }
 
 // FollowupMessageCreate mocks creating a followup message.
-func (m *MockDiscordSession) FollowupMessageCreate(i *discordgo.Interaction, wait bool, data *discordgo.WebhookParams) (*discordgo.Message, error) {
+func (m *MockDiscordSession) FollowupMessageCreate(i *discordgo.Interaction, wait bool, data *discordgo.WebhookParams, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
 	args := m.Called(i, wait, data)
+	return message(args)
+}
+
+// InteractionResponseEdit mocks editing an interaction response.
+func (m *MockDiscordSession) InteractionResponseEdit(i *discordgo.Interaction, edit *discordgo.WebhookEdit, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+	args := m.Called(i, edit)
+	return message(args)
+}
+
+// GuildMember mocks fetching a guild member.
+func (m *MockDiscordSession) GuildMember(guildID, userID string, _ ...discordgo.RequestOption) (*discordgo.Member, error) {
+	args := m.Called(guildID, userID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*discordgo.Message), args.Error(1)
+	return args.Get(0).(*discordgo.Member), args.Error(1)
 }
 
-// InteractionResponseEdit mocks editing an interaction response.
-func (m *MockDiscordSession) InteractionResponseEdit(i *discordgo.Interaction, edit *discordgo.WebhookEdit) (*discordgo.Message, error) {
-	args := m.Called(i, edit)
+// GuildRoles mocks fetching a guild's roles.
+func (m *MockDiscordSession) GuildRoles(guildID string, _ ...discordgo.RequestOption) ([]*discordgo.Role, error) {
+	args := m.Called(guildID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*discordgo.Message), args.Error(1)
+	return args.Get(0).([]*discordgo.Role), args.Error(1)
 }
 
 // GuildMemberNickname mocks updating a guild member's nickname.
-func (m *MockDiscordSession) GuildMemberNickname(guildID, userID, nickname string) error {
+func (m *MockDiscordSession) GuildMemberNickname(guildID, userID, nickname string, _ ...discordgo.RequestOption) error {
 	args := m.Called(guildID, userID, nickname)
 	return args.Error(0)
 }
 
-// ThreadStartComplex mocks creating a Discord thread.
-func (m *MockDiscordSession) ThreadStartComplex(channelID string, params *discordgo.ThreadStart) (*discordgo.Channel, error) {
-	args := m.Called(channelID, params)
+// GuildMemberRoleAdd mocks giving a guild member a role.
+func (m *MockDiscordSession) GuildMemberRoleAdd(guildID, userID, roleID string, _ ...discordgo.RequestOption) error {
+	args := m.Called(guildID, userID, roleID)
+	return args.Error(0)
+}
+
+// GuildMemberRoleRemove mocks taking a role from a guild member.
+func (m *MockDiscordSession) GuildMemberRoleRemove(guildID, userID, roleID string, _ ...discordgo.RequestOption) error {
+	args := m.Called(guildID, userID, roleID)
+	return args.Error(0)
+}
+
+// GuildScheduledEventCreate mocks creating a Discord scheduled event.
+func (m *MockDiscordSession) GuildScheduledEventCreate(guildID string, event *discordgo.GuildScheduledEventParams, _ ...discordgo.RequestOption) (*discordgo.GuildScheduledEvent, error) {
+	args := m.Called(guildID, event)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*discordgo.Channel), args.Error(1)
+	return args.Get(0).(*discordgo.GuildScheduledEvent), args.Error(1)
+}
+
+// ThreadStartComplex mocks creating a Discord thread.
+func (m *MockDiscordSession) ThreadStartComplex(channelID string, params *discordgo.ThreadStart, _ ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	args := m.Called(channelID, params)
+	return channel(args)
+}
+
+// ChannelMessageSend mocks sending a plain message.
+func (m *MockDiscordSession) ChannelMessageSend(channelID, content string, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+	args := m.Called(channelID, content)
+	return message(args)
 }
 
 // ChannelMessageSendComplex mocks sending a complex message.
-func (m *MockDiscordSession) ChannelMessageSendComplex(channelID string, data *discordgo.MessageSend) (*discordgo.Message, error) {
+func (m *MockDiscordSession) ChannelMessageSendComplex(channelID string, data *discordgo.MessageSend, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
 	args := m.Called(channelID, data)
+	return message(args)
+}
+
+// ChannelMessageSendEmbed mocks sending a single embed.
+func (m *MockDiscordSession) ChannelMessageSendEmbed(channelID string, embed *discordgo.MessageEmbed, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+	args := m.Called(channelID, embed)
+	return message(args)
+}
+
+// UserChannelCreate mocks creating a DM channel.
+func (m *MockDiscordSession) UserChannelCreate(userID string, _ ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	args := m.Called(userID)
+	return channel(args)
+}
+
+// Guild mocks fetching guild information.
+func (m *MockDiscordSession) Guild(guildID string, _ ...discordgo.RequestOption) (*discordgo.Guild, error) {
+	args := m.Called(guildID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*discordgo.Message), args.Error(1)
+	return args.Get(0).(*discordgo.Guild), args.Error(1)
 }
 
-// UserChannelCreate mocks creating a DM channel.
-func (m *MockDiscordSession) UserChannelCreate(userID string) (*discordgo.Channel, error) {
-	args := m.Called(userID)
+// message returns a mocked call's message and error.
+func message(args mock.Arguments) (*discordgo.Message, error) {
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*discordgo.Channel), args.Error(1)
+	return args.Get(0).(*discordgo.Message), args.Error(1)
 }
 
-// Guild mocks fetching guild information.
-func (m *MockDiscordSession) Guild(guildID string) (*discordgo.Guild, error) {
-	args := m.Called(guildID)
+// channel returns a mocked call's channel and error.
+func channel(args mock.Arguments) (*discordgo.Channel, error) {
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*discordgo.Guild), args.Error(1)
+	return args.Get(0).(*discordgo.Channel), args.Error(1)
 }
 
 // CreateTestInteraction creates a test Discord interaction for command testing.
@@ -2,6 +2,7 @@ package testutil
 
 import (
 	"context"
+	"time"
 
 	"github.com/kaffeed/voidling/internal/wiseoldman"
 	"github.com/stretchr/testify/mock"
@@ -39,10 +40,49 @@ func (m *MockWOMClient) CreateCompetition(ctx context.Context, req wiseoldman.Cr
 	return args.Get(0).(*wiseoldman.CreateCompetitionResponse), args.Error(1)
 }
 
-// AddParticipantsToCompetition mocks adding participants to a competition.
-func (m *MockWOMClient) AddParticipantsToCompetition(ctx context.Context, competitionID int64, usernames []string, verificationCode string) error {
+// GetCompetition mocks fetching a competition with its standings.
+func (m *MockWOMClient) GetCompetition(ctx context.Context, competitionID int64) (*wiseoldman.Competition, error) {
+	args := m.Called(ctx, competitionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*wiseoldman.Competition), args.Error(1)
+}
+
+// EditCompetition mocks editing a competition.
+func (m *MockWOMClient) EditCompetition(ctx context.Context, competitionID int64, req wiseoldman.EditCompetitionRequest) (*wiseoldman.Competition, error) {
+	args := m.Called(ctx, competitionID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*wiseoldman.Competition), args.Error(1)
+}
+
+// AddParticipants mocks adding participants to a competition.
+func (m *MockWOMClient) AddParticipants(ctx context.Context, competitionID int64, usernames []string, verificationCode string) (*wiseoldman.CompetitionUpdateResponse, error) {
 	args := m.Called(ctx, competitionID, usernames, verificationCode)
-	return args.Error(0)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*wiseoldman.CompetitionUpdateResponse), args.Error(1)
+}
+
+// AddGroupMembers mocks adding players to a group.
+func (m *MockWOMClient) AddGroupMembers(ctx context.Context, groupID int64, members []wiseoldman.GroupMember, verificationCode string) (*wiseoldman.GroupUpdateResponse, error) {
+	args := m.Called(ctx, groupID, members, verificationCode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*wiseoldman.GroupUpdateResponse), args.Error(1)
+}
+
+// GetPlayerGainsBetween mocks fetching a player's gains between two dates.
+func (m *MockWOMClient) GetPlayerGainsBetween(ctx context.Context, username string, start, end time.Time) (*wiseoldman.PlayerGains, error) {
+	args := m.Called(ctx, username, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*wiseoldman.PlayerGains), args.Error(1)
 }
 
 // CreateTestPlayer creates a test WOM player with realistic data.
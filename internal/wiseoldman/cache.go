@@ -0,0 +1,166 @@
+package wiseoldman
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// playerCacheTTL is how long player details are served from the cache.
+	playerCacheTTL = 5 * time.Minute
+	// competitionCacheTTL is how long competition details and standings are served from the cache.
+	competitionCacheTTL = time.Minute
+
+	// competitionCachePrefix starts the cache key of every competition.
+	competitionCachePrefix = "competition:"
+)
+
+// CacheStats counts how lookups were served since the client was created.
+type CacheStats struct {
+	// Hits were answered from the cache.
+	Hits int64
+	// Misses were fetched from the API.
+	Misses int64
+	// Shared waited for an identical request already in flight instead of sending their own.
+	Shared int64
+	// Entries is how many responses are cached.
+	Entries int
+}
+
+// HitRate is the share of lookups that didn't need their own API request.
+func (s CacheStats) HitRate() float64 {
+	total := s.Hits + s.Misses + s.Shared
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits+s.Shared) / float64(total)
+}
+
+type noCacheKey struct{}
+
+// NoCache returns a context whose lookups skip the cache and always reach the API. The fresh
+// response still replaces any cached one.
+func NoCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noCacheKey{}, true)
+}
+
+// cacheEntry is a cached response.
+type cacheEntry struct {
+	value   any
+	expires time.Time
+}
+
+// responseCache caches API responses for a while and collapses concurrent identical requests into
+// one.
+type responseCache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	flight  singleflight.Group
+	now     func() time.Time
+
+	hits   atomic.Int64
+	misses atomic.Int64
+	shared atomic.Int64
+}
+
+// newResponseCache creates an empty cache.
+func newResponseCache() *responseCache {
+	return &responseCache{
+		entries: make(map[string]cacheEntry),
+		now:     time.Now,
+	}
+}
+
+// get returns the cached value for key, or calls fetch and caches its result for ttl. Concurrent
+// calls for the same key share one fetch. Errors are never cached.
+func (rc *responseCache) get(ctx context.Context, key string, ttl time.Duration, fetch func() (any, error)) (any, error) {
+	if bypass, _ := ctx.Value(noCacheKey{}).(bool); !bypass {
+		if value, ok := rc.lookup(key); ok {
+			rc.hits.Add(1)
+			return value, nil
+		}
+	}
+
+	// The leader counts as a miss; everyone who joined its request shares the response
+	leader := false
+	value, err, shared := rc.flight.Do(key, func() (any, error) {
+		leader = true
+		rc.misses.Add(1)
+		value, err := fetch()
+		if err != nil {
+			return nil, err
+		}
+		rc.set(key, value, ttl)
+		return value, nil
+	})
+	if shared && !leader {
+		rc.shared.Add(1)
+	}
+	return value, err
+}
+
+// lookup returns the unexpired value cached for key.
+func (rc *responseCache) lookup(key string) (any, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	entry, ok := rc.entries[key]
+	if !ok || !rc.now().Before(entry.expires) {
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// set caches value for key for ttl, dropping expired entries.
+func (rc *responseCache) set(key string, value any, ttl time.Duration) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	now := rc.now()
+	for k, entry := range rc.entries {
+		if !now.Before(entry.expires) {
+			delete(rc.entries, k)
+		}
+	}
+	rc.entries[key] = cacheEntry{value: value, expires: now.Add(ttl)}
+}
+
+// invalidate drops the cached value for key. A request already in flight for key is forgotten so
+// later callers fetch again.
+func (rc *responseCache) invalidate(key string) {
+	rc.mu.Lock()
+	delete(rc.entries, key)
+	rc.mu.Unlock()
+	rc.flight.Forget(key)
+}
+
+// invalidatePrefix drops every cached value whose key starts with prefix.
+func (rc *responseCache) invalidatePrefix(prefix string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	for key := range rc.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(rc.entries, key)
+			rc.flight.Forget(key)
+		}
+	}
+}
+
+// stats returns the cache's counters.
+func (rc *responseCache) stats() CacheStats {
+	rc.mu.Lock()
+	entries := len(rc.entries)
+	rc.mu.Unlock()
+
+	return CacheStats{
+		Hits:    rc.hits.Load(),
+		Misses:  rc.misses.Load(),
+		Shared:  rc.shared.Load(),
+		Entries: entries,
+	}
+}
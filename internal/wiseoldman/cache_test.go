@@ -0,0 +1,165 @@
+package wiseoldman
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseCacheExpires(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache := newResponseCache()
+	cache.now = func() time.Time { return now }
+
+	fetches := 0
+	fetch := func() (any, error) {
+		fetches++
+		return fetches, nil
+	}
+
+	value, err := cache.get(context.Background(), "key", time.Minute, fetch)
+	require.NoError(t, err)
+	assert.Equal(t, 1, value)
+
+	now = now.Add(59 * time.Second)
+	value, _ = cache.get(context.Background(), "key", time.Minute, fetch)
+	assert.Equal(t, 1, value)
+
+	now = now.Add(time.Second)
+	value, _ = cache.get(context.Background(), "key", time.Minute, fetch)
+	assert.Equal(t, 2, value)
+
+	assert.Equal(t, CacheStats{Hits: 1, Misses: 2, Entries: 1}, cache.stats())
+}
+
+func TestResponseCacheSharesConcurrentFetches(t *testing.T) {
+	cache := newResponseCache()
+	release := make(chan struct{})
+	var fetches atomic.Int32
+
+	const callers = 5
+	var started, done sync.WaitGroup
+	started.Add(callers)
+	done.Add(callers)
+	for range callers {
+		go func() {
+			defer done.Done()
+			started.Done()
+			value, err := cache.get(context.Background(), "key", time.Minute, func() (any, error) {
+				fetches.Add(1)
+				<-release
+				return "value", nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, "value", value)
+		}()
+	}
+	started.Wait()
+	// Give the callers time to join the first fetch before it finishes
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	done.Wait()
+
+	assert.Equal(t, int32(1), fetches.Load())
+	stats := cache.stats()
+	assert.Equal(t, int64(1), stats.Misses)
+	assert.Equal(t, int64(callers-1), stats.Shared+stats.Hits)
+}
+
+func TestResponseCacheDoesNotCacheErrors(t *testing.T) {
+	cache := newResponseCache()
+
+	_, err := cache.get(context.Background(), "key", time.Minute, func() (any, error) {
+		return nil, ErrPlayerNotFound
+	})
+	assert.ErrorIs(t, err, ErrPlayerNotFound)
+
+	value, err := cache.get(context.Background(), "key", time.Minute, func() (any, error) {
+		return "found", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "found", value)
+}
+
+func TestResponseCacheInvalidatePrefix(t *testing.T) {
+	cache := newResponseCache()
+	cache.set("competition:1", 1, time.Minute)
+	cache.set("competition:2", 2, time.Minute)
+	cache.set("player:zezima", 3, time.Minute)
+
+	cache.invalidatePrefix(competitionCachePrefix)
+
+	_, ok := cache.lookup("competition:1")
+	assert.False(t, ok)
+	_, ok = cache.lookup("player:zezima")
+	assert.True(t, ok)
+}
+
+func TestClientCachesLookups(t *testing.T) {
+	var playerHits, competitionHits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/players/zezima":
+			playerHits.Add(1)
+			_, _ = io.WriteString(w, `{"id": 1, "username": "zezima", "displayName": "Zezima"}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/players/zezima":
+			_, _ = io.WriteString(w, `{"id": 1, "username": "zezima", "displayName": "Zezima", "exp": 100}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/competitions/7":
+			competitionHits.Add(1)
+			_, _ = io.WriteString(w, `{"id": 7, "title": "SOTW"}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/competitions/7/participants":
+			_, _ = io.WriteString(w, `{"count": 1, "message": "Added 1 participant"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	c := newTestClient(server)
+
+	// Every spelling of a name shares one cache entry
+	_, err := c.GetPlayer(ctx, "zezima")
+	require.NoError(t, err)
+	player, err := c.GetPlayer(ctx, "Zezima")
+	require.NoError(t, err)
+	assert.Equal(t, "Zezima", player.DisplayName)
+	assert.Equal(t, int32(1), playerHits.Load())
+
+	// Updating replaces the cached player
+	_, err = c.UpdatePlayer(ctx, "zezima")
+	require.NoError(t, err)
+	player, err = c.GetPlayer(ctx, "zezima")
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), player.Exp)
+	assert.Equal(t, int32(1), playerHits.Load())
+
+	// NoCache always reaches the API
+	_, err = c.GetPlayer(NoCache(ctx), "zezima")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), playerHits.Load())
+
+	// Adding participants drops the cached competition
+	_, err = c.GetCompetition(ctx, 7)
+	require.NoError(t, err)
+	_, err = c.GetCompetition(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), competitionHits.Load())
+	_, err = c.AddParticipants(ctx, 7, []string{"zezima"}, "code")
+	require.NoError(t, err)
+	_, err = c.GetCompetition(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), competitionHits.Load())
+
+	stats := c.CacheStats()
+	assert.Equal(t, int64(3), stats.Hits)
+	assert.Equal(t, int64(4), stats.Misses)
+}
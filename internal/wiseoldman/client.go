@@ -7,12 +7,38 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log"
+	"math/rand/v2"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 )
 
 const baseURL = "https://api.wiseoldman.net/v2"
 
+const (
+	// defaultUserAgent identifies the bot to Wise Old Man unless configured otherwise.
+	defaultUserAgent = "voidling"
+
+	// anonymousRequestsPerMinute and apiKeyRequestsPerMinute are Wise Old Man's rate limits
+	// without and with an API key.
+	anonymousRequestsPerMinute = 20
+	apiKeyRequestsPerMinute    = 100
+
+	// requestBurst is how many requests may be sent back to back before the rate limit applies.
+	requestBurst = 5
+
+	// maxRetries is how many times a rate-limited or failed request is retried.
+	maxRetries = 3
+	// retryBaseDelay is the backoff before the first retry; it doubles with each attempt.
+	retryBaseDelay = 500 * time.Millisecond
+	// maxRetryDelay caps how long a retry waits, including a Retry-After from the API. Requests
+	// asked to wait longer fail straight away so commands don't hang.
+	maxRetryDelay = 30 * time.Second
+)
+
 var (
 	// ErrPlayerNotFound is returned when a player is not found in the Wise Old Man API.
 	ErrPlayerNotFound = errors.New("player not found")
@@ -20,197 +46,536 @@ var (
 	// ErrCompetitionNotFound is returned when a competition is not found in the Wise Old Man API.
 	ErrCompetitionNotFound = errors.New("competition not found")
 
+	// ErrGroupNotFound is returned when a group is not found in the Wise Old Man API.
+	ErrGroupNotFound = errors.New("group not found")
+
 	// ErrUnexpectedStatus is returned when the API returns an unexpected HTTP status code.
 	ErrUnexpectedStatus = errors.New("unexpected API status")
+
+	// ErrRateLimited is returned when the API keeps rate limiting requests after retrying.
+	ErrRateLimited = errors.New("rate limited by the API")
+
+	// ErrServerError is returned when the API keeps failing with a 5xx status after retrying.
+	ErrServerError = errors.New("API server error")
 )
 
-// Client is a Wise Old Man API client.
+// StatusError is returned for unsuccessful API responses. It matches ErrRateLimited for 429
+// responses, ErrServerError for 5xx responses and ErrUnexpectedStatus otherwise.
+type StatusError struct {
+	StatusCode int
+	Body       string
+	// RetryAfter is how long the API asked callers to wait, if it said.
+	RetryAfter time.Duration
+}
+
+// Error implements error.
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%v: status %d: %s", e.Unwrap(), e.StatusCode, e.Body)
+}
+
+// Unwrap returns the sentinel error for the status code.
+func (e *StatusError) Unwrap() error {
+	switch {
+	case e.StatusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case e.StatusCode >= http.StatusInternalServerError:
+		return ErrServerError
+	default:
+		return ErrUnexpectedStatus
+	}
+}
+
+// Client is a Wise Old Man API client. Requests are rate limited to stay within the API's limits
+// and retried with backoff when rate limited or failing. Player and competition lookups are cached
+// briefly, and the cache is invalidated by changes made through the client.
 type Client struct {
 	httpClient *http.Client
 	baseURL    string
+	apiKey     string
+	userAgent  string
+	limiter    *tokenBucket
+	perMinute  int
+	retryBase  time.Duration
+	cache      *responseCache
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithAPIKey sends key in the x-api-key header, which raises Wise Old Man's rate limit.
+func WithAPIKey(key string) Option {
+	return func(c *Client) {
+		c.apiKey = key
+	}
+}
+
+// WithUserAgent sets the User-Agent sent with every request. Wise Old Man asks clients to identify
+// themselves, ideally with a way to contact the maintainer.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		if userAgent != "" {
+			c.userAgent = userAgent
+		}
+	}
+}
+
+// WithBaseURL points the client at another Wise Old Man instance, such as a self-hosted one or the
+// fake in package womtest. The URL includes the API version, e.g. "http://localhost:5000/v2".
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		if baseURL != "" {
+			c.baseURL = strings.TrimSuffix(baseURL, "/")
+		}
+	}
+}
+
+// WithRateLimit overrides how many requests the client sends per minute. By default it follows
+// Wise Old Man's limits, which depend on whether an API key is set.
+func WithRateLimit(requestsPerMinute int) Option {
+	return func(c *Client) {
+		c.perMinute = requestsPerMinute
+	}
 }
 
 // NewClient creates a new Wise Old Man API client.
-func NewClient() *Client {
-	return &Client{
+func NewClient(opts ...Option) *Client {
+	c := &Client{
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
-		baseURL: baseURL,
+		baseURL:   baseURL,
+		userAgent: defaultUserAgent,
+		retryBase: retryBaseDelay,
+		cache:     newResponseCache(),
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.perMinute <= 0 {
+		c.perMinute = anonymousRequestsPerMinute
+		if c.apiKey != "" {
+			c.perMinute = apiKeyRequestsPerMinute
+		}
+	}
+	c.limiter = newTokenBucket(float64(c.perMinute)/60, requestBurst)
+	return c
+}
+
+// CacheStats reports how lookups have been served by the response cache.
+func (c *Client) CacheStats() CacheStats {
+	return c.cache.stats()
 }
 
 // GetPlayer fetches a player's details from the Wise Old Man API.
 func (c *Client) GetPlayer(ctx context.Context, username string) (*Player, error) {
-	url := fmt.Sprintf("%s/players/%s", c.baseURL, username)
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	value, err := c.cache.get(ctx, playerCacheKey(username), playerCacheTTL, func() (any, error) {
+		var player Player
+		err := c.do(ctx, request{
+			method:   http.MethodGet,
+			path:     "/players/" + url.PathEscape(username),
+			notFound: ErrPlayerNotFound,
+		}, &player)
+		return player, err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
+		return nil, err
 	}
+	player := value.(Player)
+	return &player, nil
+}
 
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.httpClient.Do(req)
+// UpdatePlayer updates a player on Wise Old Man.
+// This fetches fresh data from the OSRS hiscores. Concurrent updates of the same player share one
+// request, and the result replaces the cached player.
+func (c *Client) UpdatePlayer(ctx context.Context, username string) (*Player, error) {
+	key := playerCacheKey(username)
+	value, err, _ := c.cache.flight.Do("update:"+key, func() (any, error) {
+		var player Player
+		err := c.do(ctx, request{
+			method:   http.MethodPost,
+			path:     "/players/" + url.PathEscape(username),
+			notFound: ErrPlayerNotFound,
+		}, &player)
+		if err != nil {
+			return nil, err
+		}
+		c.cache.set(key, player, playerCacheTTL)
+		return player, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("http request: %w", err)
+		return nil, err
 	}
-	defer func() { _ = resp.Body.Close() }()
+	player := value.(Player)
+	return &player, nil
+}
 
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, ErrPlayerNotFound
-	}
+// GetPlayerGains fetches what a player gained over the last period.
+func (c *Client) GetPlayerGains(ctx context.Context, username string, period Period) (*PlayerGains, error) {
+	return c.playerGains(ctx, username, url.Values{"period": {string(period)}})
+}
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("%w: status %d: %s", ErrUnexpectedStatus, resp.StatusCode, string(body))
+// GetPlayerGainsBetween fetches what a player gained between start and end.
+func (c *Client) GetPlayerGainsBetween(ctx context.Context, username string, start, end time.Time) (*PlayerGains, error) {
+	if !start.Before(end) {
+		return nil, fmt.Errorf("gains start %s is not before end %s", start.Format(time.RFC3339), end.Format(time.RFC3339))
 	}
+	return c.playerGains(ctx, username, url.Values{
+		"startDate": {start.UTC().Format(time.RFC3339)},
+		"endDate":   {end.UTC().Format(time.RFC3339)},
+	})
+}
 
-	var player Player
-	if err := json.NewDecoder(resp.Body).Decode(&player); err != nil {
-		return nil, fmt.Errorf("decode response: %w", err)
+// playerGains fetches a player's gains for the period described by query.
+func (c *Client) playerGains(ctx context.Context, username string, query url.Values) (*PlayerGains, error) {
+	var gains PlayerGains
+	err := c.do(ctx, request{
+		method:   http.MethodGet,
+		path:     "/players/" + url.PathEscape(username) + "/gained?" + query.Encode(),
+		notFound: ErrPlayerNotFound,
+	}, &gains)
+	if err != nil {
+		return nil, err
 	}
-
-	return &player, nil
+	return &gains, nil
 }
 
-// This fetches fresh data from the OSRS hiscores.
-func (c *Client) UpdatePlayer(ctx context.Context, username string) (*Player, error) {
-	url := fmt.Sprintf("%s/players/%s", c.baseURL, username)
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+// CreateCompetition creates a new competition.
+func (c *Client) CreateCompetition(ctx context.Context, req CreateCompetitionRequest) (*CreateCompetitionResponse, error) {
+	var result CreateCompetitionResponse
+	err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/competitions",
+		body:   req,
+		// A failed attempt may still have created the competition
+		unsafe: true,
+	}, &result)
 	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
+		return nil, err
 	}
+	return &result, nil
+}
 
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.httpClient.Do(req)
+// EditCompetition changes a competition's details or replaces its participants or teams, and
+// drops its cached details.
+func (c *Client) EditCompetition(ctx context.Context, competitionID int64, req EditCompetitionRequest) (*Competition, error) {
+	var result Competition
+	err := c.do(ctx, request{
+		method:   http.MethodPut,
+		path:     fmt.Sprintf("/competitions/%d", competitionID),
+		body:     req,
+		notFound: ErrCompetitionNotFound,
+	}, &result)
 	if err != nil {
-		return nil, fmt.Errorf("http request: %w", err)
+		return nil, err
 	}
-	defer func() { _ = resp.Body.Close() }()
+	c.cache.invalidate(competitionCacheKey(competitionID))
+	return &result, nil
+}
 
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, ErrPlayerNotFound
-	}
+// DeleteCompetition deletes a competition. Deleting a competition that no longer exists returns
+// ErrCompetitionNotFound.
+func (c *Client) DeleteCompetition(ctx context.Context, competitionID int64, verificationCode string) error {
+	err := c.do(ctx, request{
+		method:   http.MethodDelete,
+		path:     fmt.Sprintf("/competitions/%d", competitionID),
+		body:     DeleteCompetitionRequest{VerificationCode: verificationCode},
+		notFound: ErrCompetitionNotFound,
+	}, nil)
+	c.cache.invalidate(competitionCacheKey(competitionID))
+	return err
+}
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("%w: status %d: %s", ErrUnexpectedStatus, resp.StatusCode, string(body))
-	}
+// AddParticipants adds participants to a classic competition.
+func (c *Client) AddParticipants(ctx context.Context, competitionID int64, usernames []string, verificationCode string) (*CompetitionUpdateResponse, error) {
+	return c.changeCompetition(ctx, http.MethodPost, competitionID, "/participants", AddParticipantsRequest{
+		VerificationCode: verificationCode,
+		Participants:     usernames,
+	})
+}
 
-	var player Player
-	if err := json.NewDecoder(resp.Body).Decode(&player); err != nil {
-		return nil, fmt.Errorf("decode response: %w", err)
-	}
+// RemoveParticipants removes participants from a competition by username.
+func (c *Client) RemoveParticipants(ctx context.Context, competitionID int64, usernames []string, verificationCode string) (*CompetitionUpdateResponse, error) {
+	return c.changeCompetition(ctx, http.MethodDelete, competitionID, "/participants", RemoveParticipantsRequest{
+		VerificationCode: verificationCode,
+		Participants:     usernames,
+	})
+}
 
-	return &player, nil
+// AddTeams adds teams to a team competition. The count is the number of players added.
+func (c *Client) AddTeams(ctx context.Context, competitionID int64, teams []Team, verificationCode string) (*CompetitionUpdateResponse, error) {
+	return c.changeCompetition(ctx, http.MethodPost, competitionID, "/teams", AddTeamsRequest{
+		VerificationCode: verificationCode,
+		Teams:            teams,
+	})
 }
 
-// CreateCompetition creates a new competition.
-func (c *Client) CreateCompetition(ctx context.Context, req CreateCompetitionRequest) (*CreateCompetitionResponse, error) {
-	url := fmt.Sprintf("%s/competitions", c.baseURL)
+// RemoveTeams removes teams from a team competition by name. The count is the number of players
+// removed.
+func (c *Client) RemoveTeams(ctx context.Context, competitionID int64, teamNames []string, verificationCode string) (*CompetitionUpdateResponse, error) {
+	return c.changeCompetition(ctx, http.MethodDelete, competitionID, "/teams", RemoveTeamsRequest{
+		VerificationCode: verificationCode,
+		TeamNames:        teamNames,
+	})
+}
+
+// UpdateAllParticipants queues an update for every participant whose data is outdated, so
+// standings reflect recent progress.
+func (c *Client) UpdateAllParticipants(ctx context.Context, competitionID int64, verificationCode string) (*CompetitionUpdateResponse, error) {
+	return c.changeCompetition(ctx, http.MethodPost, competitionID, "/update-all", UpdateAllParticipantsRequest{
+		VerificationCode: verificationCode,
+	})
+}
 
-	body, err := json.Marshal(req)
+// changeCompetition sends a verified change to a competition and decodes the count of affected
+// players. The competition's cached details are dropped.
+func (c *Client) changeCompetition(ctx context.Context, method string, competitionID int64, subpath string, payload any) (*CompetitionUpdateResponse, error) {
+	var result CompetitionUpdateResponse
+	err := c.do(ctx, request{
+		method:   method,
+		path:     fmt.Sprintf("/competitions/%d%s", competitionID, subpath),
+		body:     payload,
+		notFound: ErrCompetitionNotFound,
+	}, &result)
 	if err != nil {
-		return nil, fmt.Errorf("marshal request: %w", err)
+		return nil, err
 	}
+	c.cache.invalidate(competitionCacheKey(competitionID))
+	return &result, nil
+}
 
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+// GetCompetition fetches competition details including standings.
+func (c *Client) GetCompetition(ctx context.Context, competitionID int64) (*Competition, error) {
+	value, err := c.cache.get(ctx, competitionCacheKey(competitionID), competitionCacheTTL, func() (any, error) {
+		var competition Competition
+		err := c.do(ctx, request{
+			method:   http.MethodGet,
+			path:     fmt.Sprintf("/competitions/%d", competitionID),
+			notFound: ErrCompetitionNotFound,
+		}, &competition)
+		return competition, err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
+		return nil, err
 	}
-	httpReq.Header.Set("Content-Type", "application/json")
+	competition := value.(Competition)
+	return &competition, nil
+}
 
-	resp, err := c.httpClient.Do(httpReq)
+// GetGroup fetches a group's details including its members.
+func (c *Client) GetGroup(ctx context.Context, groupID int64) (*GroupDetails, error) {
+	var group GroupDetails
+	err := c.do(ctx, request{
+		method:   http.MethodGet,
+		path:     fmt.Sprintf("/groups/%d", groupID),
+		notFound: ErrGroupNotFound,
+	}, &group)
 	if err != nil {
-		return nil, fmt.Errorf("http request: %w", err)
+		return nil, err
 	}
-	defer func() { _ = resp.Body.Close() }() // Error not actionable in defer
+	return &group, nil
+}
 
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("%w: status %d: %s", ErrUnexpectedStatus, resp.StatusCode, string(body))
+// GetGroupMembers fetches the memberships of a group.
+func (c *Client) GetGroupMembers(ctx context.Context, groupID int64) ([]Membership, error) {
+	group, err := c.GetGroup(ctx, groupID)
+	if err != nil {
+		return nil, err
 	}
+	return group.Memberships, nil
+}
 
-	var result CreateCompetitionResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("decode response: %w", err)
-	}
+// AddGroupMembers adds players to a group. Players already in the group are left unchanged.
+func (c *Client) AddGroupMembers(ctx context.Context, groupID int64, members []GroupMember, verificationCode string) (*GroupUpdateResponse, error) {
+	return c.changeGroup(ctx, http.MethodPost, fmt.Sprintf("/groups/%d/members", groupID), AddGroupMembersRequest{
+		VerificationCode: verificationCode,
+		Members:          members,
+	})
+}
+
+// RemoveGroupMembers removes players from a group by username.
+func (c *Client) RemoveGroupMembers(ctx context.Context, groupID int64, usernames []string, verificationCode string) (*GroupUpdateResponse, error) {
+	return c.changeGroup(ctx, http.MethodDelete, fmt.Sprintf("/groups/%d/members", groupID), RemoveGroupMembersRequest{
+		VerificationCode: verificationCode,
+		Members:          usernames,
+	})
+}
 
+// UpdateAllGroupMembers queues an update for every group member whose data is outdated.
+func (c *Client) UpdateAllGroupMembers(ctx context.Context, groupID int64, verificationCode string) (*GroupUpdateResponse, error) {
+	return c.changeGroup(ctx, http.MethodPost, fmt.Sprintf("/groups/%d/update-all", groupID), UpdateAllGroupMembersRequest{
+		VerificationCode: verificationCode,
+	})
+}
+
+// changeGroup sends a verified change to a group and decodes the count of affected players. Group
+// competitions include every group member, so cached competitions are dropped.
+func (c *Client) changeGroup(ctx context.Context, method, path string, payload any) (*GroupUpdateResponse, error) {
+	var result GroupUpdateResponse
+	err := c.do(ctx, request{
+		method:   method,
+		path:     path,
+		body:     payload,
+		notFound: ErrGroupNotFound,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.invalidatePrefix(competitionCachePrefix)
 	return &result, nil
 }
 
-// AddParticipants adds participants to a competition.
-func (c *Client) AddParticipants(ctx context.Context, competitionID int64, usernames []string, verificationCode string) (*AddParticipantsResponse, error) {
-	url := fmt.Sprintf("%s/competitions/%d/participants", c.baseURL, competitionID)
+// playerCacheKey is the cache key for a player, shared by every spelling of their name.
+func playerCacheKey(username string) string {
+	return "player:" + StandardizeUsername(username)
+}
 
-	req := AddParticipantsRequest{
-		VerificationCode: verificationCode,
-		Participants:     usernames,
+// competitionCacheKey is the cache key for a competition.
+func competitionCacheKey(competitionID int64) string {
+	return competitionCachePrefix + strconv.FormatInt(competitionID, 10)
+}
+
+// request describes a call to the API.
+type request struct {
+	method string
+	path   string
+	body   any // Encoded as JSON when set
+	// notFound is returned for 404 responses instead of a StatusError.
+	notFound error
+	// unsafe requests aren't retried after server errors, which may have been applied anyway.
+	unsafe bool
+}
+
+// do sends req, waiting for the rate limiter first, and decodes a successful response into result
+// unless it's nil.
+// Rate-limited requests, server errors and network failures are retried with jittered exponential
+// backoff, honouring the API's Retry-After.
+func (c *Client) do(ctx context.Context, req request, result any) error {
+	var body []byte
+	if req.body != nil {
+		var err error
+		body, err = json.Marshal(req.body)
+		if err != nil {
+			return fmt.Errorf("marshal request: %w", err)
+		}
 	}
 
-	body, err := json.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("marshal request: %w", err)
+	for attempt := 0; ; attempt++ {
+		if err := c.limiter.wait(ctx); err != nil {
+			return fmt.Errorf("wait for rate limit: %w", err)
+		}
+
+		err := c.send(ctx, req, body, result)
+		if err == nil {
+			return nil
+		}
+
+		delay, retry := c.retryDelay(req, err, attempt)
+		if !retry {
+			return err
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return err
+		}
+
+		log.Printf("Wise Old Man %s %s failed (attempt %d), retrying in %s: %v", req.method, req.path, attempt+1, delay.Round(time.Millisecond), err)
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		}
+	}
+}
+
+// send makes a single attempt at req.
+func (c *Client) send(ctx context.Context, req request, body []byte, result any) error {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, c.baseURL+req.path, reader)
 	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
+		return fmt.Errorf("create request: %w", err)
 	}
 	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", c.userAgent)
+	if c.apiKey != "" {
+		httpReq.Header.Set("x-api-key", c.apiKey)
+	}
 
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
-		return nil, fmt.Errorf("http request: %w", err)
+		return fmt.Errorf("http request: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }() // Error not actionable in defer
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("%w: status %d: %s", ErrUnexpectedStatus, resp.StatusCode, string(body))
+	if resp.StatusCode == http.StatusNotFound && req.notFound != nil {
+		return req.notFound
 	}
 
-	var result AddParticipantsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("decode response: %w", err)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(resp.Body)
+		return &StatusError{
+			StatusCode: resp.StatusCode,
+			Body:       string(body),
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		}
 	}
 
-	return &result, nil
+	if result == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
 }
 
-// GetCompetition fetches competition details including standings.
-func (c *Client) GetCompetition(ctx context.Context, competitionID int64) (*Competition, error) {
-	url := fmt.Sprintf("%s/competitions/%d", c.baseURL, competitionID)
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
+// retryDelay decides whether a failed attempt at req is retried and how long to wait first.
+func (c *Client) retryDelay(req request, err error, attempt int) (time.Duration, bool) {
+	if attempt >= maxRetries || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return 0, false
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("http request: %w", err)
+	var statusErr *StatusError
+	var urlErr *url.Error
+	switch {
+	case errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusTooManyRequests:
+		if statusErr.RetryAfter > 0 {
+			return statusErr.RetryAfter, statusErr.RetryAfter <= maxRetryDelay
+		}
+		return c.backoff(attempt), true
+	case errors.As(err, &statusErr) && statusErr.StatusCode >= http.StatusInternalServerError:
+		// The API may have applied an unsafe request before failing
+		return c.backoff(attempt), !req.unsafe
+	case errors.As(err, &urlErr):
+		// Network failures; the request may still have reached the API
+		return c.backoff(attempt), !req.unsafe
+	default:
+		return 0, false
 	}
-	defer func() { _ = resp.Body.Close() }()
+}
 
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, ErrCompetitionNotFound
-	}
+// backoff returns a random delay up to the retry base delay doubled attempt times, capped at
+// maxRetryDelay.
+func (c *Client) backoff(attempt int) time.Duration {
+	ceiling := min(c.retryBase<<attempt, maxRetryDelay)
+	return time.Duration(rand.Int64N(int64(ceiling))) + time.Millisecond
+}
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("%w: status %d: %s", ErrUnexpectedStatus, resp.StatusCode, string(body))
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
 	}
-
-	var competition Competition
-	if err := json.NewDecoder(resp.Body).Decode(&competition); err != nil {
-		return nil, fmt.Errorf("decode response: %w", err)
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
 	}
-
-	return &competition, nil
+	if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
 }
@@ -0,0 +1,351 @@
+package wiseoldman
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient returns a client for server that neither rate limits nor waits between retries.
+func newTestClient(server *httptest.Server, opts ...Option) *Client {
+	c := NewClient(opts...)
+	c.baseURL = server.URL
+	c.retryBase = time.Millisecond
+	c.limiter = newTokenBucket(1000, 100)
+	return c
+}
+
+func TestClientSendsHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "voidling (discord: test)", r.Header.Get("User-Agent"))
+		assert.Equal(t, "secret", r.Header.Get("x-api-key"))
+		assert.Equal(t, "/players/lynx%20titan", r.URL.EscapedPath())
+		_, _ = io.WriteString(w, `{"id": 1, "username": "lynx titan", "displayName": "Lynx Titan"}`)
+	}))
+	defer server.Close()
+
+	c := newTestClient(server, WithAPIKey("secret"), WithUserAgent("voidling (discord: test)"))
+	player, err := c.GetPlayer(context.Background(), "lynx titan")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Lynx Titan", player.DisplayName)
+}
+
+func TestClientOmitsAPIKeyByDefault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, defaultUserAgent, r.Header.Get("User-Agent"))
+		assert.Empty(t, r.Header.Values("x-api-key"))
+		_, _ = io.WriteString(w, `{"id": 1}`)
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server).GetPlayer(context.Background(), "zezima")
+	require.NoError(t, err)
+}
+
+func TestClientRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []int
+		call     func(*Client) error
+		wantErr  error
+		wantHits int32
+	}{
+		{
+			name:     "rate limit then success",
+			statuses: []int{http.StatusTooManyRequests, http.StatusOK},
+			call:     getPlayer,
+			wantHits: 2,
+		},
+		{
+			name:     "server error then success",
+			statuses: []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK},
+			call:     getPlayer,
+			wantHits: 3,
+		},
+		{
+			name:     "rate limited after every retry",
+			statuses: []int{http.StatusTooManyRequests},
+			call:     getPlayer,
+			wantErr:  ErrRateLimited,
+			wantHits: maxRetries + 1,
+		},
+		{
+			name:     "server error after every retry",
+			statuses: []int{http.StatusInternalServerError},
+			call:     getPlayer,
+			wantErr:  ErrServerError,
+			wantHits: maxRetries + 1,
+		},
+		{
+			name:     "client errors aren't retried",
+			statuses: []int{http.StatusBadRequest},
+			call:     getPlayer,
+			wantErr:  ErrUnexpectedStatus,
+			wantHits: 1,
+		},
+		{
+			name:     "not found isn't retried",
+			statuses: []int{http.StatusNotFound},
+			call:     getPlayer,
+			wantErr:  ErrPlayerNotFound,
+			wantHits: 1,
+		},
+		{
+			name:     "competition creation isn't retried after a server error",
+			statuses: []int{http.StatusInternalServerError, http.StatusOK},
+			call: func(c *Client) error {
+				_, err := c.CreateCompetition(context.Background(), CreateCompetitionRequest{Title: "SOTW"})
+				return err
+			},
+			wantErr:  ErrServerError,
+			wantHits: 1,
+		},
+		{
+			name:     "competition creation is retried when rate limited",
+			statuses: []int{http.StatusTooManyRequests, http.StatusCreated},
+			call: func(c *Client) error {
+				_, err := c.CreateCompetition(context.Background(), CreateCompetitionRequest{Title: "SOTW"})
+				return err
+			},
+			wantHits: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hits atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				hit := int(hits.Add(1))
+				status := tt.statuses[min(hit, len(tt.statuses))-1]
+				if status == http.StatusOK || status == http.StatusCreated {
+					w.WriteHeader(status)
+					_, _ = io.WriteString(w, `{"id": 1}`)
+					return
+				}
+				http.Error(w, `{"message": "nope"}`, status)
+			}))
+			defer server.Close()
+
+			err := tt.call(newTestClient(server))
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantHits, hits.Load())
+		})
+	}
+}
+
+func TestClientHonoursRetryAfter(t *testing.T) {
+	var hits atomic.Int32
+	var first time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) == 1 {
+			first = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		assert.GreaterOrEqual(t, time.Since(first), time.Second)
+		_, _ = io.WriteString(w, `{"id": 1}`)
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server).GetPlayer(context.Background(), "zezima")
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), hits.Load())
+}
+
+func TestClientGivesUpOnLongRetryAfter(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server).GetPlayer(context.Background(), "zezima")
+
+	var statusErr *StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, time.Hour, statusErr.RetryAfter)
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.Equal(t, int32(1), hits.Load())
+}
+
+func TestClientStopsRetryingWhenCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "5")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := newTestClient(server).GetPlayer(ctx, "zezima")
+
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 30*time.Second, parseRetryAfter("30", now))
+	assert.Equal(t, 90*time.Second, parseRetryAfter(now.Add(90*time.Second).Format(http.TimeFormat), now))
+	assert.Zero(t, parseRetryAfter("", now))
+	assert.Zero(t, parseRetryAfter("soon", now))
+	assert.Zero(t, parseRetryAfter(now.Add(-time.Minute).Format(http.TimeFormat), now))
+}
+
+func TestStatusErrorUnwrap(t *testing.T) {
+	assert.True(t, errors.Is(&StatusError{StatusCode: http.StatusTooManyRequests}, ErrRateLimited))
+	assert.True(t, errors.Is(&StatusError{StatusCode: http.StatusGatewayTimeout}, ErrServerError))
+	assert.True(t, errors.Is(&StatusError{StatusCode: http.StatusForbidden}, ErrUnexpectedStatus))
+	assert.False(t, errors.Is(&StatusError{StatusCode: http.StatusForbidden}, ErrServerError))
+}
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	bucket := newTokenBucket(1, 2)
+	bucket.last = now
+	bucket.now = func() time.Time { return now }
+
+	// The burst goes straight through, then requests queue a second apart
+	assert.Zero(t, bucket.reserve())
+	assert.Zero(t, bucket.reserve())
+	assert.Equal(t, time.Second, bucket.reserve())
+	assert.Equal(t, 2*time.Second, bucket.reserve())
+
+	// Tokens refill over time, up to the burst
+	now = now.Add(time.Minute)
+	assert.Zero(t, bucket.reserve())
+	assert.Zero(t, bucket.reserve())
+	assert.Equal(t, time.Second, bucket.reserve())
+}
+
+func TestTokenBucketWaitCancelled(t *testing.T) {
+	bucket := newTokenBucket(0.001, 1)
+	require.NoError(t, bucket.wait(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, bucket.wait(ctx), context.Canceled)
+}
+
+func getPlayer(c *Client) error {
+	_, err := c.GetPlayer(context.Background(), "zezima")
+	return err
+}
+
+func TestClientChangesCompetitions(t *testing.T) {
+	tests := []struct {
+		name       string
+		call       func(*Client) error
+		wantMethod string
+		wantPath   string
+		wantBody   string
+	}{
+		{
+			name: "edit",
+			call: func(c *Client) error {
+				_, err := c.EditCompetition(context.Background(), 7, EditCompetitionRequest{VerificationCode: "code", Title: "New title"})
+				return err
+			},
+			wantMethod: http.MethodPut,
+			wantPath:   "/competitions/7",
+			wantBody:   `{"verificationCode":"code","title":"New title"}`,
+		},
+		{
+			name:       "delete",
+			call:       func(c *Client) error { return c.DeleteCompetition(context.Background(), 7, "code") },
+			wantMethod: http.MethodDelete,
+			wantPath:   "/competitions/7",
+			wantBody:   `{"verificationCode":"code"}`,
+		},
+		{
+			name: "remove participants",
+			call: func(c *Client) error {
+				_, err := c.RemoveParticipants(context.Background(), 7, []string{"Zezima"}, "code")
+				return err
+			},
+			wantMethod: http.MethodDelete,
+			wantPath:   "/competitions/7/participants",
+			wantBody:   `{"verificationCode":"code","participants":["Zezima"]}`,
+		},
+		{
+			name: "add teams",
+			call: func(c *Client) error {
+				_, err := c.AddTeams(context.Background(), 7, []Team{{Name: "Red", Participants: []string{"Zezima"}}}, "code")
+				return err
+			},
+			wantMethod: http.MethodPost,
+			wantPath:   "/competitions/7/teams",
+			wantBody:   `{"verificationCode":"code","teams":[{"name":"Red","participants":["Zezima"]}]}`,
+		},
+		{
+			name: "remove teams",
+			call: func(c *Client) error {
+				_, err := c.RemoveTeams(context.Background(), 7, []string{"Red"}, "code")
+				return err
+			},
+			wantMethod: http.MethodDelete,
+			wantPath:   "/competitions/7/teams",
+			wantBody:   `{"verificationCode":"code","teamNames":["Red"]}`,
+		},
+		{
+			name: "update all",
+			call: func(c *Client) error {
+				_, err := c.UpdateAllParticipants(context.Background(), 7, "code")
+				return err
+			},
+			wantMethod: http.MethodPost,
+			wantPath:   "/competitions/7/update-all",
+			wantBody:   `{"verificationCode":"code"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var competitionHits atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					competitionHits.Add(1)
+					_, _ = io.WriteString(w, `{"id": 7}`)
+					return
+				}
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantMethod, r.Method)
+				assert.Equal(t, tt.wantPath, r.URL.Path)
+				assert.JSONEq(t, tt.wantBody, string(body))
+				_, _ = io.WriteString(w, `{"id": 7, "count": 1, "message": "ok"}`)
+			}))
+			defer server.Close()
+
+			c := newTestClient(server)
+			_, err := c.GetCompetition(context.Background(), 7)
+			require.NoError(t, err)
+			require.NoError(t, tt.call(c))
+
+			// Changes drop the cached competition
+			_, err = c.GetCompetition(context.Background(), 7)
+			require.NoError(t, err)
+			assert.Equal(t, int32(2), competitionHits.Load())
+		})
+	}
+}
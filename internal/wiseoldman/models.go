@@ -1,6 +1,11 @@
 package wiseoldman
 
-import "time"
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+)
 
 // Player represents a Wise Old Man player.
 type Player struct {
@@ -105,7 +110,14 @@ func (p *Player) GetBoss(bossName string) *BossData {
 	return &boss
 }
 
-// Competition represents a WOM competition.
+// Competition types.
+const (
+	CompetitionTypeClassic = "classic"
+	CompetitionTypeTeam    = "team"
+)
+
+// Competition represents a WOM competition. Participations and their progress are only filled in
+// by GetCompetition; the group is only set for group competitions.
 type Competition struct {
 	ID               int64                      `json:"id"`
 	Title            string                     `json:"title"`
@@ -115,13 +127,69 @@ type Competition struct {
 	EndsAt           time.Time                  `json:"endsAt"`
 	GroupID          *int64                     `json:"groupId"`
 	Score            int                        `json:"score"`
+	Visible          bool                       `json:"visible"`
 	CreatedAt        time.Time                  `json:"createdAt"`
 	UpdatedAt        time.Time                  `json:"updatedAt"`
 	ParticipantCount int                        `json:"participantCount"`
+	Group            *Group                     `json:"group,omitempty"`
 	Participations   []CompetitionParticipation `json:"participations"`
 }
 
-// CompetitionParticipation represents a player's participation in a competition.
+// IsTeamCompetition reports whether participants compete in teams.
+func (c *Competition) IsTeamCompetition() bool {
+	return c.Type == CompetitionTypeTeam
+}
+
+// TeamStanding is a team's combined progress in a team competition.
+type TeamStanding struct {
+	Name   string
+	Gained int64
+	// Participations are the team's members, best first.
+	Participations []CompetitionParticipation
+}
+
+// TeamStandings totals progress per team, best team first. Participants without a team are left
+// out, so classic competitions have no standings.
+func (c *Competition) TeamStandings() []TeamStanding {
+	var standings []TeamStanding
+	index := make(map[string]int)
+	for _, p := range c.Participations {
+		if p.TeamName == nil {
+			continue
+		}
+		i, ok := index[*p.TeamName]
+		if !ok {
+			i = len(standings)
+			index[*p.TeamName] = i
+			standings = append(standings, TeamStanding{Name: *p.TeamName})
+		}
+		if p.Progress != nil {
+			standings[i].Gained += p.Progress.Gained
+		}
+		standings[i].Participations = append(standings[i].Participations, p)
+	}
+
+	for _, team := range standings {
+		slices.SortStableFunc(team.Participations, func(a, b CompetitionParticipation) int {
+			return cmp.Compare(gained(b), gained(a))
+		})
+	}
+	slices.SortStableFunc(standings, func(a, b TeamStanding) int {
+		return cmp.Or(cmp.Compare(b.Gained, a.Gained), strings.Compare(a.Name, b.Name))
+	})
+	return standings
+}
+
+// gained returns the progress a participant has made, or 0 before any is known.
+func gained(p CompetitionParticipation) int64 {
+	if p.Progress == nil {
+		return 0
+	}
+	return p.Progress.Gained
+}
+
+// CompetitionParticipation represents a player's participation in a competition. TeamName is only
+// set in team competitions.
 type CompetitionParticipation struct {
 	PlayerID      int64                  `json:"playerId"`
 	CompetitionID int64                  `json:"competitionId"`
@@ -130,22 +198,52 @@ type CompetitionParticipation struct {
 	UpdatedAt     time.Time              `json:"updatedAt"`
 	Player        Player                 `json:"player"`
 	Progress      *ParticipationProgress `json:"progress,omitempty"`
+	Levels        *ParticipationProgress `json:"levels,omitempty"`
 }
 
-// ParticipationProgress represents progress gained during a competition.
+// ParticipationProgress represents progress gained during a competition. Levels use the same
+// shape for skill competitions.
 type ParticipationProgress struct {
 	Start  int64 `json:"start"`
 	End    int64 `json:"end"`
 	Gained int64 `json:"gained"`
 }
 
+// Team is a named team of players in a team competition.
+type Team struct {
+	Name         string   `json:"name"`
+	Participants []string `json:"participants"`
+}
+
 // CreateCompetitionRequest is the request body for creating a competition.
+// Setting GroupID creates a group competition, which every group member takes part in. Setting
+// Teams creates a team competition; Participants and Teams can't both be set.
 type CreateCompetitionRequest struct {
-	Title        string   `json:"title"`
-	Metric       string   `json:"metric"`
-	StartsAt     string   `json:"startsAt"`
-	EndsAt       string   `json:"endsAt"`
-	Participants []string `json:"participants,omitempty"`
+	Title                 string   `json:"title"`
+	Metric                string   `json:"metric"`
+	StartsAt              string   `json:"startsAt"`
+	EndsAt                string   `json:"endsAt"`
+	Participants          []string `json:"participants,omitempty"`
+	Teams                 []Team   `json:"teams,omitempty"`
+	GroupID               *int64   `json:"groupId,omitempty"`
+	GroupVerificationCode string   `json:"groupVerificationCode,omitempty"`
+}
+
+// EditCompetitionRequest is the request body for editing a competition. Empty fields are left
+// unchanged. Participants or Teams replace the competition's whole roster when set.
+type EditCompetitionRequest struct {
+	VerificationCode string   `json:"verificationCode"`
+	Title            string   `json:"title,omitempty"`
+	Metric           string   `json:"metric,omitempty"`
+	StartsAt         string   `json:"startsAt,omitempty"`
+	EndsAt           string   `json:"endsAt,omitempty"`
+	Participants     []string `json:"participants,omitempty"`
+	Teams            []Team   `json:"teams,omitempty"`
+}
+
+// DeleteCompetitionRequest is the request body for deleting a competition.
+type DeleteCompetitionRequest struct {
+	VerificationCode string `json:"verificationCode"`
 }
 
 // CreateCompetitionResponse is the response from creating a competition.
@@ -160,8 +258,201 @@ type AddParticipantsRequest struct {
 	Participants     []string `json:"participants"`
 }
 
-// AddParticipantsResponse is the response from adding participants.
-type AddParticipantsResponse struct {
+// RemoveParticipantsRequest is the request body for removing participants.
+type RemoveParticipantsRequest struct {
+	VerificationCode string   `json:"verificationCode"`
+	Participants     []string `json:"participants"`
+}
+
+// AddTeamsRequest is the request body for adding teams to a team competition.
+type AddTeamsRequest struct {
+	VerificationCode string `json:"verificationCode"`
+	Teams            []Team `json:"teams"`
+}
+
+// RemoveTeamsRequest is the request body for removing teams from a team competition by name.
+type RemoveTeamsRequest struct {
+	VerificationCode string   `json:"verificationCode"`
+	TeamNames        []string `json:"teamNames"`
+}
+
+// UpdateAllParticipantsRequest is the request body for updating every outdated participant of a
+// competition.
+type UpdateAllParticipantsRequest struct {
+	VerificationCode string `json:"verificationCode"`
+}
+
+// CompetitionUpdateResponse is the response from changing a competition's participants or teams.
+type CompetitionUpdateResponse struct {
+	Count   int    `json:"count"`
+	Message string `json:"message"`
+}
+
+// Group represents a WOM group, such as a clan.
+type Group struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	ClanChat    *string   `json:"clanChat"`
+	Description *string   `json:"description"`
+	Homeworld   *int      `json:"homeworld"`
+	Verified    bool      `json:"verified"`
+	Patron      bool      `json:"patron"`
+	Score       int       `json:"score"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	MemberCount int       `json:"memberCount"`
+}
+
+// GroupDetails is a group together with its members.
+type GroupDetails struct {
+	Group
+	Memberships []Membership `json:"memberships"`
+}
+
+// Membership represents a player's membership of a group.
+type Membership struct {
+	PlayerID  int64     `json:"playerId"`
+	GroupID   int64     `json:"groupId"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	Player    Player    `json:"player"`
+}
+
+// GroupMember is a player to add to a group with their group role, e.g. "member".
+type GroupMember struct {
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+// AddGroupMembersRequest is the request body for adding members to a group.
+type AddGroupMembersRequest struct {
+	VerificationCode string        `json:"verificationCode"`
+	Members          []GroupMember `json:"members"`
+}
+
+// RemoveGroupMembersRequest is the request body for removing members from a group.
+type RemoveGroupMembersRequest struct {
+	VerificationCode string   `json:"verificationCode"`
+	Members          []string `json:"members"`
+}
+
+// UpdateAllGroupMembersRequest is the request body for updating every outdated member of a group.
+type UpdateAllGroupMembersRequest struct {
+	VerificationCode string `json:"verificationCode"`
+}
+
+// GroupUpdateResponse is the response from changing a group's members.
+type GroupUpdateResponse struct {
 	Count   int    `json:"count"`
 	Message string `json:"message"`
 }
+
+// Period is a span of time ending now that Wise Old Man reports gains over.
+type Period string
+
+// Periods accepted by GetPlayerGains.
+const (
+	PeriodDay   Period = "day"
+	PeriodWeek  Period = "week"
+	PeriodMonth Period = "month"
+	PeriodYear  Period = "year"
+)
+
+// Delta is a metric's value at the start and end of a gains period.
+type Delta struct {
+	Start  int64 `json:"start"`
+	End    int64 `json:"end"`
+	Gained int64 `json:"gained"`
+}
+
+// FloatDelta is a Delta of a fractional metric, such as EHP.
+type FloatDelta struct {
+	Start  float64 `json:"start"`
+	End    float64 `json:"end"`
+	Gained float64 `json:"gained"`
+}
+
+// SkillGains is what a player gained in a skill.
+type SkillGains struct {
+	Metric     string     `json:"metric"`
+	Experience Delta      `json:"experience"`
+	Rank       Delta      `json:"rank"`
+	Level      Delta      `json:"level"`
+	EHP        FloatDelta `json:"ehp"`
+}
+
+// BossGains is what a player gained at a boss.
+type BossGains struct {
+	Metric string     `json:"metric"`
+	Kills  Delta      `json:"kills"`
+	Rank   Delta      `json:"rank"`
+	EHB    FloatDelta `json:"ehb"`
+}
+
+// ActivityGains is what a player gained in an activity, such as clue scrolls.
+type ActivityGains struct {
+	Metric string `json:"metric"`
+	Score  Delta  `json:"score"`
+	Rank   Delta  `json:"rank"`
+}
+
+// ComputedGains is what a player gained in a computed metric, such as EHP or EHB.
+type ComputedGains struct {
+	Metric string     `json:"metric"`
+	Value  FloatDelta `json:"value"`
+	Rank   Delta      `json:"rank"`
+}
+
+// GainsData holds a player's gains per metric.
+type GainsData struct {
+	Skills     map[string]SkillGains    `json:"skills"`
+	Bosses     map[string]BossGains     `json:"bosses"`
+	Activities map[string]ActivityGains `json:"activities"`
+	Computed   map[string]ComputedGains `json:"computed"`
+}
+
+// PlayerGains is what a player gained between StartsAt and EndsAt, the first and last snapshots
+// in the requested period. Both are nil when Wise Old Man has no snapshots in it.
+type PlayerGains struct {
+	StartsAt *time.Time `json:"startsAt"`
+	EndsAt   *time.Time `json:"endsAt"`
+	Data     GainsData  `json:"data"`
+}
+
+// TopSkills returns up to n skills with the most experience gained, most first. Overall and skills
+// without gains are left out.
+func (g *PlayerGains) TopSkills(n int) []SkillGains {
+	var top []SkillGains
+	for _, skill := range g.Data.Skills {
+		if skill.Metric != "overall" && skill.Experience.Gained > 0 {
+			top = append(top, skill)
+		}
+	}
+	slices.SortFunc(top, func(a, b SkillGains) int {
+		return cmp.Or(cmp.Compare(b.Experience.Gained, a.Experience.Gained), strings.Compare(a.Metric, b.Metric))
+	})
+	return top[:min(n, len(top))]
+}
+
+// TopBosses returns up to n bosses with the most kills gained, most first. Bosses without gains
+// are left out.
+func (g *PlayerGains) TopBosses(n int) []BossGains {
+	var top []BossGains
+	for _, boss := range g.Data.Bosses {
+		if boss.Kills.Gained > 0 {
+			top = append(top, boss)
+		}
+	}
+	slices.SortFunc(top, func(a, b BossGains) int {
+		return cmp.Or(cmp.Compare(b.Kills.Gained, a.Kills.Gained), strings.Compare(a.Metric, b.Metric))
+	})
+	return top[:min(n, len(top))]
+}
+
+// StandardizeUsername normalises a username the way WOM does, so names can be compared:
+// lowercase, with dashes and underscores as spaces and surrounding spaces trimmed.
+func StandardizeUsername(username string) string {
+	replacer := strings.NewReplacer("-", " ", "_", " ")
+	return strings.ToLower(strings.TrimSpace(replacer.Replace(username)))
+}
@@ -0,0 +1,75 @@
+package wiseoldman
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamStandings(t *testing.T) {
+	participant := func(username, team string, gained int64) CompetitionParticipation {
+		p := CompetitionParticipation{Player: Player{Username: username}}
+		if team != "" {
+			p.TeamName = &team
+		}
+		if gained >= 0 {
+			p.Progress = &ParticipationProgress{Gained: gained}
+		}
+		return p
+	}
+	comp := Competition{
+		Type: CompetitionTypeTeam,
+		Participations: []CompetitionParticipation{
+			participant("zezima", "Red", 50),
+			participant("woox", "Blue", 40),
+			participant("lynx titan", "Blue", 30),
+			participant("b0aty", "Red", 5),
+			participant("framed", "Green", -1), // no progress yet
+			participant("solo", "", 100),
+		},
+	}
+
+	standings := comp.TeamStandings()
+
+	require.Len(t, standings, 3)
+	assert.Equal(t, "Blue", standings[0].Name)
+	assert.Equal(t, int64(70), standings[0].Gained)
+	assert.Equal(t, "woox", standings[0].Participations[0].Player.Username, "the MVP comes first")
+	assert.Equal(t, "Red", standings[1].Name)
+	assert.Equal(t, int64(55), standings[1].Gained)
+	assert.Equal(t, "Green", standings[2].Name)
+	assert.Zero(t, standings[2].Gained)
+
+	classic := Competition{Participations: []CompetitionParticipation{participant("zezima", "", 10)}}
+	assert.Empty(t, classic.TeamStandings())
+}
+
+func TestPlayerGainsTop(t *testing.T) {
+	gains := PlayerGains{Data: GainsData{
+		Skills: map[string]SkillGains{
+			"overall": {Metric: "overall", Experience: Delta{Gained: 900}},
+			"mining":  {Metric: "mining", Experience: Delta{Gained: 500}},
+			"attack":  {Metric: "attack", Experience: Delta{Gained: 400}},
+			"agility": {Metric: "agility", Experience: Delta{Gained: 400}},
+			"cooking": {Metric: "cooking"},
+		},
+		Bosses: map[string]BossGains{
+			"zulrah":  {Metric: "zulrah", Kills: Delta{Gained: 12}},
+			"vorkath": {Metric: "vorkath", Kills: Delta{Gained: 30}},
+			"nex":     {Metric: "nex"},
+		},
+	}}
+
+	var skills []string
+	for _, skill := range gains.TopSkills(10) {
+		skills = append(skills, skill.Metric)
+	}
+	assert.Equal(t, []string{"mining", "agility", "attack"}, skills)
+	assert.Len(t, gains.TopSkills(2), 2)
+
+	bosses := gains.TopBosses(1)
+	require.Len(t, bosses, 1)
+	assert.Equal(t, "vorkath", bosses[0].Metric)
+	assert.Empty(t, (&PlayerGains{}).TopBosses(5))
+}
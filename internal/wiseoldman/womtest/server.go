@@ -0,0 +1,854 @@
+// Package womtest provides an in-process fake of the Wise Old Man v2 API for tests.
+//
+// The fake serves the endpoints wiseoldman.Client uses for players and competitions, including team
+// competitions. Group competitions aren't supported. Player
+// hiscores are scripted per metric over time, and the server has its own clock, so a test can
+// start a competition, move the clock forward and see the progress Wise Old Man would report.
+package womtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kaffeed/voidling/internal/wiseoldman"
+)
+
+// gainsPeriods are the spans of the gains periods Wise Old Man accepts.
+var gainsPeriods = map[wiseoldman.Period]time.Duration{
+	wiseoldman.PeriodDay:   24 * time.Hour,
+	wiseoldman.PeriodWeek:  7 * 24 * time.Hour,
+	wiseoldman.PeriodMonth: 31 * 24 * time.Hour,
+	wiseoldman.PeriodYear:  365 * 24 * time.Hour,
+}
+
+// skills are the metrics reported as skills; every other metric is reported as a boss.
+var skills = []string{
+	"overall", "attack", "defence", "strength", "hitpoints", "ranged", "prayer", "magic", "cooking",
+	"woodcutting", "fletching", "fishing", "firemaking", "crafting", "smithing", "mining", "herblore",
+	"agility", "thieving", "slayer", "farming", "runecrafting", "hunter", "construction",
+}
+
+// Server is a fake Wise Old Man API. Create one with NewServer and close it when done.
+type Server struct {
+	*httptest.Server
+
+	mu           sync.Mutex
+	offset       time.Duration
+	players      map[string]*player
+	competitions map[int64]*competition
+	nextID       int64
+}
+
+// point is a metric's value from a moment on.
+type point struct {
+	at    time.Time
+	value int64
+}
+
+type player struct {
+	id           int64
+	username     string
+	displayName  string
+	registeredAt time.Time
+	updatedAt    time.Time
+	// metrics holds each metric's scripted values, ordered by time.
+	metrics map[string][]point
+}
+
+type competition struct {
+	id           int64
+	title        string
+	metric       string
+	kind         string
+	startsAt     time.Time
+	endsAt       time.Time
+	code         string
+	createdAt    time.Time
+	updatedAt    time.Time
+	participants []string
+	// teams maps participants to their team in team competitions.
+	teams map[string]string
+}
+
+// NewServer starts a fake Wise Old Man API. Its clock starts at the current time.
+func NewServer() *Server {
+	s := &Server{
+		players:      make(map[string]*player),
+		competitions: make(map[int64]*competition),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /players/{username}", s.handleGetPlayer)
+	mux.HandleFunc("POST /players/{username}", s.handleUpdatePlayer)
+	mux.HandleFunc("GET /players/{username}/gained", s.handlePlayerGains)
+	mux.HandleFunc("POST /competitions", s.handleCreateCompetition)
+	mux.HandleFunc("GET /competitions/{id}", s.handleGetCompetition)
+	mux.HandleFunc("PUT /competitions/{id}", s.handleEditCompetition)
+	mux.HandleFunc("DELETE /competitions/{id}", s.handleDeleteCompetition)
+	mux.HandleFunc("POST /competitions/{id}/participants", s.handleAddParticipants)
+	mux.HandleFunc("DELETE /competitions/{id}/participants", s.handleRemoveParticipants)
+	mux.HandleFunc("POST /competitions/{id}/teams", s.handleAddTeams)
+	mux.HandleFunc("DELETE /competitions/{id}/teams", s.handleRemoveTeams)
+	mux.HandleFunc("POST /competitions/{id}/update-all", s.handleUpdateAllParticipants)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// Client returns a client for the fake. It isn't rate limited, so tests never wait.
+func (s *Server) Client(opts ...wiseoldman.Option) *wiseoldman.Client {
+	opts = append([]wiseoldman.Option{wiseoldman.WithBaseURL(s.URL), wiseoldman.WithRateLimit(math.MaxInt32)}, opts...)
+	return wiseoldman.NewClient(opts...)
+}
+
+// Now returns the fake's current time.
+func (s *Server) Now() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.now()
+}
+
+// Advance moves the fake's clock forward by d.
+func (s *Server) Advance(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.offset += d
+}
+
+// AddPlayer tracks a player with empty hiscores. Players are also tracked when a test scripts their
+// hiscores or the client updates them.
+func (s *Server) AddPlayer(username string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.track(username)
+}
+
+// SetMetric sets a player's hiscore value for metric from now on.
+func (s *Server) SetMetric(username, metric string, value int64) {
+	s.SetMetricAt(username, metric, s.Now(), value)
+}
+
+// SetMetricAt sets a player's hiscore value for metric from at on, scripting progress over time.
+// Experience for skills, kills for bosses.
+func (s *Server) SetMetricAt(username, metric string, at time.Time, value int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.track(username)
+	points := slices.DeleteFunc(p.metrics[metric], func(pt point) bool { return pt.at.Equal(at) })
+	points = append(points, point{at: at, value: value})
+	slices.SortFunc(points, func(a, b point) int { return a.at.Compare(b.at) })
+	p.metrics[metric] = points
+}
+
+// Gain adds amount to a player's current value for metric.
+func (s *Server) Gain(username, metric string, amount int64) {
+	s.mu.Lock()
+	now := s.now()
+	current := s.track(username).value(metric, now)
+	s.mu.Unlock()
+
+	s.SetMetricAt(username, metric, now, current+amount)
+}
+
+// Participants returns the usernames taking part in a competition, sorted. Deleted competitions
+// have none.
+func (s *Server) Participants(competitionID int64) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.competitions[competitionID]
+	if !ok {
+		return nil
+	}
+	participants := slices.Clone(c.participants)
+	slices.Sort(participants)
+	return participants
+}
+
+// Teams returns each team's usernames in a team competition, sorted.
+func (s *Server) Teams(competitionID int64) map[string][]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.competitions[competitionID]
+	if !ok {
+		return nil
+	}
+	teams := make(map[string][]string)
+	for _, username := range c.participants {
+		teams[c.teams[username]] = append(teams[c.teams[username]], username)
+	}
+	for _, members := range teams {
+		slices.Sort(members)
+	}
+	return teams
+}
+
+func (s *Server) handleGetPlayer(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.players[wiseoldman.StandardizeUsername(r.PathValue("username"))]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Player not found.")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.playerDetails(p))
+}
+
+func (s *Server) handleUpdatePlayer(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	username := r.PathValue("username")
+	if !validUsername(username) {
+		writeError(w, http.StatusBadRequest, "Validation error: Username must be between 1 and 12 characters long.")
+		return
+	}
+	p := s.track(username)
+	p.updatedAt = s.now()
+	writeJSON(w, http.StatusOK, s.playerDetails(p))
+}
+
+func (s *Server) handlePlayerGains(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.players[wiseoldman.StandardizeUsername(r.PathValue("username"))]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Player not found.")
+		return
+	}
+
+	now := s.now()
+	start, end := now, now
+	query := r.URL.Query()
+	switch {
+	case query.Get("period") != "":
+		span, ok := gainsPeriods[wiseoldman.Period(query.Get("period"))]
+		if !ok {
+			writeError(w, http.StatusBadRequest, "Invalid period: "+query.Get("period")+".")
+			return
+		}
+		start = now.Add(-span)
+	case query.Get("startDate") != "" && query.Get("endDate") != "":
+		var err error
+		if start, err = time.Parse(time.RFC3339, query.Get("startDate")); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid start date.")
+			return
+		}
+		if end, err = time.Parse(time.RFC3339, query.Get("endDate")); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid end date.")
+			return
+		}
+		if !start.Before(end) {
+			writeError(w, http.StatusBadRequest, "Start date must be before the end date.")
+			return
+		}
+		if end.After(now) {
+			end = now
+		}
+	default:
+		writeError(w, http.StatusBadRequest, "Parameter 'period' or parameters 'startDate' and 'endDate' are required.")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.playerGains(p, start, end))
+}
+
+func (s *Server) handleCreateCompetition(w http.ResponseWriter, r *http.Request) {
+	var req wiseoldman.CreateCompetitionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body.")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case strings.TrimSpace(req.Title) == "":
+		writeError(w, http.StatusBadRequest, "Parameter 'title' is required.")
+		return
+	case req.Metric == "":
+		writeError(w, http.StatusBadRequest, "Parameter 'metric' is required.")
+		return
+	case len(req.Participants) > 0 && len(req.Teams) > 0:
+		writeError(w, http.StatusBadRequest, "Cannot include both \"participants\" and \"teams\", they are mutually exclusive.")
+		return
+	case req.GroupID != nil:
+		writeError(w, http.StatusBadRequest, "Group competitions aren't supported by the fake.")
+		return
+	}
+
+	s.nextID++
+	c := &competition{
+		id:        s.nextID,
+		title:     strings.TrimSpace(req.Title),
+		metric:    req.Metric,
+		kind:      wiseoldman.CompetitionTypeClassic,
+		code:      fmt.Sprintf("%03d-%03d-%03d", s.nextID%1000, s.nextID*7%1000, s.nextID*13%1000),
+		createdAt: s.now(),
+		updatedAt: s.now(),
+		teams:     make(map[string]string),
+	}
+	if !s.setDates(w, c, req.StartsAt, req.EndsAt) {
+		return
+	}
+	if !c.startsAt.After(s.now()) {
+		writeError(w, http.StatusBadRequest, "Start date must be in the future.")
+		return
+	}
+	if len(req.Teams) > 0 {
+		c.kind = wiseoldman.CompetitionTypeTeam
+	}
+	for _, username := range req.Participants {
+		c.participants = appendParticipant(c.participants, s.track(username).username)
+	}
+	if _, err := s.addTeams(c, req.Teams); err != "" {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	s.competitions[c.id] = c
+
+	writeJSON(w, http.StatusCreated, wiseoldman.CreateCompetitionResponse{
+		Competition:      s.competitionDetails(c),
+		VerificationCode: c.code,
+	})
+}
+
+func (s *Server) handleGetCompetition(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.competition(r)
+	if !ok {
+		writeError(w, http.StatusNotFound, "Competition not found.")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.competitionDetails(c))
+}
+
+func (s *Server) handleEditCompetition(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var req wiseoldman.EditCompetitionRequest
+	c, ok := s.verify(w, r, &req, &req.VerificationCode)
+	if !ok {
+		return
+	}
+	switch {
+	case len(req.Participants) > 0 && len(req.Teams) > 0:
+		writeError(w, http.StatusBadRequest, "Cannot include both \"participants\" and \"teams\", they are mutually exclusive.")
+		return
+	case len(req.Participants) > 0 && c.kind == wiseoldman.CompetitionTypeTeam:
+		writeError(w, http.StatusBadRequest, "The competition type cannot be changed to classic.")
+		return
+	case len(req.Teams) > 0 && c.kind == wiseoldman.CompetitionTypeClassic:
+		writeError(w, http.StatusBadRequest, "The competition type cannot be changed to team.")
+		return
+	}
+
+	startsAt, endsAt := req.StartsAt, req.EndsAt
+	if startsAt == "" {
+		startsAt = c.startsAt.Format(time.RFC3339)
+	}
+	if endsAt == "" {
+		endsAt = c.endsAt.Format(time.RFC3339)
+	}
+	edited := *c
+	if !s.setDates(w, &edited, startsAt, endsAt) {
+		return
+	}
+	if title := strings.TrimSpace(req.Title); title != "" {
+		edited.title = title
+	}
+	if req.Metric != "" {
+		edited.metric = req.Metric
+	}
+	if len(req.Participants) > 0 {
+		edited.participants = nil
+		for _, username := range req.Participants {
+			edited.participants = appendParticipant(edited.participants, s.track(username).username)
+		}
+	}
+	if len(req.Teams) > 0 {
+		edited.participants, edited.teams = nil, make(map[string]string)
+		if _, err := s.addTeams(&edited, req.Teams); err != "" {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+	edited.updatedAt = s.now()
+	*c = edited
+
+	writeJSON(w, http.StatusOK, s.competitionDetails(c))
+}
+
+func (s *Server) handleDeleteCompetition(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var req wiseoldman.DeleteCompetitionRequest
+	c, ok := s.verify(w, r, &req, &req.VerificationCode)
+	if !ok {
+		return
+	}
+	delete(s.competitions, c.id)
+	writeJSON(w, http.StatusOK, map[string]string{"message": "Successfully deleted competition: " + c.title})
+}
+
+func (s *Server) handleAddParticipants(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var req wiseoldman.AddParticipantsRequest
+	c, ok := s.verify(w, r, &req, &req.VerificationCode)
+	switch {
+	case !ok:
+		return
+	case c.kind == wiseoldman.CompetitionTypeTeam:
+		writeError(w, http.StatusBadRequest, "Cannot add participants to a team competition.")
+		return
+	case len(req.Participants) == 0:
+		writeError(w, http.StatusBadRequest, "Empty participants list.")
+		return
+	}
+
+	added := 0
+	for _, username := range req.Participants {
+		name := s.track(username).username
+		if !slices.Contains(c.participants, name) {
+			c.participants = append(c.participants, name)
+			added++
+		}
+	}
+	if added == 0 {
+		writeError(w, http.StatusBadRequest, "All players given are already competing.")
+		return
+	}
+	writeUpdate(w, added, "Successfully added %d participants.")
+}
+
+func (s *Server) handleRemoveParticipants(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var req wiseoldman.RemoveParticipantsRequest
+	c, ok := s.verify(w, r, &req, &req.VerificationCode)
+	if !ok {
+		return
+	}
+
+	removed := c.remove(func(username string) bool {
+		return slices.ContainsFunc(req.Participants, func(name string) bool {
+			return wiseoldman.StandardizeUsername(name) == username
+		})
+	})
+	if removed == 0 {
+		writeError(w, http.StatusBadRequest, "None of the players given were competing.")
+		return
+	}
+	writeUpdate(w, removed, "Successfully removed %d participants.")
+}
+
+func (s *Server) handleAddTeams(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var req wiseoldman.AddTeamsRequest
+	c, ok := s.verify(w, r, &req, &req.VerificationCode)
+	switch {
+	case !ok:
+		return
+	case c.kind != wiseoldman.CompetitionTypeTeam:
+		writeError(w, http.StatusBadRequest, "Cannot add teams to a classic competition.")
+		return
+	case len(req.Teams) == 0:
+		writeError(w, http.StatusBadRequest, "Empty teams list.")
+		return
+	}
+
+	added, err := s.addTeams(c, req.Teams)
+	if err != "" {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeUpdate(w, added, "Successfully added %d participants.")
+}
+
+func (s *Server) handleRemoveTeams(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var req wiseoldman.RemoveTeamsRequest
+	c, ok := s.verify(w, r, &req, &req.VerificationCode)
+	switch {
+	case !ok:
+		return
+	case c.kind != wiseoldman.CompetitionTypeTeam:
+		writeError(w, http.StatusBadRequest, "Cannot remove teams from a classic competition.")
+		return
+	}
+
+	removed := c.remove(func(username string) bool {
+		return slices.ContainsFunc(req.TeamNames, func(team string) bool {
+			return strings.EqualFold(strings.TrimSpace(team), c.teams[username])
+		})
+	})
+	if removed == 0 {
+		writeError(w, http.StatusBadRequest, "No players were removed from the competition.")
+		return
+	}
+	writeUpdate(w, removed, "Successfully removed %d participants.")
+}
+
+func (s *Server) handleUpdateAllParticipants(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var req wiseoldman.UpdateAllParticipantsRequest
+	c, ok := s.verify(w, r, &req, &req.VerificationCode)
+	if !ok {
+		return
+	}
+	if len(c.participants) == 0 {
+		writeError(w, http.StatusBadRequest, "This competition has no outdated participants.")
+		return
+	}
+
+	for _, username := range c.participants {
+		s.players[username].updatedAt = s.now()
+	}
+	writeUpdate(w, len(c.participants), "%d outdated (updated < 60 mins ago) players are being updated.")
+}
+
+// now returns the fake's current time. The caller holds s.mu.
+func (s *Server) now() time.Time {
+	return time.Now().Add(s.offset)
+}
+
+// track returns the player called username, tracking them first if needed. The caller holds s.mu.
+func (s *Server) track(username string) *player {
+	name := wiseoldman.StandardizeUsername(username)
+	if p, ok := s.players[name]; ok {
+		return p
+	}
+
+	s.nextID++
+	p := &player{
+		id:           s.nextID,
+		username:     name,
+		displayName:  strings.TrimSpace(username),
+		registeredAt: s.now(),
+		updatedAt:    s.now(),
+		metrics:      make(map[string][]point),
+	}
+	s.players[name] = p
+	return p
+}
+
+// verify decodes the request body into body and looks up the competition named in the request
+// path. Unless it exists and code, which points into body, matches its verification code, an error
+// response is written. The caller holds s.mu.
+func (s *Server) verify(w http.ResponseWriter, r *http.Request, body any, code *string) (*competition, bool) {
+	if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body.")
+		return nil, false
+	}
+	c, ok := s.competition(r)
+	switch {
+	case !ok:
+		writeError(w, http.StatusNotFound, "Competition not found.")
+		return nil, false
+	case *code == "":
+		writeError(w, http.StatusBadRequest, "Parameter 'verificationCode' is required.")
+		return nil, false
+	case *code != c.code:
+		writeError(w, http.StatusBadRequest, "Incorrect verification code.")
+		return nil, false
+	}
+	return c, true
+}
+
+// setDates parses and validates a competition's dates, writing an error response if they're
+// invalid. The caller holds s.mu.
+func (s *Server) setDates(w http.ResponseWriter, c *competition, startsAt, endsAt string) bool {
+	start, startErr := time.Parse(time.RFC3339, startsAt)
+	end, endErr := time.Parse(time.RFC3339, endsAt)
+	switch {
+	case startErr != nil || endErr != nil:
+		writeError(w, http.StatusBadRequest, "Invalid dates.")
+		return false
+	case !end.After(start):
+		writeError(w, http.StatusBadRequest, "Start date must be before the end date.")
+		return false
+	}
+	c.startsAt, c.endsAt = start, end
+	return true
+}
+
+// addTeams adds teams' players to c and returns how many were added, or a message explaining why
+// none were. The caller holds s.mu.
+func (s *Server) addTeams(c *competition, teams []wiseoldman.Team) (int, string) {
+	var names, players []string
+	for _, team := range teams {
+		name := strings.TrimSpace(team.Name)
+		switch {
+		case name == "":
+			return 0, "Team names can't be empty."
+		case len(team.Participants) == 0:
+			return 0, "All teams must have a valid non-empty participants array."
+		case slices.ContainsFunc(names, func(n string) bool { return strings.EqualFold(n, name) }):
+			return 0, "Found repeated team names: [" + name + "]"
+		}
+		for _, existing := range c.teams {
+			if strings.EqualFold(existing, name) {
+				return 0, "Found repeated team names: [" + name + "]"
+			}
+		}
+		names = append(names, name)
+		for _, username := range team.Participants {
+			player := wiseoldman.StandardizeUsername(username)
+			if slices.Contains(players, player) || slices.Contains(c.participants, player) {
+				return 0, "Found repeated usernames: [" + player + "]"
+			}
+			players = append(players, player)
+		}
+	}
+
+	added := 0
+	for i, team := range teams {
+		for _, username := range team.Participants {
+			player := s.track(username).username
+			c.participants = append(c.participants, player)
+			c.teams[player] = names[i]
+			added++
+		}
+	}
+	return added, ""
+}
+
+// remove drops the participants matching drop and returns how many were dropped.
+func (c *competition) remove(drop func(username string) bool) int {
+	before := len(c.participants)
+	c.participants = slices.DeleteFunc(c.participants, func(username string) bool {
+		if drop(username) {
+			delete(c.teams, username)
+			return true
+		}
+		return false
+	})
+	return before - len(c.participants)
+}
+
+// competition looks up the competition named in the request path. The caller holds s.mu.
+func (s *Server) competition(r *http.Request) (*competition, bool) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		return nil, false
+	}
+	c, ok := s.competitions[id]
+	return c, ok
+}
+
+// playerDetails renders p as Wise Old Man would at the current time. The caller holds s.mu.
+func (s *Server) playerDetails(p *player) wiseoldman.Player {
+	now := s.now()
+	data := wiseoldman.SnapshotData{
+		Skills: make(map[string]wiseoldman.SkillData),
+		Bosses: make(map[string]wiseoldman.BossData),
+	}
+
+	for _, skill := range skills[1:] {
+		exp := p.value(skill, now)
+		data.Skills[skill] = wiseoldman.SkillData{Metric: skill, Experience: exp, Level: levelForExp(exp), Rank: -1}
+	}
+	overall := p.overall(now)
+	data.Skills["overall"] = wiseoldman.SkillData{Metric: "overall", Experience: overall, Rank: -1}
+
+	for metric := range p.metrics {
+		if !slices.Contains(skills, metric) {
+			data.Bosses[metric] = wiseoldman.BossData{Metric: metric, Kills: int(p.value(metric, now)), Rank: -1}
+		}
+	}
+
+	return wiseoldman.Player{
+		ID:           p.id,
+		Username:     p.username,
+		DisplayName:  p.displayName,
+		Type:         "regular",
+		Build:        "main",
+		Status:       "active",
+		Exp:          overall,
+		RegisteredAt: p.registeredAt,
+		UpdatedAt:    p.updatedAt,
+		LatestSnapshot: &wiseoldman.Snapshot{
+			PlayerID:  p.id,
+			CreatedAt: p.updatedAt,
+			Data:      data,
+		},
+	}
+}
+
+// playerGains renders what p gained between start and end. The caller holds s.mu.
+func (s *Server) playerGains(p *player, start, end time.Time) wiseoldman.PlayerGains {
+	data := wiseoldman.GainsData{
+		Skills: make(map[string]wiseoldman.SkillGains),
+		Bosses: make(map[string]wiseoldman.BossGains),
+	}
+
+	data.Skills["overall"] = wiseoldman.SkillGains{Metric: "overall", Experience: delta(p.overall(start), p.overall(end))}
+	for _, skill := range skills[1:] {
+		from, to := p.value(skill, start), p.value(skill, end)
+		data.Skills[skill] = wiseoldman.SkillGains{
+			Metric:     skill,
+			Experience: delta(from, to),
+			Level:      delta(int64(levelForExp(from)), int64(levelForExp(to))),
+		}
+	}
+	for metric := range p.metrics {
+		if !slices.Contains(skills, metric) {
+			data.Bosses[metric] = wiseoldman.BossGains{Metric: metric, Kills: delta(p.value(metric, start), p.value(metric, end))}
+		}
+	}
+
+	return wiseoldman.PlayerGains{StartsAt: &start, EndsAt: &end, Data: data}
+}
+
+// competitionDetails renders c with standings as of the current time, best first. The caller
+// holds s.mu.
+func (s *Server) competitionDetails(c *competition) wiseoldman.Competition {
+	end := s.now()
+	if end.After(c.endsAt) {
+		end = c.endsAt
+	}
+	participations := make([]wiseoldman.CompetitionParticipation, 0, len(c.participants))
+	for _, username := range c.participants {
+		p := s.players[username]
+		progress := wiseoldman.ParticipationProgress{Start: p.value(c.metric, c.startsAt), End: p.value(c.metric, c.startsAt)}
+		if end.After(c.startsAt) {
+			progress.End = p.value(c.metric, end)
+		}
+		progress.Gained = progress.End - progress.Start
+
+		participation := wiseoldman.CompetitionParticipation{
+			PlayerID:      p.id,
+			CompetitionID: c.id,
+			CreatedAt:     c.createdAt,
+			UpdatedAt:     p.updatedAt,
+			Player:        s.playerDetails(p),
+			Progress:      &progress,
+		}
+		if team, ok := c.teams[username]; ok {
+			participation.TeamName = &team
+		}
+		if slices.Contains(skills, c.metric) {
+			start, end := int64(levelForExp(progress.Start)), int64(levelForExp(progress.End))
+			participation.Levels = &wiseoldman.ParticipationProgress{Start: start, End: end, Gained: end - start}
+		}
+		participations = append(participations, participation)
+	}
+	slices.SortStableFunc(participations, func(a, b wiseoldman.CompetitionParticipation) int {
+		return int(b.Progress.Gained - a.Progress.Gained)
+	})
+
+	return wiseoldman.Competition{
+		ID:               c.id,
+		Title:            c.title,
+		Metric:           c.metric,
+		Type:             c.kind,
+		StartsAt:         c.startsAt,
+		EndsAt:           c.endsAt,
+		CreatedAt:        c.createdAt,
+		UpdatedAt:        c.updatedAt,
+		Visible:          true,
+		ParticipantCount: len(participations),
+		Participations:   participations,
+	}
+}
+
+// value returns the player's value for metric at a moment, or 0 before anything was scripted.
+func (p *player) value(metric string, at time.Time) int64 {
+	var value int64
+	for _, pt := range p.metrics[metric] {
+		if pt.at.After(at) {
+			break
+		}
+		value = pt.value
+	}
+	return value
+}
+
+// overall returns the player's total experience at a moment: the scripted overall value, or the
+// sum of their skills if it isn't scripted.
+func (p *player) overall(at time.Time) int64 {
+	if _, scripted := p.metrics["overall"]; scripted {
+		return p.value("overall", at)
+	}
+	var total int64
+	for _, skill := range skills[1:] {
+		total += p.value(skill, at)
+	}
+	return total
+}
+
+// delta is the change of a metric from start to end.
+func delta(start, end int64) wiseoldman.Delta {
+	return wiseoldman.Delta{Start: start, End: end, Gained: end - start}
+}
+
+// appendParticipant adds username to participants unless it's already there.
+func appendParticipant(participants []string, username string) []string {
+	if slices.Contains(participants, username) {
+		return participants
+	}
+	return append(participants, username)
+}
+
+// validUsername reports whether username could be an Old School RuneScape name.
+func validUsername(username string) bool {
+	name := wiseoldman.StandardizeUsername(username)
+	return name != "" && len(name) <= 12
+}
+
+// levelForExp converts skill experience to a level between 1 and 99.
+func levelForExp(exp int64) int {
+	points := 0.0
+	for level := 1; level < 99; level++ {
+		points += math.Floor(float64(level) + 300*math.Pow(2, float64(level)/7))
+		if exp < int64(points/4) {
+			return level
+		}
+	}
+	return 99
+}
+
+// writeJSON writes body with status.
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// writeUpdate writes the response to a change of count participants, formatting message with count.
+func writeUpdate(w http.ResponseWriter, count int, message string) {
+	writeJSON(w, http.StatusOK, wiseoldman.CompetitionUpdateResponse{
+		Count:   count,
+		Message: fmt.Sprintf(message, count),
+	})
+}
+
+// writeError writes an error response shaped like Wise Old Man's.
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"message": message})
+}
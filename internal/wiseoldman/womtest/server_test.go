@@ -0,0 +1,270 @@
+package womtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaffeed/voidling/internal/wiseoldman"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlayers(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+	ctx := t.Context()
+
+	_, err := client.GetPlayer(ctx, "Zezima")
+	assert.ErrorIs(t, err, wiseoldman.ErrPlayerNotFound)
+
+	server.SetMetric("Zezima", "attack", 13_034_431)
+	server.SetMetric("Zezima", "zulrah", 250)
+
+	player, err := client.UpdatePlayer(ctx, "zezima")
+	require.NoError(t, err)
+	assert.Equal(t, "zezima", player.Username)
+	assert.Equal(t, "Zezima", player.DisplayName)
+	require.NotNil(t, player.LatestSnapshot)
+	assert.Equal(t, 99, player.LatestSnapshot.Data.Skills["attack"].Level)
+	assert.Equal(t, 1, player.LatestSnapshot.Data.Skills["mining"].Level)
+	assert.Equal(t, int64(13_034_431), player.Exp)
+	assert.Equal(t, 250, player.LatestSnapshot.Data.Bosses["zulrah"].Kills)
+
+	// Updating tracks unknown players
+	player, err = client.UpdatePlayer(ctx, "Lynx Titan")
+	require.NoError(t, err)
+	assert.Equal(t, "lynx titan", player.Username)
+}
+
+func TestCompetitionProgress(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+	ctx := t.Context()
+
+	start := server.Now().Add(time.Hour)
+	server.SetMetric("Zezima", "zulrah", 100)
+	server.SetMetricAt("Zezima", "zulrah", start.Add(time.Hour), 150)
+	server.SetMetricAt("Zezima", "zulrah", start.Add(48*time.Hour), 500) // after the end
+	server.SetMetricAt("Woox", "zulrah", start.Add(-time.Minute), 10)    // before the start
+	server.SetMetricAt("Woox", "zulrah", start.Add(2*time.Hour), 90)
+
+	created, err := client.CreateCompetition(ctx, wiseoldman.CreateCompetitionRequest{
+		Title:        "Boss of the Week - Zulrah",
+		Metric:       "zulrah",
+		StartsAt:     start.Format(time.RFC3339),
+		EndsAt:       start.Add(24 * time.Hour).Format(time.RFC3339),
+		Participants: []string{"Zezima"},
+	})
+	require.NoError(t, err)
+	id := created.Competition.ID
+
+	_, err = client.AddParticipants(ctx, id, []string{"Woox"}, "wrong-code")
+	assert.ErrorIs(t, err, wiseoldman.ErrUnexpectedStatus)
+	added, err := client.AddParticipants(ctx, id, []string{"Woox", "Zezima"}, created.VerificationCode)
+	require.NoError(t, err)
+	assert.Equal(t, 1, added.Count)
+	assert.Equal(t, []string{"woox", "zezima"}, server.Participants(id))
+
+	gains := func() map[string]int64 {
+		t.Helper()
+		comp, err := client.GetCompetition(wiseoldman.NoCache(ctx), id)
+		require.NoError(t, err)
+		gained := make(map[string]int64)
+		for _, p := range comp.Participations {
+			gained[p.Player.Username] = p.Progress.Gained
+		}
+		return gained
+	}
+
+	assert.Equal(t, map[string]int64{"zezima": 0, "woox": 0}, gains())
+	server.Advance(3 * time.Hour)
+	assert.Equal(t, map[string]int64{"zezima": 50, "woox": 80}, gains())
+	server.Advance(72 * time.Hour)
+	assert.Equal(t, map[string]int64{"zezima": 50, "woox": 80}, gains())
+
+	comp, err := client.GetCompetition(wiseoldman.NoCache(ctx), id)
+	require.NoError(t, err)
+	assert.Equal(t, "woox", comp.Participations[0].Player.Username, "standings are sorted by gains")
+}
+
+func TestCreateCompetitionValidation(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	past := server.Now().Add(-time.Hour)
+	_, err := client.CreateCompetition(t.Context(), wiseoldman.CreateCompetitionRequest{
+		Title:    "Too late",
+		Metric:   "zulrah",
+		StartsAt: past.Format(time.RFC3339),
+		EndsAt:   past.Add(24 * time.Hour).Format(time.RFC3339),
+	})
+
+	var statusErr *wiseoldman.StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, 400, statusErr.StatusCode)
+	assert.Contains(t, statusErr.Body, "Start date must be in the future.")
+}
+
+func TestGain(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.SetMetric("Zezima", "mining", 1000)
+	server.Advance(time.Minute)
+	server.Gain("Zezima", "mining", 500)
+
+	player, err := server.Client().GetPlayer(t.Context(), "Zezima")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1500), player.LatestSnapshot.Data.Skills["mining"].Experience)
+}
+
+// createCompetition creates a competition starting in an hour and lasting a day.
+func createCompetition(t *testing.T, server *Server, client *wiseoldman.Client, req wiseoldman.CreateCompetitionRequest) *wiseoldman.CreateCompetitionResponse {
+	t.Helper()
+	start := server.Now().Add(time.Hour)
+	req.Title = "Skill of the Week - Mining"
+	req.Metric = "mining"
+	req.StartsAt = start.Format(time.RFC3339)
+	req.EndsAt = start.Add(24 * time.Hour).Format(time.RFC3339)
+	created, err := client.CreateCompetition(t.Context(), req)
+	require.NoError(t, err)
+	return created
+}
+
+func TestTeamCompetition(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+	ctx := t.Context()
+
+	created := createCompetition(t, server, client, wiseoldman.CreateCompetitionRequest{
+		Teams: []wiseoldman.Team{
+			{Name: "Red", Participants: []string{"Zezima", "Woox"}},
+			{Name: "Blue", Participants: []string{"Lynx Titan"}},
+		},
+	})
+	id, code := created.Competition.ID, created.VerificationCode
+	assert.True(t, created.Competition.IsTeamCompetition())
+
+	_, err := client.AddParticipants(ctx, id, []string{"B0aty"}, code)
+	assert.ErrorIs(t, err, wiseoldman.ErrUnexpectedStatus, "team competitions take teams, not participants")
+	_, err = client.AddTeams(ctx, id, []wiseoldman.Team{{Name: "Green", Participants: []string{"Woox"}}}, code)
+	assert.ErrorIs(t, err, wiseoldman.ErrUnexpectedStatus, "players can only be on one team")
+
+	added, err := client.AddTeams(ctx, id, []wiseoldman.Team{{Name: "Green", Participants: []string{"B0aty", "Framed"}}}, code)
+	require.NoError(t, err)
+	assert.Equal(t, 2, added.Count)
+	removed, err := client.RemoveTeams(ctx, id, []string{"blue"}, code)
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed.Count)
+	assert.Equal(t, map[string][]string{"Red": {"woox", "zezima"}, "Green": {"b0aty", "framed"}}, server.Teams(id))
+
+	comp, err := client.GetCompetition(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, wiseoldman.CompetitionTypeTeam, comp.Type)
+	teams := make(map[string]string)
+	for _, p := range comp.Participations {
+		require.NotNil(t, p.TeamName)
+		teams[p.Player.Username] = *p.TeamName
+		require.NotNil(t, p.Levels, "skill competitions report levels")
+	}
+	assert.Equal(t, map[string]string{"zezima": "Red", "woox": "Red", "b0aty": "Green", "framed": "Green"}, teams)
+}
+
+func TestEditCompetition(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+	ctx := t.Context()
+
+	created := createCompetition(t, server, client, wiseoldman.CreateCompetitionRequest{
+		Participants: []string{"Zezima", "Woox"},
+	})
+	id, code := created.Competition.ID, created.VerificationCode
+
+	_, err := client.EditCompetition(ctx, id, wiseoldman.EditCompetitionRequest{VerificationCode: "wrong", Title: "Nope"})
+	assert.ErrorIs(t, err, wiseoldman.ErrUnexpectedStatus)
+
+	// Fetch the competition so the edit has a cached copy to replace
+	_, err = client.GetCompetition(ctx, id)
+	require.NoError(t, err)
+
+	edited, err := client.EditCompetition(ctx, id, wiseoldman.EditCompetitionRequest{
+		VerificationCode: code,
+		Title:            "Skill of the Week - Fishing",
+		Metric:           "fishing",
+		Participants:     []string{"Lynx Titan"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Skill of the Week - Fishing", edited.Title)
+	assert.True(t, edited.StartsAt.Equal(created.Competition.StartsAt), "unset dates are unchanged")
+
+	comp, err := client.GetCompetition(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "fishing", comp.Metric)
+	require.Len(t, comp.Participations, 1)
+	assert.Equal(t, "lynx titan", comp.Participations[0].Player.Username)
+}
+
+func TestChangeParticipants(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+	ctx := t.Context()
+
+	created := createCompetition(t, server, client, wiseoldman.CreateCompetitionRequest{
+		Participants: []string{"Zezima", "Woox", "Lynx Titan"},
+	})
+	id, code := created.Competition.ID, created.VerificationCode
+
+	removed, err := client.RemoveParticipants(ctx, id, []string{"WOOX", "B0aty"}, code)
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed.Count)
+	assert.Equal(t, []string{"lynx titan", "zezima"}, server.Participants(id))
+
+	updated, err := client.UpdateAllParticipants(ctx, id, code)
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated.Count)
+
+	require.NoError(t, client.DeleteCompetition(ctx, id, code))
+	assert.Empty(t, server.Participants(id))
+	_, err = client.GetCompetition(ctx, id)
+	assert.ErrorIs(t, err, wiseoldman.ErrCompetitionNotFound)
+	assert.ErrorIs(t, client.DeleteCompetition(ctx, id, code), wiseoldman.ErrCompetitionNotFound)
+}
+
+func TestPlayerGains(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+	ctx := t.Context()
+
+	now := server.Now()
+	server.SetMetricAt("Zezima", "mining", now.Add(-10*24*time.Hour), 1_000_000)
+	server.SetMetricAt("Zezima", "mining", now.Add(-2*24*time.Hour), 1_500_000)
+	server.SetMetricAt("Zezima", "attack", now.Add(-time.Hour), 100)
+	server.SetMetricAt("Zezima", "zulrah", now.Add(-3*time.Hour), 40)
+
+	gains, err := client.GetPlayerGains(ctx, "zezima", wiseoldman.PeriodWeek)
+	require.NoError(t, err)
+	assert.Equal(t, int64(500_000), gains.Data.Skills["mining"].Experience.Gained)
+	assert.Equal(t, int64(4), gains.Data.Skills["mining"].Level.Gained) // 73 to 77
+	assert.Equal(t, int64(500_100), gains.Data.Skills["overall"].Experience.Gained)
+	assert.Equal(t, int64(40), gains.Data.Bosses["zulrah"].Kills.Gained)
+
+	gains, err = client.GetPlayerGainsBetween(ctx, "zezima", now.Add(-2*time.Hour), now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), gains.Data.Skills["mining"].Experience.Gained)
+	assert.Equal(t, int64(100), gains.Data.Skills["attack"].Experience.Gained)
+	assert.Equal(t, int64(0), gains.Data.Bosses["zulrah"].Kills.Gained)
+	require.NotNil(t, gains.EndsAt)
+	assert.False(t, gains.EndsAt.After(server.Now()), "gains end now at the latest")
+
+	_, err = client.GetPlayerGains(ctx, "zezima", "fortnight")
+	assert.ErrorIs(t, err, wiseoldman.ErrUnexpectedStatus)
+	_, err = client.GetPlayerGains(ctx, "Woox", wiseoldman.PeriodDay)
+	assert.ErrorIs(t, err, wiseoldman.ErrPlayerNotFound)
+}
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE guild_config ADD COLUMN nickname_template TEXT;
+
+-- +goose Down
+ALTER TABLE guild_config DROP COLUMN nickname_template;
@@ -0,0 +1,45 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE guild_config ADD COLUMN warning_expiry_days INTEGER;
+
+-- Escalation rules: when a member reaches `threshold` active warnings, `action` is taken
+CREATE TABLE warning_escalation_rules (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    guild_id INTEGER NOT NULL,
+    threshold INTEGER NOT NULL,
+    action TEXT NOT NULL CHECK(action IN ('timeout', 'remove_role', 'kick_suggestion')),
+    duration_minutes INTEGER,
+    role_id INTEGER,
+    created_by INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_warning_escalation_rules_guild ON warning_escalation_rules(guild_id, threshold);
+
+-- Every automatic action taken by an escalation rule, so it can be reviewed and reverted
+CREATE TABLE warning_actions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    guild_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    warning_id INTEGER NOT NULL,
+    rule_id INTEGER,
+    action TEXT NOT NULL,
+    duration_minutes INTEGER,
+    role_id INTEGER,
+    status TEXT NOT NULL CHECK(status IN ('applied', 'suggested', 'failed', 'reverted')),
+    error TEXT,
+    reverted_by INTEGER,
+    reverted_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (rule_id) REFERENCES warning_escalation_rules(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_warning_actions_guild_user ON warning_actions(guild_id, user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS warning_actions;
+DROP TABLE IF EXISTS warning_escalation_rules;
+ALTER TABLE guild_config DROP COLUMN warning_expiry_days;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE audit_log (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    guild_id INTEGER NOT NULL,
+    actor_id INTEGER NOT NULL,
+    action TEXT NOT NULL,
+    target TEXT,
+    before_value TEXT,
+    after_value TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_audit_log_guild_created ON audit_log(guild_id, created_at);
+CREATE INDEX idx_audit_log_guild_actor ON audit_log(guild_id, actor_id);
+
+ALTER TABLE guild_config ADD COLUMN audit_log_channel_id INTEGER;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE guild_config DROP COLUMN audit_log_channel_id;
+DROP TABLE IF EXISTS audit_log;
+-- +goose StatementEnd
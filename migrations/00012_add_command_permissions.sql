@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Per-guild overrides of who may run a command or subcommand (e.g. "botw finish", "warn add").
+-- When a command has overrides, only the listed roles and users (and server administrators) may run it.
+CREATE TABLE command_permissions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    guild_id INTEGER NOT NULL,
+    command TEXT NOT NULL,
+    subject_type TEXT NOT NULL CHECK(subject_type IN ('role', 'user')),
+    subject_id INTEGER NOT NULL,
+    created_by INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(guild_id, command, subject_type, subject_id)
+);
+
+CREATE INDEX idx_command_permissions_guild ON command_permissions(guild_id, command);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS command_permissions;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Snapshots of a guild's full settings (as exported by /config export), taken before every change
+-- so /config rollback can restore an earlier configuration.
+CREATE TABLE guild_config_history (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    guild_id INTEGER NOT NULL,
+    settings TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    changed_by INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_guild_config_history_guild ON guild_config_history(guild_id, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS guild_config_history;
+-- +goose StatementEnd
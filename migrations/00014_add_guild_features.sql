@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Features a guild has switched off with /config features (e.g. "botw", "welcome-dm").
+-- Every feature is enabled unless the guild has a row here.
+CREATE TABLE guild_disabled_features (
+    guild_id INTEGER NOT NULL,
+    feature TEXT NOT NULL,
+    disabled_by INTEGER NOT NULL,
+    disabled_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (guild_id, feature)
+);
+
+-- Remember which guild a mass was scheduled in so reminders can honour its feature flags.
+-- Existing events keep a NULL guild and are treated as enabled.
+ALTER TABLE schedulable_events ADD COLUMN guild_id INTEGER;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE schedulable_events DROP COLUMN guild_id;
+DROP TABLE IF EXISTS guild_disabled_features;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Per-guild onboarding for new members. Empty templates fall back to the built-in welcome DM.
+-- checklist is a comma-separated list of onboarding steps ("link-rsn", "set-timezone",
+-- "notification-roles"); an empty string means no checklist.
+CREATE TABLE guild_welcome_settings (
+    guild_id INTEGER PRIMARY KEY,
+    dm_title TEXT,
+    dm_message TEXT,
+    channel_id INTEGER,
+    channel_message TEXT,
+    checklist TEXT NOT NULL DEFAULT 'link-rsn',
+    linked_role_id INTEGER,
+    updated_by INTEGER NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS guild_welcome_settings;
+-- +goose StatementEnd
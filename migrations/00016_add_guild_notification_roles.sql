@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Roles pinged for a specific kind of event (e.g. "MASS", "WILDY_WEDNESDAY").
+-- Event types without a row here ping guild_config.event_notification_role_id instead.
+CREATE TABLE guild_notification_roles (
+    guild_id INTEGER NOT NULL,
+    event_type TEXT NOT NULL,
+    role_id INTEGER NOT NULL,
+    updated_by INTEGER NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (guild_id, event_type)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS guild_notification_roles;
+-- +goose StatementEnd
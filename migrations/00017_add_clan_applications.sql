@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Where applications are reviewed, what an approved applicant gets, and the minimum stats
+-- applicants should have. Requirements of 0 are not checked.
+CREATE TABLE guild_application_settings (
+    guild_id INTEGER PRIMARY KEY,
+    review_channel_id INTEGER,
+    approved_role_id INTEGER,
+    min_total_level INTEGER NOT NULL DEFAULT 0,
+    min_combat_level INTEGER NOT NULL DEFAULT 0,
+    min_ehp REAL NOT NULL DEFAULT 0,
+    updated_by INTEGER NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Clan applications submitted through the apply button.
+-- total_level, combat_level and ehp are Wise Old Man stats at the time of applying.
+CREATE TABLE clan_applications (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    guild_id INTEGER NOT NULL,
+    discord_user_id INTEGER NOT NULL,
+    rsn TEXT NOT NULL,
+    claimed_total_level INTEGER NOT NULL,
+    playtime TEXT NOT NULL,
+    referral TEXT NOT NULL,
+    total_level INTEGER NOT NULL,
+    combat_level INTEGER NOT NULL,
+    ehp REAL NOT NULL,
+    meets_requirements BOOLEAN NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    review_channel_id INTEGER,
+    review_message_id INTEGER,
+    reviewed_by INTEGER,
+    review_reason TEXT,
+    reviewed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_clan_applications_guild_user ON clan_applications(guild_id, discord_user_id, status);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_clan_applications_guild_user;
+DROP TABLE IF EXISTS clan_applications;
+DROP TABLE IF EXISTS guild_application_settings;
+-- +goose StatementEnd
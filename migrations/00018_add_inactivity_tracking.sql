@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Where and how inactivity reports are posted. Members whose XP hasn't changed in
+-- threshold_days are reported; nudge_enabled also DMs them a reminder.
+CREATE TABLE guild_inactivity_settings (
+    guild_id INTEGER PRIMARY KEY,
+    report_channel_id INTEGER,
+    threshold_days INTEGER NOT NULL DEFAULT 30,
+    nudge_enabled BOOLEAN NOT NULL DEFAULT 0,
+    last_report_at TIMESTAMP,
+    updated_by INTEGER NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Wise Old Man activity for linked RSNs, refreshed by the inactivity job so reports
+-- don't need a lookup per member. runescape_name is lowercase.
+CREATE TABLE player_activity (
+    runescape_name TEXT PRIMARY KEY,
+    last_changed_at TIMESTAMP,
+    wom_updated_at TIMESTAMP NOT NULL,
+    checked_at TIMESTAMP NOT NULL
+);
+
+-- When a member was last DMed about being inactive, so they are nudged once per inactive spell.
+CREATE TABLE inactivity_nudges (
+    guild_id INTEGER NOT NULL,
+    discord_member_id INTEGER NOT NULL,
+    nudged_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (guild_id, discord_member_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS inactivity_nudges;
+DROP TABLE IF EXISTS player_activity;
+DROP TABLE IF EXISTS guild_inactivity_settings;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The Wise Old Man group a guild's clan is tracked in. The verification code lets the
+-- bot add members and create group competitions, so it is never exported or shown.
+CREATE TABLE guild_wom_groups (
+    guild_id INTEGER PRIMARY KEY,
+    group_id INTEGER NOT NULL,
+    verification_code TEXT NOT NULL,
+    updated_by INTEGER NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Set for group competitions, which members join by being added to the group.
+ALTER TABLE wom_competitions ADD COLUMN wom_group_id INTEGER;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE wom_competitions DROP COLUMN wom_group_id;
+DROP TABLE IF EXISTS guild_wom_groups;
+-- +goose StatementEnd
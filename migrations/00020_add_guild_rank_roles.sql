@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Discord roles mirroring ranks in the guild's Wise Old Man group (e.g. "captain").
+-- Linked members get the roles for their rank and lose the mapped roles they no longer hold.
+CREATE TABLE guild_rank_roles (
+    guild_id INTEGER NOT NULL,
+    wom_role TEXT NOT NULL,
+    role_id INTEGER NOT NULL,
+    updated_by INTEGER NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (guild_id, wom_role)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS guild_rank_roles;
+-- +goose StatementEnd
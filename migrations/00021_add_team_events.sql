@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Team BOTW/SOTW events. Wise Old Man only creates team competitions with players on a
+-- team, so the competition is created when the first member joins and edited as others join.
+CREATE TABLE team_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    guild_id INTEGER NOT NULL,
+    type TEXT NOT NULL CHECK(type IN ('BOSS_OF_THE_WEEK', 'SKILL_OF_THE_WEEK')),
+    metric TEXT NOT NULL,
+    title TEXT NOT NULL,
+    discord_thread_id TEXT NOT NULL,
+    balanced BOOLEAN NOT NULL DEFAULT 0,
+    ends_at TIMESTAMP NOT NULL,
+    wom_competition_id INTEGER,
+    created_by INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_team_events_guild_type ON team_events(guild_id, type);
+
+-- A team event's teams, in the order the coordinator named them.
+CREATE TABLE team_event_teams (
+    team_event_id INTEGER NOT NULL,
+    position INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    PRIMARY KEY (team_event_id, position),
+    FOREIGN KEY (team_event_id) REFERENCES team_events(id) ON DELETE CASCADE
+);
+
+-- Members who joined a team event. score is their KC or XP when they joined, which
+-- balanced events use to even out the teams.
+CREATE TABLE team_event_members (
+    team_event_id INTEGER NOT NULL,
+    account_link_id INTEGER NOT NULL,
+    team_position INTEGER NOT NULL,
+    score INTEGER NOT NULL DEFAULT 0,
+    joined_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (team_event_id, account_link_id),
+    FOREIGN KEY (team_event_id) REFERENCES team_events(id) ON DELETE CASCADE,
+    FOREIGN KEY (account_link_id) REFERENCES account_links(id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS team_event_members;
+DROP TABLE IF EXISTS team_event_teams;
+DROP TABLE IF EXISTS team_events;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Final standings of finished BOTW/SOTW competitions for linked participants, so profiles can
+-- show a member's event history after the Wise Old Man competition is gone.
+CREATE TABLE event_results (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    guild_id INTEGER NOT NULL,
+    wom_competition_id INTEGER NOT NULL,
+    type TEXT NOT NULL CHECK(type IN ('BOSS_OF_THE_WEEK', 'SKILL_OF_THE_WEEK')),
+    metric TEXT NOT NULL,
+    account_link_id INTEGER NOT NULL,
+    placement INTEGER NOT NULL,
+    gained INTEGER NOT NULL,
+    finished_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(wom_competition_id, account_link_id),
+    FOREIGN KEY (account_link_id) REFERENCES account_links(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_event_results_guild_account ON event_results(guild_id, account_link_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS event_results;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Masses used to be stored with the scheduler's UTC offset (e.g. "2024-05-15 20:00:00+02:00").
+-- Start times are compared as text against UTC bounds now, so rewrite the old rows in UTC, in the
+-- same format the driver writes. Masses are scheduled to the minute, so no fractions are lost.
+UPDATE schedulable_events
+SET scheduled_at = strftime('%Y-%m-%d %H:%M:%S', scheduled_at) || '+00:00'
+WHERE scheduled_at NOT LIKE '%+00:00'
+  AND strftime('%Y-%m-%d %H:%M:%S', scheduled_at) IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- The original offsets aren't kept; UTC values still describe the same moments.
+SELECT 1;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- When a coordinator finished the team event. Finished events stop accepting joins, even if
+-- their end date hasn't passed yet.
+ALTER TABLE team_events ADD COLUMN finished_at TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE team_events DROP COLUMN finished_at;
+-- +goose StatementEnd
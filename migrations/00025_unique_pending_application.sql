@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A member may only have one application waiting for review per guild. Double submissions made
+-- before this index existed are denied, keeping the earliest one pending.
+UPDATE clan_applications
+SET status = 'denied',
+    review_reason = 'Duplicate of an earlier application',
+    reviewed_at = CURRENT_TIMESTAMP
+WHERE status = 'pending'
+  AND EXISTS (
+    SELECT 1 FROM clan_applications earlier
+    WHERE earlier.guild_id = clan_applications.guild_id
+      AND earlier.discord_user_id = clan_applications.discord_user_id
+      AND earlier.status = 'pending'
+      AND earlier.id < clan_applications.id
+  );
+
+CREATE UNIQUE INDEX idx_clan_applications_one_pending ON clan_applications(guild_id, discord_user_id) WHERE status = 'pending';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_clan_applications_one_pending;
+-- +goose StatementEnd
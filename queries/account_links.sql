@@ -42,3 +42,13 @@ ORDER BY created_at DESC;
 SELECT * FROM account_links
 WHERE runescape_name = ? AND is_active = 1
 LIMIT 1;
+
+-- name: GetAccountLinksByUsername :many
+SELECT * FROM account_links
+WHERE LOWER(runescape_name) = LOWER(?)
+ORDER BY created_at DESC;
+
+-- name: GetActiveAccountLinks :many
+SELECT * FROM account_links
+WHERE is_active = 1
+ORDER BY runescape_name;
@@ -0,0 +1,13 @@
+-- name: CreateAuditLogEntry :one
+INSERT INTO audit_log (guild_id, actor_id, action, target, before_value, after_value)
+VALUES (?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: ListAuditLog :many
+SELECT * FROM audit_log
+WHERE guild_id = sqlc.arg(guild_id)
+  AND (CAST(sqlc.narg(actor_id) AS INTEGER) IS NULL OR actor_id = sqlc.narg(actor_id))
+  AND (CAST(sqlc.narg(action) AS TEXT) IS NULL OR substr(action, 1, length(sqlc.narg(action))) = LOWER(sqlc.narg(action)))
+  AND (CAST(sqlc.narg(since) AS TIMESTAMP) IS NULL OR created_at >= sqlc.narg(since))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(row_limit);
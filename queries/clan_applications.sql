@@ -0,0 +1,50 @@
+-- name: GetApplicationSettings :one
+SELECT * FROM guild_application_settings
+WHERE guild_id = ?
+LIMIT 1;
+
+-- name: UpsertApplicationSettings :exec
+INSERT INTO guild_application_settings (guild_id, review_channel_id, approved_role_id, min_total_level, min_combat_level, min_ehp, updated_by)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(guild_id) DO UPDATE SET
+    review_channel_id = excluded.review_channel_id,
+    approved_role_id = excluded.approved_role_id,
+    min_total_level = excluded.min_total_level,
+    min_combat_level = excluded.min_combat_level,
+    min_ehp = excluded.min_ehp,
+    updated_by = excluded.updated_by,
+    updated_at = CURRENT_TIMESTAMP;
+
+-- name: DeleteApplicationSettings :exec
+DELETE FROM guild_application_settings
+WHERE guild_id = ?;
+
+-- name: CreateApplication :one
+INSERT INTO clan_applications (guild_id, discord_user_id, rsn, claimed_total_level, playtime, referral, total_level, combat_level, ehp, meets_requirements)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetApplication :one
+SELECT * FROM clan_applications
+WHERE id = ?
+LIMIT 1;
+
+-- name: GetPendingApplicationByUser :one
+SELECT * FROM clan_applications
+WHERE guild_id = ? AND discord_user_id = ? AND status = 'pending'
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: SetApplicationReviewMessage :exec
+UPDATE clan_applications
+SET review_channel_id = ?, review_message_id = ?
+WHERE id = ?;
+
+-- name: ReviewApplication :execrows
+UPDATE clan_applications
+SET status = ?, reviewed_by = ?, review_reason = ?, reviewed_at = CURRENT_TIMESTAMP
+WHERE id = ? AND status = 'pending';
+
+-- name: DeleteApplication :exec
+DELETE FROM clan_applications
+WHERE id = ?;
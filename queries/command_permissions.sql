@@ -0,0 +1,21 @@
+-- name: AddCommandPermission :execrows
+INSERT INTO command_permissions (guild_id, command, subject_type, subject_id, created_by)
+VALUES (?, ?, ?, ?, ?)
+ON CONFLICT (guild_id, command, subject_type, subject_id) DO NOTHING;
+
+-- name: GetCommandPermissions :many
+SELECT * FROM command_permissions
+WHERE guild_id = ?
+ORDER BY command, subject_type, subject_id;
+
+-- name: RemoveCommandPermission :execrows
+DELETE FROM command_permissions
+WHERE guild_id = ? AND command = ? AND subject_type = ? AND subject_id = ?;
+
+-- name: ResetCommandPermissions :execrows
+DELETE FROM command_permissions
+WHERE guild_id = ? AND command = ?;
+
+-- name: DeleteCommandPermissionsByGuild :exec
+DELETE FROM command_permissions
+WHERE guild_id = ?;
@@ -0,0 +1,20 @@
+-- name: UpsertEventResult :exec
+INSERT INTO event_results (guild_id, wom_competition_id, type, metric, account_link_id, placement, gained)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(wom_competition_id, account_link_id) DO UPDATE SET
+    placement = excluded.placement,
+    gained = excluded.gained,
+    finished_at = CURRENT_TIMESTAMP;
+
+-- name: ListMemberEventResults :many
+SELECT er.* FROM event_results er
+JOIN account_links al ON er.account_link_id = al.id
+WHERE er.guild_id = ? AND al.discord_member_id = ?
+ORDER BY er.finished_at DESC, er.id DESC;
+
+-- Masses scheduled before they recorded their guild have none and count for every guild.
+-- name: CountAttendedMasses :one
+SELECT COUNT(*) FROM schedulable_event_participations sep
+JOIN account_links al ON sep.account_link_id = al.id
+JOIN schedulable_events se ON sep.event_id = se.id
+WHERE (se.guild_id = ? OR se.guild_id IS NULL) AND al.discord_member_id = ? AND se.type = 'Mass' AND se.scheduled_at <= ?;
@@ -42,3 +42,31 @@ WHERE guild_id = ?;
 UPDATE guild_config
 SET event_notification_channel_id = ?, updated_at = CURRENT_TIMESTAMP
 WHERE guild_id = ?;
+
+-- name: UpdateNicknameTemplate :exec
+UPDATE guild_config
+SET nickname_template = ?, updated_at = CURRENT_TIMESTAMP
+WHERE guild_id = ?;
+
+-- name: UpdateWarningExpiryDays :exec
+UPDATE guild_config
+SET warning_expiry_days = ?, updated_at = CURRENT_TIMESTAMP
+WHERE guild_id = ?;
+
+-- name: UpdateAuditLogChannel :exec
+UPDATE guild_config
+SET audit_log_channel_id = ?, updated_at = CURRENT_TIMESTAMP
+WHERE guild_id = ?;
+
+-- name: ReplaceGuildConfigSettings :exec
+UPDATE guild_config
+SET coordinator_role_id = ?,
+    competition_code_channel_id = ?,
+    default_timezone = ?,
+    event_notification_role_id = ?,
+    event_notification_channel_id = ?,
+    nickname_template = ?,
+    warning_expiry_days = ?,
+    audit_log_channel_id = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE guild_id = ?;
@@ -0,0 +1,15 @@
+-- name: CreateGuildConfigVersion :one
+INSERT INTO guild_config_history (guild_id, settings, reason, changed_by)
+VALUES (?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetGuildConfigHistory :many
+SELECT * FROM guild_config_history
+WHERE guild_id = ?
+ORDER BY id DESC
+LIMIT ?;
+
+-- name: GetGuildConfigVersion :one
+SELECT * FROM guild_config_history
+WHERE id = ? AND guild_id = ?
+LIMIT 1;
@@ -0,0 +1,17 @@
+-- name: DisableGuildFeature :execrows
+INSERT INTO guild_disabled_features (guild_id, feature, disabled_by)
+VALUES (?, ?, ?)
+ON CONFLICT (guild_id, feature) DO NOTHING;
+
+-- name: EnableGuildFeature :execrows
+DELETE FROM guild_disabled_features
+WHERE guild_id = ? AND feature = ?;
+
+-- name: GetDisabledGuildFeatures :many
+SELECT feature FROM guild_disabled_features
+WHERE guild_id = ?
+ORDER BY feature;
+
+-- name: DeleteDisabledGuildFeaturesByGuild :exec
+DELETE FROM guild_disabled_features
+WHERE guild_id = ?;
@@ -0,0 +1,20 @@
+-- name: GetNotificationRoles :many
+SELECT * FROM guild_notification_roles
+WHERE guild_id = ?
+ORDER BY event_type;
+
+-- name: SetNotificationRole :exec
+INSERT INTO guild_notification_roles (guild_id, event_type, role_id, updated_by)
+VALUES (?, ?, ?, ?)
+ON CONFLICT(guild_id, event_type) DO UPDATE SET
+    role_id = excluded.role_id,
+    updated_by = excluded.updated_by,
+    updated_at = CURRENT_TIMESTAMP;
+
+-- name: DeleteNotificationRole :execrows
+DELETE FROM guild_notification_roles
+WHERE guild_id = ? AND event_type = ?;
+
+-- name: DeleteNotificationRolesByGuild :exec
+DELETE FROM guild_notification_roles
+WHERE guild_id = ?;
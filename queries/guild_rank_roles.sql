@@ -0,0 +1,20 @@
+-- name: GetRankRoles :many
+SELECT * FROM guild_rank_roles
+WHERE guild_id = ?
+ORDER BY wom_role;
+
+-- name: SetRankRole :exec
+INSERT INTO guild_rank_roles (guild_id, wom_role, role_id, updated_by)
+VALUES (?, ?, ?, ?)
+ON CONFLICT(guild_id, wom_role) DO UPDATE SET
+    role_id = excluded.role_id,
+    updated_by = excluded.updated_by,
+    updated_at = CURRENT_TIMESTAMP;
+
+-- name: DeleteRankRole :execrows
+DELETE FROM guild_rank_roles
+WHERE guild_id = ? AND wom_role = ?;
+
+-- name: DeleteRankRolesByGuild :exec
+DELETE FROM guild_rank_roles
+WHERE guild_id = ?;
@@ -0,0 +1,21 @@
+-- name: GetWelcomeSettings :one
+SELECT * FROM guild_welcome_settings
+WHERE guild_id = ?
+LIMIT 1;
+
+-- name: UpsertWelcomeSettings :exec
+INSERT INTO guild_welcome_settings (guild_id, dm_title, dm_message, channel_id, channel_message, checklist, linked_role_id, updated_by)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(guild_id) DO UPDATE SET
+    dm_title = excluded.dm_title,
+    dm_message = excluded.dm_message,
+    channel_id = excluded.channel_id,
+    channel_message = excluded.channel_message,
+    checklist = excluded.checklist,
+    linked_role_id = excluded.linked_role_id,
+    updated_by = excluded.updated_by,
+    updated_at = CURRENT_TIMESTAMP;
+
+-- name: DeleteWelcomeSettings :exec
+DELETE FROM guild_welcome_settings
+WHERE guild_id = ?;
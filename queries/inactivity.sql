@@ -0,0 +1,44 @@
+-- name: GetInactivitySettings :one
+SELECT * FROM guild_inactivity_settings
+WHERE guild_id = ?
+LIMIT 1;
+
+-- name: UpsertInactivitySettings :exec
+INSERT INTO guild_inactivity_settings (guild_id, report_channel_id, threshold_days, nudge_enabled, updated_by)
+VALUES (?, ?, ?, ?, ?)
+ON CONFLICT(guild_id) DO UPDATE SET
+    report_channel_id = excluded.report_channel_id,
+    threshold_days = excluded.threshold_days,
+    nudge_enabled = excluded.nudge_enabled,
+    updated_by = excluded.updated_by,
+    updated_at = CURRENT_TIMESTAMP;
+
+-- name: SetInactivityReportSent :exec
+UPDATE guild_inactivity_settings
+SET last_report_at = ?
+WHERE guild_id = ?;
+
+-- name: DeleteInactivitySettings :exec
+DELETE FROM guild_inactivity_settings
+WHERE guild_id = ?;
+
+-- name: GetPlayerActivities :many
+SELECT * FROM player_activity;
+
+-- name: UpsertPlayerActivity :exec
+INSERT INTO player_activity (runescape_name, last_changed_at, wom_updated_at, checked_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT(runescape_name) DO UPDATE SET
+    last_changed_at = excluded.last_changed_at,
+    wom_updated_at = excluded.wom_updated_at,
+    checked_at = excluded.checked_at;
+
+-- name: GetInactivityNudges :many
+SELECT * FROM inactivity_nudges
+WHERE guild_id = ?;
+
+-- name: SetInactivityNudge :exec
+INSERT INTO inactivity_nudges (guild_id, discord_member_id, nudged_at)
+VALUES (?, ?, ?)
+ON CONFLICT(guild_id, discord_member_id) DO UPDATE SET
+    nudged_at = excluded.nudged_at;
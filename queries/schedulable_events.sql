@@ -1,6 +1,6 @@
 -- name: CreateSchedulableEvent :one
-INSERT INTO schedulable_events (type, activity, location, scheduled_at, discord_event_id, timezone)
-VALUES (?, ?, ?, ?, ?, ?)
+INSERT INTO schedulable_events (type, activity, location, scheduled_at, discord_event_id, timezone, guild_id)
+VALUES (?, ?, ?, ?, ?, ?, ?)
 RETURNING *;
 
 -- name: GetSchedulableEventByID :one
@@ -40,7 +40,7 @@ WHERE sep.event_id = ?
 ORDER BY sep.created_at;
 
 -- name: GetUnnotifiedParticipations :many
-SELECT sep.*, al.discord_member_id, al.runescape_name, se.activity, se.location, se.scheduled_at, se.type
+SELECT sep.*, al.discord_member_id, al.runescape_name, se.activity, se.location, se.scheduled_at, se.type, se.guild_id
 FROM schedulable_event_participations sep
 JOIN account_links al ON sep.account_link_id = al.id
 JOIN schedulable_events se ON sep.event_id = se.id
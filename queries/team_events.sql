@@ -0,0 +1,56 @@
+-- name: CreateTeamEvent :one
+INSERT INTO team_events (guild_id, type, metric, title, discord_thread_id, balanced, ends_at, created_by)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetTeamEvent :one
+SELECT * FROM team_events
+WHERE id = ?
+LIMIT 1;
+
+-- name: GetLatestTeamEventByType :one
+SELECT * FROM team_events
+WHERE guild_id = ? AND type = ?
+ORDER BY created_at DESC, id DESC
+LIMIT 1;
+
+-- name: SetTeamEventCompetition :exec
+UPDATE team_events
+SET wom_competition_id = ?
+WHERE id = ?;
+
+-- name: FinishTeamEvent :exec
+UPDATE team_events
+SET finished_at = CURRENT_TIMESTAMP
+WHERE id = ? AND finished_at IS NULL;
+
+-- name: FinishTeamEventByCompetition :exec
+UPDATE team_events
+SET finished_at = CURRENT_TIMESTAMP
+WHERE wom_competition_id = ? AND finished_at IS NULL;
+
+-- name: AddTeamEventTeam :exec
+INSERT INTO team_event_teams (team_event_id, position, name)
+VALUES (?, ?, ?);
+
+-- name: ListTeamEventTeams :many
+SELECT * FROM team_event_teams
+WHERE team_event_id = ?
+ORDER BY position;
+
+-- name: AddTeamEventMember :execrows
+INSERT INTO team_event_members (team_event_id, account_link_id, team_position, score)
+VALUES (?, ?, ?, ?)
+ON CONFLICT(team_event_id, account_link_id) DO NOTHING;
+
+-- name: RemoveTeamEventMember :exec
+DELETE FROM team_event_members
+WHERE team_event_id = ? AND account_link_id = ?;
+
+-- name: ListTeamEventMembers :many
+SELECT m.team_event_id, m.account_link_id, m.team_position, m.score, m.joined_at,
+       a.discord_member_id, a.runescape_name
+FROM team_event_members m
+JOIN account_links a ON a.id = m.account_link_id
+WHERE m.team_event_id = ?
+ORDER BY m.team_position, m.joined_at, a.runescape_name;
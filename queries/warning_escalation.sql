@@ -0,0 +1,42 @@
+-- name: CreateEscalationRule :one
+INSERT INTO warning_escalation_rules (guild_id, threshold, action, duration_minutes, role_id, created_by)
+VALUES (?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetEscalationRules :many
+SELECT * FROM warning_escalation_rules
+WHERE guild_id = ?
+ORDER BY threshold, id;
+
+-- name: GetEscalationRulesForThreshold :many
+SELECT * FROM warning_escalation_rules
+WHERE guild_id = ? AND threshold = ?
+ORDER BY id;
+
+-- name: DeleteEscalationRule :execrows
+DELETE FROM warning_escalation_rules
+WHERE id = ? AND guild_id = ?;
+
+-- name: CreateWarningAction :one
+INSERT INTO warning_actions (guild_id, user_id, warning_id, rule_id, action, duration_minutes, role_id, status, error)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetWarningActionByID :one
+SELECT * FROM warning_actions
+WHERE id = ?
+LIMIT 1;
+
+-- name: GetWarningActionsByUser :many
+SELECT * FROM warning_actions
+WHERE guild_id = ? AND user_id = ?
+ORDER BY created_at DESC;
+
+-- name: MarkWarningActionReverted :exec
+UPDATE warning_actions
+SET status = 'reverted', reverted_by = ?, reverted_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: DeleteEscalationRulesByGuild :exec
+DELETE FROM warning_escalation_rules
+WHERE guild_id = ?;
@@ -32,3 +32,11 @@ LIMIT 1;
 -- name: DeleteGuildWarningChannel :exec
 DELETE FROM guild_warning_channels
 WHERE guild_id = ?;
+
+-- name: DeleteWarning :execrows
+DELETE FROM warnings
+WHERE id = ? AND guild_id = ?;
+
+-- name: CountActiveWarnings :one
+SELECT COUNT(*) FROM warnings
+WHERE guild_id = ? AND user_id = ? AND created_at >= ?;
@@ -1,6 +1,6 @@
 -- name: CreateWOMCompetition :one
-INSERT INTO wom_competitions (wom_competition_id, verification_code, discord_thread_id, metric, type)
-VALUES (?, ?, ?, ?, ?)
+INSERT INTO wom_competitions (wom_competition_id, verification_code, discord_thread_id, metric, type, wom_group_id)
+VALUES (?, ?, ?, ?, ?, ?)
 RETURNING *;
 
 -- name: GetWOMCompetitionByID :one
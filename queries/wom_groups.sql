@@ -0,0 +1,20 @@
+-- name: GetWOMGroup :one
+SELECT * FROM guild_wom_groups
+WHERE guild_id = ?
+LIMIT 1;
+
+-- name: GetWOMGroups :many
+SELECT * FROM guild_wom_groups;
+
+-- name: UpsertWOMGroup :exec
+INSERT INTO guild_wom_groups (guild_id, group_id, verification_code, updated_by)
+VALUES (?, ?, ?, ?)
+ON CONFLICT(guild_id) DO UPDATE SET
+    group_id = excluded.group_id,
+    verification_code = excluded.verification_code,
+    updated_by = excluded.updated_by,
+    updated_at = CURRENT_TIMESTAMP;
+
+-- name: DeleteWOMGroup :exec
+DELETE FROM guild_wom_groups
+WHERE guild_id = ?;